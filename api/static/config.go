@@ -49,4 +49,8 @@ type PERouterConfig struct {
 	L3VPNs         []StaticL3VPN               `yaml:"l3vpns"`
 	BGPPassthrough v1alpha1.L3PassthroughSpec  `yaml:"bgppassthrough"`
 	RawFRRConfigs  []v1alpha1.RawFRRConfigSpec `yaml:"rawfrrconfigs"`
+	// NodeAddressing overrides the addresses derived from the node index. Its
+	// nodeName defaults to the name of the node, and it refers to the underlays
+	// and host sessions by their static names (e.g. underlay-0, l3passthrough).
+	NodeAddressing *v1alpha1.NodeAddressingSpec `yaml:"nodeaddressing"`
 }
@@ -46,6 +46,8 @@ func addKnownTypes(scheme *runtime.Scheme) error {
 		&L3VPNList{},
 		&L3Passthrough{},
 		&L3PassthroughList{},
+		&NodeAddressing{},
+		&NodeAddressingList{},
 		&RawFRRConfig{},
 		&RawFRRConfigList{},
 		&Underlay{},
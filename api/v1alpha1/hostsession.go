@@ -34,6 +34,120 @@ type HostSession struct {
 	// +required
 	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="LocalCIDR can't be changed"
 	LocalCIDR LocalCIDRConfig `json:"localCIDR,omitzero"` //nolint:kubeapilinter // CEL rule on LocalCIDRConfig enforces at least one of ipv4/ipv6
+
+	// importPolicy filters and modifies the routes received from the host.
+	// It applies to both the ipv4 and the ipv6 unicast address families.
+	// When omitted, all the routes are accepted.
+	// +optional
+	ImportPolicy *RoutePolicy `json:"importPolicy,omitempty"`
+
+	// allowedPrefixes lists the prefixes the host is allowed to advertise
+	// into the VRF. When set, the routes received from the host that don't
+	// match any of the prefixes are rejected, before the import policy is
+	// applied. An address family with no allowed prefix rejects all the
+	// routes of that family. When omitted, all the routes are accepted.
+	// +kubebuilder:validation:MaxItems=128
+	// +listType=atomic
+	// +optional
+	AllowedPrefixes []PrefixMatch `json:"allowedPrefixes,omitempty"`
+
+	// exportPolicy filters and modifies the routes advertised to the host.
+	// It applies to both the ipv4 and the ipv6 unicast address families.
+	// When omitted, all the routes are advertised.
+	// +optional
+	ExportPolicy *RoutePolicy `json:"exportPolicy,omitempty"`
+
+	// bfd enables BFD on the session with the host. An empty bfd enables it
+	// with FRR's defaults, the other settings are rendered as a BFD profile.
+	// +optional
+	BFD *BFDSettings `json:"bfd,omitempty"`
+
+	// passwordSecret is the name of the secret holding the TCP-MD5 password
+	// of the session with the host. The secret must be of type
+	// "kubernetes.io/basic-auth", labeled "openperouter.io/credentials", and
+	// created in the same namespace as the perouter daemon. The password is stored in the secret as the key
+	// "password".
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=253
+	// +optional
+	PasswordSecret *string `json:"passwordSecret,omitempty"`
+
+	// maximumPrefix limits the number of prefixes accepted from the host,
+	// per address family, protecting the VRF from a misbehaving speaker.
+	// +optional
+	MaximumPrefix *MaximumPrefix `json:"maximumPrefix,omitempty"`
+
+	// gracefulRestart configures the graceful restart behaviour of the router
+	// towards the host speaker.
+	// +optional
+	GracefulRestart *HostSessionGracefulRestart `json:"gracefulRestart,omitempty"`
+}
+
+// MaximumPrefixAction is what the router does when a neighbor exceeds its
+// maximum number of prefixes.
+// +kubebuilder:validation:Enum=Teardown;WarningOnly
+type MaximumPrefixAction string
+
+const (
+	// MaximumPrefixActionTeardown closes the session when the limit is
+	// exceeded. The session is re-established only when cleared.
+	MaximumPrefixActionTeardown MaximumPrefixAction = "Teardown"
+
+	// MaximumPrefixActionWarningOnly only logs a warning when the limit is
+	// exceeded, keeping the session and the prefixes.
+	MaximumPrefixActionWarningOnly MaximumPrefixAction = "WarningOnly"
+)
+
+// MaximumPrefix limits the number of prefixes accepted from a neighbor.
+// +kubebuilder:validation:XValidation:rule="has(self.ipv4) || has(self.ipv6)",message="at least one of ipv4 or ipv6 must be specified"
+type MaximumPrefix struct {
+	// ipv4 is the maximum number of IPv4 unicast prefixes accepted.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=4294967295
+	// +optional
+	IPv4 *int64 `json:"ipv4,omitempty"`
+
+	// ipv6 is the maximum number of IPv6 unicast prefixes accepted.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=4294967295
+	// +optional
+	IPv6 *int64 `json:"ipv6,omitempty"`
+
+	// action is what the router does when the limit is exceeded. Teardown
+	// closes the session, WarningOnly only logs a warning.
+	// +default="Teardown"
+	// +optional
+	Action MaximumPrefixAction `json:"action,omitempty"`
+
+	// warningThresholdPercent is the percentage of the limit at which a
+	// warning is logged. FRR defaults to 75.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=100
+	// +optional
+	WarningThresholdPercent *int32 `json:"warningThresholdPercent,omitempty"`
+}
+
+// HostSessionGracefulRestartMode is the graceful restart role of the router
+// on the session with the host.
+// +kubebuilder:validation:Enum=Helper;Disabled
+type HostSessionGracefulRestartMode string
+
+const (
+	// HostSessionGracefulRestartHelper retains the routes learned from the
+	// host while its speaker restarts.
+	HostSessionGracefulRestartHelper HostSessionGracefulRestartMode = "Helper"
+
+	// HostSessionGracefulRestartDisabled withdraws the routes learned from
+	// the host as soon as the session goes down.
+	HostSessionGracefulRestartDisabled HostSessionGracefulRestartMode = "Disabled"
+)
+
+// HostSessionGracefulRestart configures the graceful restart behaviour of
+// the session with the host.
+type HostSessionGracefulRestart struct {
+	// mode is the graceful restart role of the router on the session.
+	// +required
+	Mode HostSessionGracefulRestartMode `json:"mode,omitempty"`
 }
 
 // +kubebuilder:validation:XValidation:rule="has(self.ipv4) || has(self.ipv6)",message="at least one of ipv4 or ipv6 must be specified"
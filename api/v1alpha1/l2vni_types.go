@@ -33,6 +33,8 @@ const (
 
 // L2VNISpec defines the desired state of VNI.
 // +kubebuilder:validation:XValidation:rule="!has(self.gatewayIPs) || size(self.gatewayIPs) == 0 || has(self.routingDomain)",message="gatewayIPs cannot be set without routingDomain"
+// +kubebuilder:validation:XValidation:rule="!has(self.gatewayMAC) || (has(self.gatewayIPs) && size(self.gatewayIPs) > 0)",message="gatewayMAC cannot be set without gatewayIPs"
+// +kubebuilder:validation:XValidation:rule="!has(self.gatewayAdvertisements) || size(self.gatewayAdvertisements) == 0 || (has(self.gatewayIPs) && size(self.gatewayIPs) > 0)",message="gatewayAdvertisements cannot be set without gatewayIPs"
 type L2VNISpec struct {
 	// nodeSelector specifies which nodes this L2VNI applies to.
 	// If empty or not specified, applies to all nodes.
@@ -65,6 +67,15 @@ type L2VNISpec struct {
 	// +optional
 	UnderlayAddressFamily *string `json:"underlayAddressFamily,omitempty"`
 
+	// underlay is the name of the Underlay whose tunnel endpoint this VNI
+	// rides on. It is required only when more than one Underlay with a
+	// tunnelEndpoint applies to the same node, for example on fabrics with
+	// several independent planes.
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=253
+	// +optional
+	Underlay *string `json:"underlay,omitempty"`
+
 	// hostMaster is the interface on the host the veth should be attached to.
 	// If not set, the host veth will not be attached to any interface and it must be
 	// attached manually (or by some other means). This is useful if another controller
@@ -83,6 +94,98 @@ type L2VNISpec struct {
 	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="GatewayIPs cannot be changed"
 	// +listType=atomic
 	GatewayIPs []string `json:"gatewayIPs,omitempty"`
+
+	// gatewayMAC is the MAC address of the distributed anycast gateway,
+	// the same on all the nodes. Setting it to the anycast gateway MAC of
+	// the leaves of the fabric lets the hosts move between them and the
+	// nodes without refreshing their neighbor entries. When omitted, a MAC
+	// derived from the VNI is used.
+	// +kubebuilder:validation:Pattern=`^([0-9a-fA-F]{2}:){5}[0-9a-fA-F]{2}$`
+	// +optional
+	GatewayMAC *string `json:"gatewayMAC,omitempty"`
+
+	// gatewayAdvertisements lists how the distributed anycast gateway is
+	// advertised as EVPN MAC/IP routes:
+	// DefaultGateway advertises the gateway MAC and IPs with the default
+	// gateway extended community (advertise-default-gw);
+	// SVIIP advertises the gateway MAC and IPs as a regular host
+	// (advertise-svi-ip).
+	// +kubebuilder:validation:MaxItems=2
+	// +listType=set
+	// +optional
+	GatewayAdvertisements []GatewayAdvertisement `json:"gatewayAdvertisements,omitempty"`
+
+	// neighborSuppression controls the ARP and ND suppression on the VXLan
+	// interface of the L2VNI. When Enabled, the router answers the ARP and
+	// ND requests for the remote hosts it learned through EVPN instead of
+	// flooding them to the other VTEPs. Defaults to Enabled.
+	// +kubebuilder:validation:Enum=Enabled;Disabled
+	// +optional
+	NeighborSuppression *NeighborSuppression `json:"neighborSuppression,omitempty"`
+
+	// ethernetSegment makes the host facing attachment of this L2VNI part of
+	// an EVPN Ethernet Segment, so that a workload attached to multiple nodes
+	// gets all-active redundancy and split-horizon filtering (EVPN multihoming).
+	// The same segment must be configured on all the nodes the workload is
+	// attached to.
+	// +optional
+	EthernetSegment *EthernetSegment `json:"ethernetSegment,omitempty"`
+}
+
+// GatewayAdvertisement is a way of advertising the distributed anycast
+// gateway of an L2VNI as EVPN MAC/IP routes.
+// +kubebuilder:validation:Enum=DefaultGateway;SVIIP
+type GatewayAdvertisement string
+
+const (
+	// GatewayAdvertisementDefaultGateway advertises the gateway with the
+	// default gateway extended community.
+	GatewayAdvertisementDefaultGateway GatewayAdvertisement = "DefaultGateway"
+
+	// GatewayAdvertisementSVIIP advertises the gateway IPs of the SVI.
+	GatewayAdvertisementSVIIP GatewayAdvertisement = "SVIIP"
+)
+
+// NeighborSuppression tells whether the ARP and ND suppression is enabled.
+type NeighborSuppression string
+
+const (
+	NeighborSuppressionEnabled  NeighborSuppression = "Enabled"
+	NeighborSuppressionDisabled NeighborSuppression = "Disabled"
+)
+
+// EthernetSegment identifies an EVPN Ethernet Segment, either with a type 0
+// ESI or with a type 3 ESI derived from a system MAC and a local
+// discriminator.
+// +kubebuilder:validation:XValidation:rule="has(self.esi) != has(self.localDiscriminator)",message="exactly one of esi and localDiscriminator must be set"
+// +kubebuilder:validation:XValidation:rule="has(self.localDiscriminator) == has(self.systemMAC)",message="systemMAC must be set together with localDiscriminator"
+type EthernetSegment struct {
+	// esi is the type 0 Ethernet Segment Identifier, as ten colon separated
+	// octets where the first one is 00 (e.g. 00:11:22:33:44:55:66:77:88:99).
+	// +kubebuilder:validation:Pattern=`^00(:[0-9a-fA-F]{2}){9}$`
+	// +optional
+	ESI *string `json:"esi,omitempty"`
+
+	// localDiscriminator is the local discriminator of the type 3 ESI,
+	// which is auto derived from systemMAC and localDiscriminator.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=16777215
+	// +optional
+	LocalDiscriminator *int32 `json:"localDiscriminator,omitempty"`
+
+	// systemMAC is the system MAC of the type 3 ESI.
+	// +kubebuilder:validation:Pattern=`^([0-9a-fA-F]{2}:){5}[0-9a-fA-F]{2}$`
+	// +optional
+	SystemMAC *string `json:"systemMAC,omitempty"`
+
+	// dfPreference is the preference of this node in the Designated
+	// Forwarder election of the segment: the node with the highest
+	// preference forwards the broadcast, unknown unicast and multicast
+	// traffic to the segment. Defaults to 32767.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65535
+	// +optional
+	DFPreference *int32 `json:"dfPreference,omitempty"`
 }
 
 // RoutingDomain is a discriminated union over the resource kinds that can
@@ -159,6 +262,7 @@ type LinuxBridgeConfig struct {
 
 // OVSBridgeConfig contains configuration for OVS bridge type.
 // +kubebuilder:validation:XValidation:rule="(self.?name.orValue(\"\") != \"\") != (self.?lifecycle.orValue(\"\") == 'Managed')",message="name must be set when lifecycle is External, and must not be set when it is Managed."
+// +kubebuilder:validation:XValidation:rule="!has(self.sampling) || self.lifecycle == 'Managed'",message="sampling can be set only when lifecycle is Managed"
 type OVSBridgeConfig struct {
 	// lifecycle determines if the OVS bridge is managed by the controller or
 	// provided by the user.
@@ -172,6 +276,50 @@ type OVSBridgeConfig struct {
 	// +kubebuilder:validation:MaxLength=15
 	// +optional
 	Name *string `json:"name,omitempty"`
+
+	// sampling exports samples of the traffic crossing the bridge to sFlow
+	// or IPFIX collectors, giving visibility over the flows of the L2VNI.
+	// It can be set only on the bridges managed by the controller.
+	// +optional
+	Sampling *OVSSampling `json:"sampling,omitempty"`
+}
+
+// OVSSamplingProtocol is the protocol the traffic samples are exported with.
+// +kubebuilder:validation:Enum=SFlow;IPFIX
+type OVSSamplingProtocol string
+
+const (
+	OVSSamplingProtocolSFlow OVSSamplingProtocol = "SFlow"
+	OVSSamplingProtocolIPFIX OVSSamplingProtocol = "IPFIX"
+)
+
+// OVSSampling configures the traffic sampling of an OVS bridge.
+// +kubebuilder:validation:XValidation:rule="!has(self.pollingInterval) || self.protocol == 'SFlow'",message="pollingInterval can be set only with the SFlow protocol"
+type OVSSampling struct {
+	// protocol is the protocol the samples are exported with.
+	// +required
+	Protocol OVSSamplingProtocol `json:"protocol,omitempty"`
+
+	// collectors are the addresses the samples are sent to, in the ip:port
+	// form, with IPv6 addresses enclosed in square brackets.
+	// +kubebuilder:validation:MinItems=1
+	// +kubebuilder:validation:MaxItems=8
+	// +kubebuilder:validation:items:MaxLength=64
+	// +listType=set
+	// +required
+	Collectors []string `json:"collectors,omitempty"`
+
+	// samplingRate is the rate packets are sampled at: one packet out of
+	// samplingRate is exported. Defaults to 400.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	SamplingRate *int32 `json:"samplingRate,omitempty"`
+
+	// pollingInterval is the interval in seconds between the interface
+	// counters samples. Only valid with the SFlow protocol. Defaults to 30.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	PollingInterval *int32 `json:"pollingInterval,omitempty"`
 }
 
 // +kubebuilder:validation:XValidation:rule="(self.type == 'LinuxBridge' && has(self.linuxBridge) && !has(self.ovsBridge)) || (self.type == 'OVSBridge' && has(self.ovsBridge) && !has(self.linuxBridge))",message="type/config mismatch: 'LinuxBridge' requires linuxBridge field, 'OVSBridge' requires ovsBridge field"
@@ -190,14 +338,18 @@ type HostMaster struct {
 	OVSBridge *OVSBridgeConfig `json:"ovsBridge,omitempty"`
 }
 
-// VNIStatus defines the observed state of VNI.
+// L2VNIStatus defines the observed state of L2VNI.
 type L2VNIStatus struct {
-	// INSERT ADDITIONAL STATUS FIELD - define observed state of cluster
-	// Important: Run "make" to regenerate code after modifying this file
+	NodesConfigurationStatus `json:",inline"` // nolint:kubeapilinter // embedded to share the per node aggregated status
 }
 
 // +kubebuilder:object:root=true
 // +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Ready",type=string,JSONPath=`.status.conditions[?(@.type=="Ready")].status`,description=Ready
+// +kubebuilder:printcolumn:name="Selected",type=integer,JSONPath=`.status.selectedNodes`,description=Number of selected nodes
+// +kubebuilder:printcolumn:name="Applied",type=integer,JSONPath=`.status.appliedNodes`,description=Number of nodes the resource is applied on
+// +kubebuilder:printcolumn:name="Failed",type=integer,JSONPath=`.status.failedNodes`,description=Number of nodes the resource failed on
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
 // +kubebuilder:webhook:verbs=create;update,path=/validate-openperouter-io-v1alpha1-l2vni,mutating=false,failurePolicy=fail,groups=network.openperouter.io,resources=l2vnis,versions=v1alpha1,name=l2vnivalidationwebhook.openperouter.io,sideEffects=None,admissionReviewVersions=v1
 
 // L2VNI represents a VXLan VNI to receive EVPN type 2 routes
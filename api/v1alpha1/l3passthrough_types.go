@@ -23,7 +23,8 @@ import (
 type L3PassthroughSpec struct {
 	// nodeSelector specifies which nodes this L3Passthrough applies to.
 	// If empty or not specified, applies to all nodes.
-	// Multiple L3Passthrough with overlapping node selectors will be rejected.
+	// Multiple L3Passthrough can apply to the same node, each one with its
+	// own veth pair and host session. Their localCIDRs must not overlap.
 	// +optional
 	NodeSelector *metav1.LabelSelector `json:"nodeSelector,omitempty"`
 
@@ -34,12 +35,16 @@ type L3PassthroughSpec struct {
 
 // L3PassthroughStatus defines the observed state of L3Passthrough.
 type L3PassthroughStatus struct {
-	// INSERT ADDITIONAL STATUS FIELD - define observed state of cluster
-	// Important: Run "make" to regenerate code after modifying this file
+	NodesConfigurationStatus `json:",inline"` // nolint:kubeapilinter // embedded to share the per node aggregated status
 }
 
 // +kubebuilder:object:root=true
 // +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Ready",type=string,JSONPath=`.status.conditions[?(@.type=="Ready")].status`,description=Ready
+// +kubebuilder:printcolumn:name="Selected",type=integer,JSONPath=`.status.selectedNodes`,description=Number of selected nodes
+// +kubebuilder:printcolumn:name="Applied",type=integer,JSONPath=`.status.appliedNodes`,description=Number of nodes the resource is applied on
+// +kubebuilder:printcolumn:name="Failed",type=integer,JSONPath=`.status.failedNodes`,description=Number of nodes the resource failed on
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
 // +kubebuilder:webhook:verbs=create;update,path=/validate-openperouter-io-v1alpha1-l3passthrough,mutating=false,failurePolicy=fail,groups=network.openperouter.io,resources=l3passthroughs,versions=v1alpha1,name=l3passthroughvalidationwebhook.openperouter.io,sideEffects=None,admissionReviewVersions=v1
 
 // L3Passthrough represents a session with the host which is not encapsulated and
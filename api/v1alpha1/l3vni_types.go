@@ -54,6 +54,15 @@ type L3VNISpec struct {
 	// +optional
 	UnderlayAddressFamily *string `json:"underlayAddressFamily,omitempty"`
 
+	// underlay is the name of the Underlay whose tunnel endpoint this VNI
+	// rides on. It is required only when more than one Underlay with a
+	// tunnelEndpoint applies to the same node, for example on fabrics with
+	// several independent planes.
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=253
+	// +optional
+	Underlay *string `json:"underlay,omitempty"`
+
 	// hostSession is the configuration for the host session.
 	// +optional
 	HostSession *HostSession `json:"hostSession,omitempty"`
@@ -71,6 +80,11 @@ type L3VNISpec struct {
 	// +kubebuilder:validation:MaxItems:=100
 	// +listType=atomic
 	ImportRTs []RouteTarget `json:"importRTs,omitempty"`
+
+	// routeLeaking leaks routes between the VRF of this L3VNI and the other
+	// VRFs of the router, such as a shared services VRF or the default VRF.
+	// +optional
+	RouteLeaking *RouteLeaking `json:"routeLeaking,omitempty"`
 }
 
 // RouteTarget defines a BGP Extended Community for route filtering.
@@ -79,12 +93,16 @@ type RouteTarget string
 
 // L3VNIStatus defines the observed state of L3VNI.
 type L3VNIStatus struct {
-	// INSERT ADDITIONAL STATUS FIELD - define observed state of cluster
-	// Important: Run "make" to regenerate code after modifying this file
+	NodesConfigurationStatus `json:",inline"` // nolint:kubeapilinter // embedded to share the per node aggregated status
 }
 
 // +kubebuilder:object:root=true
 // +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Ready",type=string,JSONPath=`.status.conditions[?(@.type=="Ready")].status`,description=Ready
+// +kubebuilder:printcolumn:name="Selected",type=integer,JSONPath=`.status.selectedNodes`,description=Number of selected nodes
+// +kubebuilder:printcolumn:name="Applied",type=integer,JSONPath=`.status.appliedNodes`,description=Number of nodes the resource is applied on
+// +kubebuilder:printcolumn:name="Failed",type=integer,JSONPath=`.status.failedNodes`,description=Number of nodes the resource failed on
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
 // +kubebuilder:webhook:verbs=create;update,path=/validate-openperouter-io-v1alpha1-l3vni,mutating=false,failurePolicy=fail,groups=network.openperouter.io,resources=l3vnis,versions=v1alpha1,name=l3vnivalidationwebhook.openperouter.io,sideEffects=None,admissionReviewVersions=v1
 
 // L3VNI represents a VXLan L3VNI to receive EVPN type 5 routes
@@ -64,16 +64,25 @@ type L3VPNSpec struct {
 	// hostSession is the configuration for the host session.
 	// +optional
 	HostSession *HostSession `json:"hostSession,omitempty"`
+
+	// routeLeaking leaks routes between the VRF of this L3VPN and the other
+	// VRFs of the router, such as a shared services VRF or the default VRF.
+	// +optional
+	RouteLeaking *RouteLeaking `json:"routeLeaking,omitempty"`
 }
 
 // L3VPNStatus defines the observed state of L3VPN.
 type L3VPNStatus struct {
-	// INSERT ADDITIONAL STATUS FIELD - define observed state of cluster
-	// Important: Run "make" to regenerate code after modifying this file
+	NodesConfigurationStatus `json:",inline"` // nolint:kubeapilinter // embedded to share the per node aggregated status
 }
 
 // +kubebuilder:object:root=true
 // +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Ready",type=string,JSONPath=`.status.conditions[?(@.type=="Ready")].status`,description=Ready
+// +kubebuilder:printcolumn:name="Selected",type=integer,JSONPath=`.status.selectedNodes`,description=Number of selected nodes
+// +kubebuilder:printcolumn:name="Applied",type=integer,JSONPath=`.status.appliedNodes`,description=Number of nodes the resource is applied on
+// +kubebuilder:printcolumn:name="Failed",type=integer,JSONPath=`.status.failedNodes`,description=Number of nodes the resource failed on
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
 // +kubebuilder:webhook:verbs=create;update,path=/validate-openperouter-io-v1alpha1-l3vpn,mutating=false,failurePolicy=fail,groups=network.openperouter.io,resources=l3vpns,versions=v1alpha1,name=l3vpnsvalidationwebhook.openperouter.io,sideEffects=None,admissionReviewVersions=v1
 
 // L3VPN represents an SRv6 IP VPN.
@@ -2,6 +2,11 @@
 
 package v1alpha1
 
+// CredentialsSecretLabel is the label the Secrets holding the passwords
+// referenced by the resources must carry, with any value.
+// The Secrets without it are not read by the router.
+const CredentialsSecretLabel = "openperouter.io/credentials"
+
 // Neighbor represents a BGP Neighbor we want FRR to connect to.
 // +kubebuilder:validation:XValidation:rule="has(self.asn) || has(self.type)",message="either ASN or Type must be set"
 // +kubebuilder:validation:XValidation:rule="!has(self.asn) || !has(self.type)",message="ASN and Type cannot be set together"
@@ -70,9 +75,10 @@ type Neighbor struct {
 	Password *string `json:"password,omitempty"`
 
 	// passwordSecret is name of the authentication secret for the neighbor.
-	// the secret must be of type "kubernetes.io/basic-auth", and created in the
-	// same namespace as the perouter daemon. The password is stored in the
-	// secret as the key "password".
+	// the secret must be of type "kubernetes.io/basic-auth", labeled
+	// "openperouter.io/credentials", and created in the same namespace as the
+	// perouter daemon. The password is stored in the secret as the key
+	// "password".
 	// Password and PasswordSecret are mutually exclusive.
 	// +optional
 	PasswordSecret *string `json:"passwordSecret,omitempty"`
@@ -182,7 +188,8 @@ type NeighborProperty struct {
 	EBGPMultiHop *EBGPMultiHopProperties `json:"ebgpMultiHop,omitempty"`
 }
 
-// BFDSettings defines the BFD configuration for a BGP session.
+// BFDSettings defines the BFD configuration for a BGP session or an ISIS
+// interface.
 type BFDSettings struct {
 	// receiveInterval is the minimum interval that this system is capable of
 	// receiving control packets in milliseconds.
@@ -242,6 +249,18 @@ type NeighborAddressFamily struct {
 	// +listType=map
 	// +listMapKey=type
 	Properties []AddressFamilyProperty `json:"properties,omitempty"`
+
+	// importPolicy filters and modifies the routes received from the
+	// neighbor in this address family. When omitted, all the routes are
+	// accepted.
+	// +optional
+	ImportPolicy *RoutePolicy `json:"importPolicy,omitempty"`
+
+	// exportPolicy filters and modifies the routes advertised to the
+	// neighbor in this address family. When omitted, all the routes are
+	// advertised.
+	// +optional
+	ExportPolicy *RoutePolicy `json:"exportPolicy,omitempty"`
 }
 
 // AddressFamilyPropertyType defines an optional feature on a neighbor
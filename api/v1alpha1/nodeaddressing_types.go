@@ -0,0 +1,140 @@
+// SPDX-License-Identifier:Apache-2.0
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// NodeIndexAnnotation is the annotation holding the index assigned to a node,
+// which the addresses of the node are derived from.
+const NodeIndexAnnotation = "openpe.io/nodeindex"
+
+// NodeAddressingSpec defines the addresses of a node, overriding the ones
+// derived from its node index.
+type NodeAddressingSpec struct {
+	// nodeName is the name of the node the addresses apply to.
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=253
+	// +required
+	NodeName string `json:"nodeName,omitempty"`
+
+	// routerID is the router ID of the node, overriding the one derived from
+	// the routerIDCIDR of the Underlay.
+	// +kubebuilder:validation:XValidation:rule="isIP(self) && ip(self).family() == 4",message="routerID must be a valid IPv4 address"
+	// +kubebuilder:validation:MaxLength=15
+	// +optional
+	RouterID *string `json:"routerID,omitempty"`
+
+	// tunnelEndpoints are the tunnel endpoint IPs of the node, overriding the
+	// ones derived from the tunnelEndpoint CIDRs of the Underlays.
+	// +kubebuilder:validation:MaxItems=8
+	// +listType=map
+	// +listMapKey=underlay
+	// +optional
+	TunnelEndpoints []TunnelEndpointAddresses `json:"tunnelEndpoints,omitempty"`
+
+	// hostSessions are the host side IPs of the veth legs of the node,
+	// overriding the ones derived from the localCIDR of the host sessions.
+	// +kubebuilder:validation:MaxItems=256
+	// +listType=map
+	// +listMapKey=kind
+	// +listMapKey=name
+	// +optional
+	HostSessions []HostSessionAddresses `json:"hostSessions,omitempty"`
+}
+
+// TunnelEndpointAddresses are the tunnel endpoint IPs of a node on an Underlay.
+type TunnelEndpointAddresses struct {
+	// underlay is the name of the Underlay.
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=253
+	// +required
+	Underlay string `json:"underlay,omitempty"`
+
+	// ips are the tunnel endpoint IPs. At most one of each family may be
+	// specified, and it is used in place of the one derived from the CIDR of
+	// the same family.
+	// +kubebuilder:validation:MinItems=1
+	// +kubebuilder:validation:MaxItems=2
+	// +kubebuilder:validation:items:MaxLength=39
+	// +kubebuilder:validation:XValidation:rule="self.all(i, isIP(i))",message="all entries must be valid IPs"
+	// +kubebuilder:validation:XValidation:rule="self.filter(i, isIP(i) && ip(i).family() == 4).size() <= 1",message="at most one IPv4 address is allowed"
+	// +kubebuilder:validation:XValidation:rule="self.filter(i, isIP(i) && ip(i).family() == 6).size() <= 1",message="at most one IPv6 address is allowed"
+	// +listType=atomic
+	// +required
+	IPs []string `json:"ips,omitempty"`
+}
+
+// HostSessionOwnerKind is the kind of the resource a host session belongs to.
+// +kubebuilder:validation:Enum=L3VNI;L3VPN;L3Passthrough
+type HostSessionOwnerKind string
+
+const (
+	HostSessionOwnerKindL3VNI         HostSessionOwnerKind = "L3VNI"
+	HostSessionOwnerKindL3VPN         HostSessionOwnerKind = "L3VPN"
+	HostSessionOwnerKindL3Passthrough HostSessionOwnerKind = "L3Passthrough"
+)
+
+// HostSessionAddresses are the host side IPs of a node for a host session.
+// +kubebuilder:validation:XValidation:rule="has(self.ipv4) || has(self.ipv6)",message="at least one of ipv4 or ipv6 must be specified"
+type HostSessionAddresses struct {
+	// kind is the kind of the resource the host session belongs to.
+	// +required
+	Kind HostSessionOwnerKind `json:"kind,omitempty"`
+
+	// name is the name of the resource the host session belongs to.
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=253
+	// +required
+	Name string `json:"name,omitempty"`
+
+	// ipv4 is the host side IPv4 address. It must belong to the IPv4
+	// localCIDR of the host session, and differ from the PERouter side one.
+	// +kubebuilder:validation:XValidation:rule="isIP(self) && ip(self).family() == 4",message="ipv4 must be a valid IPv4 address"
+	// +kubebuilder:validation:MaxLength=15
+	// +optional
+	IPv4 *string `json:"ipv4,omitempty"`
+
+	// ipv6 is the host side IPv6 address. It must belong to the IPv6
+	// localCIDR of the host session, and differ from the PERouter side one.
+	// +kubebuilder:validation:XValidation:rule="isIP(self) && ip(self).family() == 6",message="ipv6 must be a valid IPv6 address"
+	// +kubebuilder:validation:MaxLength=39
+	// +optional
+	IPv6 *string `json:"ipv6,omitempty"`
+}
+
+// NodeAddressingStatus defines the observed state of NodeAddressing.
+type NodeAddressingStatus struct {
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Node",type=string,JSONPath=`.spec.nodeName`
+// +kubebuilder:webhook:verbs=create;update,path=/validate-openperouter-io-v1alpha1-nodeaddressing,mutating=false,failurePolicy=fail,groups=network.openperouter.io,resources=nodeaddressings,versions=v1alpha1,name=nodeaddressingvalidationwebhook.openperouter.io,sideEffects=None,admissionReviewVersions=v1
+
+// NodeAddressing is the Schema for the nodeaddressings API.
+// It sets explicit addresses for a node, in place of the ones derived from
+// its node index.
+type NodeAddressing struct {
+	metav1.TypeMeta `json:",inline"`
+	// metadata is the standard object metadata.
+	// +optional
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// spec defines the desired state of NodeAddressing.
+	// +required
+	Spec NodeAddressingSpec `json:"spec,omitzero,omitempty"`
+	// status defines the observed state of NodeAddressing.
+	// +optional
+	Status *NodeAddressingStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// NodeAddressingList contains a list of NodeAddressing.
+type NodeAddressingList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []NodeAddressing `json:"items"`
+}
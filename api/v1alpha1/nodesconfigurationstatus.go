@@ -0,0 +1,70 @@
+// SPDX-License-Identifier:Apache-2.0
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// ConditionReasonConfigPending is set when some of the selected nodes
+	// did not report their router configuration status yet.
+	ConditionReasonConfigPending = "ConfigurationPending"
+)
+
+// NodesConfigurationStatus aggregates the outcome of applying a resource on
+// all the nodes selected by its nodeSelector, as reported by the
+// RouterNodeConfigurationStatus of each node.
+type NodesConfigurationStatus struct {
+	// conditions list of conditions. Ready is true when the resource is
+	// applied on all the selected nodes, Degraded is true when the resource
+	// failed on at least one of them.
+	// +listType=map
+	// +listMapKey=type
+	// +patchStrategy=merge
+	// +patchMergeKey=type
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"` // nolint:kubeapilinter // suggested additional tags are not needed
+
+	// selectedNodes is the number of nodes selected by the resource.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	SelectedNodes int32 `json:"selectedNodes"` // nolint:kubeapilinter // zero is a meaningful counter value
+
+	// appliedNodes is the number of selected nodes where the resource
+	// is applied successfully.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	AppliedNodes int32 `json:"appliedNodes"` // nolint:kubeapilinter // zero is a meaningful counter value
+
+	// failedNodes is the number of selected nodes where the resource
+	// failed to be applied.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	FailedNodes int32 `json:"failedNodes"` // nolint:kubeapilinter // zero is a meaningful counter value
+
+	// nodeFailures lists the nodes where the resource failed, with the reason.
+	// +listType=map
+	// +listMapKey=node
+	// +optional
+	NodeFailures []NodeFailure `json:"nodeFailures,omitempty"`
+}
+
+// NodeFailure describes why a resource failed to be applied on a node.
+type NodeFailure struct {
+	// node is the name of the node the resource failed on.
+	// +required
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=253
+	Node string `json:"node"` // nolint:kubeapilinter // required filed should not set omitempty
+
+	// reason failure reason.
+	// +required
+	Reason FailedResourceReason `json:"reason"` // nolint:kubeapilinter // required filed should not set omitempty
+
+	// message human-readable failure description.
+	// +required
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=500
+	Message string `json:"message"` // nolint:kubeapilinter // required filed should not set omitempty
+}
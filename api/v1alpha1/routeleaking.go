@@ -0,0 +1,44 @@
+// SPDX-License-Identifier:Apache-2.0
+
+package v1alpha1
+
+// DefaultVRF is the name used to reference the default VRF of the router,
+// where the underlay and the L3Passthroughs live, when leaking routes.
+const DefaultVRF = "default"
+
+// RouteLeaking describes the routes leaked between the VRF of an L3VNI or
+// L3VPN and the other VRFs of the same router, without going through the
+// fabric.
+type RouteLeaking struct {
+	// importFrom lists the VRFs whose routes are imported into this VRF.
+	// +kubebuilder:validation:MaxItems=32
+	// +listType=map
+	// +listMapKey=vrf
+	// +optional
+	ImportFrom []VRFRouteLeak `json:"importFrom,omitempty"`
+
+	// exportTo lists the VRFs the routes of this VRF are exported to.
+	// +kubebuilder:validation:MaxItems=32
+	// +listType=map
+	// +listMapKey=vrf
+	// +optional
+	ExportTo []VRFRouteLeak `json:"exportTo,omitempty"`
+}
+
+// VRFRouteLeak selects the routes leaked from or to another VRF.
+type VRFRouteLeak struct {
+	// vrf is the name of the other VRF. It must be the VRF of an L3VNI or an
+	// L3VPN applied to the same node, or "default" for the default VRF.
+	// +kubebuilder:validation:Pattern=`^[a-zA-Z][a-zA-Z0-9_-]*$`
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=15
+	// +required
+	VRF string `json:"vrf,omitempty"`
+
+	// prefixes restricts the leaked routes to the ones matching any of the
+	// given prefixes. When omitted, all the routes are leaked.
+	// +kubebuilder:validation:MaxItems=64
+	// +listType=atomic
+	// +optional
+	Prefixes []PrefixMatch `json:"prefixes,omitempty"`
+}
@@ -0,0 +1,152 @@
+// SPDX-License-Identifier:Apache-2.0
+
+package v1alpha1
+
+// RoutePolicyAction is the action applied to the routes matched by a rule.
+// +kubebuilder:validation:Enum=Permit;Deny
+type RoutePolicyAction string
+
+const (
+	// RoutePolicyActionPermit accepts the matched routes, applying the
+	// set actions of the rule.
+	RoutePolicyActionPermit RoutePolicyAction = "Permit"
+
+	// RoutePolicyActionDeny rejects the matched routes.
+	RoutePolicyActionDeny RoutePolicyAction = "Deny"
+)
+
+// BGPCommunity is a standard BGP community in the asn:value form.
+// +kubebuilder:validation:Pattern=`^[0-9]{1,5}:[0-9]{1,5}$`
+// +kubebuilder:validation:MaxLength=11
+type BGPCommunity string
+
+// BGPLargeCommunity is a large BGP community in the asn:value:value form.
+// +kubebuilder:validation:Pattern=`^[0-9]{1,10}:[0-9]{1,10}:[0-9]{1,10}$`
+// +kubebuilder:validation:MaxLength=32
+type BGPLargeCommunity string
+
+// RoutePolicy filters and manipulates the routes exchanged with a BGP peer.
+// The rules are evaluated in order, and the first rule matching a route
+// decides its fate. Routes not matched by any rule are handled according
+// to defaultAction.
+type RoutePolicy struct {
+	// rules is the ordered list of rules of the policy.
+	// +kubebuilder:validation:MinItems=1
+	// +kubebuilder:validation:MaxItems=32
+	// +listType=atomic
+	// +required
+	Rules []RoutePolicyRule `json:"rules,omitempty"`
+
+	// defaultAction is the action applied to the routes not matched by any
+	// rule. Defaults to Deny.
+	// +optional
+	DefaultAction *RoutePolicyAction `json:"defaultAction,omitempty"`
+}
+
+// RoutePolicyRule matches a set of routes and applies an action to them.
+// +kubebuilder:validation:XValidation:rule="!has(self.set) || self.action == 'Permit'",message="set can only be used with the Permit action"
+type RoutePolicyRule struct {
+	// action is the action applied to the matched routes.
+	// +required
+	Action RoutePolicyAction `json:"action,omitempty"`
+
+	// match selects the routes the rule applies to. When omitted, the rule
+	// matches all the routes.
+	// +optional
+	Match *RoutePolicyMatch `json:"match,omitempty"`
+
+	// set lists the attributes modified on the matched routes.
+	// May only be set when action is Permit.
+	// +optional
+	Set *RoutePolicySet `json:"set,omitempty"`
+}
+
+// RoutePolicyMatch selects routes. A route is matched when all the
+// non empty criteria are satisfied, and each criteria is satisfied when
+// any of its items matches.
+type RoutePolicyMatch struct {
+	// prefixes matches the routes against a list of prefixes. Only the
+	// prefixes of the same IP family of the address family the policy is
+	// applied to are taken into account, and a rule with no prefix
+	// of that family is skipped. Not supported on the evpn address family.
+	// +kubebuilder:validation:MaxItems=64
+	// +listType=atomic
+	// +optional
+	Prefixes []PrefixMatch `json:"prefixes,omitempty"`
+
+	// communities matches the routes carrying any of the given communities.
+	// +kubebuilder:validation:MaxItems=16
+	// +listType=set
+	// +optional
+	Communities []BGPCommunity `json:"communities,omitempty"`
+
+	// largeCommunities matches the routes carrying any of the given large
+	// communities.
+	// +kubebuilder:validation:MaxItems=16
+	// +listType=set
+	// +optional
+	LargeCommunities []BGPLargeCommunity `json:"largeCommunities,omitempty"`
+}
+
+// PrefixMatch matches a prefix, optionally extended to the more specific
+// prefixes with a length in the [ge, le] range.
+type PrefixMatch struct {
+	// prefix is the prefix to match, in CIDR notation.
+	// +kubebuilder:validation:MaxLength=43
+	// +required
+	Prefix string `json:"prefix,omitempty"`
+
+	// ge matches the prefixes with a length greater or equal to the given one.
+	// Must be greater than the length of prefix.
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=128
+	// +optional
+	GE *int32 `json:"ge,omitempty"`
+
+	// le matches the prefixes with a length less or equal to the given one.
+	// Must be greater than the length of prefix, and than ge if set.
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=128
+	// +optional
+	LE *int32 `json:"le,omitempty"`
+}
+
+// RoutePolicySet lists the attributes to modify on the matched routes.
+type RoutePolicySet struct {
+	// localPreference sets the local preference of the routes.
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=4294967295
+	// +optional
+	LocalPreference *int64 `json:"localPreference,omitempty"`
+
+	// communities are added to the communities of the routes.
+	// +kubebuilder:validation:MaxItems=16
+	// +listType=set
+	// +optional
+	Communities []BGPCommunity `json:"communities,omitempty"`
+
+	// largeCommunities are added to the large communities of the routes.
+	// +kubebuilder:validation:MaxItems=16
+	// +listType=set
+	// +optional
+	LargeCommunities []BGPLargeCommunity `json:"largeCommunities,omitempty"`
+
+	// asPathPrepend prepends an AS number to the AS path of the routes.
+	// +optional
+	ASPathPrepend *ASPathPrepend `json:"asPathPrepend,omitempty"`
+}
+
+// ASPathPrepend describes the AS number to prepend to the AS path.
+type ASPathPrepend struct {
+	// asn is the AS number to prepend. Defaults to the local AS number.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=4294967295
+	// +optional
+	ASN *int64 `json:"asn,omitempty"`
+
+	// times is how many times the AS number is prepended.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=10
+	// +required
+	Times int32 `json:"times,omitempty"`
+}
@@ -54,4 +54,123 @@ type RouterNodeConfigurationStatusStatus struct {
 	// +patchMergeKey=type
 	// +optional
 	Conditions []metav1.Condition `json:"conditions,omitempty"` // nolint:kubeapilinter // suggested additional tags are not needed
+
+	// operationalState runtime state of the router, periodically retrieved from FRR.
+	// Unlike the conditions, which tell whether the configuration was applied, it
+	// tells whether the fabric is actually working.
+	// +optional
+	OperationalState *RouterOperationalState `json:"operationalState,omitempty"`
+}
+
+// RouterOperationalState describes the runtime state of the router.
+type RouterOperationalState struct {
+	// lastUpdateTime is the last time the operational state changed.
+	// +required
+	LastUpdateTime metav1.Time `json:"lastUpdateTime"` // nolint:kubeapilinter // required filed should not set omitempty
+
+	// localVTEPIP is the ip of the local VXLAN tunnel endpoint, as advertised in the EVPN routes.
+	// +optional
+	LocalVTEPIP string `json:"localVTEPIP,omitempty"`
+
+	// underlayNeighbors state of the BGP sessions with the underlay neighbors.
+	// +listType=map
+	// +listMapKey=peer
+	// +optional
+	UnderlayNeighbors []BGPNeighborState `json:"underlayNeighbors,omitempty"`
+
+	// hostSessions state of the sessions with the hosts whose advertised
+	// prefixes are restricted by allowedPrefixes.
+	// +listType=atomic
+	// +optional
+	HostSessions []HostSessionState `json:"hostSessions,omitempty"`
+
+	// bfdPeers state of the BFD sessions.
+	// +listType=atomic
+	// +optional
+	BFDPeers []BFDPeerState `json:"bfdPeers,omitempty"`
+
+	// vrfs count of the EVPN routes received for each VRF.
+	// +listType=map
+	// +listMapKey=name
+	// +optional
+	VRFs []VRFRoutesState `json:"vrfs,omitempty"`
+}
+
+// BGPNeighborState describes the state of a BGP session.
+type BGPNeighborState struct {
+	// peer is the address of the neighbor, or the interface name for unnumbered neighbors.
+	// +required
+	Peer string `json:"peer"` // nolint:kubeapilinter // required filed should not set omitempty
+
+	// state is the BGP state of the session, as reported by FRR (e.g. Established).
+	// +required
+	State string `json:"state"` // nolint:kubeapilinter // required filed should not set omitempty
+
+	// prefixesReceived is the number of prefixes accepted from the neighbor.
+	// +optional
+	PrefixesReceived int32 `json:"prefixesReceived"` // nolint:kubeapilinter // zero is a meaningful counter value
+
+	// prefixesSent is the number of prefixes advertised to the neighbor.
+	// +optional
+	PrefixesSent int32 `json:"prefixesSent"` // nolint:kubeapilinter // zero is a meaningful counter value
+}
+
+// HostSessionState describes the state of a BGP session with a host.
+type HostSessionState struct {
+	// peer is the address of the host side of the session.
+	// +required
+	Peer string `json:"peer"` // nolint:kubeapilinter // required filed should not set omitempty
+
+	// vrf is the vrf the session belongs to.
+	// +required
+	VRF string `json:"vrf"` // nolint:kubeapilinter // required filed should not set omitempty
+
+	// state is the BGP state of the session, as reported by FRR (e.g. Established).
+	// +required
+	State string `json:"state"` // nolint:kubeapilinter // required filed should not set omitempty
+
+	// prefixesReceived is the number of prefixes accepted from the host.
+	// +optional
+	PrefixesReceived int32 `json:"prefixesReceived"` // nolint:kubeapilinter // zero is a meaningful counter value
+
+	// prefixesRejected is the number of prefixes advertised by the host and
+	// rejected by the inbound filters of the session.
+	// +optional
+	PrefixesRejected int32 `json:"prefixesRejected"` // nolint:kubeapilinter // zero is a meaningful counter value
+}
+
+// BFDPeerState describes the state of a BFD session.
+type BFDPeerState struct {
+	// peer is the address of the BFD peer.
+	// +required
+	Peer string `json:"peer"` // nolint:kubeapilinter // required filed should not set omitempty
+
+	// vrf is the vrf the session belongs to.
+	// +optional
+	VRF string `json:"vrf,omitempty"`
+
+	// interface is the interface the session is established on.
+	// +optional
+	Interface string `json:"interface,omitempty"`
+
+	// status is the BFD status of the session, as reported by FRR (e.g. up).
+	// +required
+	Status string `json:"status"` // nolint:kubeapilinter // required filed should not set omitempty
+}
+
+// VRFRoutesState describes the EVPN routes received for a VRF.
+type VRFRoutesState struct {
+	// name is the name of the VRF.
+	// +required
+	Name string `json:"name"` // nolint:kubeapilinter // required filed should not set omitempty
+
+	// type5Routes is the number of EVPN type 5 (IP prefix) routes received
+	// with the route targets imported by the L3 VNI of the VRF.
+	// +optional
+	Type5Routes int32 `json:"type5Routes"` // nolint:kubeapilinter // zero is a meaningful counter value
+
+	// type2Routes is the number of EVPN type 2 (MAC/IP) routes received
+	// with the route targets imported by the L2 VNIs of the VRF.
+	// +optional
+	Type2Routes int32 `json:"type2Routes"` // nolint:kubeapilinter // zero is a meaningful counter value
 }
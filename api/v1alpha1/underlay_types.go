@@ -23,12 +23,17 @@ import (
 
 // UnderlaySpec defines the desired state of Underlay.
 // +kubebuilder:validation:XValidation:rule="!has(self.srv6) || has(self.isis)",message="SRv6 can only be configured if isis is set"
+// +kubebuilder:validation:XValidation:rule="!has(self.isis) || !has(self.ospf)",message="isis and ospf are mutually exclusive"
 // +kubebuilder:validation:XValidation:rule="!has(self.srv6) || (has(self.tunnelEndpoint) && has(self.tunnelEndpoint.cidrs) && self.tunnelEndpoint.cidrs.exists(c, cidr(c).ip().family() == 6))",message="SRv6 requires at least one IPv6 CIDR in tunnelEndpoint.cidrs"
 // +kubebuilder:validation:XValidation:rule="!has(self.routeReflector) || !has(self.routeReflector.clusterID) || !isIP(self.routeReflector.clusterID) || !has(self.routerIDCIDR) || !isCIDR(self.routerIDCIDR) || !cidr(self.routerIDCIDR).containsIP(self.routeReflector.clusterID)",message="routeReflector.clusterID must be outside the routerIDCIDR range"
 type UnderlaySpec struct {
 	// nodeSelector specifies which nodes this Underlay applies to.
 	// If empty or not specified, applies to all nodes (backward compatible).
-	// Multiple Underlays with overlapping node selectors will be rejected.
+	// Multiple Underlays can apply to the same node, for example one per
+	// fabric plane. They must not share interfaces, neighbors or tunnel
+	// endpoint CIDRs, must have the same asn, routerIDCIDR, gracefulRestart
+	// and routeReflector, and only one of them can configure isis, ospf and
+	// srv6.
 	// +optional
 	NodeSelector *metav1.LabelSelector `json:"nodeSelector,omitempty"`
 
@@ -83,6 +88,11 @@ type UnderlaySpec struct {
 	// +optional
 	ISIS *ISISConfig `json:"isis,omitempty"`
 
+	// ospf holds the OSPF configuration for the underlay, an alternative
+	// to isis as the underlay IGP.
+	// +optional
+	OSPF *OSPFConfig `json:"ospf,omitempty"`
+
 	// srv6 holds the SRv6 configuration. Requires ISIS or Neighbors configuration.
 	// +optional
 	SRV6 *SRV6Config `json:"srv6,omitempty"`
@@ -262,8 +272,32 @@ type TunnelEndpointConfig struct {
 	// +listType=atomic
 	// +required
 	CIDRs []string `json:"cidrs,omitempty"`
+
+	// vxlanMode selects how the router implements the VNIs using this tunnel
+	// endpoint. PerVNI, the default, creates a bridge and a VXLAN interface
+	// for each VNI. SingleVXLANDevice maps each VNI to a VLAN of a single
+	// VLAN aware bridge and carries all of them over a single VXLAN interface
+	// with VNI filtering, which scales to many more L2VNIs.
+	// A SingleVXLANDevice Underlay must be the only Underlay with a tunnel
+	// endpoint on its nodes, and all the VNIs must use the same vxlanPort and
+	// the same underlay address family.
+	// +optional
+	VXLANMode VXLANMode `json:"vxlanMode,omitempty"`
 }
 
+// VXLANMode selects how the router implements the VNIs.
+// +kubebuilder:validation:Enum=PerVNI;SingleVXLANDevice
+type VXLANMode string
+
+const (
+	// VXLANModePerVNI creates a bridge and a VXLAN interface for each VNI.
+	VXLANModePerVNI VXLANMode = "PerVNI"
+
+	// VXLANModeSingleVXLANDevice maps each VNI to a VLAN of a single VLAN
+	// aware bridge, attached to a single VXLAN interface with VNI filtering.
+	VXLANModeSingleVXLANDevice VXLANMode = "SingleVXLANDevice"
+)
+
 // ISISConfig contains ISIS configuration for the underlay.
 type ISISConfig struct {
 	// baseNet holds the ISIS NET address.
@@ -291,6 +325,38 @@ type ISISConfig struct {
 	// +kubebuilder:validation:Enum:=1;2
 	// +optional
 	Level *int32 `json:"level,omitempty"`
+	// areaAuthentication authenticates the level-1 LSPs and SNPs (area-password).
+	// +optional
+	AreaAuthentication *ISISAuthentication `json:"areaAuthentication,omitempty"`
+	// domainAuthentication authenticates the level-2 LSPs and SNPs (domain-password).
+	// +optional
+	DomainAuthentication *ISISAuthentication `json:"domainAuthentication,omitempty"`
+}
+
+// ISISAuthenticationType is the type of an ISIS authentication.
+// +kubebuilder:validation:Enum=HMACMD5
+type ISISAuthenticationType string
+
+const (
+	// ISISAuthenticationHMACMD5 authenticates the ISIS PDUs with HMAC-MD5, per RFC 5304.
+	ISISAuthenticationHMACMD5 ISISAuthenticationType = "HMACMD5"
+)
+
+// ISISAuthentication holds the authentication of ISIS PDUs.
+type ISISAuthentication struct {
+	// type is the authentication type. Only HMACMD5 is supported, as FRR
+	// does not implement the HMAC-SHA authentication of RFC 5310.
+	// +default="HMACMD5"
+	// +optional
+	Type ISISAuthenticationType `json:"type,omitempty"`
+	// passwordSecret is the name of the secret holding the authentication key.
+	// The secret must be of type "kubernetes.io/basic-auth", labeled
+	// "openperouter.io/credentials", and created in the same namespace as the
+	// perouter daemon. The key is stored in the secret as the key "password".
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=253
+	// +required
+	PasswordSecret string `json:"passwordSecret,omitempty"`
 }
 
 // ISISNet represents a single ISIS NET address.
@@ -322,18 +388,97 @@ type ISISInterface struct {
 	// features enables ISIS interface boolean features.
 	// Supported features are:
 	// passive: configures ISIS passive mode on this interface.
+	// pointToPoint: configures the interface as a point-to-point ISIS network.
 	// +kubebuilder:validation:MaxItems:=32
 	// +listType=atomic
 	// +optional
 	Features []ISISInterfaceFeature `json:"features,omitempty"`
+	// authentication authenticates the ISIS hellos sent and received on this
+	// interface.
+	// +optional
+	Authentication *ISISAuthentication `json:"authentication,omitempty"`
+	// metric is the ISIS metric of this interface, for both levels.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=16777215
+	// +optional
+	Metric *int32 `json:"metric,omitempty"`
+	// helloIntervalSeconds is the interval between the ISIS hellos sent on
+	// this interface. FRR defaults to 3 seconds.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=600
+	// +optional
+	HelloIntervalSeconds *int32 `json:"helloIntervalSeconds,omitempty"`
+	// helloMultiplier is the number of hellos that can be missed before the
+	// adjacency goes down. FRR defaults to 10.
+	// +kubebuilder:validation:Minimum=2
+	// +kubebuilder:validation:Maximum=100
+	// +optional
+	HelloMultiplier *int32 `json:"helloMultiplier,omitempty"`
+	// bfd enables BFD for the ISIS adjacencies of this interface. An empty
+	// bfd enables it with FRR's defaults, the other settings are rendered as
+	// a BFD profile.
+	// +optional
+	BFD *BFDSettings `json:"bfd,omitempty"`
 }
 
 // ISISInterfaceFeature represents a single ISIS feature of an ISIS interface.
 // +kubebuilder:validation:MinLength:=1
 // +kubebuilder:validation:MaxLength:=128
-// +kubebuilder:validation:Enum:=passive
+// +kubebuilder:validation:Enum:=passive;pointToPoint
 type ISISInterfaceFeature string
 
+// OSPFConfig contains OSPF configuration for the underlay.
+type OSPFConfig struct {
+	// area is the OSPF area the interfaces belong to, in dotted decimal
+	// (0.0.0.0) or decimal (0) format.
+	// +default="0.0.0.0"
+	// +optional
+	Area *OSPFArea `json:"area,omitempty"`
+	// ipFamily configures which OSPF versions run on the underlay: OSPFv2
+	// for IPv4, OSPFv3 for IPv6 or both. It defaults to IPv4.
+	// +optional
+	IPFamily *IPFamily `json:"ipFamily,omitempty"`
+	// interfaces holds additional OSPF interface level configuration and / or per
+	// interface overrides. By default, OpenPERouter enables OSPF on the loopback
+	// as a passive interface and on all the underlay interfaces.
+	// +listType=map
+	// +listMapKey=name
+	// +kubebuilder:validation:MaxItems:=128
+	// +optional
+	Interfaces []OSPFInterface `json:"interfaces,omitempty"`
+}
+
+// OSPFArea represents a single OSPF area ID.
+// +kubebuilder:validation:MinLength:=1
+// +kubebuilder:validation:MaxLength:=15
+// +kubebuilder:validation:XValidation:rule=`self.matches('^[0-9]+$') || (isIP(self) && ip(self).family() == 4)`,message="area must be a decimal number or a dotted decimal IPv4 address"
+type OSPFArea string
+
+// OSPFInterface holds OSPF interface level configuration.
+type OSPFInterface struct {
+	// name of the interface that these settings shall apply to.
+	// +kubebuilder:validation:XValidation:rule=`self.matches('^[^\\/:\\s]+$')`,message="Interface must not contain /, :, or whitespace"
+	// +kubebuilder:validation:XValidation:rule=`self != '.' && self != '..'`,message="Interface cannot be . or .."
+	// +kubebuilder:validation:MaxLength:=15
+	// +kubebuilder:validation:MinLength:=1
+	// +required
+	Name string `json:"name,omitempty"`
+	// features enables OSPF interface boolean features.
+	// Supported features are:
+	// passive: configures OSPF passive mode on this interface.
+	// pointToPoint: configures the interface as a point-to-point OSPF network.
+	// +kubebuilder:validation:MaxItems:=32
+	// +listType=set
+	// +optional
+	Features []OSPFInterfaceFeature `json:"features,omitempty"`
+}
+
+// OSPFInterfaceFeature represents a single OSPF feature of an OSPF interface.
+// +kubebuilder:validation:MinLength:=1
+// +kubebuilder:validation:MaxLength:=128
+// +kubebuilder:validation:Enum:=passive;pointToPoint
+type OSPFInterfaceFeature string
+
 // IPFamily specifies which address families are enabled.
 // +kubebuilder:validation:Enum=IPv4;IPv6;DualStack
 type IPFamily string
@@ -395,12 +540,16 @@ type SRV6Locator struct {
 
 // UnderlayStatus defines the observed state of Underlay.
 type UnderlayStatus struct {
-	// INSERT ADDITIONAL STATUS FIELD - define observed state of cluster
-	// Important: Run "make" to regenerate code after modifying this file
+	NodesConfigurationStatus `json:",inline"` // nolint:kubeapilinter // embedded to share the per node aggregated status
 }
 
 // +kubebuilder:object:root=true
 // +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Ready",type=string,JSONPath=`.status.conditions[?(@.type=="Ready")].status`,description=Ready
+// +kubebuilder:printcolumn:name="Selected",type=integer,JSONPath=`.status.selectedNodes`,description=Number of selected nodes
+// +kubebuilder:printcolumn:name="Applied",type=integer,JSONPath=`.status.appliedNodes`,description=Number of nodes the resource is applied on
+// +kubebuilder:printcolumn:name="Failed",type=integer,JSONPath=`.status.failedNodes`,description=Number of nodes the resource failed on
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
 // +kubebuilder:webhook:verbs=create;update,path=/validate-openperouter-io-v1alpha1-underlay,mutating=false,failurePolicy=fail,groups=network.openperouter.io,resources=underlays,versions=v1alpha1,name=underlayvalidationwebhook.openperouter.io,sideEffects=None,admissionReviewVersions=v1
 
 // Underlay is the Schema for the underlays API.
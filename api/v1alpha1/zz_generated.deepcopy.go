@@ -26,6 +26,26 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ASPathPrepend) DeepCopyInto(out *ASPathPrepend) {
+	*out = *in
+	if in.ASN != nil {
+		in, out := &in.ASN, &out.ASN
+		*out = new(int64)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ASPathPrepend.
+func (in *ASPathPrepend) DeepCopy() *ASPathPrepend {
+	if in == nil {
+		return nil
+	}
+	out := new(ASPathPrepend)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AddressFamilyProperty) DeepCopyInto(out *AddressFamilyProperty) {
 	*out = *in
@@ -41,6 +61,21 @@ func (in *AddressFamilyProperty) DeepCopy() *AddressFamilyProperty {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BFDPeerState) DeepCopyInto(out *BFDPeerState) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BFDPeerState.
+func (in *BFDPeerState) DeepCopy() *BFDPeerState {
+	if in == nil {
+		return nil
+	}
+	out := new(BFDPeerState)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *BFDSettings) DeepCopyInto(out *BFDSettings) {
 	*out = *in
@@ -81,6 +116,21 @@ func (in *BFDSettings) DeepCopy() *BFDSettings {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BGPNeighborState) DeepCopyInto(out *BGPNeighborState) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BGPNeighborState.
+func (in *BGPNeighborState) DeepCopy() *BGPNeighborState {
+	if in == nil {
+		return nil
+	}
+	out := new(BGPNeighborState)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CNIDevice) DeepCopyInto(out *CNIDevice) {
 	*out = *in
@@ -131,6 +181,41 @@ func (in *EBGPMultiHopProperties) DeepCopy() *EBGPMultiHopProperties {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EthernetSegment) DeepCopyInto(out *EthernetSegment) {
+	*out = *in
+	if in.ESI != nil {
+		in, out := &in.ESI, &out.ESI
+		*out = new(string)
+		**out = **in
+	}
+	if in.LocalDiscriminator != nil {
+		in, out := &in.LocalDiscriminator, &out.LocalDiscriminator
+		*out = new(int32)
+		**out = **in
+	}
+	if in.SystemMAC != nil {
+		in, out := &in.SystemMAC, &out.SystemMAC
+		*out = new(string)
+		**out = **in
+	}
+	if in.DFPreference != nil {
+		in, out := &in.DFPreference, &out.DFPreference
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EthernetSegment.
+func (in *EthernetSegment) DeepCopy() *EthernetSegment {
+	if in == nil {
+		return nil
+	}
+	out := new(EthernetSegment)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *FailedResource) DeepCopyInto(out *FailedResource) {
 	*out = *in
@@ -210,6 +295,43 @@ func (in *HostSession) DeepCopyInto(out *HostSession) {
 		**out = **in
 	}
 	in.LocalCIDR.DeepCopyInto(&out.LocalCIDR)
+	if in.ImportPolicy != nil {
+		in, out := &in.ImportPolicy, &out.ImportPolicy
+		*out = new(RoutePolicy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.AllowedPrefixes != nil {
+		in, out := &in.AllowedPrefixes, &out.AllowedPrefixes
+		*out = make([]PrefixMatch, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ExportPolicy != nil {
+		in, out := &in.ExportPolicy, &out.ExportPolicy
+		*out = new(RoutePolicy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.BFD != nil {
+		in, out := &in.BFD, &out.BFD
+		*out = new(BFDSettings)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PasswordSecret != nil {
+		in, out := &in.PasswordSecret, &out.PasswordSecret
+		*out = new(string)
+		**out = **in
+	}
+	if in.MaximumPrefix != nil {
+		in, out := &in.MaximumPrefix, &out.MaximumPrefix
+		*out = new(MaximumPrefix)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.GracefulRestart != nil {
+		in, out := &in.GracefulRestart, &out.GracefulRestart
+		*out = new(HostSessionGracefulRestart)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HostSession.
@@ -222,6 +344,76 @@ func (in *HostSession) DeepCopy() *HostSession {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HostSessionAddresses) DeepCopyInto(out *HostSessionAddresses) {
+	*out = *in
+	if in.IPv4 != nil {
+		in, out := &in.IPv4, &out.IPv4
+		*out = new(string)
+		**out = **in
+	}
+	if in.IPv6 != nil {
+		in, out := &in.IPv6, &out.IPv6
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HostSessionAddresses.
+func (in *HostSessionAddresses) DeepCopy() *HostSessionAddresses {
+	if in == nil {
+		return nil
+	}
+	out := new(HostSessionAddresses)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HostSessionGracefulRestart) DeepCopyInto(out *HostSessionGracefulRestart) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HostSessionGracefulRestart.
+func (in *HostSessionGracefulRestart) DeepCopy() *HostSessionGracefulRestart {
+	if in == nil {
+		return nil
+	}
+	out := new(HostSessionGracefulRestart)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HostSessionState) DeepCopyInto(out *HostSessionState) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HostSessionState.
+func (in *HostSessionState) DeepCopy() *HostSessionState {
+	if in == nil {
+		return nil
+	}
+	out := new(HostSessionState)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ISISAuthentication) DeepCopyInto(out *ISISAuthentication) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ISISAuthentication.
+func (in *ISISAuthentication) DeepCopy() *ISISAuthentication {
+	if in == nil {
+		return nil
+	}
+	out := new(ISISAuthentication)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ISISConfig) DeepCopyInto(out *ISISConfig) {
 	*out = *in
@@ -242,6 +434,16 @@ func (in *ISISConfig) DeepCopyInto(out *ISISConfig) {
 		*out = new(int32)
 		**out = **in
 	}
+	if in.AreaAuthentication != nil {
+		in, out := &in.AreaAuthentication, &out.AreaAuthentication
+		*out = new(ISISAuthentication)
+		**out = **in
+	}
+	if in.DomainAuthentication != nil {
+		in, out := &in.DomainAuthentication, &out.DomainAuthentication
+		*out = new(ISISAuthentication)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ISISConfig.
@@ -267,6 +469,31 @@ func (in *ISISInterface) DeepCopyInto(out *ISISInterface) {
 		*out = make([]ISISInterfaceFeature, len(*in))
 		copy(*out, *in)
 	}
+	if in.Authentication != nil {
+		in, out := &in.Authentication, &out.Authentication
+		*out = new(ISISAuthentication)
+		**out = **in
+	}
+	if in.Metric != nil {
+		in, out := &in.Metric, &out.Metric
+		*out = new(int32)
+		**out = **in
+	}
+	if in.HelloIntervalSeconds != nil {
+		in, out := &in.HelloIntervalSeconds, &out.HelloIntervalSeconds
+		*out = new(int32)
+		**out = **in
+	}
+	if in.HelloMultiplier != nil {
+		in, out := &in.HelloMultiplier, &out.HelloMultiplier
+		*out = new(int32)
+		**out = **in
+	}
+	if in.BFD != nil {
+		in, out := &in.BFD, &out.BFD
+		*out = new(BFDSettings)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ISISInterface.
@@ -288,7 +515,7 @@ func (in *L2VNI) DeepCopyInto(out *L2VNI) {
 	if in.Status != nil {
 		in, out := &in.Status, &out.Status
 		*out = new(L2VNIStatus)
-		**out = **in
+		(*in).DeepCopyInto(*out)
 	}
 }
 
@@ -365,6 +592,11 @@ func (in *L2VNISpec) DeepCopyInto(out *L2VNISpec) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.Underlay != nil {
+		in, out := &in.Underlay, &out.Underlay
+		*out = new(string)
+		**out = **in
+	}
 	if in.HostMaster != nil {
 		in, out := &in.HostMaster, &out.HostMaster
 		*out = new(HostMaster)
@@ -375,6 +607,26 @@ func (in *L2VNISpec) DeepCopyInto(out *L2VNISpec) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.GatewayMAC != nil {
+		in, out := &in.GatewayMAC, &out.GatewayMAC
+		*out = new(string)
+		**out = **in
+	}
+	if in.GatewayAdvertisements != nil {
+		in, out := &in.GatewayAdvertisements, &out.GatewayAdvertisements
+		*out = make([]GatewayAdvertisement, len(*in))
+		copy(*out, *in)
+	}
+	if in.NeighborSuppression != nil {
+		in, out := &in.NeighborSuppression, &out.NeighborSuppression
+		*out = new(NeighborSuppression)
+		**out = **in
+	}
+	if in.EthernetSegment != nil {
+		in, out := &in.EthernetSegment, &out.EthernetSegment
+		*out = new(EthernetSegment)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new L2VNISpec.
@@ -390,6 +642,7 @@ func (in *L2VNISpec) DeepCopy() *L2VNISpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *L2VNIStatus) DeepCopyInto(out *L2VNIStatus) {
 	*out = *in
+	in.NodesConfigurationStatus.DeepCopyInto(&out.NodesConfigurationStatus)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new L2VNIStatus.
@@ -411,7 +664,7 @@ func (in *L3Passthrough) DeepCopyInto(out *L3Passthrough) {
 	if in.Status != nil {
 		in, out := &in.Status, &out.Status
 		*out = new(L3PassthroughStatus)
-		**out = **in
+		(*in).DeepCopyInto(*out)
 	}
 }
 
@@ -489,6 +742,7 @@ func (in *L3PassthroughSpec) DeepCopy() *L3PassthroughSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *L3PassthroughStatus) DeepCopyInto(out *L3PassthroughStatus) {
 	*out = *in
+	in.NodesConfigurationStatus.DeepCopyInto(&out.NodesConfigurationStatus)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new L3PassthroughStatus.
@@ -510,7 +764,7 @@ func (in *L3VNI) DeepCopyInto(out *L3VNI) {
 	if in.Status != nil {
 		in, out := &in.Status, &out.Status
 		*out = new(L3VNIStatus)
-		**out = **in
+		(*in).DeepCopyInto(*out)
 	}
 }
 
@@ -597,6 +851,11 @@ func (in *L3VNISpec) DeepCopyInto(out *L3VNISpec) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.Underlay != nil {
+		in, out := &in.Underlay, &out.Underlay
+		*out = new(string)
+		**out = **in
+	}
 	if in.HostSession != nil {
 		in, out := &in.HostSession, &out.HostSession
 		*out = new(HostSession)
@@ -612,6 +871,11 @@ func (in *L3VNISpec) DeepCopyInto(out *L3VNISpec) {
 		*out = make([]RouteTarget, len(*in))
 		copy(*out, *in)
 	}
+	if in.RouteLeaking != nil {
+		in, out := &in.RouteLeaking, &out.RouteLeaking
+		*out = new(RouteLeaking)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new L3VNISpec.
@@ -627,6 +891,7 @@ func (in *L3VNISpec) DeepCopy() *L3VNISpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *L3VNIStatus) DeepCopyInto(out *L3VNIStatus) {
 	*out = *in
+	in.NodesConfigurationStatus.DeepCopyInto(&out.NodesConfigurationStatus)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new L3VNIStatus.
@@ -648,7 +913,7 @@ func (in *L3VPN) DeepCopyInto(out *L3VPN) {
 	if in.Status != nil {
 		in, out := &in.Status, &out.Status
 		*out = new(L3VPNStatus)
-		**out = **in
+		(*in).DeepCopyInto(*out)
 	}
 }
 
@@ -740,6 +1005,11 @@ func (in *L3VPNSpec) DeepCopyInto(out *L3VPNSpec) {
 		*out = new(HostSession)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.RouteLeaking != nil {
+		in, out := &in.RouteLeaking, &out.RouteLeaking
+		*out = new(RouteLeaking)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new L3VPNSpec.
@@ -755,6 +1025,7 @@ func (in *L3VPNSpec) DeepCopy() *L3VPNSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *L3VPNStatus) DeepCopyInto(out *L3VPNStatus) {
 	*out = *in
+	in.NodesConfigurationStatus.DeepCopyInto(&out.NodesConfigurationStatus)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new L3VPNStatus.
@@ -812,6 +1083,36 @@ func (in *LocalCIDRConfig) DeepCopy() *LocalCIDRConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MaximumPrefix) DeepCopyInto(out *MaximumPrefix) {
+	*out = *in
+	if in.IPv4 != nil {
+		in, out := &in.IPv4, &out.IPv4
+		*out = new(int64)
+		**out = **in
+	}
+	if in.IPv6 != nil {
+		in, out := &in.IPv6, &out.IPv6
+		*out = new(int64)
+		**out = **in
+	}
+	if in.WarningThresholdPercent != nil {
+		in, out := &in.WarningThresholdPercent, &out.WarningThresholdPercent
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MaximumPrefix.
+func (in *MaximumPrefix) DeepCopy() *MaximumPrefix {
+	if in == nil {
+		return nil
+	}
+	out := new(MaximumPrefix)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Neighbor) DeepCopyInto(out *Neighbor) {
 	*out = *in
@@ -909,6 +1210,16 @@ func (in *NeighborAddressFamily) DeepCopyInto(out *NeighborAddressFamily) {
 		*out = make([]AddressFamilyProperty, len(*in))
 		copy(*out, *in)
 	}
+	if in.ImportPolicy != nil {
+		in, out := &in.ImportPolicy, &out.ImportPolicy
+		*out = new(RoutePolicy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ExportPolicy != nil {
+		in, out := &in.ExportPolicy, &out.ExportPolicy
+		*out = new(RoutePolicy)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NeighborAddressFamily.
@@ -957,50 +1268,30 @@ func (in *NetworkDevice) DeepCopy() *NetworkDevice {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *OVSBridgeConfig) DeepCopyInto(out *OVSBridgeConfig) {
-	*out = *in
-	if in.Name != nil {
-		in, out := &in.Name, &out.Name
-		*out = new(string)
-		**out = **in
-	}
-}
-
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OVSBridgeConfig.
-func (in *OVSBridgeConfig) DeepCopy() *OVSBridgeConfig {
-	if in == nil {
-		return nil
-	}
-	out := new(OVSBridgeConfig)
-	in.DeepCopyInto(out)
-	return out
-}
-
-// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *RawFRRConfig) DeepCopyInto(out *RawFRRConfig) {
+func (in *NodeAddressing) DeepCopyInto(out *NodeAddressing) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
 	if in.Status != nil {
 		in, out := &in.Status, &out.Status
-		*out = new(RawFRRConfigStatus)
+		*out = new(NodeAddressingStatus)
 		**out = **in
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RawFRRConfig.
-func (in *RawFRRConfig) DeepCopy() *RawFRRConfig {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeAddressing.
+func (in *NodeAddressing) DeepCopy() *NodeAddressing {
 	if in == nil {
 		return nil
 	}
-	out := new(RawFRRConfig)
+	out := new(NodeAddressing)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *RawFRRConfig) DeepCopyObject() runtime.Object {
+func (in *NodeAddressing) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -1008,31 +1299,31 @@ func (in *RawFRRConfig) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *RawFRRConfigList) DeepCopyInto(out *RawFRRConfigList) {
+func (in *NodeAddressingList) DeepCopyInto(out *NodeAddressingList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]RawFRRConfig, len(*in))
+		*out = make([]NodeAddressing, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RawFRRConfigList.
-func (in *RawFRRConfigList) DeepCopy() *RawFRRConfigList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeAddressingList.
+func (in *NodeAddressingList) DeepCopy() *NodeAddressingList {
 	if in == nil {
 		return nil
 	}
-	out := new(RawFRRConfigList)
+	out := new(NodeAddressingList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *RawFRRConfigList) DeepCopyObject() runtime.Object {
+func (in *NodeAddressingList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -1040,16 +1331,302 @@ func (in *RawFRRConfigList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *RawFRRConfigSpec) DeepCopyInto(out *RawFRRConfigSpec) {
+func (in *NodeAddressingSpec) DeepCopyInto(out *NodeAddressingSpec) {
 	*out = *in
-	if in.NodeSelector != nil {
-		in, out := &in.NodeSelector, &out.NodeSelector
-		*out = new(v1.LabelSelector)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.Priority != nil {
-		in, out := &in.Priority, &out.Priority
-		*out = new(int32)
+	if in.RouterID != nil {
+		in, out := &in.RouterID, &out.RouterID
+		*out = new(string)
+		**out = **in
+	}
+	if in.TunnelEndpoints != nil {
+		in, out := &in.TunnelEndpoints, &out.TunnelEndpoints
+		*out = make([]TunnelEndpointAddresses, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.HostSessions != nil {
+		in, out := &in.HostSessions, &out.HostSessions
+		*out = make([]HostSessionAddresses, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeAddressingSpec.
+func (in *NodeAddressingSpec) DeepCopy() *NodeAddressingSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(NodeAddressingSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodeAddressingStatus) DeepCopyInto(out *NodeAddressingStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeAddressingStatus.
+func (in *NodeAddressingStatus) DeepCopy() *NodeAddressingStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(NodeAddressingStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodeFailure) DeepCopyInto(out *NodeFailure) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeFailure.
+func (in *NodeFailure) DeepCopy() *NodeFailure {
+	if in == nil {
+		return nil
+	}
+	out := new(NodeFailure)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodesConfigurationStatus) DeepCopyInto(out *NodesConfigurationStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.NodeFailures != nil {
+		in, out := &in.NodeFailures, &out.NodeFailures
+		*out = make([]NodeFailure, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodesConfigurationStatus.
+func (in *NodesConfigurationStatus) DeepCopy() *NodesConfigurationStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(NodesConfigurationStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OSPFConfig) DeepCopyInto(out *OSPFConfig) {
+	*out = *in
+	if in.Area != nil {
+		in, out := &in.Area, &out.Area
+		*out = new(OSPFArea)
+		**out = **in
+	}
+	if in.IPFamily != nil {
+		in, out := &in.IPFamily, &out.IPFamily
+		*out = new(IPFamily)
+		**out = **in
+	}
+	if in.Interfaces != nil {
+		in, out := &in.Interfaces, &out.Interfaces
+		*out = make([]OSPFInterface, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OSPFConfig.
+func (in *OSPFConfig) DeepCopy() *OSPFConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(OSPFConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OSPFInterface) DeepCopyInto(out *OSPFInterface) {
+	*out = *in
+	if in.Features != nil {
+		in, out := &in.Features, &out.Features
+		*out = make([]OSPFInterfaceFeature, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OSPFInterface.
+func (in *OSPFInterface) DeepCopy() *OSPFInterface {
+	if in == nil {
+		return nil
+	}
+	out := new(OSPFInterface)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OVSBridgeConfig) DeepCopyInto(out *OVSBridgeConfig) {
+	*out = *in
+	if in.Name != nil {
+		in, out := &in.Name, &out.Name
+		*out = new(string)
+		**out = **in
+	}
+	if in.Sampling != nil {
+		in, out := &in.Sampling, &out.Sampling
+		*out = new(OVSSampling)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OVSBridgeConfig.
+func (in *OVSBridgeConfig) DeepCopy() *OVSBridgeConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(OVSBridgeConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OVSSampling) DeepCopyInto(out *OVSSampling) {
+	*out = *in
+	if in.Collectors != nil {
+		in, out := &in.Collectors, &out.Collectors
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.SamplingRate != nil {
+		in, out := &in.SamplingRate, &out.SamplingRate
+		*out = new(int32)
+		**out = **in
+	}
+	if in.PollingInterval != nil {
+		in, out := &in.PollingInterval, &out.PollingInterval
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OVSSampling.
+func (in *OVSSampling) DeepCopy() *OVSSampling {
+	if in == nil {
+		return nil
+	}
+	out := new(OVSSampling)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PrefixMatch) DeepCopyInto(out *PrefixMatch) {
+	*out = *in
+	if in.GE != nil {
+		in, out := &in.GE, &out.GE
+		*out = new(int32)
+		**out = **in
+	}
+	if in.LE != nil {
+		in, out := &in.LE, &out.LE
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PrefixMatch.
+func (in *PrefixMatch) DeepCopy() *PrefixMatch {
+	if in == nil {
+		return nil
+	}
+	out := new(PrefixMatch)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RawFRRConfig) DeepCopyInto(out *RawFRRConfig) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	if in.Status != nil {
+		in, out := &in.Status, &out.Status
+		*out = new(RawFRRConfigStatus)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RawFRRConfig.
+func (in *RawFRRConfig) DeepCopy() *RawFRRConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(RawFRRConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *RawFRRConfig) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RawFRRConfigList) DeepCopyInto(out *RawFRRConfigList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]RawFRRConfig, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RawFRRConfigList.
+func (in *RawFRRConfigList) DeepCopy() *RawFRRConfigList {
+	if in == nil {
+		return nil
+	}
+	out := new(RawFRRConfigList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *RawFRRConfigList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RawFRRConfigSpec) DeepCopyInto(out *RawFRRConfigSpec) {
+	*out = *in
+	if in.NodeSelector != nil {
+		in, out := &in.NodeSelector, &out.NodeSelector
+		*out = new(v1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Priority != nil {
+		in, out := &in.Priority, &out.Priority
+		*out = new(int32)
 		**out = **in
 	}
 }
@@ -1079,6 +1656,154 @@ func (in *RawFRRConfigStatus) DeepCopy() *RawFRRConfigStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RouteLeaking) DeepCopyInto(out *RouteLeaking) {
+	*out = *in
+	if in.ImportFrom != nil {
+		in, out := &in.ImportFrom, &out.ImportFrom
+		*out = make([]VRFRouteLeak, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ExportTo != nil {
+		in, out := &in.ExportTo, &out.ExportTo
+		*out = make([]VRFRouteLeak, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RouteLeaking.
+func (in *RouteLeaking) DeepCopy() *RouteLeaking {
+	if in == nil {
+		return nil
+	}
+	out := new(RouteLeaking)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RoutePolicy) DeepCopyInto(out *RoutePolicy) {
+	*out = *in
+	if in.Rules != nil {
+		in, out := &in.Rules, &out.Rules
+		*out = make([]RoutePolicyRule, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.DefaultAction != nil {
+		in, out := &in.DefaultAction, &out.DefaultAction
+		*out = new(RoutePolicyAction)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RoutePolicy.
+func (in *RoutePolicy) DeepCopy() *RoutePolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(RoutePolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RoutePolicyMatch) DeepCopyInto(out *RoutePolicyMatch) {
+	*out = *in
+	if in.Prefixes != nil {
+		in, out := &in.Prefixes, &out.Prefixes
+		*out = make([]PrefixMatch, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Communities != nil {
+		in, out := &in.Communities, &out.Communities
+		*out = make([]BGPCommunity, len(*in))
+		copy(*out, *in)
+	}
+	if in.LargeCommunities != nil {
+		in, out := &in.LargeCommunities, &out.LargeCommunities
+		*out = make([]BGPLargeCommunity, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RoutePolicyMatch.
+func (in *RoutePolicyMatch) DeepCopy() *RoutePolicyMatch {
+	if in == nil {
+		return nil
+	}
+	out := new(RoutePolicyMatch)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RoutePolicyRule) DeepCopyInto(out *RoutePolicyRule) {
+	*out = *in
+	if in.Match != nil {
+		in, out := &in.Match, &out.Match
+		*out = new(RoutePolicyMatch)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Set != nil {
+		in, out := &in.Set, &out.Set
+		*out = new(RoutePolicySet)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RoutePolicyRule.
+func (in *RoutePolicyRule) DeepCopy() *RoutePolicyRule {
+	if in == nil {
+		return nil
+	}
+	out := new(RoutePolicyRule)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RoutePolicySet) DeepCopyInto(out *RoutePolicySet) {
+	*out = *in
+	if in.LocalPreference != nil {
+		in, out := &in.LocalPreference, &out.LocalPreference
+		*out = new(int64)
+		**out = **in
+	}
+	if in.Communities != nil {
+		in, out := &in.Communities, &out.Communities
+		*out = make([]BGPCommunity, len(*in))
+		copy(*out, *in)
+	}
+	if in.LargeCommunities != nil {
+		in, out := &in.LargeCommunities, &out.LargeCommunities
+		*out = make([]BGPLargeCommunity, len(*in))
+		copy(*out, *in)
+	}
+	if in.ASPathPrepend != nil {
+		in, out := &in.ASPathPrepend, &out.ASPathPrepend
+		*out = new(ASPathPrepend)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RoutePolicySet.
+func (in *RoutePolicySet) DeepCopy() *RoutePolicySet {
+	if in == nil {
+		return nil
+	}
+	out := new(RoutePolicySet)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RouteReflectorConfig) DeepCopyInto(out *RouteReflectorConfig) {
 	*out = *in
@@ -1176,6 +1901,11 @@ func (in *RouterNodeConfigurationStatusStatus) DeepCopyInto(out *RouterNodeConfi
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.OperationalState != nil {
+		in, out := &in.OperationalState, &out.OperationalState
+		*out = new(RouterOperationalState)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RouterNodeConfigurationStatusStatus.
@@ -1188,6 +1918,42 @@ func (in *RouterNodeConfigurationStatusStatus) DeepCopy() *RouterNodeConfigurati
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RouterOperationalState) DeepCopyInto(out *RouterOperationalState) {
+	*out = *in
+	in.LastUpdateTime.DeepCopyInto(&out.LastUpdateTime)
+	if in.UnderlayNeighbors != nil {
+		in, out := &in.UnderlayNeighbors, &out.UnderlayNeighbors
+		*out = make([]BGPNeighborState, len(*in))
+		copy(*out, *in)
+	}
+	if in.HostSessions != nil {
+		in, out := &in.HostSessions, &out.HostSessions
+		*out = make([]HostSessionState, len(*in))
+		copy(*out, *in)
+	}
+	if in.BFDPeers != nil {
+		in, out := &in.BFDPeers, &out.BFDPeers
+		*out = make([]BFDPeerState, len(*in))
+		copy(*out, *in)
+	}
+	if in.VRFs != nil {
+		in, out := &in.VRFs, &out.VRFs
+		*out = make([]VRFRoutesState, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RouterOperationalState.
+func (in *RouterOperationalState) DeepCopy() *RouterOperationalState {
+	if in == nil {
+		return nil
+	}
+	out := new(RouterOperationalState)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RoutingDomain) DeepCopyInto(out *RoutingDomain) {
 	*out = *in
@@ -1249,6 +2015,26 @@ func (in *SRV6Locator) DeepCopy() *SRV6Locator {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TunnelEndpointAddresses) DeepCopyInto(out *TunnelEndpointAddresses) {
+	*out = *in
+	if in.IPs != nil {
+		in, out := &in.IPs, &out.IPs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TunnelEndpointAddresses.
+func (in *TunnelEndpointAddresses) DeepCopy() *TunnelEndpointAddresses {
+	if in == nil {
+		return nil
+	}
+	out := new(TunnelEndpointAddresses)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TunnelEndpointConfig) DeepCopyInto(out *TunnelEndpointConfig) {
 	*out = *in
@@ -1278,7 +2064,7 @@ func (in *Underlay) DeepCopyInto(out *Underlay) {
 	if in.Status != nil {
 		in, out := &in.Status, &out.Status
 		*out = new(UnderlayStatus)
-		**out = **in
+		(*in).DeepCopyInto(*out)
 	}
 }
 
@@ -1399,6 +2185,11 @@ func (in *UnderlaySpec) DeepCopyInto(out *UnderlaySpec) {
 		*out = new(ISISConfig)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.OSPF != nil {
+		in, out := &in.OSPF, &out.OSPF
+		*out = new(OSPFConfig)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.SRV6 != nil {
 		in, out := &in.SRV6, &out.SRV6
 		*out = new(SRV6Config)
@@ -1424,6 +2215,7 @@ func (in *UnderlaySpec) DeepCopy() *UnderlaySpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *UnderlayStatus) DeepCopyInto(out *UnderlayStatus) {
 	*out = *in
+	in.NodesConfigurationStatus.DeepCopyInto(&out.NodesConfigurationStatus)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UnderlayStatus.
@@ -1435,3 +2227,40 @@ func (in *UnderlayStatus) DeepCopy() *UnderlayStatus {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VRFRouteLeak) DeepCopyInto(out *VRFRouteLeak) {
+	*out = *in
+	if in.Prefixes != nil {
+		in, out := &in.Prefixes, &out.Prefixes
+		*out = make([]PrefixMatch, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VRFRouteLeak.
+func (in *VRFRouteLeak) DeepCopy() *VRFRouteLeak {
+	if in == nil {
+		return nil
+	}
+	out := new(VRFRouteLeak)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VRFRoutesState) DeepCopyInto(out *VRFRoutesState) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VRFRoutesState.
+func (in *VRFRoutesState) DeepCopy() *VRFRoutesState {
+	if in == nil {
+		return nil
+	}
+	out := new(VRFRoutesState)
+	in.DeepCopyInto(out)
+	return out
+}
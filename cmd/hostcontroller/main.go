@@ -44,6 +44,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/event"
 	"sigs.k8s.io/controller-runtime/pkg/healthz"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
 	"sigs.k8s.io/controller-runtime/pkg/metrics/server"
 
 	"github.com/go-logr/logr"
@@ -57,6 +58,8 @@ import (
 	"github.com/openperouter/openperouter/internal/dhcp"
 	"github.com/openperouter/openperouter/internal/filewatcher"
 	"github.com/openperouter/openperouter/internal/frr"
+	frrmetrics "github.com/openperouter/openperouter/internal/frr/metrics"
+	"github.com/openperouter/openperouter/internal/frr/vtysh"
 	"github.com/openperouter/openperouter/internal/hostnetwork"
 	"github.com/openperouter/openperouter/internal/logging"
 	"github.com/openperouter/openperouter/internal/staticconfiguration"
@@ -71,6 +74,8 @@ const (
 	modeK8s          = "k8s"
 	modeHost         = "host"
 	restartDHCPEvent = "dhcp-restart-trigger"
+	// eventSource is the reporting controller of the events emitted on the resources.
+	eventSource = "openperouter-controller"
 )
 
 var (
@@ -125,19 +130,22 @@ func (s *stringSliceFlag) Set(value string) error {
 }
 
 type parameters struct {
-	probeAddr       string
-	frrConfigPath   string
-	reloaderSocket  string
-	mode            string
-	ovsSocketPath   string
-	nodeName        string
-	namespace       string
-	logLevel        string
-	bgpListenLimit  uint
-	cniPluginDirs   stringSliceFlag
-	cniCacheDir     string
-	datapath        string
-	groutSocketPath string
+	probeAddr                 string
+	metricsAddr               string
+	frrMetricsInterval        time.Duration
+	operationalStatusInterval time.Duration
+	frrConfigPath             string
+	reloaderSocket            string
+	mode                      string
+	ovsSocketPath             string
+	nodeName                  string
+	namespace                 string
+	logLevel                  string
+	bgpListenLimit            uint
+	cniPluginDirs             stringSliceFlag
+	cniCacheDir               string
+	datapath                  string
+	groutSocketPath           string
 }
 
 func main() {
@@ -147,6 +155,12 @@ func main() {
 	args := parameters{}
 
 	flag.StringVar(&args.probeAddr, "health-probe-bind-address", ":9081", "The address the probe endpoint binds to.")
+	flag.StringVar(&args.metricsAddr, "metrics-bind-address", ":9082",
+		"The address the metrics endpoint binds to. Set to 0 to disable the metrics endpoint.")
+	flag.DurationVar(&args.frrMetricsInterval, "frr-metrics-interval", 30*time.Second,
+		"the interval FRR is polled at to refresh the BGP and BFD metrics")
+	flag.DurationVar(&args.operationalStatusInterval, "operational-status-interval", time.Minute,
+		"the interval FRR is polled at to refresh the operational state in the node status. Set to 0 to disable it.")
 	flag.StringVar(&args.logLevel, "loglevel", "info", "the verbosity of the process")
 	flag.UintVar(&args.bgpListenLimit, "bgplistenlimit", frr.DefaultListenLimit,
 		"the maximum number of dynamic BGP sessions accepted via listen ranges (1-65535)")
@@ -239,6 +253,10 @@ func main() {
 		os.Exit(1)
 	}
 
+	if args.metricsAddr != "0" {
+		startFRRMetrics(ctx, args.frrMetricsInterval, logger)
+	}
+
 	if args.mode == modeK8s {
 		runK8sMode(ctx, args, logger)
 		return
@@ -329,8 +347,8 @@ func runHostMode(
 		"node", args.nodeName, "nodeIndex", nodeConfig.NodeIndex.Index)
 
 	stopStaticReconciler()
-	// Wait for the static reconciler to fully stop and release the health probe port
-	// before starting the K8s reconciler, which binds the same port.
+	// Wait for the static reconciler to fully stop and release the health probe and
+	// metrics ports before starting the K8s reconciler, which binds the same ports.
 	<-staticDone
 	logger.Info("static reconciler fully stopped, starting k8s reconciler")
 
@@ -353,6 +371,7 @@ func runK8sConfigReconcilerHostMode(ctx context.Context,
 
 	mgr, err := createK8sManager(k8sConfig, args.nodeName, args.namespace, func(opts *ctrl.Options) {
 		opts.HealthProbeBindAddress = args.probeAddr
+		opts.Metrics = server.Options{BindAddress: args.metricsAddr}
 	})
 	if err != nil {
 		return fmt.Errorf("unable to start manager: %w", err)
@@ -384,6 +403,10 @@ func runK8sConfigReconcilerHostMode(ctx context.Context,
 		return fmt.Errorf("unable to add DHCP supervisor: %w", err)
 	}
 
+	if err := addOperationalStatusReporter(mgr, args, logger); err != nil {
+		return err
+	}
+
 	apiReconciler := &routerconfiguration.PERouterReconciler{
 		Client:               mgr.GetClient(),
 		Scheme:               mgr.GetScheme(),
@@ -398,6 +421,7 @@ func runK8sConfigReconcilerHostMode(ctx context.Context,
 		NodeConfigPath:       hostModeParams.nodeConfigPath,
 		TriggerChan:          triggerChan,
 		DatapathConfigurator: datapathConfigurator,
+		EventRecorder:        mgr.GetEventRecorder(eventSource),
 	}
 
 	if err := apiReconciler.SetupWithManager(mgr); err != nil {
@@ -465,6 +489,7 @@ func runK8sConfigReconciler(ctx context.Context,
 
 	mgr, err := createK8sManager(k8sConfig, args.nodeName, args.namespace, func(opts *ctrl.Options) {
 		opts.HealthProbeBindAddress = probeAddr
+		opts.Metrics = server.Options{BindAddress: args.metricsAddr}
 	})
 	if err != nil {
 		return fmt.Errorf("unable to start manager: %w", err)
@@ -492,6 +517,10 @@ func runK8sConfigReconciler(ctx context.Context,
 		return fmt.Errorf("unable to add DHCP supervisor: %w", err)
 	}
 
+	if err := addOperationalStatusReporter(mgr, args, logger); err != nil {
+		return err
+	}
+
 	apiReconciler := &routerconfiguration.PERouterReconciler{
 		Client:               mgr.GetClient(),
 		Scheme:               mgr.GetScheme(),
@@ -504,6 +533,7 @@ func runK8sConfigReconciler(ctx context.Context,
 		MyNamespace:          args.namespace,
 		DatapathConfigurator: datapathConfigurator,
 		TriggerChan:          triggerChan,
+		EventRecorder:        mgr.GetEventRecorder(eventSource),
 	}
 
 	if err := apiReconciler.SetupWithManager(mgr); err != nil {
@@ -529,7 +559,7 @@ func runStaticConfigReconciler(ctx context.Context,
 		HealthProbeBindAddress: probeAddr,
 		LeaderElection:         false,
 		Metrics: server.Options{
-			BindAddress: "0", // disable metrics
+			BindAddress: args.metricsAddr,
 		},
 	})
 	if err != nil {
@@ -613,6 +643,11 @@ func createK8sManager(
 	namespace string,
 	modifiers ...func(*ctrl.Options),
 ) (ctrl.Manager, error) {
+	// The Secrets carrying the credentials label, whatever its value.
+	credentialsSecrets, err := labels.Parse(periov1alpha1.CredentialsSecretLabel)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build the credentials secret selector: %w", err)
+	}
 	opts := ctrl.Options{
 		Scheme: scheme,
 		// Restrict client cache/informer to events for the node running this pod.
@@ -631,6 +666,14 @@ func createK8sManager(
 						"metadata.namespace": namespace,
 					}.AsSelector(),
 				},
+				// Only the labeled Secrets of the namespace can hold the
+				// passwords referenced by the resources.
+				&corev1.Secret{}: {
+					Label: credentialsSecrets,
+					Field: fields.Set{
+						"metadata.namespace": namespace,
+					}.AsSelector(),
+				},
 				&periov1alpha1.RouterNodeConfigurationStatus{}: {
 					Field: fields.Set{
 						"metadata.name":      nodeName,
@@ -658,6 +701,39 @@ func createK8sManager(
 	return res, nil
 }
 
+// startFRRMetrics registers the collector exposing the BGP and BFD sessions state
+// and starts polling FRR. The collector is registered to the controller-runtime
+// registry, so it is served by the metrics endpoint of whatever manager is running.
+func startFRRMetrics(ctx context.Context, interval time.Duration, logger *slog.Logger) {
+	collector := frrmetrics.NewCollector(vtysh.NewCLI(), interval, logger)
+	metrics.Registry.MustRegister(collector)
+	go func() {
+		if err := collector.Start(ctx); err != nil {
+			logger.Error("frr metrics collector failed", "error", err)
+		}
+	}()
+}
+
+// addOperationalStatusReporter adds to the manager the runnable publishing
+// the runtime state of the router in the node status.
+func addOperationalStatusReporter(mgr ctrl.Manager, args parameters, logger *slog.Logger) error {
+	if args.operationalStatusInterval == 0 {
+		return nil
+	}
+	reporter := &routerconfiguration.OperationalStatusReporter{
+		Client:      mgr.GetClient(),
+		MyNode:      args.nodeName,
+		MyNamespace: args.namespace,
+		Logger:      logger,
+		Interval:    args.operationalStatusInterval,
+		FRRCli:      vtysh.NewCLI(),
+	}
+	if err := mgr.Add(reporter); err != nil {
+		return fmt.Errorf("unable to add operational status reporter: %w", err)
+	}
+	return nil
+}
+
 func waitForKubernetes(ctx context.Context, waitInterval time.Duration) (*rest.Config, error) {
 	var config *rest.Config
 	err := wait.PollUntilContextCancel(ctx, waitInterval, true, func(ctx context.Context) (bool, error) {
@@ -700,6 +776,12 @@ func validateParameters(args parameters, hostModeParams hostModeParameters) erro
 	if args.namespace == "" {
 		return fmt.Errorf("namespace is required")
 	}
+	if args.frrMetricsInterval <= 0 {
+		return fmt.Errorf("frr-metrics-interval must be positive")
+	}
+	if args.operationalStatusInterval < 0 {
+		return fmt.Errorf("operational-status-interval must not be negative")
+	}
 
 	if args.mode == modeK8s {
 		if hostModeParams.hostContainerPidPath != "" {
@@ -22,15 +22,18 @@ import (
 	"fmt"
 	"log/slog"
 	"os"
+	"time"
 
 	_ "k8s.io/client-go/plugin/pkg/client/auth"
 
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/healthz"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
 	metricsserver "sigs.k8s.io/controller-runtime/pkg/metrics/server"
@@ -41,6 +44,7 @@ import (
 	"github.com/openperouter/openperouter/api/v1alpha1"
 	"github.com/openperouter/openperouter/internal/buildversion"
 	"github.com/openperouter/openperouter/internal/controller/nodeindex"
+	"github.com/openperouter/openperouter/internal/controller/resourcestatus"
 	"github.com/openperouter/openperouter/internal/conversion"
 	"github.com/openperouter/openperouter/internal/logging"
 	"github.com/openperouter/openperouter/internal/tlsconfig"
@@ -58,6 +62,9 @@ const (
 	WebhookModeDisabled    = "disabled"
 	WebhookModeEnabled     = "enabled"
 	WebhookModeWebhookOnly = "webhookonly"
+
+	// eventSource is the reporting controller of the events emitted on the nodes.
+	eventSource = "openperouter-nodemarker"
 )
 
 func init() {
@@ -83,6 +90,7 @@ func main() {
 		certDir                       string
 		certServiceName               string
 		datapath                      string
+		nodeIndexGracePeriod          time.Duration
 	}{}
 
 	flag.StringVar(&args.metricsAddr, "metrics-bind-address", ":8080", "The address the metric endpoint binds to.")
@@ -105,6 +113,8 @@ func main() {
 	flag.IntVar(&args.webhookPort, "webhook-port", 9443, "the port of the webhook service")
 	flag.StringVar(&args.webhookMode, "webhookmode", WebhookModeEnabled, "webhook mode: disabled, enabled, or webhookonly")
 	flag.StringVar(&args.datapath, "datapath", "kernel", "The datapath to use (kernel or grout)")
+	flag.DurationVar(&args.nodeIndexGracePeriod, "nodeindex-grace-period", time.Hour,
+		"How long the index of a deleted node is kept before being assigned to another node.")
 
 	flag.Parse()
 
@@ -136,9 +146,25 @@ func main() {
 		TLSOpts:       args.tlsOpts,
 	}
 
+	operatorNS := args.namespace
+	if operatorNS == "" {
+		operatorNS = os.Getenv("POD_NAMESPACE")
+	}
+
+	// The only ConfigMap the nodemarker reads is the one recording the node
+	// indexes, in its own namespace.
+	cacheOptions := cache.Options{}
+	if operatorNS != "" {
+		cacheOptions.ByObject = map[client.Object]cache.ByObject{
+			&corev1.ConfigMap{}: {
+				Namespaces: map[string]cache.Config{operatorNS: {}},
+			},
+		}
+	}
+
 	mgr, err := ctrl.NewManager(ctrl.GetConfigOrDie(), ctrl.Options{
 		Scheme:                 scheme,
-		Cache:                  cache.Options{},
+		Cache:                  cacheOptions,
 		Metrics:                metricsServerOptions,
 		HealthProbeBindAddress: args.probeAddr,
 		WebhookServer: webhook.NewServer(
@@ -168,14 +194,26 @@ func main() {
 		if args.webhookMode != WebhookModeWebhookOnly {
 			setupLog.Info("Starting controllers")
 			if err = (&nodeindex.NodesReconciler{
-				Client:   mgr.GetClient(),
-				Scheme:   mgr.GetScheme(),
-				LogLevel: args.logLevel,
-				Logger:   logger,
+				Client:        mgr.GetClient(),
+				Scheme:        mgr.GetScheme(),
+				LogLevel:      args.logLevel,
+				Logger:        logger,
+				Namespace:     operatorNS,
+				GracePeriod:   args.nodeIndexGracePeriod,
+				EventRecorder: mgr.GetEventRecorder(eventSource),
 			}).SetupWithManager(signalHandlerContext, mgr); err != nil {
 				setupLog.Error(err, "unable to create controller", "controller", "NodeReconciler")
 				os.Exit(1)
 			}
+			if err = (&resourcestatus.Reconciler{
+				Client:    mgr.GetClient(),
+				Scheme:    mgr.GetScheme(),
+				Logger:    logger,
+				Namespace: args.namespace,
+			}).SetupWithManager(mgr); err != nil {
+				setupLog.Error(err, "unable to create controller", "controller", "ResourceStatusReconciler")
+				os.Exit(1)
+			}
 			// +kubebuilder:scaffold:builder
 		}
 
@@ -184,11 +222,6 @@ func main() {
 			datapathConfigValidator = &conversion.GroutDatapathConfigValidator{}
 		}
 
-		operatorNS := args.namespace
-		if operatorNS == "" {
-			operatorNS = os.Getenv("POD_NAMESPACE")
-		}
-
 		if args.webhookMode == WebhookModeEnabled || args.webhookMode == WebhookModeWebhookOnly {
 			setupLog.Info("Starting webhooks")
 			if err := v1alpha1.AddToScheme(mgr.GetScheme()); err != nil {
@@ -295,6 +328,10 @@ func setupWebhook(
 		logger.Error("unable to create the webhook", "error", err, "webhook", "L3Passthroughs")
 		return err
 	}
+	if err := webhooks.SetupNodeAddressing(mgr); err != nil {
+		logger.Error("unable to create the webhook", "error", err, "webhook", "NodeAddressings")
+		return err
+	}
 	if err := webhooks.SetupRawFRRConfig(mgr, operatorNamespace); err != nil {
 		logger.Error("unable to create the webhook", "error", err, "webhook", "RawFRRConfigs")
 		return err
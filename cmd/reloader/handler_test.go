@@ -3,6 +3,7 @@
 package main
 
 import (
+	"encoding/json"
 	"errors"
 	"net/http"
 	"net/http/httptest"
@@ -11,38 +12,59 @@ import (
 	"github.com/openperouter/openperouter/internal/frrconfig"
 )
 
+var testDiff = frrconfig.ConfigDiff{
+	Added: []frrconfig.ConfigChange{
+		{Context: []string{"router bgp 64514"}, Line: "neighbor 192.168.11.3 remote-as 64512"},
+	},
+}
+
 func TestHandler(t *testing.T) {
-	reloadSucceeds := func(_ string) error {
-		return nil
+	reloadSucceeds := func(_ string) (frrconfig.ConfigDiff, error) {
+		return testDiff, nil
+	}
+
+	reloadUnchanged := func(_ string) (frrconfig.ConfigDiff, error) {
+		return frrconfig.ConfigDiff{}, nil
 	}
 
-	reloadFails := func(_ string) error {
-		return errors.New("failed")
+	reloadFails := func(_ string) (frrconfig.ConfigDiff, error) {
+		return frrconfig.ConfigDiff{}, errors.New("failed")
 	}
 
 	tests := []struct {
-		name       string
-		reloadMock func(string) error
-		method     string
-		httpStatus int
+		name         string
+		reloadMock   func(string) (frrconfig.ConfigDiff, error)
+		method       string
+		httpStatus   int
+		wantReloaded bool
 	}{
 		{
 			"succeeds",
 			reloadSucceeds,
 			http.MethodPost,
 			200,
+			true,
+		},
+		{
+			"unchanged",
+			reloadUnchanged,
+			http.MethodPost,
+			200,
+			false,
 		},
 		{
 			"wrong method",
 			reloadSucceeds,
 			http.MethodGet,
 			http.StatusBadRequest,
+			false,
 		},
 		{
 			"reload fails",
 			reloadFails,
 			http.MethodPost,
 			http.StatusInternalServerError,
+			false,
 		},
 	}
 
@@ -54,7 +76,7 @@ func TestHandler(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			w := httptest.NewRecorder()
 			req, _ := http.NewRequest(tc.method, "/", nil)
-			handler := http.HandlerFunc(reloadHandler("/etc/frr/frr.conf"))
+			handler := http.HandlerFunc(reloadHandler(newTestReloader(t), &reloadStatus{}))
 
 			handler.ServeHTTP(w, req)
 			res := w.Result()
@@ -64,6 +86,69 @@ func TestHandler(t *testing.T) {
 			if res.StatusCode != tc.httpStatus {
 				t.Fatalf("expecting %d, got %d", res.StatusCode, tc.httpStatus)
 			}
+			if tc.method != http.MethodPost {
+				return
+			}
+			var result frrconfig.ReloadResult
+			if err := json.NewDecoder(w.Body).Decode(&result); err != nil {
+				t.Fatalf("failed to decode the reload result: %s", err)
+			}
+			if (res.StatusCode != http.StatusOK) != (result.Error != "") {
+				t.Fatalf("expecting an error in the result for status %d, got %q", res.StatusCode, result.Error)
+			}
+			if result.Reloaded != tc.wantReloaded {
+				t.Fatalf("expecting reloaded %t, got %t", tc.wantReloaded, result.Reloaded)
+			}
 		})
 	}
 }
+
+func TestLastAppliedHandler(t *testing.T) {
+	t.Cleanup(func() {
+		updateConfig = frrconfig.Update
+	})
+	status := &reloadStatus{}
+	reload := http.HandlerFunc(reloadHandler(newTestReloader(t), status))
+	lastApplied := http.HandlerFunc(lastAppliedHandler(status))
+
+	get := func() *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodGet, lastAppliedPath, nil)
+		lastApplied.ServeHTTP(w, req)
+		return w
+	}
+	post := func() {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodPost, "/", nil)
+		reload.ServeHTTP(w, req)
+		if w.Code != http.StatusOK {
+			t.Fatalf("reload failed with status %d", w.Code)
+		}
+	}
+
+	if w := get(); w.Code != http.StatusNotFound {
+		t.Fatalf("expecting %d before any reload, got %d", http.StatusNotFound, w.Code)
+	}
+
+	updateConfig = func(_ string) (frrconfig.ConfigDiff, error) {
+		return testDiff, nil
+	}
+	post()
+	// an unchanged configuration must not overwrite the last applied one
+	updateConfig = func(_ string) (frrconfig.ConfigDiff, error) {
+		return frrconfig.ConfigDiff{}, nil
+	}
+	post()
+
+	w := get()
+	if w.Code != http.StatusOK {
+		t.Fatalf("expecting %d, got %d", http.StatusOK, w.Code)
+	}
+	var result frrconfig.ReloadResult
+	if err := json.NewDecoder(w.Body).Decode(&result); err != nil {
+		t.Fatalf("failed to decode the last applied result: %s", err)
+	}
+	if !result.Reloaded || len(result.Diff.Added) != 1 || result.Timestamp.IsZero() {
+		t.Fatalf("unexpected last applied result %+v", result)
+	}
+}
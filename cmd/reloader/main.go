@@ -21,6 +21,7 @@ import (
 	// to ensure that exec-entrypoint and run can make use of them.
 
 	"context"
+	"encoding/json"
 	"flag"
 	"fmt"
 	"log"
@@ -29,6 +30,7 @@ import (
 	"net/http"
 	"os"
 	"os/signal"
+	"sync"
 	"syscall"
 	"time"
 
@@ -40,11 +42,12 @@ import (
 )
 
 type Args struct {
-	bindAddress   string
-	frrConfigPath string
-	logLevel      string
-	unixSocket    string
-	vtyshTimeout  time.Duration
+	bindAddress     string
+	frrConfigPath   string
+	logLevel        string
+	unixSocket      string
+	vtyshTimeout    time.Duration
+	sessionsTimeout time.Duration
 }
 
 func main() {
@@ -55,6 +58,9 @@ func main() {
 	flag.StringVar(&args.frrConfigPath, "frrconfig", "/etc/frr/frr.conf", "The path the frr configuration is at")
 	flag.DurationVar(&args.vtyshTimeout, "vtysh-timeout", vtysh.DefaultTimeout,
 		"Timeout for vtysh commands used in health checks")
+	flag.DurationVar(&args.sessionsTimeout, "verify-sessions-timeout", 0,
+		"How long to wait after a reload for the underlay BGP sessions to be established again "+
+			"before rolling back to the last known good configuration. Zero disables the check")
 	flag.Parse()
 
 	_, err := logging.New(args.logLevel)
@@ -86,8 +92,17 @@ func serveReload(args Args) error {
 		return fmt.Errorf("failed to listen on unix socket %s: %w", args.unixSocket, err)
 	}
 
+	reloader := &safeReloader{
+		frrConfigPath:   args.frrConfigPath,
+		frrCli:          vtysh.NewCLIWithTimeout(args.vtyshTimeout),
+		sessionsTimeout: args.sessionsTimeout,
+	}
+	status := &reloadStatus{}
 	unixServer := newServer(
-		[]handlerConfig{{pattern: "/", handler: reloadHandler(args.frrConfigPath)}},
+		[]handlerConfig{
+			{pattern: "/", handler: reloadHandler(reloader, status)},
+			{pattern: lastAppliedPath, handler: lastAppliedHandler(status)},
+		},
 	)
 
 	healthHandler := health(vtysh.NewCLIWithTimeout(args.vtyshTimeout))
@@ -144,20 +159,72 @@ func serveReload(args Args) error {
 
 var updateConfig = frrconfig.Update
 
-func reloadHandler(frrConfigPath string) func(w http.ResponseWriter, req *http.Request) {
+// lastAppliedPath is the endpoint of the unix socket returning the last
+// change applied to the FRR configuration.
+const lastAppliedPath = "/lastapplied"
+
+// reloadStatus keeps track of the last change applied to the FRR
+// configuration, so that session flaps can be traced back to it.
+type reloadStatus struct {
+	mu          sync.Mutex
+	lastApplied *frrconfig.ReloadResult
+}
+
+func (s *reloadStatus) setLastApplied(result frrconfig.ReloadResult) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.lastApplied = &result
+}
+
+func (s *reloadStatus) getLastApplied() *frrconfig.ReloadResult {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.lastApplied
+}
+
+func reloadHandler(reloader *safeReloader, status *reloadStatus) func(w http.ResponseWriter, req *http.Request) {
 	return func(w http.ResponseWriter, req *http.Request) {
 		if req.Method != http.MethodPost {
 			http.Error(w, "invalid method", http.StatusBadRequest)
 			return
 		}
 		slog.Info("reload handler", "event", "received request")
-		err := updateConfig(frrConfigPath)
+		result, err := reloader.apply()
 		if err != nil {
-			http.Error(w, err.Error(), http.StatusInternalServerError)
+			slog.Error("reload handler", "event", "reload failed", "error", err, "rolledBack", result.RolledBack)
+			writeJSON(w, http.StatusInternalServerError, result)
 			return
 		}
-		w.WriteHeader(http.StatusOK)
-		slog.Info("reload handler", "event", "reload successful")
+		if result.Reloaded {
+			status.setLastApplied(result)
+			slog.Info("reload handler", "event", "reload successful", "diff", result.Diff)
+		} else {
+			slog.Info("reload handler", "event", "configuration unchanged")
+		}
+		writeJSON(w, http.StatusOK, result)
+	}
+}
+
+func lastAppliedHandler(status *reloadStatus) func(w http.ResponseWriter, req *http.Request) {
+	return func(w http.ResponseWriter, req *http.Request) {
+		if req.Method != http.MethodGet {
+			http.Error(w, "invalid method", http.StatusMethodNotAllowed)
+			return
+		}
+		lastApplied := status.getLastApplied()
+		if lastApplied == nil {
+			http.Error(w, "no configuration applied yet", http.StatusNotFound)
+			return
+		}
+		writeJSON(w, http.StatusOK, lastApplied)
+	}
+}
+
+func writeJSON(w http.ResponseWriter, statusCode int, obj any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	if err := json.NewEncoder(w).Encode(obj); err != nil {
+		slog.Info("reload handler write failed", "error", err)
 	}
 }
 
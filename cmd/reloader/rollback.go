@@ -0,0 +1,248 @@
+// SPDX-License-Identifier:Apache-2.0
+
+package main
+
+import (
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/openperouter/openperouter/internal/frr"
+	"github.com/openperouter/openperouter/internal/frr/liveness"
+	"github.com/openperouter/openperouter/internal/frr/vtysh"
+	"github.com/openperouter/openperouter/internal/frrconfig"
+)
+
+var sessionsPollInterval = time.Second
+
+// lastGoodSuffix is appended to the path of the FRR configuration to get the
+// path the last configuration known to be good is persisted at, so that it
+// survives the restarts of the reloader.
+const lastGoodSuffix = ".lastgood"
+
+// safeReloader applies the FRR configuration, verifying FRR is healthy
+// afterwards and restoring the last configuration known to be good when
+// the reload fails or leaves FRR unhealthy.
+type safeReloader struct {
+	mu            sync.Mutex
+	frrConfigPath string
+	frrCli        vtysh.Cli
+	// sessionsTimeout is how long to wait for the underlay BGP sessions
+	// established before the reload to come back. Zero disables the check.
+	sessionsTimeout time.Duration
+	// generation is incremented by each reload, so that the verification of
+	// a reload superseded by a newer one is discarded.
+	generation uint64
+	// lastFailed is the last configuration that failed to apply, which is
+	// not applied again until the configuration changes.
+	lastFailed *failedConfig
+}
+
+// failedConfig is a configuration that failed to apply, with the outcome of
+// the attempt.
+type failedConfig struct {
+	hash       [sha256.Size]byte
+	err        error
+	rolledBack bool
+}
+
+// apply reloads the configuration at frrConfigPath. The returned result
+// carries the error and whether the configuration was rolled back on failure.
+func (r *safeReloader) apply() (frrconfig.ReloadResult, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	result := frrconfig.ReloadResult{Timestamp: time.Now()}
+	newConfig, err := os.ReadFile(r.frrConfigPath)
+	if err != nil {
+		return r.failed(result, fmt.Errorf("failed to read %s: %w", r.frrConfigPath, err))
+	}
+	hash := sha256.Sum256(newConfig)
+	if r.lastFailed != nil && r.lastFailed.hash == hash {
+		return r.skipFailed(result)
+	}
+
+	var established []string
+	if r.sessionsTimeout > 0 {
+		established, err = r.establishedUnderlaySessions()
+		if err != nil {
+			slog.Warn("failed to retrieve the underlay sessions before the reload", "error", err)
+		}
+	}
+
+	diff, err := updateConfig(r.frrConfigPath)
+	if errors.Is(err, frrconfig.ErrInvalidConfig) {
+		// frr-reload rejected the configuration before applying it
+		r.lastFailed = &failedConfig{hash: hash, err: err}
+		return r.failed(result, err)
+	}
+	if err == nil && diff.IsEmpty() {
+		r.commit(newConfig)
+		return result, nil
+	}
+	if err == nil {
+		result.Diff = diff
+		result.Reloaded = true
+		// The lock is released while waiting for the sessions, a newer
+		// reload superseding this one takes over the verification.
+		r.generation++
+		generation := r.generation
+		r.mu.Unlock()
+		err = r.verify(established)
+		r.mu.Lock()
+		if generation != r.generation {
+			slog.Info("frr reload superseded by a newer one, skipping its verification result")
+			return result, nil
+		}
+	}
+	if err != nil {
+		slog.Error("frr reload failed, rolling back", "error", err)
+		r.lastFailed = &failedConfig{hash: hash, err: err}
+		if rollbackErr := r.rollback(); rollbackErr != nil {
+			slog.Error("frr rollback failed", "error", rollbackErr)
+			return r.failed(result, fmt.Errorf("%w, rollback failed: %w", err, rollbackErr))
+		}
+		r.lastFailed.rolledBack = true
+		result.RolledBack = true
+		return r.failed(result, err)
+	}
+
+	r.commit(newConfig)
+	return result, nil
+}
+func (r *safeReloader) failed(result frrconfig.ReloadResult, err error) (frrconfig.ReloadResult, error) {
+	result.Reloaded = false
+	result.Error = err.Error()
+	return result, err
+}
+
+// skipFailed reports the failure of the configuration that already failed
+// to apply, without applying it again. A configuration that was rolled back
+// is replaced by the last known good one, as the rollback did.
+func (r *safeReloader) skipFailed(result frrconfig.ReloadResult) (frrconfig.ReloadResult, error) {
+	err := fmt.Errorf("%w, not applied again until the configuration changes", r.lastFailed.err)
+	if r.lastFailed.rolledBack {
+		if restoreErr := r.restoreLastGood(); restoreErr != nil {
+			return r.failed(result, fmt.Errorf("%w, restore failed: %w", err, restoreErr))
+		}
+		result.RolledBack = true
+	}
+	return r.failed(result, err)
+}
+
+// commit records the given configuration as the last known good one,
+// persisting it next to the FRR configuration.
+func (r *safeReloader) commit(config []byte) {
+	r.lastFailed = nil
+	tmp := r.lastGoodPath() + ".tmp"
+	err := os.WriteFile(tmp, config, 0600)
+	if err == nil {
+		err = os.Rename(tmp, r.lastGoodPath())
+	}
+	if err != nil {
+		slog.Warn("failed to persist the last good configuration", "path", r.lastGoodPath(), "error", err)
+	}
+}
+
+func (r *safeReloader) lastGoodPath() string {
+	return r.frrConfigPath + lastGoodSuffix
+}
+
+// restoreLastGood writes the last known good configuration to frrConfigPath.
+func (r *safeReloader) restoreLastGood() error {
+	lastGood, err := os.ReadFile(r.lastGoodPath())
+	if errors.Is(err, os.ErrNotExist) {
+		return errors.New("no known good configuration to roll back to")
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read the last good configuration: %w", err)
+	}
+	if err := os.WriteFile(r.frrConfigPath, lastGood, 0600); err != nil {
+		return fmt.Errorf("failed to restore %s: %w", r.frrConfigPath, err)
+	}
+	return nil
+}
+
+// rollback restores the last known good configuration.
+func (r *safeReloader) rollback() error {
+	if err := r.restoreLastGood(); err != nil {
+		return err
+	}
+	if _, err := updateConfig(r.frrConfigPath); err != nil {
+		return err
+	}
+	return liveness.PingFrr(r.frrCli)
+}
+
+// verify checks that FRR is alive, and that the given underlay sessions
+// are established again unless the new configuration removed them.
+func (r *safeReloader) verify(established []string) error {
+	if err := liveness.PingFrr(r.frrCli); err != nil {
+		return fmt.Errorf("frr is not healthy after the reload: %w", err)
+	}
+	if len(established) == 0 {
+		return nil
+	}
+
+	var notRecovered []string
+	deadline := time.Now().Add(r.sessionsTimeout)
+	for {
+		neighbors, err := r.underlayNeighbors()
+		if err != nil {
+			return fmt.Errorf("failed to retrieve the underlay sessions after the reload: %w", err)
+		}
+		notRecovered = notRecovered[:0]
+		for _, id := range established {
+			n, ok := neighbors[id]
+			if ok && !n.Connected {
+				notRecovered = append(notRecovered, id)
+			}
+		}
+		if len(notRecovered) == 0 {
+			return nil
+		}
+		if time.Now().After(deadline) {
+			break
+		}
+		time.Sleep(sessionsPollInterval)
+	}
+	slices.Sort(notRecovered)
+	return fmt.Errorf("underlay sessions %v not established %s after the reload", notRecovered, r.sessionsTimeout)
+}
+
+func (r *safeReloader) establishedUnderlaySessions() ([]string, error) {
+	neighbors, err := r.underlayNeighbors()
+	if err != nil {
+		return nil, err
+	}
+	var res []string
+	for id, n := range neighbors {
+		if n.Connected {
+			res = append(res, id)
+		}
+	}
+	return res, nil
+}
+
+// underlayNeighbors returns the BGP neighbors of the default VRF, where the
+// underlay sessions live, by their configured id.
+func (r *safeReloader) underlayNeighbors() (map[string]*frr.Neighbor, error) {
+	out, err := r.frrCli("show bgp neighbors json")
+	if err != nil {
+		return nil, fmt.Errorf("failed to query the bgp neighbors: %w", err)
+	}
+	neighbors, err := frr.ParseNeighbours(out)
+	if err != nil {
+		return nil, err
+	}
+	res := make(map[string]*frr.Neighbor, len(neighbors))
+	for _, n := range neighbors {
+		res[n.ID()] = n
+	}
+	return res, nil
+}
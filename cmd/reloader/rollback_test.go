@@ -0,0 +1,339 @@
+// SPDX-License-Identifier:Apache-2.0
+
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/openperouter/openperouter/internal/frrconfig"
+)
+
+const allDaemons = "zebra bgpd staticd bfdd watchfrr"
+
+var changedDiff = frrconfig.ConfigDiff{
+	Added: []frrconfig.ConfigChange{{Line: "ip nht resolve-via-default"}},
+}
+
+// fakeFRR emulates the running FRR: applying a configuration through the
+// mocked updateConfig, and answering the vtysh commands used to verify it.
+type fakeFRR struct {
+	t       *testing.T
+	path    string
+	running string
+	// failOn is the configuration for which the reload fails
+	failOn string
+	// crashOn is the configuration that kills bgpd once applied
+	crashOn string
+	// downOn is the configuration that brings the underlay session down
+	downOn string
+	// updates counts the configurations applied
+	updates int
+	// onSessionsCheck is called when the underlay sessions are queried
+	onSessionsCheck func()
+}
+
+func newTestReloader(t *testing.T) *safeReloader {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "frr.conf")
+	if err := os.WriteFile(path, []byte("initial"), 0600); err != nil {
+		t.Fatalf("failed to write the config: %v", err)
+	}
+	return &safeReloader{
+		frrConfigPath: path,
+		frrCli: func(string) (string, error) {
+			return allDaemons, nil
+		},
+	}
+}
+
+func (f *fakeFRR) update(path string) (frrconfig.ConfigDiff, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return frrconfig.ConfigDiff{}, err
+	}
+	config := string(content)
+	f.updates++
+	if strings.HasPrefix(config, "invalid") {
+		return frrconfig.ConfigDiff{}, fmt.Errorf("%w: test failed", frrconfig.ErrInvalidConfig)
+	}
+	if config == f.running {
+		return frrconfig.ConfigDiff{}, nil
+	}
+	f.running = config
+	if config == f.failOn {
+		return frrconfig.ConfigDiff{}, errors.New("frr update reload failed")
+	}
+	return changedDiff, nil
+}
+
+func (f *fakeFRR) vtysh(cmd string) (string, error) {
+	switch cmd {
+	case "show daemons":
+		if f.running == f.crashOn {
+			return "zebra staticd bfdd watchfrr", nil
+		}
+		return allDaemons, nil
+	case "show bgp neighbors json":
+		if f.onSessionsCheck != nil {
+			f.onSessionsCheck()
+		}
+		state := "Established"
+		if f.running == f.downOn {
+			state = "Active"
+		}
+		return fmt.Sprintf(`{"192.168.11.2": {"bgpState": %q}}`, state), nil
+	}
+	f.t.Fatalf("unexpected vtysh command %q", cmd)
+	return "", nil
+}
+
+func TestSafeReloader(t *testing.T) {
+	sessionsPollInterval = time.Millisecond
+	t.Cleanup(func() {
+		updateConfig = frrconfig.Update
+		sessionsPollInterval = time.Second
+	})
+
+	tests := []struct {
+		name           string
+		lastGood       string
+		config         string
+		wantErr        bool
+		wantRolledBack bool
+		wantRunning    string
+		wantFile       string
+	}{
+		{
+			name:        "applies the configuration",
+			lastGood:    "good",
+			config:      "new",
+			wantRunning: "new",
+			wantFile:    "new",
+		},
+		{
+			name:        "invalid configuration is not applied",
+			lastGood:    "good",
+			config:      "invalid",
+			wantErr:     true,
+			wantRunning: "good",
+			wantFile:    "invalid",
+		},
+		{
+			name:           "failed reload is rolled back",
+			lastGood:       "good",
+			config:         "fails",
+			wantErr:        true,
+			wantRolledBack: true,
+			wantRunning:    "good",
+			wantFile:       "good",
+		},
+		{
+			name:           "unhealthy frr is rolled back",
+			lastGood:       "good",
+			config:         "crashes",
+			wantErr:        true,
+			wantRolledBack: true,
+			wantRunning:    "good",
+			wantFile:       "good",
+		},
+		{
+			name:           "underlay session down is rolled back",
+			lastGood:       "good",
+			config:         "sessiondown",
+			wantErr:        true,
+			wantRolledBack: true,
+			wantRunning:    "good",
+			wantFile:       "good",
+		},
+		{
+			name:        "no configuration to roll back to",
+			config:      "fails",
+			wantErr:     true,
+			wantRunning: "fails",
+			wantFile:    "fails",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			frr := &fakeFRR{t: t, failOn: "fails", crashOn: "crashes", downOn: "sessiondown"}
+			updateConfig = frr.update
+			reloader := newTestReloader(t)
+			reloader.frrCli = frr.vtysh
+			reloader.sessionsTimeout = 10 * time.Millisecond
+
+			if tc.lastGood != "" {
+				apply(t, reloader, tc.lastGood)
+			}
+
+			if err := os.WriteFile(reloader.frrConfigPath, []byte(tc.config), 0600); err != nil {
+				t.Fatalf("failed to write the config: %v", err)
+			}
+			result, err := reloader.apply()
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("expecting error %t, got %v", tc.wantErr, err)
+			}
+			if (result.Error != "") != tc.wantErr {
+				t.Fatalf("expecting error in the result %t, got %q", tc.wantErr, result.Error)
+			}
+			if result.RolledBack != tc.wantRolledBack {
+				t.Fatalf("expecting rolled back %t, got %t", tc.wantRolledBack, result.RolledBack)
+			}
+			if frr.running != tc.wantRunning {
+				t.Fatalf("expecting running config %q, got %q", tc.wantRunning, frr.running)
+			}
+			content, err := os.ReadFile(reloader.frrConfigPath)
+			if err != nil {
+				t.Fatalf("failed to read the config: %v", err)
+			}
+			if string(content) != tc.wantFile {
+				t.Fatalf("expecting config file %q, got %q", tc.wantFile, string(content))
+			}
+		})
+	}
+}
+
+func TestSafeReloaderPersistsLastGood(t *testing.T) {
+	t.Cleanup(func() { updateConfig = frrconfig.Update })
+	frr := &fakeFRR{t: t, failOn: "fails"}
+	updateConfig = frr.update
+	reloader := newTestReloader(t)
+	reloader.frrCli = frr.vtysh
+	apply(t, reloader, "good")
+
+	// A restarted reloader rolls back to the configuration applied before.
+	restarted := &safeReloader{frrConfigPath: reloader.frrConfigPath, frrCli: frr.vtysh}
+	if err := os.WriteFile(restarted.frrConfigPath, []byte("fails"), 0600); err != nil {
+		t.Fatalf("failed to write the config: %v", err)
+	}
+	result, err := restarted.apply()
+	if err == nil || !result.RolledBack {
+		t.Fatalf("expecting the reload to be rolled back, got %v, rolled back %t", err, result.RolledBack)
+	}
+	if frr.running != "good" {
+		t.Fatalf("expecting running config %q, got %q", "good", frr.running)
+	}
+}
+
+func TestSafeReloaderSkipsFailedConfig(t *testing.T) {
+	t.Cleanup(func() { updateConfig = frrconfig.Update })
+
+	tests := []struct {
+		name           string
+		config         string
+		wantRolledBack bool
+		wantFile       string
+	}{
+		{
+			name:           "rolled back configuration",
+			config:         "fails",
+			wantRolledBack: true,
+			wantFile:       "good",
+		},
+		{
+			name:     "invalid configuration",
+			config:   "invalid",
+			wantFile: "invalid",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			frr := &fakeFRR{t: t, failOn: "fails"}
+			updateConfig = frr.update
+			reloader := newTestReloader(t)
+			reloader.frrCli = frr.vtysh
+			apply(t, reloader, "good")
+
+			for range 2 {
+				if err := os.WriteFile(reloader.frrConfigPath, []byte(tc.config), 0600); err != nil {
+					t.Fatalf("failed to write the config: %v", err)
+				}
+				if _, err := reloader.apply(); err == nil {
+					t.Fatalf("expecting %q to fail", tc.config)
+				}
+			}
+			updates := frr.updates
+
+			if err := os.WriteFile(reloader.frrConfigPath, []byte(tc.config), 0600); err != nil {
+				t.Fatalf("failed to write the config: %v", err)
+			}
+			result, err := reloader.apply()
+			if err == nil || !strings.Contains(err.Error(), "not applied again") {
+				t.Fatalf("expecting the failed config to be skipped, got %v", err)
+			}
+			if result.RolledBack != tc.wantRolledBack {
+				t.Fatalf("expecting rolled back %t, got %t", tc.wantRolledBack, result.RolledBack)
+			}
+			if frr.updates != updates {
+				t.Fatalf("expecting the failed config not to be applied again")
+			}
+			content, err := os.ReadFile(reloader.frrConfigPath)
+			if err != nil {
+				t.Fatalf("failed to read the config: %v", err)
+			}
+			if string(content) != tc.wantFile {
+				t.Fatalf("expecting config file %q, got %q", tc.wantFile, string(content))
+			}
+
+			apply(t, reloader, "new")
+			if frr.running != "new" {
+				t.Fatalf("expecting running config %q, got %q", "new", frr.running)
+			}
+		})
+	}
+}
+
+func TestSafeReloaderVerifiesWithoutLock(t *testing.T) {
+	sessionsPollInterval = time.Millisecond
+	t.Cleanup(func() {
+		updateConfig = frrconfig.Update
+		sessionsPollInterval = time.Second
+	})
+	frr := &fakeFRR{t: t, downOn: "sessiondown"}
+	updateConfig = frr.update
+	reloader := newTestReloader(t)
+	reloader.frrCli = frr.vtysh
+	reloader.sessionsTimeout = 10 * time.Millisecond
+	apply(t, reloader, "good")
+
+	// A newer reload happening while the sessions are verified supersedes
+	// the verification of the current one.
+	frr.onSessionsCheck = func() {
+		if frr.running != "sessiondown" {
+			return
+		}
+		if !reloader.mu.TryLock() {
+			t.Errorf("expecting the lock to be released during the verification")
+			return
+		}
+		reloader.generation++
+		reloader.mu.Unlock()
+	}
+	if err := os.WriteFile(reloader.frrConfigPath, []byte("sessiondown"), 0600); err != nil {
+		t.Fatalf("failed to write the config: %v", err)
+	}
+	result, err := reloader.apply()
+	if err != nil || result.RolledBack {
+		t.Fatalf("expecting the superseded reload not to be rolled back, got %v, rolled back %t", err, result.RolledBack)
+	}
+	if frr.running != "sessiondown" {
+		t.Fatalf("expecting running config %q, got %q", "sessiondown", frr.running)
+	}
+}
+
+func apply(t *testing.T, reloader *safeReloader, config string) {
+	t.Helper()
+	if err := os.WriteFile(reloader.frrConfigPath, []byte(config), 0600); err != nil {
+		t.Fatalf("failed to write the config: %v", err)
+	}
+	if _, err := reloader.apply(); err != nil {
+		t.Fatalf("failed to apply %q: %v", config, err)
+	}
+}
@@ -0,0 +1,224 @@
+// SPDX-License-Identifier:Apache-2.0
+
+// render prints the configuration the router of a node would apply for a
+// given set of resources, without needing a cluster nor a running router.
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"io/fs"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/yaml"
+
+	"github.com/openperouter/openperouter/internal/controller/routerconfiguration"
+	"github.com/openperouter/openperouter/internal/conversion"
+)
+
+const (
+	datapathKernel = "kernel"
+	datapathGrout  = "grout"
+)
+
+type parameters struct {
+	nodeName        string
+	nodeIndex       int
+	nodeLabels      string
+	namespace       string
+	staticConfigDir string
+	datapath        string
+	logLevel        string
+}
+
+func main() {
+	args := parameters{}
+	flag.StringVar(&args.nodeName, "node", "", "The name of the node to render the configuration for")
+	flag.IntVar(&args.nodeIndex, "node-index", 0, "The index of the node, as assigned by the nodemarker")
+	flag.StringVar(&args.nodeLabels, "node-labels", "",
+		"Comma separated key=value labels of the node, used when the node is not part of the resources")
+	flag.StringVar(&args.namespace, "namespace", "openperouter-system", "The namespace openperouter runs in")
+	flag.StringVar(&args.staticConfigDir, "static-config-dir", "", "A directory containing PERouterConfig files")
+	flag.StringVar(&args.datapath, "datapath", datapathKernel, "The datapath to validate against (kernel or grout)")
+	flag.StringVar(&args.logLevel, "loglevel", "info", "The FRR log level (debug, info, warn, error)")
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "Usage: %s --node NAME [flags] [FILE|DIR]...\n\n", os.Args[0])
+		fmt.Fprintf(flag.CommandLine.Output(),
+			"Renders the FRR configuration and the host plan of a node from the given resource manifests.\n\n")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	// Everything on stdout is the rendered output, keep the logs out of it.
+	slog.SetDefault(slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelWarn})))
+
+	failed, err := run(context.Background(), args, flag.Args(), os.Stdout)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error: %v\n", err)
+		os.Exit(2)
+	}
+	if failed {
+		os.Exit(1)
+	}
+}
+
+// run renders the configuration of the node, returning true if any of the
+// resources failed.
+func run(ctx context.Context, args parameters, paths []string, out io.Writer) (bool, error) {
+	if args.nodeName == "" {
+		return false, fmt.Errorf("--node is required")
+	}
+	validator, err := validatorFor(args.datapath)
+	if err != nil {
+		return false, err
+	}
+
+	resources, nodes, err := readResources(paths)
+	if err != nil {
+		return false, err
+	}
+	node, err := nodeFor(args, nodes)
+	if err != nil {
+		return false, err
+	}
+	apiConfig, err := routerconfiguration.ConfigForNode(node, args.namespace, resources)
+	if err != nil {
+		return false, err
+	}
+	if args.staticConfigDir != "" {
+		staticConfig, err := routerconfiguration.ReadStaticConfigForNode(args.staticConfigDir, args.nodeName, args.namespace)
+		if err != nil {
+			return false, err
+		}
+		apiConfig, err = conversion.MergeAPIConfigs(apiConfig, staticConfig)
+		if err != nil {
+			return false, fmt.Errorf("failed to merge the static configuration: %w", err)
+		}
+	}
+
+	res, err := routerconfiguration.DryRun(ctx, apiConfig, args.nodeIndex, args.logLevel, validator)
+	if err != nil {
+		return false, err
+	}
+	if err := printResult(out, res); err != nil {
+		return false, err
+	}
+	return len(res.FailedResources) > 0, nil
+}
+
+func validatorFor(datapath string) (conversion.DatapathConfigValidator, error) {
+	switch datapath {
+	case datapathKernel:
+		return &conversion.KernelDatapathConfigValidator{}, nil
+	case datapathGrout:
+		return &conversion.GroutDatapathConfigValidator{}, nil
+	}
+	return nil, fmt.Errorf("invalid datapath %q: must be %q or %q", datapath, datapathKernel, datapathGrout)
+}
+
+// readResources reads the given files, and the yaml and json files in the
+// given directories.
+func readResources(paths []string) (conversion.APIConfigData, []corev1.Node, error) {
+	var files []string
+	for _, p := range paths {
+		err := filepath.WalkDir(p, func(path string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			if d.IsDir() {
+				return nil
+			}
+			switch filepath.Ext(path) {
+			case ".yaml", ".yml", ".json":
+				files = append(files, path)
+			default:
+				if path == p {
+					files = append(files, path)
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			return conversion.APIConfigData{}, nil, fmt.Errorf("failed to read %s: %w", p, err)
+		}
+	}
+
+	readers := make([]io.Reader, 0, len(files))
+	for _, f := range files {
+		file, err := os.Open(f)
+		if err != nil {
+			return conversion.APIConfigData{}, nil, err
+		}
+		defer func() {
+			if err := file.Close(); err != nil {
+				slog.Error("failed to close file", "file", f, "error", err)
+			}
+		}()
+		readers = append(readers, file)
+	}
+	return routerconfiguration.ReadResources(readers...)
+}
+
+// nodeFor returns the node with the given name among the resources, or
+// builds it from the labels passed as parameters.
+func nodeFor(args parameters, nodes []corev1.Node) (*corev1.Node, error) {
+	for i := range nodes {
+		if nodes[i].Name == args.nodeName {
+			return &nodes[i], nil
+		}
+	}
+
+	labels := map[string]string{
+		"kubernetes.io/hostname": args.nodeName,
+	}
+	for entry := range strings.SplitSeq(args.nodeLabels, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		key, value, ok := strings.Cut(entry, "=")
+		if !ok {
+			return nil, fmt.Errorf("invalid node label %q: must be in the key=value form", entry)
+		}
+		labels[key] = value
+	}
+	return &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   args.nodeName,
+			Labels: labels,
+		},
+	}, nil
+}
+
+func printResult(out io.Writer, res routerconfiguration.DryRunResult) error {
+	hostConfig := []byte("{}\n")
+	if res.HostConfig != nil {
+		var err error
+		hostConfig, err = yaml.Marshal(res.HostConfig)
+		if err != nil {
+			return fmt.Errorf("failed to marshal the host configuration: %w", err)
+		}
+	}
+
+	var b strings.Builder
+	b.WriteString("### FRR configuration\n")
+	b.WriteString(res.FRRConfig)
+	b.WriteString("\n### Host configuration\n")
+	b.Write(hostConfig)
+	b.WriteString("\n### Failed resources\n")
+	if len(res.FailedResources) == 0 {
+		b.WriteString("none\n")
+	}
+	for _, f := range res.FailedResources {
+		fmt.Fprintf(&b, "%s/%s: %s: %s\n", f.Kind, f.Name, f.Reason, f.Message)
+	}
+	_, err := io.WriteString(out, b.String())
+	return err
+}
@@ -216,9 +216,9 @@ var _ = ginkgo.Describe("Router Host configuration", func() {
 
 				validateConfig(underlayParams{
 					UnderlayInterfaces: []underlayInterface{{InterfaceName: "toswitch1", Kind: "netdev"}},
-					TunnelEndpoint: &tunnelEndpointParams{
+					TunnelEndpoints: []tunnelEndpointParams{{
 						IPv4: vtepIP,
-					},
+					}},
 				}, underlayConfiguredTestSelector, p)
 			}
 		})
@@ -301,9 +301,9 @@ var _ = ginkgo.Describe("Router Host configuration", func() {
 
 				validateConfig(underlayParams{
 					UnderlayInterfaces: []underlayInterface{{InterfaceName: "toswitch1", Kind: "netdev"}},
-					TunnelEndpoint: &tunnelEndpointParams{
+					TunnelEndpoints: []tunnelEndpointParams{{
 						IPv4: vtepIP,
-					},
+					}},
 				}, underlayConfiguredTestSelector, p)
 			}
 		})
@@ -369,9 +369,9 @@ var _ = ginkgo.Describe("Router Host configuration", func() {
 
 				validateConfig(underlayParams{
 					UnderlayInterfaces: []underlayInterface{{InterfaceName: "toswitch1", Kind: "netdev"}},
-					TunnelEndpoint: &tunnelEndpointParams{
+					TunnelEndpoints: []tunnelEndpointParams{{
 						IPv4: vtepIP,
-					},
+					}},
 				}, underlayConfiguredTestSelector, p)
 			}
 		})
@@ -408,9 +408,9 @@ var _ = ginkgo.Describe("Router Host configuration", func() {
 					ginkgo.By(fmt.Sprintf("validating underlay for pod %s", p.Name))
 					validateConfig(underlayParams{
 						UnderlayInterfaces: []underlayInterface{{InterfaceName: "toswitch1", Kind: "netdev"}},
-						TunnelEndpoint: &tunnelEndpointParams{
+						TunnelEndpoints: []tunnelEndpointParams{{
 							IPv4: vtepIP,
-						},
+						}},
 					}, underlayConfiguredTestSelector, p)
 				}
 			}
@@ -488,9 +488,9 @@ var _ = ginkgo.Describe("Router Host configuration", func() {
 
 				validateConfig(underlayParams{
 					UnderlayInterfaces: []underlayInterface{{InterfaceName: "toswitch1", Kind: "netdev"}},
-					TunnelEndpoint: &tunnelEndpointParams{
+					TunnelEndpoints: []tunnelEndpointParams{{
 						IPv4: vtepIP,
-					},
+					}},
 				}, underlayConfiguredTestSelector, p)
 			}
 		})
@@ -525,9 +525,9 @@ var _ = ginkgo.Describe("Router Host configuration", func() {
 
 				validateConfig(underlayParams{
 					UnderlayInterfaces: []underlayInterface{{InterfaceName: "toswitch1", Kind: "netdev"}},
-					TunnelEndpoint: &tunnelEndpointParams{
+					TunnelEndpoints: []tunnelEndpointParams{{
 						IPv4: vtepIP,
-					},
+					}},
 				}, underlayConfiguredTestSelector, p)
 			}
 		})
@@ -683,9 +683,9 @@ var _ = ginkgo.Describe("Router Host configuration", func() {
 				vtepIP := vtepIPv4ForPod(cs, underlay.Spec.TunnelEndpoint, p)
 				validateConfig(underlayParams{
 					UnderlayInterfaces: []underlayInterface{{InterfaceName: "toswitch1", Kind: "netdev"}},
-					TunnelEndpoint: &tunnelEndpointParams{
+					TunnelEndpoints: []tunnelEndpointParams{{
 						IPv4: vtepIP,
-					},
+					}},
 				}, underlayConfiguredTestSelector, p)
 			}
 
@@ -710,9 +710,9 @@ var _ = ginkgo.Describe("Router Host configuration", func() {
 				vtepIP := vtepIPv4ForPod(cs, underlay.Spec.TunnelEndpoint, p)
 				validateConfig(underlayParams{
 					UnderlayInterfaces: []underlayInterface{{InterfaceName: "toswitch1", Kind: "netdev"}},
-					TunnelEndpoint: &tunnelEndpointParams{
+					TunnelEndpoints: []tunnelEndpointParams{{
 						IPv4: vtepIP,
-					},
+					}},
 				}, underlayConfiguredTestSelector, p)
 			}
 
@@ -741,9 +741,9 @@ var _ = ginkgo.Describe("Router Host configuration", func() {
 				vtepIP := vtepIPv4ForPod(cs, underlay.Spec.TunnelEndpoint, p)
 				validateConfig(underlayParams{
 					UnderlayInterfaces: []underlayInterface{{InterfaceName: "toswitch1", Kind: "netdev"}},
-					TunnelEndpoint: &tunnelEndpointParams{
+					TunnelEndpoints: []tunnelEndpointParams{{
 						IPv4: vtepIP,
-					},
+					}},
 				}, underlayConfiguredTestSelector, p)
 			}
 		})
@@ -795,9 +795,9 @@ var _ = ginkgo.Describe("Router Host configuration", func() {
 
 				validateConfig(underlayParams{
 					UnderlayInterfaces: []underlayInterface{{InterfaceName: "toswitch1", Kind: "netdev"}},
-					TunnelEndpoint: &tunnelEndpointParams{
+					TunnelEndpoints: []tunnelEndpointParams{{
 						IPv4: vtepIP,
-					},
+					}},
 				}, underlayConfiguredTestSelector, p)
 			}
 		})
@@ -865,9 +865,9 @@ var _ = ginkgo.Describe("Router Host configuration", func() {
 
 				validateConfig(underlayParams{
 					UnderlayInterfaces: []underlayInterface{{InterfaceName: "toswitch1", Kind: "netdev"}},
-					TunnelEndpoint: &tunnelEndpointParams{
+					TunnelEndpoints: []tunnelEndpointParams{{
 						IPv4: vtepIP,
-					},
+					}},
 				}, underlayConfiguredTestSelector, p)
 			}
 		})
@@ -923,9 +923,9 @@ var _ = ginkgo.Describe("Router Host configuration", func() {
 
 				validateConfig(underlayParams{
 					UnderlayInterfaces: []underlayInterface{{InterfaceName: "toswitch1", Kind: "netdev"}},
-					TunnelEndpoint: &tunnelEndpointParams{
+					TunnelEndpoints: []tunnelEndpointParams{{
 						IPv4: vtepIP,
-					},
+					}},
 				}, underlayConfiguredTestSelector, p)
 			}
 		})
@@ -1017,9 +1017,9 @@ var _ = ginkgo.Describe("Router Host configuration", func() {
 				vtepIP := vtepIPv4ForPod(cs, underlay.Spec.TunnelEndpoint, p)
 				validateConfig(underlayParams{
 					UnderlayInterfaces: []underlayInterface{{InterfaceName: "toswitch1", Kind: "netdev"}},
-					TunnelEndpoint: &tunnelEndpointParams{
+					TunnelEndpoints: []tunnelEndpointParams{{
 						IPv4: vtepIP,
-					},
+					}},
 				}, underlayConfiguredTestSelector, p)
 			}
 
@@ -1046,9 +1046,9 @@ var _ = ginkgo.Describe("Router Host configuration", func() {
 				vtepIP := vtepIPv4ForPod(cs, underlay.Spec.TunnelEndpoint, p)
 				validateConfig(underlayParams{
 					UnderlayInterfaces: []underlayInterface{{InterfaceName: "toswitch1", Kind: "netdev"}},
-					TunnelEndpoint: &tunnelEndpointParams{
+					TunnelEndpoints: []tunnelEndpointParams{{
 						IPv4: vtepIP,
-					},
+					}},
 				}, underlayConfiguredTestSelector, p)
 			}
 
@@ -1077,9 +1077,9 @@ var _ = ginkgo.Describe("Router Host configuration", func() {
 				vtepIP := vtepIPv4ForPod(cs, underlay.Spec.TunnelEndpoint, p)
 				validateConfig(underlayParams{
 					UnderlayInterfaces: []underlayInterface{{InterfaceName: "toswitch1", Kind: "netdev"}},
-					TunnelEndpoint: &tunnelEndpointParams{
+					TunnelEndpoints: []tunnelEndpointParams{{
 						IPv4: vtepIP,
-					},
+					}},
 				}, underlayConfiguredTestSelector, p)
 			}
 		})
@@ -1139,18 +1139,18 @@ var _ = ginkgo.Describe("Router Host configuration", func() {
 					vtepIP := vtepIPv4ForPod(cs, underlay1WithNodeSelector.Spec.TunnelEndpoint, p)
 					validateConfig(underlayParams{
 						UnderlayInterfaces: []underlayInterface{{InterfaceName: "toswitch1", Kind: "netdev"}},
-						TunnelEndpoint: &tunnelEndpointParams{
+						TunnelEndpoints: []tunnelEndpointParams{{
 							IPv4: vtepIP,
-						},
+						}},
 					}, underlayConfiguredTestSelector, p)
 				case nodes[1].Name:
 					ginkgo.By(fmt.Sprintf("validating underlay2 configured on node %s", p.Spec.NodeName))
 					vtepIP := vtepIPv4ForPod(cs, underlay2WithNodeSelector.Spec.TunnelEndpoint, p)
 					validateConfig(underlayParams{
 						UnderlayInterfaces: []underlayInterface{{InterfaceName: "toswitch1", Kind: "netdev"}},
-						TunnelEndpoint: &tunnelEndpointParams{
+						TunnelEndpoints: []tunnelEndpointParams{{
 							IPv4: vtepIP,
-						},
+						}},
 					}, underlayConfiguredTestSelector, p)
 				default:
 					ginkgo.By(fmt.Sprintf("validating underlay is not configured for pod %q on node %s", p.Name, p.Spec.NodeName))
@@ -1181,9 +1181,9 @@ var _ = ginkgo.Describe("Router Host configuration", func() {
 					vtepIP := vtepIPv4ForPod(cs, underlay1WithNodeSelector.Spec.TunnelEndpoint, p)
 					validateConfig(underlayParams{
 						UnderlayInterfaces: []underlayInterface{{InterfaceName: "toswitch1", Kind: "netdev"}},
-						TunnelEndpoint: &tunnelEndpointParams{
+						TunnelEndpoints: []tunnelEndpointParams{{
 							IPv4: vtepIP,
-						},
+						}},
 					}, underlayConfiguredTestSelector, p)
 				} else {
 					ginkgo.By(fmt.Sprintf("validating underlay is not configured on node %s", p.Spec.NodeName))
@@ -1207,9 +1207,9 @@ var _ = ginkgo.Describe("Router Host configuration", func() {
 				vtepIP := vtepIPv4ForPod(cs, underlay1WithNodeSelector.Spec.TunnelEndpoint, p)
 				validateConfig(underlayParams{
 					UnderlayInterfaces: []underlayInterface{{InterfaceName: "toswitch1", Kind: "netdev"}},
-					TunnelEndpoint: &tunnelEndpointParams{
+					TunnelEndpoints: []tunnelEndpointParams{{
 						IPv4: vtepIP,
-					},
+					}},
 				}, underlayConfiguredTestSelector, p)
 			}
 		})
@@ -1450,6 +1450,7 @@ var _ = ginkgo.Describe("Router Host configuration", func() {
 			})).To(Succeed())
 
 			l3PassthroughParams := l3passthroughParams{
+				Name: passthroughWithNodeSelector.Name,
 				LinkIPs: &linkIPs{
 					NSIPv4: routerIPWithNetmask(passthroughWithNodeSelector.Spec.HostSession.LocalCIDR.IPv4),
 					NSIPv6: routerIPWithNetmask(passthroughWithNodeSelector.Spec.HostSession.LocalCIDR.IPv6),
@@ -1505,6 +1506,7 @@ var _ = ginkgo.Describe("Router Host configuration", func() {
 })
 
 type l3passthroughParams struct {
+	Name     string   `json:"name"`
 	TargetNS string   `json:"targetns"`
 	LinkIPs  *linkIPs `json:"linkIPs"`
 }
@@ -1533,8 +1535,8 @@ type l2vniParams struct {
 }
 
 type underlayParams struct {
-	UnderlayInterfaces []underlayInterface   `json:"underlay_interfaces"`
-	TunnelEndpoint     *tunnelEndpointParams `json:"tunnel_endpoint"`
+	UnderlayInterfaces []underlayInterface    `json:"underlay_interfaces"`
+	TunnelEndpoints    []tunnelEndpointParams `json:"tunnel_endpoints"`
 }
 
 // underlayInterface mirrors hostnetwork.UnderlayInterface.
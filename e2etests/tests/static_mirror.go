@@ -522,7 +522,7 @@ var _ = Describe("Mirror static config to Kubernetes", Label("systemdmode"), Ord
 		By("waiting for mirrored underlay to exist")
 		Eventually(validateUnderlays, "60s", "2s").Should(Succeed())
 
-		By("attempting to create a K8s-managed underlay (conflicts: can't have >1 underlay per node)")
+		By("attempting to create a K8s-managed underlay (conflicts: underlays on the same node must share the asn)")
 		err := Updater.Update(config.Resources{
 			Underlays: []v1alpha1.Underlay{
 				{
@@ -552,6 +552,6 @@ var _ = Describe("Mirror static config to Kubernetes", Label("systemdmode"), Ord
 			},
 		})
 		Expect(err).To(HaveOccurred())
-		Expect(err.Error()).To(ContainSubstring("can't have more than one underlay per node"))
+		Expect(err.Error()).To(ContainSubstring("must have the same asn"))
 	})
 })
@@ -651,6 +651,39 @@ var _ = Describe("Webhooks", func() {
 					},
 				},
 			}, "all entries must be valid CIDRs"),
+			Entry("when trying to create an underlay with an invalid ospf area", v1alpha1.Underlay{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "underlay",
+					Namespace: openperouter.Namespace,
+				},
+				Spec: v1alpha1.UnderlaySpec{
+					ASN:        65000,
+					Interfaces: []v1alpha1.UnderlayInterface{{Type: "NetworkDevice", NetworkDevice: &v1alpha1.NetworkDevice{InterfaceName: "nic1"}}},
+					Neighbors: []v1alpha1.Neighbor{
+						{ASN: new(int64(65001)), Address: new("192.168.1.1")},
+					},
+					OSPF: &v1alpha1.OSPFConfig{
+						Area: new(v1alpha1.OSPFArea("notanarea")),
+					},
+				},
+			}, "area must be a decimal number or a dotted decimal IPv4 address"),
+			Entry("when trying to create an underlay with both isis and ospf", v1alpha1.Underlay{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "underlay",
+					Namespace: openperouter.Namespace,
+				},
+				Spec: v1alpha1.UnderlaySpec{
+					ASN:        65000,
+					Interfaces: []v1alpha1.UnderlayInterface{{Type: "NetworkDevice", NetworkDevice: &v1alpha1.NetworkDevice{InterfaceName: "nic1"}}},
+					Neighbors: []v1alpha1.Neighbor{
+						{ASN: new(int64(65001)), Address: new("192.168.1.1")},
+					},
+					ISIS: &v1alpha1.ISISConfig{
+						BaseNet: "49.0001.0002.0003.0004.00",
+					},
+					OSPF: &v1alpha1.OSPFConfig{},
+				},
+			}, "isis and ospf are mutually exclusive"),
 		)
 
 		It("should allow creating an underlay with multiple NICs and neighbors", func() {
@@ -724,7 +757,7 @@ var _ = Describe("Webhooks", func() {
 				})
 				Expect(err).To(MatchError(ContainSubstring(expectedError)))
 			},
-			Entry("when trying to create a second different underlay (should fail)",
+			Entry("when trying to create a second underlay with a different asn (should fail)",
 				[]v1alpha1.Underlay{
 					{
 						ObjectMeta: metav1.ObjectMeta{
@@ -743,7 +776,7 @@ var _ = Describe("Webhooks", func() {
 						},
 					},
 				},
-				"can't have more than one underlay",
+				"must have the same asn",
 			),
 			Entry("when updating the existing underlay with an invalid CIDR (should fail)",
 				[]v1alpha1.Underlay{
@@ -0,0 +1,68 @@
+// SPDX-License-Identifier:Apache-2.0
+
+package nodeindex
+
+import (
+	"encoding/json"
+	"fmt"
+
+	v1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	// AllocationsConfigMap is the name of the ConfigMap recording the node
+	// indexes, in the namespace of the nodemarker.
+	AllocationsConfigMap = "openpe-nodeindex"
+	// allocationsKey holds the indexes assigned to the nodes, and the ones
+	// released within the grace period. It is owned by the controller.
+	allocationsKey = "allocations"
+	// pinsKey holds the pins set by the user.
+	pinsKey = "pins"
+)
+
+// allocationsFromConfigMap returns the allocations recorded in the ConfigMap.
+func allocationsFromConfigMap(cm *v1.ConfigMap) ([]IndexAllocation, error) {
+	data := cm.Data[allocationsKey]
+	if data == "" {
+		return nil, nil
+	}
+	var res []IndexAllocation
+	if err := json.Unmarshal([]byte(data), &res); err != nil {
+		return nil, fmt.Errorf("failed to parse %s of configmap %s/%s: %w", allocationsKey, cm.Namespace, cm.Name, err)
+	}
+	return res, nil
+}
+
+// pinsFromConfigMap returns the pins set in the ConfigMap.
+func pinsFromConfigMap(cm *v1.ConfigMap) ([]IndexPin, error) {
+	data := cm.Data[pinsKey]
+	if data == "" {
+		return nil, nil
+	}
+	var res []IndexPin
+	if err := yaml.UnmarshalStrict([]byte(data), &res); err != nil {
+		return nil, fmt.Errorf("failed to parse %s of configmap %s/%s: %w", pinsKey, cm.Namespace, cm.Name, err)
+	}
+	return res, nil
+}
+
+// setAllocations records the allocations in the ConfigMap, returning true if
+// they changed.
+func setAllocations(cm *v1.ConfigMap, allocations []IndexAllocation) (bool, error) {
+	if allocations == nil {
+		allocations = []IndexAllocation{}
+	}
+	data, err := json.Marshal(allocations)
+	if err != nil {
+		return false, fmt.Errorf("failed to marshal the node index allocations: %w", err)
+	}
+	if cm.Data[allocationsKey] == string(data) {
+		return false, nil
+	}
+	if cm.Data == nil {
+		cm.Data = map[string]string{}
+	}
+	cm.Data[allocationsKey] = string(data)
+	return true, nil
+}
@@ -13,13 +13,15 @@ import (
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/client-go/kubernetes"
+
+	"github.com/openperouter/openperouter/api/v1alpha1"
 )
 
 func AnnotateNodeIndex(ctx context.Context, clientset kubernetes.Interface, nodeName string, nodeIndex int) error {
 	patchData := map[string]any{
 		"metadata": map[string]any{
 			"annotations": map[string]string{
-				OpenpeNodeIndex: strconv.Itoa(nodeIndex),
+				v1alpha1.NodeIndexAnnotation: strconv.Itoa(nodeIndex),
 			},
 		},
 	}
@@ -3,104 +3,294 @@
 package nodeindex
 
 import (
-	"sort"
+	"fmt"
+	"log/slog"
+	"slices"
 	"strconv"
+	"strings"
+	"time"
 
 	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+
+	"github.com/openperouter/openperouter/api/v1alpha1"
 )
 
-const OpenpeNodeIndex = "openpe.io/nodeindex"
+// IndexAllocation records the index assigned to a node.
+type IndexAllocation struct {
+	Node  string `json:"node"`
+	Index int    `json:"index"`
+	// ReleasedAt is set when the node is deleted. The index is not assigned
+	// to other nodes until the grace period expires, and is given back to
+	// the node if it is recreated in the meanwhile.
+	ReleasedAt *metav1.Time `json:"releasedAt,omitempty"`
+}
+
+// IndexPin pins the index of the node with the given name, or of the single
+// node matching the given selector.
+type IndexPin struct {
+	NodeName     string                `json:"nodeName,omitempty"`
+	NodeSelector *metav1.LabelSelector `json:"nodeSelector,omitempty"`
+	Index        int                   `json:"index"`
+}
+
+// allocationIssue is a problem preventing a node from getting the expected
+// index, reported as an event on the node.
+type allocationIssue struct {
+	node    string
+	reason  string
+	message string
+}
+
+type allocationParams struct {
+	allocations []IndexAllocation
+	pins        []IndexPin
+	now         time.Time
+	gracePeriod time.Duration
+	// poolSize is the number of indexes available, 0 meaning unbounded.
+	poolSize uint64
+}
+
+type allocationResult struct {
+	// toAnnotate are the nodes whose index annotation changed.
+	toAnnotate []v1.Node
+	// allocations is the new record of the assigned and released indexes,
+	// sorted by index.
+	allocations []IndexAllocation
+	issues      []allocationIssue
+	// requeueAfter is the time left before the first grace period expires,
+	// 0 if no index is released.
+	requeueAfter time.Duration
+}
 
+// nodesToAnnotate returns the nodes needing a new index, with no previous
+// allocation record nor pins.
 func nodesToAnnotate(allNodes []v1.Node) []v1.Node {
-	taken := make([]int, 0)
-
-	res := make([]v1.Node, 0)
-	for _, n := range allNodes {
-		if n.Annotations[OpenpeNodeIndex] != "" {
-			index, err := strconv.Atoi(n.Annotations[OpenpeNodeIndex])
-			if err != nil { // not an int, let's ignore it so we'll add again
-				res = append(res, n)
-				continue
+	return allocateIndexes(allNodes, allocationParams{now: time.Now()}).toAnnotate
+}
+
+// allocateIndexes assigns an index to each node. The indexes are assigned in
+// order to:
+//   - the annotated nodes, keeping their index unless it is duplicated or the
+//     node is pinned to another one;
+//   - the pinned nodes, unless the pinned index is used by another node;
+//   - the nodes recreated within the grace period, getting their index back;
+//   - the remaining nodes, getting the lowest index neither used, nor
+//     released within the grace period, nor pinned to another node.
+func allocateIndexes(nodes []v1.Node, params allocationParams) allocationResult {
+	res := allocationResult{}
+
+	previous := map[string]IndexAllocation{}
+	for _, a := range params.allocations {
+		if a.ReleasedAt != nil && !params.now.Before(a.ReleasedAt.Add(params.gracePeriod)) {
+			continue
+		}
+		previous[a.Node] = a
+	}
+
+	pinned, reserved, pinIssues := resolvePins(nodes, params.pins)
+	res.issues = append(res.issues, pinIssues...)
+
+	taken := map[int]string{}
+	assigned := map[string]int{}
+	assign := func(node string, index int) {
+		taken[index] = node
+		assigned[node] = index
+	}
+
+	for _, n := range nodes {
+		index, ok := annotatedIndex(n)
+		if !ok {
+			continue
+		}
+		if pin, isPinned := pinned[n.Name]; isPinned && pin != index {
+			continue
+		}
+		if _, isTaken := taken[index]; isTaken {
+			continue
+		}
+		assign(n.Name, index)
+	}
+
+	for _, n := range nodes {
+		pin, isPinned := pinned[n.Name]
+		if _, done := assigned[n.Name]; done || !isPinned {
+			continue
+		}
+		if owner, isTaken := taken[pin]; isTaken {
+			res.issues = append(res.issues, allocationIssue{
+				node:    n.Name,
+				reason:  EventReasonNodeIndexPinConflict,
+				message: fmt.Sprintf("pinned node index %d is used by node %s", pin, owner),
+			})
+			continue
+		}
+		assign(n.Name, pin)
+	}
+
+	// free tells whether the index can be given to the node.
+	free := func(node string, index int) bool {
+		if _, isTaken := taken[index]; isTaken {
+			return false
+		}
+		if owner, isReserved := reserved[index]; isReserved && owner != node {
+			return false
+		}
+		for _, a := range previous {
+			if a.Index == index && a.Node != node {
+				return false
 			}
-			if alreadyExistsInSorted(taken, index) {
-				res = append(res, n)
+		}
+		return true
+	}
+
+	for _, n := range nodes {
+		if _, done := assigned[n.Name]; done {
+			continue
+		}
+		if a, ok := previous[n.Name]; ok && free(n.Name, a.Index) {
+			assign(n.Name, a.Index)
+			continue
+		}
+		index := 0
+		for !free(n.Name, index) {
+			index++
+		}
+		assign(n.Name, index)
+	}
+
+	live := map[string]bool{}
+	for _, n := range nodes {
+		live[n.Name] = true
+		index := assigned[n.Name]
+		current, hasIndex := annotatedIndex(n)
+		if params.poolSize > 0 && uint64(index) >= params.poolSize {
+			res.issues = append(res.issues, allocationIssue{
+				node:   n.Name,
+				reason: EventReasonNodeIndexPoolExhausted,
+				message: fmt.Sprintf("node index %d is out of the pool of %d indexes allowed by the smallest CIDR",
+					index, params.poolSize),
+			})
+			if !hasIndex || current != index {
+				// The index is unusable, the node is left with its current
+				// annotation until an index is released.
+				delete(assigned, n.Name)
 				continue
 			}
-			taken = insertSorted(taken, index)
+		}
+		if n.Annotations[v1alpha1.NodeIndexAnnotation] == strconv.Itoa(index) {
 			continue
 		}
-		res = append(res, n)
+		if n.Annotations == nil {
+			n.Annotations = map[string]string{}
+		}
+		n.Annotations[v1alpha1.NodeIndexAnnotation] = strconv.Itoa(index)
+		res.toAnnotate = append(res.toAnnotate, n)
 	}
 
-	allocator := newAllocator(taken)
-	for i := range res {
-		if res[i].Annotations == nil {
-			res[i].Annotations = make(map[string]string)
+	for node, index := range assigned {
+		res.allocations = append(res.allocations, IndexAllocation{Node: node, Index: index})
+	}
+	for _, a := range previous {
+		if live[a.Node] || params.gracePeriod <= 0 {
+			continue
+		}
+		if _, isTaken := taken[a.Index]; isTaken {
+			continue
+		}
+		if a.ReleasedAt == nil {
+			a.ReleasedAt = &metav1.Time{Time: params.now}
+		}
+		res.allocations = append(res.allocations, a)
+		left := a.ReleasedAt.Add(params.gracePeriod).Sub(params.now)
+		if res.requeueAfter == 0 || left < res.requeueAfter {
+			res.requeueAfter = left
 		}
-		index := allocator.getNextFree()
-		res[i].Annotations[OpenpeNodeIndex] = strconv.Itoa(index)
 	}
+	slices.SortFunc(res.allocations, func(a, b IndexAllocation) int {
+		if a.Index != b.Index {
+			return a.Index - b.Index
+		}
+		return strings.Compare(a.Node, b.Node)
+	})
 	return res
 }
 
-type allocator struct {
-	lastAssigned int
-	upperIndex   int
-	taken        []int
-}
+// resolvePins returns the index pinned to each node, and the indexes reserved
+// to the nodes pinned by name, alongside the issues found. The first pin wins
+// when several pins apply to the same node or index.
+func resolvePins(nodes []v1.Node, pins []IndexPin) (map[string]int, map[int]string, []allocationIssue) {
+	pinned := map[string]int{}
+	reserved := map[int]string{}
+	var issues []allocationIssue
 
-func newAllocator(taken []int) *allocator {
-	return &allocator{
-		lastAssigned: -1,
-		upperIndex:   0,
-		taken:        taken,
+	for _, p := range pins {
+		if p.Index < 0 {
+			slog.Warn("ignoring node index pin with negative index", "pin", p)
+			continue
+		}
+		node := p.NodeName
+		if p.NodeSelector != nil {
+			selector, err := metav1.LabelSelectorAsSelector(p.NodeSelector)
+			if err != nil {
+				slog.Warn("ignoring node index pin with invalid selector", "pin", p, "error", err)
+				continue
+			}
+			matching := nodesMatching(nodes, selector)
+			if len(matching) > 1 {
+				for _, n := range matching {
+					issues = append(issues, allocationIssue{
+						node:   n,
+						reason: EventReasonNodeIndexPinConflict,
+						message: fmt.Sprintf("node index %d is pinned to %d nodes by selector %s",
+							p.Index, len(matching), selector),
+					})
+				}
+				continue
+			}
+			if len(matching) == 0 {
+				continue
+			}
+			node = matching[0]
+		}
+		if node == "" {
+			slog.Warn("ignoring node index pin with neither node name nor selector", "pin", p)
+			continue
+		}
+		if _, ok := pinned[node]; ok {
+			slog.Warn("ignoring node index pin, the node is already pinned", "pin", p, "node", node)
+			continue
+		}
+		if owner, ok := reserved[p.Index]; ok {
+			slog.Warn("ignoring node index pin, the index is already pinned", "pin", p, "node", owner)
+			continue
+		}
+		pinned[node] = p.Index
+		reserved[p.Index] = node
 	}
+	return pinned, reserved, issues
 }
 
-func (a *allocator) getNextFree() int {
-	nextFree := a.findNextFree()
-	a.lastAssigned = nextFree
-	return nextFree
-}
-
-// findNextFree returns the next valid item starting from the lastAssigned
-// index in a sorted "taken" slice containing all the already taken indexes.
-// An upperIndex pointer is maintained to track the upper limit item
-// in the taken index.
-func (a *allocator) findNextFree() int {
-	if len(a.taken) == 0 {
-		return a.lastAssigned + 1 // no taken seats, we don't need much logic
-	}
-	candidate := a.lastAssigned + 1
-	for a.upperIndex < len(a.taken) {
-		upper := a.taken[a.upperIndex]
-
-		if upper > candidate { // there's a hole
-			return candidate
+func nodesMatching(nodes []v1.Node, selector labels.Selector) []string {
+	var res []string
+	for _, n := range nodes {
+		if selector.Matches(labels.Set(n.Labels)) {
+			res = append(res, n.Name)
 		}
-		// no holes, let's try with right one more than upper and
-		// check against the next upper
-		candidate = upper + 1
-		a.upperIndex++
 	}
-	return candidate // we are past the rightmost
+	return res
 }
 
-func alreadyExistsInSorted(slice []int, toCheck int) bool {
-	if len(slice) == 0 {
-		return false
+// annotatedIndex returns the index annotated on the node, if valid.
+func annotatedIndex(n v1.Node) (int, bool) {
+	value, ok := n.Annotations[v1alpha1.NodeIndexAnnotation]
+	if !ok {
+		return 0, false
 	}
-	index := sort.Search(len(slice), func(i int) bool { return slice[i] >= toCheck })
-	if index >= len(slice) {
-		return false
+	index, err := strconv.Atoi(value)
+	if err != nil || index < 0 {
+		return 0, false
 	}
-	return slice[index] == toCheck
-}
-
-func insertSorted(slice []int, toAdd int) []int {
-	index := sort.Search(len(slice), func(i int) bool { return slice[i] >= toAdd })
-	slice = append(slice, 0)
-	copy(slice[index+1:], slice[index:])
-	slice[index] = toAdd
-	return slice
+	return index, true
 }
@@ -4,9 +4,14 @@ package nodeindex
 
 import (
 	"testing"
+	"time"
 
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/openperouter/openperouter/api/v1alpha1"
 )
 
 func TestNodesToAnnotate(t *testing.T) {
@@ -18,8 +23,8 @@ func TestNodesToAnnotate(t *testing.T) {
 		{
 			name: "Nodes with existing annotations",
 			nodes: []v1.Node{
-				{ObjectMeta: metav1.ObjectMeta{Name: "first", Annotations: map[string]string{OpenpeNodeIndex: "0"}}},
-				{ObjectMeta: metav1.ObjectMeta{Name: "second", Annotations: map[string]string{OpenpeNodeIndex: "1"}}},
+				{ObjectMeta: metav1.ObjectMeta{Name: "first", Annotations: map[string]string{v1alpha1.NodeIndexAnnotation: "0"}}},
+				{ObjectMeta: metav1.ObjectMeta{Name: "second", Annotations: map[string]string{v1alpha1.NodeIndexAnnotation: "1"}}},
 				{ObjectMeta: metav1.ObjectMeta{Name: "third", Annotations: map[string]string{}}},
 			},
 			expectedAnnotations: map[string]string{
@@ -40,8 +45,8 @@ func TestNodesToAnnotate(t *testing.T) {
 		{
 			name: "Nodes with hole in sequence",
 			nodes: []v1.Node{
-				{ObjectMeta: metav1.ObjectMeta{Name: "first", Annotations: map[string]string{OpenpeNodeIndex: "2"}}},
-				{ObjectMeta: metav1.ObjectMeta{Name: "second", Annotations: map[string]string{OpenpeNodeIndex: "0"}}},
+				{ObjectMeta: metav1.ObjectMeta{Name: "first", Annotations: map[string]string{v1alpha1.NodeIndexAnnotation: "2"}}},
+				{ObjectMeta: metav1.ObjectMeta{Name: "second", Annotations: map[string]string{v1alpha1.NodeIndexAnnotation: "0"}}},
 				{ObjectMeta: metav1.ObjectMeta{Name: "third", Annotations: map[string]string{}}},
 			},
 			expectedAnnotations: map[string]string{
@@ -51,10 +56,10 @@ func TestNodesToAnnotate(t *testing.T) {
 		{
 			name: "Nodes with non int index",
 			nodes: []v1.Node{
-				{ObjectMeta: metav1.ObjectMeta{Name: "first", Annotations: map[string]string{OpenpeNodeIndex: "2"}}},
-				{ObjectMeta: metav1.ObjectMeta{Name: "second", Annotations: map[string]string{OpenpeNodeIndex: "5"}}},
-				{ObjectMeta: metav1.ObjectMeta{Name: "third", Annotations: map[string]string{OpenpeNodeIndex: "foo"}}},
-				{ObjectMeta: metav1.ObjectMeta{Name: "fourth", Annotations: map[string]string{OpenpeNodeIndex: "7"}}},
+				{ObjectMeta: metav1.ObjectMeta{Name: "first", Annotations: map[string]string{v1alpha1.NodeIndexAnnotation: "2"}}},
+				{ObjectMeta: metav1.ObjectMeta{Name: "second", Annotations: map[string]string{v1alpha1.NodeIndexAnnotation: "5"}}},
+				{ObjectMeta: metav1.ObjectMeta{Name: "third", Annotations: map[string]string{v1alpha1.NodeIndexAnnotation: "foo"}}},
+				{ObjectMeta: metav1.ObjectMeta{Name: "fourth", Annotations: map[string]string{v1alpha1.NodeIndexAnnotation: "7"}}},
 				{ObjectMeta: metav1.ObjectMeta{Name: "fifth", Annotations: map[string]string{}}},
 			},
 			expectedAnnotations: map[string]string{
@@ -67,8 +72,8 @@ func TestNodesToAnnotate(t *testing.T) {
 			nodes: []v1.Node{
 				{ObjectMeta: metav1.ObjectMeta{Name: "first", Annotations: map[string]string{}}},
 				{ObjectMeta: metav1.ObjectMeta{Name: "second", Annotations: map[string]string{}}},
-				{ObjectMeta: metav1.ObjectMeta{Name: "third", Annotations: map[string]string{OpenpeNodeIndex: "0"}}},
-				{ObjectMeta: metav1.ObjectMeta{Name: "fourth", Annotations: map[string]string{OpenpeNodeIndex: "1"}}},
+				{ObjectMeta: metav1.ObjectMeta{Name: "third", Annotations: map[string]string{v1alpha1.NodeIndexAnnotation: "0"}}},
+				{ObjectMeta: metav1.ObjectMeta{Name: "fourth", Annotations: map[string]string{v1alpha1.NodeIndexAnnotation: "1"}}},
 			},
 			expectedAnnotations: map[string]string{
 				"first":  "2",
@@ -80,8 +85,8 @@ func TestNodesToAnnotate(t *testing.T) {
 			nodes: []v1.Node{
 				{ObjectMeta: metav1.ObjectMeta{Name: "first", Annotations: map[string]string{}}},
 				{ObjectMeta: metav1.ObjectMeta{Name: "second", Annotations: map[string]string{}}},
-				{ObjectMeta: metav1.ObjectMeta{Name: "third", Annotations: map[string]string{OpenpeNodeIndex: "1"}}},
-				{ObjectMeta: metav1.ObjectMeta{Name: "fourth", Annotations: map[string]string{OpenpeNodeIndex: "1"}}},
+				{ObjectMeta: metav1.ObjectMeta{Name: "third", Annotations: map[string]string{v1alpha1.NodeIndexAnnotation: "1"}}},
+				{ObjectMeta: metav1.ObjectMeta{Name: "fourth", Annotations: map[string]string{v1alpha1.NodeIndexAnnotation: "1"}}},
 			},
 			expectedAnnotations: map[string]string{
 				"first":  "0",
@@ -95,10 +100,205 @@ func TestNodesToAnnotate(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			annotatedNodes := nodesToAnnotate(tt.nodes)
 			for _, node := range annotatedNodes {
-				if node.Annotations[OpenpeNodeIndex] != tt.expectedAnnotations[node.Name] {
-					t.Errorf("expected %s, got %s", tt.expectedAnnotations[node.Name], node.Annotations[OpenpeNodeIndex])
+				if node.Annotations[v1alpha1.NodeIndexAnnotation] != tt.expectedAnnotations[node.Name] {
+					t.Errorf("expected %s, got %s", tt.expectedAnnotations[node.Name], node.Annotations[v1alpha1.NodeIndexAnnotation])
 				}
 			}
 		})
 	}
 }
+
+func TestAllocateIndexes(t *testing.T) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	released := func(ago time.Duration) *metav1.Time {
+		return &metav1.Time{Time: now.Add(-ago)}
+	}
+	node := func(name, index string, labels map[string]string) v1.Node {
+		n := v1.Node{ObjectMeta: metav1.ObjectMeta{Name: name, Labels: labels}}
+		if index != "" {
+			n.Annotations = map[string]string{v1alpha1.NodeIndexAnnotation: index}
+		}
+		return n
+	}
+
+	tests := []struct {
+		name                string
+		nodes               []v1.Node
+		params              allocationParams
+		expectedAnnotations map[string]string
+		expectedAllocations []IndexAllocation
+		expectedIssues      []allocationIssue
+		expectedRequeue     time.Duration
+	}{
+		{
+			name: "deleted node index is held for the grace period",
+			nodes: []v1.Node{
+				node("first", "0", nil),
+				node("third", "", nil),
+			},
+			params: allocationParams{
+				allocations: []IndexAllocation{{Node: "first", Index: 0}, {Node: "second", Index: 1}},
+			},
+			expectedAnnotations: map[string]string{"third": "2"},
+			expectedAllocations: []IndexAllocation{
+				{Node: "first", Index: 0},
+				{Node: "second", Index: 1, ReleasedAt: released(0)},
+				{Node: "third", Index: 2},
+			},
+			expectedRequeue: time.Hour,
+		},
+		{
+			name: "recreated node gets its index back",
+			nodes: []v1.Node{
+				node("first", "0", nil),
+				node("third", "", nil),
+				node("second", "", nil),
+			},
+			params: allocationParams{
+				allocations: []IndexAllocation{
+					{Node: "first", Index: 0},
+					{Node: "second", Index: 1, ReleasedAt: released(10 * time.Minute)},
+				},
+			},
+			expectedAnnotations: map[string]string{"second": "1", "third": "2"},
+			expectedAllocations: []IndexAllocation{
+				{Node: "first", Index: 0},
+				{Node: "second", Index: 1},
+				{Node: "third", Index: 2},
+			},
+		},
+		{
+			name: "released index is reused after the grace period",
+			nodes: []v1.Node{
+				node("first", "0", nil),
+				node("third", "", nil),
+			},
+			params: allocationParams{
+				allocations: []IndexAllocation{
+					{Node: "first", Index: 0},
+					{Node: "second", Index: 1, ReleasedAt: released(2 * time.Hour)},
+				},
+			},
+			expectedAnnotations: map[string]string{"third": "1"},
+			expectedAllocations: []IndexAllocation{
+				{Node: "first", Index: 0},
+				{Node: "third", Index: 1},
+			},
+		},
+		{
+			name: "pinned by name",
+			nodes: []v1.Node{
+				node("first", "", nil),
+				node("second", "", nil),
+			},
+			params: allocationParams{
+				pins: []IndexPin{{NodeName: "second", Index: 5}, {NodeName: "missing", Index: 0}},
+			},
+			expectedAnnotations: map[string]string{"first": "1", "second": "5"},
+			expectedAllocations: []IndexAllocation{
+				{Node: "first", Index: 1},
+				{Node: "second", Index: 5},
+			},
+		},
+		{
+			name: "pinned by label moves an annotated node",
+			nodes: []v1.Node{
+				node("first", "0", map[string]string{"rack": "r1"}),
+				node("second", "1", map[string]string{"rack": "r2"}),
+			},
+			params: allocationParams{
+				pins: []IndexPin{{NodeSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"rack": "r1"}}, Index: 3}},
+			},
+			expectedAnnotations: map[string]string{"first": "3"},
+			expectedAllocations: []IndexAllocation{
+				{Node: "second", Index: 1},
+				{Node: "first", Index: 3},
+			},
+		},
+		{
+			name: "label pin matching multiple nodes is ignored",
+			nodes: []v1.Node{
+				node("first", "", map[string]string{"rack": "r1"}),
+				node("second", "", map[string]string{"rack": "r1"}),
+			},
+			params: allocationParams{
+				pins: []IndexPin{{NodeSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"rack": "r1"}}, Index: 3}},
+			},
+			expectedAnnotations: map[string]string{"first": "0", "second": "1"},
+			expectedAllocations: []IndexAllocation{
+				{Node: "first", Index: 0},
+				{Node: "second", Index: 1},
+			},
+			expectedIssues: []allocationIssue{
+				{node: "first", reason: EventReasonNodeIndexPinConflict},
+				{node: "second", reason: EventReasonNodeIndexPinConflict},
+			},
+		},
+		{
+			name: "pinned index used by another node",
+			nodes: []v1.Node{
+				node("first", "2", nil),
+				node("second", "", nil),
+			},
+			params: allocationParams{
+				pins: []IndexPin{{NodeName: "second", Index: 2}},
+			},
+			expectedAnnotations: map[string]string{"second": "0"},
+			expectedAllocations: []IndexAllocation{
+				{Node: "second", Index: 0},
+				{Node: "first", Index: 2},
+			},
+			expectedIssues: []allocationIssue{
+				{node: "second", reason: EventReasonNodeIndexPinConflict},
+			},
+		},
+		{
+			name: "pool exhausted",
+			nodes: []v1.Node{
+				node("first", "0", nil),
+				node("second", "1", nil),
+				node("third", "", nil),
+				node("fourth", "4", nil),
+			},
+			params: allocationParams{
+				poolSize: 2,
+			},
+			expectedAnnotations: map[string]string{},
+			expectedAllocations: []IndexAllocation{
+				{Node: "first", Index: 0},
+				{Node: "second", Index: 1},
+				{Node: "fourth", Index: 4},
+			},
+			expectedIssues: []allocationIssue{
+				{node: "third", reason: EventReasonNodeIndexPoolExhausted},
+				{node: "fourth", reason: EventReasonNodeIndexPoolExhausted},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.params.now = now
+			tt.params.gracePeriod = time.Hour
+			res := allocateIndexes(tt.nodes, tt.params)
+
+			annotations := map[string]string{}
+			for _, n := range res.toAnnotate {
+				annotations[n.Name] = n.Annotations[v1alpha1.NodeIndexAnnotation]
+			}
+			if diff := cmp.Diff(tt.expectedAnnotations, annotations, cmpopts.EquateEmpty()); diff != "" {
+				t.Errorf("unexpected annotations (-want +got):\n%s", diff)
+			}
+			if diff := cmp.Diff(tt.expectedAllocations, res.allocations); diff != "" {
+				t.Errorf("unexpected allocations (-want +got):\n%s", diff)
+			}
+			ignoreMessage := cmpopts.IgnoreFields(allocationIssue{}, "message")
+			if diff := cmp.Diff(tt.expectedIssues, res.issues, cmp.AllowUnexported(allocationIssue{}), ignoreMessage); diff != "" {
+				t.Errorf("unexpected issues (-want +got):\n%s", diff)
+			}
+			if res.requeueAfter != tt.expectedRequeue {
+				t.Errorf("expected requeue after %s, got %s", tt.expectedRequeue, res.requeueAfter)
+			}
+		})
+	}
+}
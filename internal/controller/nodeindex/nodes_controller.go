@@ -19,15 +19,35 @@ package nodeindex
 import (
 	"context"
 	"log/slog"
+	"time"
 
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/events"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
+	"github.com/openperouter/openperouter/api/v1alpha1"
 	v1 "k8s.io/api/core/v1"
 )
 
+const (
+	// EventReasonNodeIndexPoolExhausted is the reason of the events emitted
+	// on a node whose index doesn't fit in the smallest CIDR.
+	EventReasonNodeIndexPoolExhausted = "NodeIndexPoolExhausted"
+	// EventReasonNodeIndexPinConflict is the reason of the events emitted on
+	// a node whose pinned index can't be assigned.
+	EventReasonNodeIndexPinConflict = "NodeIndexPinConflict"
+
+	eventActionAllocate = "AllocateIndex"
+)
+
 type requestKey string
 
 type NodesReconciler struct {
@@ -35,12 +55,23 @@ type NodesReconciler struct {
 	Scheme   *runtime.Scheme
 	LogLevel string
 	Logger   *slog.Logger
+	// Namespace is the namespace of the ConfigMap recording the node indexes.
+	// The resources whose CIDRs bound the index pool are looked for in all
+	// the namespaces, as the router configures them from all the namespaces.
+	Namespace string
+	// GracePeriod is how long the index of a deleted node is kept before
+	// being assigned to another node.
+	GracePeriod time.Duration
+	// EventRecorder emits events on the nodes whose index can't be assigned
+	// as expected. Events are not emitted when nil.
+	EventRecorder events.EventRecorder
 }
 
 // +kubebuilder:rbac:groups="",resources=nodes,verbs=get;list;watch;update;patch
+// +kubebuilder:rbac:groups=events.k8s.io,resources=events,verbs=create;patch
 // +kubebuilder:rbac:groups="admissionregistration.k8s.io",resources=validatingwebhookconfigurations,verbs=get;list;watch
 // +kubebuilder:rbac:groups="admissionregistration.k8s.io",resources=validatingwebhookconfigurations,resourceNames="openpe-validating-webhook-configuration",verbs=update
-// +kubebuilder:rbac:groups=network.openperouter.io,resources=l2vnis;l3passthroughs;l3vnis;l3vpns;rawfrrconfigs;underlays,verbs=get;list;watch
+// +kubebuilder:rbac:groups=network.openperouter.io,resources=l2vnis;l3passthroughs;l3vnis;l3vpns;nodeaddressings;rawfrrconfigs;underlays,verbs=get;list;watch
 
 func (r *NodesReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
 	logger := r.Logger.With("controller", "NodeIndex", "request", req.String())
@@ -55,21 +86,130 @@ func (r *NodesReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl
 		return ctrl.Result{}, err
 	}
 
-	nodesToAnnotate := nodesToAnnotate(nodes.Items)
-	for _, n := range nodesToAnnotate {
+	cm := &v1.ConfigMap{}
+	cmKey := types.NamespacedName{Namespace: r.Namespace, Name: AllocationsConfigMap}
+	err := r.Get(ctx, cmKey, cm)
+	if apierrors.IsNotFound(err) {
+		cm = &v1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Namespace: r.Namespace, Name: AllocationsConfigMap}}
+	} else if err != nil {
+		slog.Error("failed to get the node index configmap", "configmap", cmKey, "error", err)
+		return ctrl.Result{}, err
+	}
+
+	allocations, err := allocationsFromConfigMap(cm)
+	if err != nil {
+		slog.Error("failed to read the node index allocations", "error", err)
+		return ctrl.Result{}, err
+	}
+	// A malformed pin must not prevent the new nodes from getting an index.
+	pins, err := pinsFromConfigMap(cm)
+	if err != nil {
+		slog.Error("ignoring the node index pins", "error", err)
+	}
+
+	resources, err := r.poolResources(ctx)
+	if err != nil {
+		slog.Error("failed to list the resources bounding the node index pool", "error", err)
+		return ctrl.Result{}, err
+	}
+
+	res := allocateIndexes(nodes.Items, allocationParams{
+		allocations: allocations,
+		pins:        pins,
+		now:         time.Now(),
+		gracePeriod: r.GracePeriod,
+		poolSize:    poolSize(resources),
+	})
+
+	// The allocations are persisted before annotating the nodes, so a node
+	// index is never assigned without being recorded.
+	changed, err := setAllocations(cm, res.allocations)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	switch {
+	case changed && cm.ResourceVersion == "":
+		err = r.Create(ctx, cm)
+	case changed:
+		err = r.Update(ctx, cm)
+	}
+	if err != nil {
+		slog.Error("failed to store the node index allocations", "configmap", cmKey, "error", err)
+		return ctrl.Result{}, err
+	}
+
+	for _, n := range res.toAnnotate {
 		if err := r.Update(ctx, &n); err != nil {
 			slog.Error("failed to update node", "node", n.Name, "error", err)
 			return ctrl.Result{}, err
 		}
 	}
 
-	return ctrl.Result{}, nil
+	r.emitIssueEvents(nodes.Items, res.issues)
+
+	return ctrl.Result{RequeueAfter: res.requeueAfter}, nil
+}
+
+// poolResources returns the resources whose CIDRs bound the index pool.
+func (r *NodesReconciler) poolResources(ctx context.Context) (poolResources, error) {
+	var underlays v1alpha1.UnderlayList
+	if err := r.List(ctx, &underlays); err != nil {
+		return poolResources{}, err
+	}
+	var l3vnis v1alpha1.L3VNIList
+	if err := r.List(ctx, &l3vnis); err != nil {
+		return poolResources{}, err
+	}
+	var l3vpns v1alpha1.L3VPNList
+	if err := r.List(ctx, &l3vpns); err != nil {
+		return poolResources{}, err
+	}
+	var l3passthroughs v1alpha1.L3PassthroughList
+	if err := r.List(ctx, &l3passthroughs); err != nil {
+		return poolResources{}, err
+	}
+	return poolResources{
+		underlays:      underlays.Items,
+		l3vnis:         l3vnis.Items,
+		l3vpns:         l3vpns.Items,
+		l3passthroughs: l3passthroughs.Items,
+	}, nil
+}
+
+func (r *NodesReconciler) emitIssueEvents(nodes []v1.Node, issues []allocationIssue) {
+	if r.EventRecorder == nil {
+		return
+	}
+	for _, issue := range issues {
+		for i := range nodes {
+			if nodes[i].Name != issue.node {
+				continue
+			}
+			r.EventRecorder.Eventf(&nodes[i], nil, v1.EventTypeWarning, issue.reason, eventActionAllocate, "%s", issue.message)
+		}
+	}
 }
 
+// allocationsRequest is the single request all the events are mapped to, as
+// the index of every node depends on the indexes of the others.
+var allocationsRequest = reconcile.Request{NamespacedName: types.NamespacedName{Name: AllocationsConfigMap}}
+
 func (r *NodesReconciler) SetupWithManager(ctx context.Context, mgr ctrl.Manager) error {
+	toAllocations := handler.EnqueueRequestsFromMapFunc(func(context.Context, client.Object) []reconcile.Request {
+		return []reconcile.Request{allocationsRequest}
+	})
+	isAllocationsConfigMap := predicate.NewPredicateFuncs(func(obj client.Object) bool {
+		return obj.GetNamespace() == r.Namespace && obj.GetName() == AllocationsConfigMap
+	})
+
 	return ctrl.NewControllerManagedBy(mgr).
-		For(&v1.Node{}).
-		WithEventFilter(predicate.AnnotationChangedPredicate{}).
 		Named("nodecontroller").
+		Watches(&v1.Node{}, toAllocations,
+			builder.WithPredicates(predicate.Or(predicate.AnnotationChangedPredicate{}, predicate.LabelChangedPredicate{}))).
+		Watches(&v1.ConfigMap{}, toAllocations, builder.WithPredicates(isAllocationsConfigMap)).
+		Watches(&v1alpha1.Underlay{}, toAllocations, builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		Watches(&v1alpha1.L3VNI{}, toAllocations, builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		Watches(&v1alpha1.L3VPN{}, toAllocations, builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		Watches(&v1alpha1.L3Passthrough{}, toAllocations, builder.WithPredicates(predicate.GenerationChangedPredicate{})).
 		Complete(r)
 }
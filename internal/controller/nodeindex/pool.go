@@ -0,0 +1,69 @@
+// SPDX-License-Identifier:Apache-2.0
+
+package nodeindex
+
+import (
+	"github.com/openperouter/openperouter/api/v1alpha1"
+	"github.com/openperouter/openperouter/internal/ipam"
+	"k8s.io/utils/ptr"
+)
+
+// poolResources are the resources whose CIDRs are indexed by the node index.
+type poolResources struct {
+	underlays      []v1alpha1.Underlay
+	l3vnis         []v1alpha1.L3VNI
+	l3vpns         []v1alpha1.L3VPN
+	l3passthroughs []v1alpha1.L3Passthrough
+}
+
+// poolSize returns the number of node indexes the CIDRs can accommodate,
+// which is the number of IPs of the smallest one, minus the IPs reserved to
+// the router side of the veths for the host session CIDRs. It returns 0 when
+// there are no CIDRs.
+func poolSize(resources poolResources) uint64 {
+	var cidrs, vethCIDRs []string
+	for _, u := range resources.underlays {
+		cidrs = append(cidrs, ptr.Deref(u.Spec.RouterIDCIDR, ""))
+		if u.Spec.TunnelEndpoint != nil {
+			cidrs = append(cidrs, u.Spec.TunnelEndpoint.CIDRs...)
+		}
+	}
+	hostSessionCIDRs := func(s *v1alpha1.HostSession) {
+		if s == nil {
+			return
+		}
+		vethCIDRs = append(vethCIDRs, ptr.Deref(s.LocalCIDR.IPv4, ""), ptr.Deref(s.LocalCIDR.IPv6, ""))
+	}
+	for _, vni := range resources.l3vnis {
+		hostSessionCIDRs(vni.Spec.HostSession)
+	}
+	for _, vpn := range resources.l3vpns {
+		hostSessionCIDRs(vpn.Spec.HostSession)
+	}
+	for _, p := range resources.l3passthroughs {
+		hostSessionCIDRs(&p.Spec.HostSession)
+	}
+
+	var res uint64
+	found := false
+	smallest := func(cidrs []string, sizeOf func(string) (uint64, error)) {
+		for _, cidr := range cidrs {
+			if cidr == "" {
+				continue
+			}
+			// Invalid CIDRs are rejected by the webhooks, and reported by the
+			// controller configuring the nodes.
+			size, err := sizeOf(cidr)
+			if err != nil {
+				continue
+			}
+			if !found || size < res {
+				res = size
+				found = true
+			}
+		}
+	}
+	smallest(cidrs, ipam.IPsInCIDR)
+	smallest(vethCIDRs, ipam.VethIndexesInCIDR)
+	return res
+}
@@ -0,0 +1,133 @@
+// SPDX-License-Identifier:Apache-2.0
+
+package nodeindex
+
+import (
+	"context"
+	"testing"
+
+	"github.com/openperouter/openperouter/api/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestPoolSize(t *testing.T) {
+	tests := []struct {
+		name      string
+		resources poolResources
+		expected  uint64
+	}{
+		{
+			name:     "no resources",
+			expected: 0,
+		},
+		{
+			name: "smallest underlay cidr",
+			resources: poolResources{
+				underlays: []v1alpha1.Underlay{{
+					Spec: v1alpha1.UnderlaySpec{
+						RouterIDCIDR:   ptr.To("10.0.0.0/24"),
+						TunnelEndpoint: &v1alpha1.TunnelEndpointConfig{CIDRs: []string{"100.65.0.0/26", "fd00::/120"}},
+					},
+				}},
+			},
+			expected: 64,
+		},
+		{
+			name: "host session cidr smaller than the underlay ones",
+			resources: poolResources{
+				underlays: []v1alpha1.Underlay{{
+					Spec: v1alpha1.UnderlaySpec{
+						TunnelEndpoint: &v1alpha1.TunnelEndpointConfig{CIDRs: []string{"100.65.0.0/24"}},
+					},
+				}},
+				l3vnis: []v1alpha1.L3VNI{{
+					Spec: v1alpha1.L3VNISpec{
+						HostSession: &v1alpha1.HostSession{LocalCIDR: v1alpha1.LocalCIDRConfig{IPv4: ptr.To("192.169.10.0/28")}},
+					},
+				}},
+				l3passthroughs: []v1alpha1.L3Passthrough{{
+					Spec: v1alpha1.L3PassthroughSpec{
+						HostSession: v1alpha1.HostSession{LocalCIDR: v1alpha1.LocalCIDRConfig{IPv6: ptr.To("fd00:10::/125")}},
+					},
+				}},
+			},
+			expected: 6,
+		},
+		{
+			name: "host session cidr excludes the router side ip",
+			resources: poolResources{
+				underlays: []v1alpha1.Underlay{{
+					Spec: v1alpha1.UnderlaySpec{
+						TunnelEndpoint: &v1alpha1.TunnelEndpointConfig{CIDRs: []string{"100.65.0.0/29"}},
+					},
+				}},
+				l3vnis: []v1alpha1.L3VNI{{
+					Spec: v1alpha1.L3VNISpec{
+						HostSession: &v1alpha1.HostSession{LocalCIDR: v1alpha1.LocalCIDRConfig{IPv4: ptr.To("192.169.10.4/30")}},
+					},
+				}},
+			},
+			expected: 3,
+		},
+		{
+			name: "host session cidr of the same size as the underlay one",
+			resources: poolResources{
+				underlays: []v1alpha1.Underlay{{
+					Spec: v1alpha1.UnderlaySpec{
+						TunnelEndpoint: &v1alpha1.TunnelEndpointConfig{CIDRs: []string{"100.65.0.0/28"}},
+					},
+				}},
+				l3vnis: []v1alpha1.L3VNI{{
+					Spec: v1alpha1.L3VNISpec{
+						HostSession: &v1alpha1.HostSession{LocalCIDR: v1alpha1.LocalCIDRConfig{IPv4: ptr.To("192.169.10.0/28")}},
+					},
+				}},
+			},
+			expected: 14,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := poolSize(tt.resources); got != tt.expected {
+				t.Errorf("expected pool size %d, got %d", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestPoolResourcesAllNamespaces(t *testing.T) {
+	s := runtime.NewScheme()
+	if err := v1alpha1.AddToScheme(s); err != nil {
+		t.Fatalf("failed to add v1alpha1 to scheme: %v", err)
+	}
+	cli := fake.NewClientBuilder().
+		WithScheme(s).
+		WithObjects(
+			&v1alpha1.Underlay{
+				ObjectMeta: metav1.ObjectMeta{Name: "underlay", Namespace: "openperouter-system"},
+				Spec: v1alpha1.UnderlaySpec{
+					TunnelEndpoint: &v1alpha1.TunnelEndpointConfig{CIDRs: []string{"100.65.0.0/24"}},
+				},
+			},
+			&v1alpha1.L3VNI{
+				ObjectMeta: metav1.ObjectMeta{Name: "l3vni", Namespace: "tenant"},
+				Spec: v1alpha1.L3VNISpec{
+					HostSession: &v1alpha1.HostSession{LocalCIDR: v1alpha1.LocalCIDRConfig{IPv4: ptr.To("192.169.10.0/28")}},
+				},
+			},
+		).
+		Build()
+
+	r := &NodesReconciler{Client: cli, Scheme: s, Namespace: "openperouter-system"}
+	resources, err := r.poolResources(context.Background())
+	if err != nil {
+		t.Fatalf("failed to list the pool resources: %v", err)
+	}
+	if got := poolSize(resources); got != 14 {
+		t.Fatalf("expected the cidr of the other namespace to bound the pool to 14, got %d", got)
+	}
+}
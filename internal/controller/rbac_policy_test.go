@@ -21,9 +21,11 @@ func TestControllerClusterRole(t *testing.T) {
 	cr := loadClusterRole(t, "role.yaml")
 	assertRules(t, cr.Name, cr.Rules, []expectedRule{
 		{APIGroups: []string{""}, Resources: []string{"nodes"}, Verbs: []string{"get", "list", "patch", "watch"}},
+		{APIGroups: []string{"events.k8s.io"}, Resources: []string{"events"}, Verbs: []string{"create", "patch"}},
 		{APIGroups: crdGroup, Resources: []string{"l2vnis", "l3passthroughs", "l3vnis", "l3vpns", "rawfrrconfigs", "routernodeconfigurationstatuses", "underlays"}, Verbs: []string{"create", "delete", "get", "list", "patch", "update", "watch"}},
 		{APIGroups: crdGroup, Resources: []string{"l2vnis/finalizers", "l3passthroughs/finalizers", "l3vnis/finalizers", "l3vpns/finalizers", "rawfrrconfigs/finalizers", "underlays/finalizers"}, Verbs: []string{"update"}},
 		{APIGroups: crdGroup, Resources: []string{"l2vnis/status", "l3passthroughs/status", "l3vnis/status", "l3vpns/status", "rawfrrconfigs/status", "routernodeconfigurationstatuses/status", "underlays/status"}, Verbs: []string{"get", "patch", "update"}},
+		{APIGroups: crdGroup, Resources: []string{"nodeaddressings"}, Verbs: []string{"get", "list", "watch"}},
 	})
 }
 
@@ -33,7 +35,9 @@ func TestNodemarkerClusterRole(t *testing.T) {
 		{APIGroups: []string{""}, Resources: []string{"nodes"}, Verbs: []string{"get", "list", "patch", "update", "watch"}},
 		{APIGroups: []string{"admissionregistration.k8s.io"}, Resources: []string{"validatingwebhookconfigurations"}, Verbs: []string{"get", "list", "watch"}},
 		{APIGroups: []string{"admissionregistration.k8s.io"}, Resources: []string{"validatingwebhookconfigurations"}, ResourceNames: []string{"openpe-validating-webhook-configuration"}, Verbs: []string{"update"}},
-		{APIGroups: crdGroup, Resources: []string{"l2vnis", "l3passthroughs", "l3vnis", "l3vpns", "rawfrrconfigs", "underlays"}, Verbs: []string{"get", "list", "watch"}},
+		{APIGroups: []string{"events.k8s.io"}, Resources: []string{"events"}, Verbs: []string{"create", "patch"}},
+		{APIGroups: crdGroup, Resources: []string{"l2vnis", "l3passthroughs", "l3vnis", "l3vpns", "nodeaddressings", "rawfrrconfigs", "routernodeconfigurationstatuses", "underlays"}, Verbs: []string{"get", "list", "watch"}},
+		{APIGroups: crdGroup, Resources: []string{"l2vnis/status", "l3passthroughs/status", "l3vnis/status", "l3vpns/status", "underlays/status"}, Verbs: []string{"get", "patch", "update"}},
 	})
 }
 
@@ -48,6 +52,7 @@ func TestControllerNamespacedRole(t *testing.T) {
 func TestNodemarkerNamespacedRole(t *testing.T) {
 	role := loadRole(t, "nodemarker_role.yaml")
 	assertRules(t, role.Name, role.Rules, []expectedRule{
+		{APIGroups: []string{""}, Resources: []string{"configmaps"}, Verbs: []string{"create", "get", "list", "update", "watch"}},
 		{APIGroups: []string{""}, Resources: []string{"secrets"}, Verbs: []string{"get", "list", "watch"}},
 		{APIGroups: []string{""}, Resources: []string{"secrets"}, ResourceNames: []string{"openpe-webhook-server-cert"}, Verbs: []string{"update"}},
 	})
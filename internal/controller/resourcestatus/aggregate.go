@@ -0,0 +1,174 @@
+// SPDX-License-Identifier:Apache-2.0
+
+package resourcestatus
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+
+	"github.com/openperouter/openperouter/api/v1alpha1"
+	openpeerrors "github.com/openperouter/openperouter/internal/errors"
+)
+
+// nodeOutcome is the outcome of applying a resource on a single node.
+type nodeOutcome int
+
+const (
+	outcomePending nodeOutcome = iota
+	outcomeApplied
+	outcomeFailed
+)
+
+// aggregateStatus computes the status of the resource of the given kind and name,
+// selected by selector, out of the router status reported by the selected nodes.
+// The conditions of existing are carried over so that their transition time is
+// changed only when their status changes.
+func aggregateStatus(kind v1alpha1.FailedResourceKind, name string, selector *metav1.LabelSelector,
+	nodes []corev1.Node, routerStatuses map[string]*v1alpha1.RouterNodeConfigurationStatus,
+	existing *v1alpha1.NodesConfigurationStatus) (v1alpha1.NodesConfigurationStatus, error) {
+	var res v1alpha1.NodesConfigurationStatus
+	if existing != nil && len(existing.Conditions) > 0 {
+		res.Conditions = slices.Clone(existing.Conditions)
+	}
+
+	sel, err := selectorFor(selector)
+	if err != nil {
+		return v1alpha1.NodesConfigurationStatus{}, fmt.Errorf("invalid node selector for %s %s: %w", kind, name, err)
+	}
+
+	pending := 0
+	for _, node := range nodes {
+		if !sel.Matches(labels.Set(node.Labels)) {
+			continue
+		}
+		res.SelectedNodes++
+
+		outcome, failure := outcomeForNode(kind, name, routerStatuses[node.Name])
+		switch outcome {
+		case outcomePending:
+			pending++
+		case outcomeApplied:
+			res.AppliedNodes++
+		case outcomeFailed:
+			res.FailedNodes++
+			failure.Node = node.Name
+			res.NodeFailures = append(res.NodeFailures, failure)
+		}
+	}
+	slices.SortFunc(res.NodeFailures, func(a, b v1alpha1.NodeFailure) int {
+		return strings.Compare(a.Node, b.Node)
+	})
+
+	setConditions(&res, pending)
+	return res, nil
+}
+
+func selectorFor(selector *metav1.LabelSelector) (labels.Selector, error) {
+	if selector == nil {
+		return labels.Everything(), nil
+	}
+	return metav1.LabelSelectorAsSelector(selector)
+}
+
+// outcomeForNode tells whether the resource is applied on the node reporting
+// the given router status. A resource is failed when it's listed among the
+// failed resources of the node, or when the node failed as a whole without
+// pointing to a specific resource.
+func outcomeForNode(kind v1alpha1.FailedResourceKind, name string,
+	routerStatus *v1alpha1.RouterNodeConfigurationStatus) (nodeOutcome, v1alpha1.NodeFailure) {
+	if routerStatus == nil || routerStatus.Status == nil {
+		return outcomePending, v1alpha1.NodeFailure{}
+	}
+	ready := apimeta.FindStatusCondition(routerStatus.Status.Conditions, v1alpha1.ConditionTypeReady)
+	if ready == nil {
+		return outcomePending, v1alpha1.NodeFailure{}
+	}
+
+	for _, f := range routerStatus.Status.FailedResources {
+		if f.Kind == kind && f.Name == name {
+			return outcomeFailed, v1alpha1.NodeFailure{Reason: f.Reason, Message: f.Message}
+		}
+	}
+	// A failed FRR reload leaves the previous configuration in place, so
+	// none of the resources of the node are applied.
+	for _, f := range routerStatus.Status.FailedResources {
+		if f.Kind == openpeerrors.KindFrrConfiguration {
+			return outcomeFailed, v1alpha1.NodeFailure{Reason: f.Reason, Message: f.Message}
+		}
+	}
+
+	if ready.Status == metav1.ConditionTrue {
+		return outcomeApplied, v1alpha1.NodeFailure{}
+	}
+
+	// An underlay failure leaves the whole router configuration untouched, so
+	// the resources depending on it are not applied either.
+	if ready.Reason == v1alpha1.ConditionReasonUnderlayFailed && kind != openpeerrors.KindUnderlay {
+		return outcomeFailed, v1alpha1.NodeFailure{
+			Reason:  v1alpha1.FailedResourceReasonDependencyFailed,
+			Message: "the underlay failed on the node",
+		}
+	}
+	if len(routerStatus.Status.FailedResources) == 0 {
+		return outcomeFailed, v1alpha1.NodeFailure{
+			Reason:  v1alpha1.FailedResourceReasonFrrConfigurationFailed,
+			Message: truncate(ready.Message, maxMessageLength),
+		}
+	}
+	return outcomeApplied, v1alpha1.NodeFailure{}
+}
+
+func setConditions(s *v1alpha1.NodesConfigurationStatus, pending int) {
+	ready := metav1.Condition{
+		Type:    v1alpha1.ConditionTypeReady,
+		Status:  metav1.ConditionTrue,
+		Reason:  v1alpha1.ConditionReasonConfigSuccessful,
+		Message: fmt.Sprintf("Applied on %d of %d selected nodes", s.AppliedNodes, s.SelectedNodes),
+	}
+	degraded := metav1.Condition{
+		Type:    v1alpha1.ConditionTypeDegraded,
+		Status:  metav1.ConditionFalse,
+		Reason:  v1alpha1.ConditionReasonConfigSuccessful,
+		Message: ready.Message,
+	}
+
+	switch {
+	case s.FailedNodes > 0:
+		msg := fmt.Sprintf("Failed on %d of %d selected nodes, see status.nodeFailures for details",
+			s.FailedNodes, s.SelectedNodes)
+		ready.Status = metav1.ConditionFalse
+		ready.Reason = v1alpha1.ConditionReasonConfigFailed
+		ready.Message = msg
+		degraded.Status = metav1.ConditionTrue
+		degraded.Reason = v1alpha1.ConditionReasonConfigFailed
+		degraded.Message = msg
+	case pending > 0:
+		ready.Status = metav1.ConditionFalse
+		ready.Reason = v1alpha1.ConditionReasonConfigPending
+		ready.Message = fmt.Sprintf("Waiting for %d of %d selected nodes to report their status",
+			pending, s.SelectedNodes)
+		degraded.Reason = v1alpha1.ConditionReasonConfigPending
+		degraded.Message = ready.Message
+	}
+
+	apimeta.SetStatusCondition(&s.Conditions, ready)
+	apimeta.SetStatusCondition(&s.Conditions, degraded)
+}
+
+const maxMessageLength = 500
+
+func truncate(s string, length int) string {
+	if s == "" {
+		return "unknown failure"
+	}
+	if len(s) <= length {
+		return s
+	}
+	return s[:length]
+}
@@ -0,0 +1,253 @@
+// SPDX-License-Identifier:Apache-2.0
+
+package resourcestatus
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	corev1 "k8s.io/api/core/v1"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/openperouter/openperouter/api/v1alpha1"
+	openpeerrors "github.com/openperouter/openperouter/internal/errors"
+)
+
+func node(name string, labels map[string]string) corev1.Node {
+	return corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: name, Labels: labels}}
+}
+
+func routerStatus(ready bool, reason, message string, failed ...v1alpha1.FailedResource) *v1alpha1.RouterNodeConfigurationStatus {
+	status := metav1.ConditionFalse
+	if ready {
+		status = metav1.ConditionTrue
+	}
+	return &v1alpha1.RouterNodeConfigurationStatus{
+		Status: &v1alpha1.RouterNodeConfigurationStatusStatus{
+			FailedResources: failed,
+			Conditions: []metav1.Condition{{
+				Type:    v1alpha1.ConditionTypeReady,
+				Status:  status,
+				Reason:  reason,
+				Message: message,
+			}},
+		},
+	}
+}
+
+func TestAggregateStatus(t *testing.T) {
+	nodes := []corev1.Node{
+		node("node0", map[string]string{"rack": "a"}),
+		node("node1", map[string]string{"rack": "a"}),
+		node("node2", map[string]string{"rack": "b"}),
+	}
+	vniFailure := v1alpha1.FailedResource{
+		Kind:    openpeerrors.KindL3VNI,
+		Name:    "red",
+		Reason:  v1alpha1.FailedResourceReasonValidationFailed,
+		Message: "duplicate vni",
+	}
+	otherFailure := v1alpha1.FailedResource{
+		Kind:    openpeerrors.KindL2VNI,
+		Name:    "blue",
+		Reason:  v1alpha1.FailedResourceReasonValidationFailed,
+		Message: "invalid vrf",
+	}
+
+	tests := []struct {
+		name           string
+		kind           v1alpha1.FailedResourceKind
+		selector       *metav1.LabelSelector
+		routerStatuses map[string]*v1alpha1.RouterNodeConfigurationStatus
+		expected       v1alpha1.NodesConfigurationStatus
+		expectedReady  metav1.ConditionStatus
+		expectedReason string
+	}{
+		{
+			name: "applied on all nodes",
+			kind: openpeerrors.KindL3VNI,
+			routerStatuses: map[string]*v1alpha1.RouterNodeConfigurationStatus{
+				"node0": routerStatus(true, v1alpha1.ConditionReasonConfigSuccessful, ""),
+				"node1": routerStatus(true, v1alpha1.ConditionReasonConfigSuccessful, ""),
+				"node2": routerStatus(false, v1alpha1.ConditionReasonConfigFailed, "", otherFailure),
+			},
+			expected: v1alpha1.NodesConfigurationStatus{
+				SelectedNodes: 3,
+				AppliedNodes:  3,
+			},
+			expectedReady:  metav1.ConditionTrue,
+			expectedReason: v1alpha1.ConditionReasonConfigSuccessful,
+		},
+		{
+			name:     "only selected nodes are considered",
+			kind:     openpeerrors.KindL3VNI,
+			selector: &metav1.LabelSelector{MatchLabels: map[string]string{"rack": "a"}},
+			routerStatuses: map[string]*v1alpha1.RouterNodeConfigurationStatus{
+				"node0": routerStatus(true, v1alpha1.ConditionReasonConfigSuccessful, ""),
+				"node1": routerStatus(true, v1alpha1.ConditionReasonConfigSuccessful, ""),
+				"node2": routerStatus(false, v1alpha1.ConditionReasonConfigFailed, "", vniFailure),
+			},
+			expected: v1alpha1.NodesConfigurationStatus{
+				SelectedNodes: 2,
+				AppliedNodes:  2,
+			},
+			expectedReady:  metav1.ConditionTrue,
+			expectedReason: v1alpha1.ConditionReasonConfigSuccessful,
+		},
+		{
+			name: "failed on a node",
+			kind: openpeerrors.KindL3VNI,
+			routerStatuses: map[string]*v1alpha1.RouterNodeConfigurationStatus{
+				"node0": routerStatus(true, v1alpha1.ConditionReasonConfigSuccessful, ""),
+				"node1": routerStatus(false, v1alpha1.ConditionReasonConfigFailed, "", vniFailure, otherFailure),
+				"node2": routerStatus(true, v1alpha1.ConditionReasonConfigSuccessful, ""),
+			},
+			expected: v1alpha1.NodesConfigurationStatus{
+				SelectedNodes: 3,
+				AppliedNodes:  2,
+				FailedNodes:   1,
+				NodeFailures: []v1alpha1.NodeFailure{
+					{Node: "node1", Reason: v1alpha1.FailedResourceReasonValidationFailed, Message: "duplicate vni"},
+				},
+			},
+			expectedReady:  metav1.ConditionFalse,
+			expectedReason: v1alpha1.ConditionReasonConfigFailed,
+		},
+		{
+			name: "node not reporting yet",
+			kind: openpeerrors.KindL3VNI,
+			routerStatuses: map[string]*v1alpha1.RouterNodeConfigurationStatus{
+				"node0": routerStatus(true, v1alpha1.ConditionReasonConfigSuccessful, ""),
+				"node2": routerStatus(true, v1alpha1.ConditionReasonConfigSuccessful, ""),
+			},
+			expected: v1alpha1.NodesConfigurationStatus{
+				SelectedNodes: 3,
+				AppliedNodes:  2,
+			},
+			expectedReady:  metav1.ConditionFalse,
+			expectedReason: v1alpha1.ConditionReasonConfigPending,
+		},
+		{
+			name: "underlay failure fails the overlays",
+			kind: openpeerrors.KindL3VNI,
+			routerStatuses: map[string]*v1alpha1.RouterNodeConfigurationStatus{
+				"node0": routerStatus(false, v1alpha1.ConditionReasonUnderlayFailed, "underlay failed",
+					v1alpha1.FailedResource{
+						Kind:    openpeerrors.KindUnderlay,
+						Name:    "underlay",
+						Reason:  v1alpha1.FailedResourceReasonValidationFailed,
+						Message: "invalid asn",
+					}),
+				"node1": routerStatus(true, v1alpha1.ConditionReasonConfigSuccessful, ""),
+				"node2": routerStatus(true, v1alpha1.ConditionReasonConfigSuccessful, ""),
+			},
+			expected: v1alpha1.NodesConfigurationStatus{
+				SelectedNodes: 3,
+				AppliedNodes:  2,
+				FailedNodes:   1,
+				NodeFailures: []v1alpha1.NodeFailure{
+					{Node: "node0", Reason: v1alpha1.FailedResourceReasonDependencyFailed, Message: "the underlay failed on the node"},
+				},
+			},
+			expectedReady:  metav1.ConditionFalse,
+			expectedReason: v1alpha1.ConditionReasonConfigFailed,
+		},
+		{
+			name: "node failing to reload frr",
+			kind: openpeerrors.KindL3VNI,
+			routerStatuses: map[string]*v1alpha1.RouterNodeConfigurationStatus{
+				"node0": routerStatus(true, v1alpha1.ConditionReasonConfigSuccessful, ""),
+				"node1": routerStatus(false, v1alpha1.ConditionReasonConfigFailed, "FRR configuration failed to apply",
+					v1alpha1.FailedResource{
+						Kind:    openpeerrors.KindFrrConfiguration,
+						Name:    "frr.conf",
+						Reason:  v1alpha1.FailedResourceReasonFrrConfigurationFailed,
+						Message: "frr update reload failed, rolled back to the last known good configuration",
+					}),
+				"node2": routerStatus(true, v1alpha1.ConditionReasonConfigSuccessful, ""),
+			},
+			expected: v1alpha1.NodesConfigurationStatus{
+				SelectedNodes: 3,
+				AppliedNodes:  2,
+				FailedNodes:   1,
+				NodeFailures: []v1alpha1.NodeFailure{
+					{Node: "node1", Reason: v1alpha1.FailedResourceReasonFrrConfigurationFailed,
+						Message: "frr update reload failed, rolled back to the last known good configuration"},
+				},
+			},
+			expectedReady:  metav1.ConditionFalse,
+			expectedReason: v1alpha1.ConditionReasonConfigFailed,
+		},
+		{
+			name: "node failing without failed resources",
+			kind: openpeerrors.KindUnderlay,
+			routerStatuses: map[string]*v1alpha1.RouterNodeConfigurationStatus{
+				"node0": routerStatus(true, v1alpha1.ConditionReasonConfigSuccessful, ""),
+				"node1": routerStatus(true, v1alpha1.ConditionReasonConfigSuccessful, ""),
+				"node2": routerStatus(false, v1alpha1.ConditionReasonConfigFailed, "failed to reload frr"),
+			},
+			expected: v1alpha1.NodesConfigurationStatus{
+				SelectedNodes: 3,
+				AppliedNodes:  2,
+				FailedNodes:   1,
+				NodeFailures: []v1alpha1.NodeFailure{
+					{Node: "node2", Reason: v1alpha1.FailedResourceReasonFrrConfigurationFailed, Message: "failed to reload frr"},
+				},
+			},
+			expectedReady:  metav1.ConditionFalse,
+			expectedReason: v1alpha1.ConditionReasonConfigFailed,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := aggregateStatus(tc.kind, "red", tc.selector, nodes, tc.routerStatuses, nil)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if diff := cmp.Diff(tc.expected, got, cmpopts.IgnoreFields(v1alpha1.NodesConfigurationStatus{}, "Conditions")); diff != "" {
+				t.Fatalf("unexpected status (-want +got)\n%s", diff)
+			}
+			ready := apimeta.FindStatusCondition(got.Conditions, v1alpha1.ConditionTypeReady)
+			if ready == nil {
+				t.Fatal("ready condition not set")
+			}
+			if ready.Status != tc.expectedReady || ready.Reason != tc.expectedReason {
+				t.Fatalf("unexpected ready condition: %+v", ready)
+			}
+			degraded := apimeta.FindStatusCondition(got.Conditions, v1alpha1.ConditionTypeDegraded)
+			if degraded == nil {
+				t.Fatal("degraded condition not set")
+			}
+			if (degraded.Status == metav1.ConditionTrue) != (tc.expected.FailedNodes > 0) {
+				t.Fatalf("unexpected degraded condition: %+v", degraded)
+			}
+		})
+	}
+}
+
+func TestAggregateStatusKeepsTransitionTime(t *testing.T) {
+	nodes := []corev1.Node{node("node0", nil)}
+	statuses := map[string]*v1alpha1.RouterNodeConfigurationStatus{
+		"node0": routerStatus(true, v1alpha1.ConditionReasonConfigSuccessful, ""),
+	}
+
+	first, err := aggregateStatus(openpeerrors.KindL2VNI, "red", nil, nodes, statuses, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	past := metav1.NewTime(first.Conditions[0].LastTransitionTime.Add(-1000000000))
+	for i := range first.Conditions {
+		first.Conditions[i].LastTransitionTime = past
+	}
+
+	second, err := aggregateStatus(openpeerrors.KindL2VNI, "red", nil, nodes, statuses, &first)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !cmp.Equal(first, second) {
+		t.Fatalf("status changed on a second aggregation (-want +got)\n%s", cmp.Diff(first, second))
+	}
+}
@@ -0,0 +1,230 @@
+// SPDX-License-Identifier:Apache-2.0
+
+package resourcestatus
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/openperouter/openperouter/api/v1alpha1"
+	openpeerrors "github.com/openperouter/openperouter/internal/errors"
+)
+
+// aggregateRequest is the single request all the events are mapped to, as the
+// status of every resource depends on the status of every node.
+var aggregateRequest = reconcile.Request{NamespacedName: types.NamespacedName{Name: "resource-status"}}
+
+// Reconciler aggregates the per node RouterNodeConfigurationStatus objects into the
+// status of the Underlay, L3VNI, L2VNI, L3VPN and L3Passthrough resources.
+type Reconciler struct {
+	client.Client
+	Scheme *runtime.Scheme
+	Logger *slog.Logger
+	// Namespace is the namespace the RouterNodeConfigurationStatus objects are
+	// looked for in. All the namespaces are considered when empty.
+	Namespace string
+}
+
+// +kubebuilder:rbac:groups="",resources=nodes,verbs=get;list;watch
+// +kubebuilder:rbac:groups=network.openperouter.io,resources=routernodeconfigurationstatuses,verbs=get;list;watch
+// +kubebuilder:rbac:groups=network.openperouter.io,resources=l2vnis;l3passthroughs;l3vnis;l3vpns;underlays,verbs=get;list;watch
+// +kubebuilder:rbac:groups=network.openperouter.io,resources=l2vnis/status;l3passthroughs/status;l3vnis/status;l3vpns/status;underlays/status,verbs=get;update;patch
+
+func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	logger := r.Logger.With("controller", "ResourceStatus", "request", req.String())
+	logger.Info("start reconcile")
+	defer logger.Info("end reconcile")
+
+	var nodes corev1.NodeList
+	if err := r.List(ctx, &nodes); err != nil {
+		logger.Error("failed to list nodes", "error", err)
+		return ctrl.Result{}, err
+	}
+
+	var routerStatuses v1alpha1.RouterNodeConfigurationStatusList
+	if err := r.List(ctx, &routerStatuses, client.InNamespace(r.Namespace)); err != nil {
+		logger.Error("failed to list router node configuration statuses", "error", err)
+		return ctrl.Result{}, err
+	}
+	statusForNode := map[string]*v1alpha1.RouterNodeConfigurationStatus{}
+	for i := range routerStatuses.Items {
+		statusForNode[routerStatuses.Items[i].Name] = &routerStatuses.Items[i]
+	}
+
+	u := statusUpdater{Client: r.Client, nodes: nodes.Items, routerStatuses: statusForNode}
+	err := errors.Join(
+		updateStatuses[v1alpha1.UnderlayList](ctx, u, openpeerrors.KindUnderlay),
+		updateStatuses[v1alpha1.L3VNIList](ctx, u, openpeerrors.KindL3VNI),
+		updateStatuses[v1alpha1.L2VNIList](ctx, u, openpeerrors.KindL2VNI),
+		updateStatuses[v1alpha1.L3VPNList](ctx, u, openpeerrors.KindL3VPN),
+		updateStatuses[v1alpha1.L3PassthroughList](ctx, u, openpeerrors.KindL3Passthrough),
+	)
+	if err != nil {
+		logger.Error("failed to update resources status", "error", err)
+		return ctrl.Result{}, err
+	}
+	return ctrl.Result{}, nil
+}
+
+func (r *Reconciler) SetupWithManager(mgr ctrl.Manager) error {
+	toAggregate := handler.EnqueueRequestsFromMapFunc(func(context.Context, client.Object) []reconcile.Request {
+		return []reconcile.Request{aggregateRequest}
+	})
+	// The status patched by this controller must not trigger a new reconciliation.
+	specChanged := predicate.Or(predicate.GenerationChangedPredicate{}, predicate.LabelChangedPredicate{})
+	// The operational state periodically published by the nodes is not relevant
+	// for the aggregated status.
+	configStatusChanged := predicate.Funcs{
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			return !equality.Semantic.DeepEqual(configurationStatus(e.ObjectOld), configurationStatus(e.ObjectNew))
+		},
+	}
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named("resourcestatuscontroller").
+		Watches(&corev1.Node{}, toAggregate, builder.WithPredicates(predicate.LabelChangedPredicate{})).
+		Watches(&v1alpha1.RouterNodeConfigurationStatus{}, toAggregate, builder.WithPredicates(configStatusChanged)).
+		Watches(&v1alpha1.Underlay{}, toAggregate, builder.WithPredicates(specChanged)).
+		Watches(&v1alpha1.L3VNI{}, toAggregate, builder.WithPredicates(specChanged)).
+		Watches(&v1alpha1.L2VNI{}, toAggregate, builder.WithPredicates(specChanged)).
+		Watches(&v1alpha1.L3VPN{}, toAggregate, builder.WithPredicates(specChanged)).
+		Watches(&v1alpha1.L3Passthrough{}, toAggregate, builder.WithPredicates(specChanged)).
+		Complete(r)
+}
+
+// configurationStatus returns the status of the given RouterNodeConfigurationStatus
+// without the operational state.
+func configurationStatus(obj client.Object) *v1alpha1.RouterNodeConfigurationStatusStatus {
+	s, ok := obj.(*v1alpha1.RouterNodeConfigurationStatus)
+	if !ok || s.Status == nil {
+		return nil
+	}
+	res := s.Status.DeepCopy()
+	res.OperationalState = nil
+	return res
+}
+
+type statusUpdater struct {
+	client.Client
+	nodes          []corev1.Node
+	routerStatuses map[string]*v1alpha1.RouterNodeConfigurationStatus
+}
+
+// updateStatuses patches the aggregated status of every resource of the list
+// type L, whose kind is kind.
+func updateStatuses[L any, PL interface {
+	*L
+	client.ObjectList
+}](ctx context.Context, u statusUpdater, kind v1alpha1.FailedResourceKind) error {
+	list := PL(new(L))
+	if err := u.List(ctx, list); err != nil {
+		return fmt.Errorf("failed to list %s resources: %w", kind, err)
+	}
+	items, err := apimeta.ExtractList(list)
+	if err != nil {
+		return fmt.Errorf("failed to extract %s resources: %w", kind, err)
+	}
+	var errs []error
+	for _, item := range items {
+		obj, ok := item.(client.Object)
+		if !ok {
+			errs = append(errs, fmt.Errorf("unexpected %s item type %T", kind, item))
+			continue
+		}
+		errs = append(errs, u.patchStatus(ctx, obj, kind))
+	}
+	return errors.Join(errs...)
+}
+
+// patchStatus computes the aggregated status of obj and patches it if it
+// differs from the existing one.
+func (u statusUpdater) patchStatus(ctx context.Context, obj client.Object, kind v1alpha1.FailedResourceKind) error {
+	selector, existing, err := nodesStatusFields(obj)
+	if err != nil {
+		return err
+	}
+	status, err := aggregateStatus(kind, obj.GetName(), selector, u.nodes, u.routerStatuses, existing)
+	if err != nil {
+		return err
+	}
+	if existing != nil && equality.Semantic.DeepEqual(*existing, status) {
+		return nil
+	}
+
+	updated := obj.DeepCopyObject().(client.Object)
+	if err := setNodesStatus(updated, status); err != nil {
+		return err
+	}
+	if err := u.Status().Patch(ctx, updated, client.MergeFrom(obj)); err != nil {
+		return fmt.Errorf("failed to patch status of %s %s/%s: %w", kind, obj.GetNamespace(), obj.GetName(), err)
+	}
+	return nil
+}
+
+// nodesStatusFields returns the node selector of obj and its existing
+// aggregated status, nil when it was never set.
+func nodesStatusFields(obj client.Object) (*metav1.LabelSelector, *v1alpha1.NodesConfigurationStatus, error) {
+	switch o := obj.(type) {
+	case *v1alpha1.Underlay:
+		if o.Status == nil {
+			return o.Spec.NodeSelector, nil, nil
+		}
+		return o.Spec.NodeSelector, &o.Status.NodesConfigurationStatus, nil
+	case *v1alpha1.L3VNI:
+		if o.Status == nil {
+			return o.Spec.NodeSelector, nil, nil
+		}
+		return o.Spec.NodeSelector, &o.Status.NodesConfigurationStatus, nil
+	case *v1alpha1.L2VNI:
+		if o.Status == nil {
+			return o.Spec.NodeSelector, nil, nil
+		}
+		return o.Spec.NodeSelector, &o.Status.NodesConfigurationStatus, nil
+	case *v1alpha1.L3VPN:
+		if o.Status == nil {
+			return o.Spec.NodeSelector, nil, nil
+		}
+		return o.Spec.NodeSelector, &o.Status.NodesConfigurationStatus, nil
+	case *v1alpha1.L3Passthrough:
+		if o.Status == nil {
+			return o.Spec.NodeSelector, nil, nil
+		}
+		return o.Spec.NodeSelector, &o.Status.NodesConfigurationStatus, nil
+	}
+	return nil, nil, fmt.Errorf("unsupported resource type %T", obj)
+}
+
+// setNodesStatus sets the aggregated status of obj.
+func setNodesStatus(obj client.Object, s v1alpha1.NodesConfigurationStatus) error {
+	switch o := obj.(type) {
+	case *v1alpha1.Underlay:
+		o.Status = &v1alpha1.UnderlayStatus{NodesConfigurationStatus: s}
+	case *v1alpha1.L3VNI:
+		o.Status = &v1alpha1.L3VNIStatus{NodesConfigurationStatus: s}
+	case *v1alpha1.L2VNI:
+		o.Status = &v1alpha1.L2VNIStatus{NodesConfigurationStatus: s}
+	case *v1alpha1.L3VPN:
+		o.Status = &v1alpha1.L3VPNStatus{NodesConfigurationStatus: s}
+	case *v1alpha1.L3Passthrough:
+		o.Status = &v1alpha1.L3PassthroughStatus{NodesConfigurationStatus: s}
+	default:
+		return fmt.Errorf("unsupported resource type %T", obj)
+	}
+	return nil
+}
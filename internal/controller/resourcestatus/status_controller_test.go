@@ -0,0 +1,75 @@
+// SPDX-License-Identifier:Apache-2.0
+
+package resourcestatus
+
+import (
+	"context"
+	"log/slog"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/openperouter/openperouter/api/v1alpha1"
+	openpeerrors "github.com/openperouter/openperouter/internal/errors"
+)
+
+func TestReconcileUpdatesEveryKind(t *testing.T) {
+	s := runtime.NewScheme()
+	if err := v1alpha1.AddToScheme(s); err != nil {
+		t.Fatalf("failed to add v1alpha1 to scheme: %v", err)
+	}
+	if err := corev1.AddToScheme(s); err != nil {
+		t.Fatalf("failed to add corev1 to scheme: %v", err)
+	}
+
+	meta := func(name string) metav1.ObjectMeta {
+		return metav1.ObjectMeta{Name: name, Namespace: "default"}
+	}
+	n := node("node0", nil)
+	status := routerStatus(false, v1alpha1.ConditionReasonConfigFailed, "",
+		v1alpha1.FailedResource{Kind: openpeerrors.KindL3VNI, Name: "l3vni", Reason: "Invalid", Message: "broken"})
+	status.ObjectMeta = meta("node0")
+
+	resources := []client.Object{
+		&v1alpha1.Underlay{ObjectMeta: meta("underlay")},
+		&v1alpha1.L3VNI{ObjectMeta: meta("l3vni")},
+		&v1alpha1.L2VNI{ObjectMeta: meta("l2vni")},
+		&v1alpha1.L3VPN{ObjectMeta: meta("l3vpn")},
+		&v1alpha1.L3Passthrough{ObjectMeta: meta("passthrough")},
+	}
+	cli := fake.NewClientBuilder().
+		WithScheme(s).
+		WithObjects(append([]client.Object{&n, status}, resources...)...).
+		WithStatusSubresource(resources...).
+		Build()
+
+	r := &Reconciler{Client: cli, Scheme: s, Logger: slog.Default(), Namespace: "default"}
+	if _, err := r.Reconcile(context.Background(), aggregateRequest); err != nil {
+		t.Fatalf("reconcile failed: %v", err)
+	}
+
+	for _, res := range resources {
+		updated := res.DeepCopyObject().(client.Object)
+		if err := cli.Get(context.Background(), client.ObjectKeyFromObject(res), updated); err != nil {
+			t.Fatalf("failed to get %s: %v", res.GetName(), err)
+		}
+		_, got, err := nodesStatusFields(updated)
+		if err != nil {
+			t.Fatalf("failed to get the status of %s: %v", res.GetName(), err)
+		}
+		if got == nil {
+			t.Fatalf("expected the status of %s to be set", res.GetName())
+		}
+		wantFailed := int32(0)
+		if res.GetName() == "l3vni" {
+			wantFailed = 1
+		}
+		if got.SelectedNodes != 1 || got.FailedNodes != wantFailed || got.AppliedNodes != 1-wantFailed {
+			t.Fatalf("unexpected status for %s: %+v", res.GetName(), got)
+		}
+	}
+}
@@ -0,0 +1,234 @@
+// SPDX-License-Identifier:Apache-2.0
+
+package routerconfiguration
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	k8sjson "k8s.io/apimachinery/pkg/util/json"
+	utilyaml "k8s.io/apimachinery/pkg/util/yaml"
+
+	"github.com/openperouter/openperouter/api/v1alpha1"
+	"github.com/openperouter/openperouter/internal/conversion"
+	openpeerrors "github.com/openperouter/openperouter/internal/errors"
+	"github.com/openperouter/openperouter/internal/filter"
+)
+
+var (
+	nodeGVK   = corev1.SchemeGroupVersion.WithKind("Node")
+	secretGVK = corev1.SchemeGroupVersion.WithKind("Secret")
+)
+
+// DryRunResult is what a reconciliation would apply to a node.
+type DryRunResult struct {
+	// FRRConfig is the rendered frr.conf.
+	FRRConfig string
+	// HostConfig is the plan handed to the datapath, nil when the node
+	// has no underlay and so no host configuration.
+	HostConfig *conversion.HostConfigData
+	// FailedResources are the resources skipped because of an error.
+	FailedResources []v1alpha1.FailedResource
+}
+
+// DryRun runs the same validation, filtering and conversion steps of
+// Reconcile against the given configuration, without reloading FRR nor
+// touching the host. The configuration must already be filtered for the node.
+// The returned error is set only when the reconciliation fails as a whole,
+// the per resource failures are reported in the result.
+func DryRun(ctx context.Context, apiConfig conversion.APIConfigData, nodeIndex int, logLevel string,
+	validator conversion.DatapathConfigValidator) (DryRunResult, error) {
+	res := DryRunResult{}
+	updater := func(_ context.Context, config string) error {
+		res.FRRConfig = config
+		return nil
+	}
+	planner := &planningDatapathConfigurator{DatapathConfigValidator: validator}
+
+	err := Reconcile(ctx, apiConfig, nodeIndex, logLevel, "", dryRunNamespace, updater, planner, configureFRR)
+	res.HostConfig = planner.plan
+	res.FailedResources = openpeerrors.CollectFailures(err)
+	if openpeerrors.IsNonResourceError(err) {
+		return res, err
+	}
+	return res, nil
+}
+
+// dryRunNamespace is the network namespace reported in the host plan, as
+// the dry run has no router to find it from.
+const dryRunNamespace = "/var/run/netns/perouter"
+
+// planningDatapathConfigurator records the host configuration the datapath
+// would apply instead of applying it.
+type planningDatapathConfigurator struct {
+	conversion.DatapathConfigValidator
+	plan *conversion.HostConfigData
+}
+
+func (p *planningDatapathConfigurator) Configure(_ context.Context, config interfacesConfiguration) error {
+	if len(config.Underlays) == 0 {
+		return nil
+	}
+	hostConfig, err := conversion.APItoHostConfig(config.nodeIndex, config.targetNamespace, config.APIConfigData)
+	if err != nil {
+		return fmt.Errorf("failed to convert config to host configuration: %w", err)
+	}
+	p.plan = &hostConfig
+	return nil
+}
+
+// ReadStaticConfigForNode reads the PERouterConfig files in configDir the
+// way the router of the given node does.
+func ReadStaticConfigForNode(configDir, nodeName, namespace string) (conversion.APIConfigData, error) {
+	return readStaticConfigs(configDir, nodeName, namespace)
+}
+
+// ReadResources decodes the openperouter resources, the Nodes and the Secrets
+// contained in the given multi document YAML or JSON streams, applying the CRD
+// defaults and validation rules as the API server would. Other kinds are
+// ignored.
+func ReadResources(inputs ...io.Reader) (conversion.APIConfigData, []corev1.Node, error) {
+	res := conversion.APIConfigData{}
+	var nodes []corev1.Node
+	for _, input := range inputs {
+		decoder := utilyaml.NewYAMLOrJSONDecoder(input, 4096)
+		for {
+			var raw json.RawMessage
+			err := decoder.Decode(&raw)
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			if err != nil {
+				return conversion.APIConfigData{}, nil, fmt.Errorf("failed to decode resource: %w", err)
+			}
+			// Unmarshalling with the apimachinery json keeps the integers
+			// as int64, as the CEL validation expects.
+			obj := &unstructured.Unstructured{}
+			if err := k8sjson.Unmarshal(raw, &obj.Object); err != nil {
+				return conversion.APIConfigData{}, nil, fmt.Errorf("failed to decode resource: %w", err)
+			}
+			if len(obj.Object) == 0 {
+				continue
+			}
+			if err := addResource(&res, &nodes, obj); err != nil {
+				return conversion.APIConfigData{}, nil, err
+			}
+		}
+	}
+	return res, nodes, nil
+}
+
+func addResource(config *conversion.APIConfigData, nodes *[]corev1.Node, obj *unstructured.Unstructured) error {
+	var err error
+	switch gvk := obj.GroupVersionKind(); gvk {
+	case underlayGVK:
+		err = appendResource(&config.Underlays, obj, gvk)
+	case l3vniGVK:
+		err = appendResource(&config.L3VNIs, obj, gvk)
+	case l2vniGVK:
+		err = appendResource(&config.L2VNIs, obj, gvk)
+	case l3vpnGVK:
+		err = appendResource(&config.L3VPNs, obj, gvk)
+	case l3passthroughGVK:
+		err = appendResource(&config.L3Passthrough, obj, gvk)
+	case rawFRRConfigGVK:
+		err = appendResource(&config.RawFRRConfigs, obj, gvk)
+	case nodeAddressingGVK:
+		err = appendResource(&config.NodeAddressings, obj, gvk)
+	case nodeGVK:
+		var node corev1.Node
+		err = runtime.DefaultUnstructuredConverter.FromUnstructured(obj.Object, &node)
+		*nodes = append(*nodes, node)
+	case secretGVK:
+		var secret corev1.Secret
+		err = runtime.DefaultUnstructuredConverter.FromUnstructured(obj.Object, &secret)
+		// stringData is merged into data by the API server.
+		for k, v := range secret.StringData {
+			if secret.Data == nil {
+				secret.Data = map[string][]byte{}
+			}
+			secret.Data[k] = []byte(v)
+		}
+		config.Secrets = append(config.Secrets, secret)
+	default:
+		slog.Debug("ignoring resource", "kind", gvk.String(), "name", obj.GetName())
+	}
+	if err != nil {
+		return fmt.Errorf("invalid %s %q: %w", obj.GetKind(), obj.GetName(), err)
+	}
+	return nil
+}
+
+func appendResource[T any](items *[]T, obj *unstructured.Unstructured, gvk schema.GroupVersionKind) error {
+	var typed T
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(obj.Object, &typed); err != nil {
+		return err
+	}
+	res, errs := applyDefaultsAndValidate(&typed, gvk)
+	if len(errs) > 0 {
+		return errs.ToAggregate()
+	}
+	*items = append(*items, *res)
+	return nil
+}
+
+// ConfigForNode returns the resources selecting the given node. As for the
+// router, the RawFRRConfigs and the Secrets are taken only from the given
+// namespace, and the Secrets only when carrying the credentials label.
+func ConfigForNode(node *corev1.Node, namespace string, config conversion.APIConfigData) (conversion.APIConfigData, error) {
+	underlays, err := filter.UnderlaysForNode(node, config.Underlays)
+	if err != nil {
+		return conversion.APIConfigData{}, fmt.Errorf("failed to filter underlays for node %s: %w", node.Name, err)
+	}
+	l3vnis, err := filter.L3VNIsForNode(node, config.L3VNIs)
+	if err != nil {
+		return conversion.APIConfigData{}, fmt.Errorf("failed to filter l3vnis for node %s: %w", node.Name, err)
+	}
+	l2vnis, err := filter.L2VNIsForNode(node, config.L2VNIs)
+	if err != nil {
+		return conversion.APIConfigData{}, fmt.Errorf("failed to filter l2vnis for node %s: %w", node.Name, err)
+	}
+	l3vpns, err := filter.L3VPNsForNode(node, config.L3VPNs)
+	if err != nil {
+		return conversion.APIConfigData{}, fmt.Errorf("failed to filter l3vpns for node %s: %w", node.Name, err)
+	}
+	l3passthrough, err := filter.L3PassthroughsForNode(node, config.L3Passthrough)
+	if err != nil {
+		return conversion.APIConfigData{}, fmt.Errorf("failed to filter l3passthrough for node %s: %w", node.Name, err)
+	}
+	var inNamespace []v1alpha1.RawFRRConfig
+	for _, raw := range config.RawFRRConfigs {
+		if raw.Namespace == namespace {
+			inNamespace = append(inNamespace, raw)
+		}
+	}
+	var secretsInNamespace []corev1.Secret
+	for _, secret := range config.Secrets {
+		if _, ok := secret.Labels[v1alpha1.CredentialsSecretLabel]; ok && secret.Namespace == namespace {
+			secretsInNamespace = append(secretsInNamespace, secret)
+		}
+	}
+	rawFRRConfigs, err := filter.RawFRRConfigsForNode(node, inNamespace)
+	if err != nil {
+		return conversion.APIConfigData{}, fmt.Errorf("failed to filter rawfrrconfigs for node %s: %w", node.Name, err)
+	}
+
+	return conversion.APIConfigData{
+		Underlays:       underlays,
+		L3VNIs:          l3vnis,
+		L2VNIs:          l2vnis,
+		L3VPNs:          l3vpns,
+		L3Passthrough:   l3passthrough,
+		RawFRRConfigs:   rawFRRConfigs,
+		NodeAddressings: filter.NodeAddressingsForNode(node, config.NodeAddressings),
+		Secrets:         secretsInNamespace,
+	}, nil
+}
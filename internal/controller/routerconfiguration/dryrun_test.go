@@ -0,0 +1,242 @@
+// SPDX-License-Identifier:Apache-2.0
+
+package routerconfiguration
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	"github.com/openperouter/openperouter/api/v1alpha1"
+	"github.com/openperouter/openperouter/internal/conversion"
+	openpeerrors "github.com/openperouter/openperouter/internal/errors"
+)
+
+const dryRunResources = `
+apiVersion: v1
+kind: Namespace
+metadata:
+  name: openperouter-system
+---
+apiVersion: v1
+kind: Node
+metadata:
+  name: node-a
+  labels:
+    zone: a
+---
+apiVersion: network.openperouter.io/v1alpha1
+kind: Underlay
+metadata:
+  name: underlay
+  namespace: openperouter-system
+spec:
+  asn: 64514
+  interfaces:
+    - type: NetworkDevice
+      networkDevice:
+        interfaceName: toswitch
+  tunnelEndpoint:
+    cidrs:
+      - 100.65.0.0/24
+  neighbors:
+    - asn: 64512
+      address: 192.168.11.2
+---
+apiVersion: network.openperouter.io/v1alpha1
+kind: L3VNI
+metadata:
+  name: red
+  namespace: openperouter-system
+spec:
+  vrf: red
+  vni: 100
+---
+apiVersion: network.openperouter.io/v1alpha1
+kind: L3VNI
+metadata:
+  name: red-duplicate
+  namespace: openperouter-system
+spec:
+  vrf: blue
+  vni: 100
+---
+apiVersion: network.openperouter.io/v1alpha1
+kind: L3VNI
+metadata:
+  name: zone-b
+  namespace: openperouter-system
+spec:
+  nodeSelector:
+    matchLabels:
+      zone: b
+  vrf: green
+  vni: 300
+---
+apiVersion: network.openperouter.io/v1alpha1
+kind: RawFRRConfig
+metadata:
+  name: other-namespace
+  namespace: default
+spec:
+  rawConfig: "! ignored"
+---
+apiVersion: v1
+kind: Secret
+metadata:
+  name: isis-key
+  namespace: openperouter-system
+  labels:
+    openperouter.io/credentials: ""
+type: kubernetes.io/basic-auth
+stringData:
+  password: secret-key
+---
+apiVersion: v1
+kind: Secret
+metadata:
+  name: unlabeled
+  namespace: openperouter-system
+type: kubernetes.io/basic-auth
+stringData:
+  password: ignored
+---
+apiVersion: v1
+kind: Secret
+metadata:
+  name: other-namespace
+  namespace: default
+type: kubernetes.io/basic-auth
+stringData:
+  password: ignored
+`
+
+func TestReadResources(t *testing.T) {
+	config, nodes, err := ReadResources(strings.NewReader(dryRunResources))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(nodes) != 1 || nodes[0].Name != "node-a" || nodes[0].Labels["zone"] != "a" {
+		t.Errorf("expected node-a to be read, got %v", nodes)
+	}
+	if len(config.Underlays) != 1 || len(config.L3VNIs) != 3 || len(config.RawFRRConfigs) != 1 {
+		t.Fatalf("unexpected resources read: %d underlays, %d l3vnis, %d rawfrrconfigs",
+			len(config.Underlays), len(config.L3VNIs), len(config.RawFRRConfigs))
+	}
+	if len(config.Secrets) != 3 || string(config.Secrets[0].Data[corev1.BasicAuthPasswordKey]) != "secret-key" {
+		t.Errorf("expected the secrets to be read with their stringData, got %v", config.Secrets)
+	}
+	if ptr.Deref(config.Underlays[0].Spec.RouterIDCIDR, "") != defaultRouterIDCIDR {
+		t.Errorf("expected the default routerIDCIDR to be applied, got %q", ptr.Deref(config.Underlays[0].Spec.RouterIDCIDR, ""))
+	}
+	if ptr.Deref(config.L3VNIs[0].Spec.VXLanPort, 0) != 4789 {
+		t.Errorf("expected the default vxlanPort to be applied, got %d", ptr.Deref(config.L3VNIs[0].Spec.VXLanPort, 0))
+	}
+
+	t.Run("rejects invalid resources", func(t *testing.T) {
+		_, _, err := ReadResources(strings.NewReader(`
+apiVersion: network.openperouter.io/v1alpha1
+kind: L3VNI
+metadata:
+  name: invalid
+spec:
+  vrf: red
+  vni: 0
+`))
+		if err == nil || !strings.Contains(err.Error(), `L3VNI "invalid"`) {
+			t.Errorf("expected an error for the invalid L3VNI, got %v", err)
+		}
+	})
+}
+
+func TestConfigForNode(t *testing.T) {
+	config, nodes, err := ReadResources(strings.NewReader(dryRunResources))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	forNode, err := ConfigForNode(&nodes[0], "openperouter-system", config)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var names []string
+	for _, l3 := range forNode.L3VNIs {
+		names = append(names, l3.Name)
+	}
+	if diff := cmp.Diff([]string{"red", "red-duplicate"}, names); diff != "" {
+		t.Errorf("unexpected l3vnis for node (-want, +got):\n%s", diff)
+	}
+	if len(forNode.RawFRRConfigs) != 0 {
+		t.Errorf("expected the rawfrrconfigs of other namespaces to be ignored, got %v", forNode.RawFRRConfigs)
+	}
+	if len(forNode.Secrets) != 1 || forNode.Secrets[0].Name != "isis-key" {
+		t.Errorf("expected only the labeled secrets of the namespace, got %v", forNode.Secrets)
+	}
+
+	zoneB := &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node-b", Labels: map[string]string{"zone": "b"}}}
+	forNode, err = ConfigForNode(zoneB, "openperouter-system", config)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(forNode.L3VNIs) != 3 {
+		t.Errorf("expected 3 l3vnis for node-b, got %d", len(forNode.L3VNIs))
+	}
+}
+
+func TestDryRun(t *testing.T) {
+	config, nodes, err := ReadResources(strings.NewReader(dryRunResources))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	forNode, err := ConfigForNode(&nodes[0], "openperouter-system", config)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	res, err := DryRun(context.Background(), forNode, 1, "info", &conversion.KernelDatapathConfigValidator{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !strings.Contains(res.FRRConfig, "router bgp 64514 vrf red\n") {
+		t.Errorf("expected the red vrf in the frr configuration, got:\n%s", res.FRRConfig)
+	}
+	if strings.Contains(res.FRRConfig, "vrf blue") {
+		t.Errorf("expected the failed L3VNI not to be rendered, got:\n%s", res.FRRConfig)
+	}
+	if res.HostConfig == nil {
+		t.Fatal("expected a host configuration")
+	}
+	if len(res.HostConfig.Underlay.TunnelEndpoints) != 1 || res.HostConfig.Underlay.TunnelEndpoints[0].IPv4CIDR != "100.65.0.1/32" {
+		t.Errorf("unexpected tunnel endpoints %v", res.HostConfig.Underlay.TunnelEndpoints)
+	}
+	if len(res.HostConfig.L3VNIs) != 1 || res.HostConfig.L3VNIs[0].VRF != "red" {
+		t.Errorf("unexpected l3vnis in the host configuration %v", res.HostConfig.L3VNIs)
+	}
+	wantFailures := []v1alpha1.FailedResource{
+		{Kind: openpeerrors.KindL3VNI, Name: "red-duplicate", Reason: v1alpha1.FailedResourceReasonValidationFailed,
+			Message: "duplicate vni 100:L3VNI/red"},
+	}
+	if diff := cmp.Diff(wantFailures, res.FailedResources); diff != "" {
+		t.Errorf("unexpected failed resources (-want, +got):\n%s", diff)
+	}
+
+	t.Run("without underlay", func(t *testing.T) {
+		res, err := DryRun(context.Background(), conversion.APIConfigData{}, 1, "info", &conversion.GroutDatapathConfigValidator{})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if res.HostConfig != nil {
+			t.Errorf("expected no host configuration, got %v", res.HostConfig)
+		}
+		if len(res.FailedResources) != 0 {
+			t.Errorf("expected no failures, got %v", res.FailedResources)
+		}
+	})
+}
@@ -0,0 +1,133 @@
+// SPDX-License-Identifier:Apache-2.0
+
+package routerconfiguration
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/openperouter/openperouter/api/v1alpha1"
+	"github.com/openperouter/openperouter/internal/conversion"
+	openpeerrors "github.com/openperouter/openperouter/internal/errors"
+)
+
+const (
+	// EventReasonConfigurationFailed is the reason of the events emitted when
+	// a resource starts failing on a node.
+	EventReasonConfigurationFailed = "ConfigurationFailed"
+	// EventReasonConfigurationRecovered is the reason of the events emitted
+	// when a resource failing on a node is applied again.
+	EventReasonConfigurationRecovered = "ConfigurationRecovered"
+
+	eventActionConfigure = "Configure"
+)
+
+type failureKey struct {
+	kind v1alpha1.FailedResourceKind
+	name string
+}
+
+// emitResourceEvents emits an event on each resource that started failing on
+// the node, and on each one that recovered, comparing the failures reported
+// in the previous node status with the current ones.
+func (r *PERouterReconciler) emitResourceEvents(node *corev1.Node, config conversion.APIConfigData,
+	previous, current []v1alpha1.FailedResource, reconcileErr error) {
+	if r.EventRecorder == nil {
+		return
+	}
+
+	previousByKey := make(map[failureKey]v1alpha1.FailedResource, len(previous))
+	for _, f := range previous {
+		previousByKey[failureKey{f.Kind, f.Name}] = f
+	}
+	currentKeys := make(map[failureKey]bool, len(current))
+	for _, f := range current {
+		key := failureKey{f.Kind, f.Name}
+		currentKeys[key] = true
+		if old, ok := previousByKey[key]; ok && old.Reason == f.Reason {
+			continue
+		}
+		obj := eventTarget(node, config, f.Kind, f.Name)
+		if obj == nil {
+			continue
+		}
+		r.EventRecorder.Eventf(obj, node, corev1.EventTypeWarning, EventReasonConfigurationFailed, eventActionConfigure,
+			"%s on node %s: %s", f.Reason, node.Name, f.Message)
+	}
+
+	// When the reconciliation stops before validating all the resources, a
+	// failure missing from the current status doesn't mean it recovered.
+	if !failuresComplete(reconcileErr) {
+		return
+	}
+	for _, f := range previous {
+		key := failureKey{f.Kind, f.Name}
+		if currentKeys[key] {
+			continue
+		}
+		obj := eventTarget(node, config, key.kind, key.name)
+		if obj == nil {
+			continue
+		}
+		r.EventRecorder.Eventf(obj, node, corev1.EventTypeNormal, EventReasonConfigurationRecovered, eventActionConfigure,
+			"Configuration applied on node %s", node.Name)
+	}
+}
+
+// failuresComplete tells if the given reconcile error lists all the resources
+// failing on the node.
+func failuresComplete(err error) bool {
+	if err == nil {
+		return true
+	}
+	if openpeerrors.IsNonResourceError(err) || openpeerrors.HasUnderlayFailure(err) {
+		return false
+	}
+	for _, f := range openpeerrors.CollectFailures(err) {
+		if f.Kind == openpeerrors.KindFrrConfiguration {
+			return false
+		}
+	}
+	return true
+}
+
+// eventTarget returns the object the events about the given failed resource
+// are emitted on. Failures of the FRR configuration are not tied to a resource
+// and are reported on the node. Resources coming from the static configuration
+// have no API object to emit the event on, and nil is returned.
+func eventTarget(node *corev1.Node, config conversion.APIConfigData,
+	kind v1alpha1.FailedResourceKind, name string) runtime.Object {
+	var candidates []client.Object
+	switch kind {
+	case openpeerrors.KindFrrConfiguration:
+		return node
+	case openpeerrors.KindUnderlay:
+		candidates = objectsOf(config.Underlays)
+	case openpeerrors.KindL3VNI:
+		candidates = objectsOf(config.L3VNIs)
+	case openpeerrors.KindL2VNI:
+		candidates = objectsOf(config.L2VNIs)
+	case openpeerrors.KindL3VPN:
+		candidates = objectsOf(config.L3VPNs)
+	case openpeerrors.KindL3Passthrough:
+		candidates = objectsOf(config.L3Passthrough)
+	}
+	for _, obj := range candidates {
+		if obj.GetName() == name && obj.GetUID() != "" {
+			return obj
+		}
+	}
+	return nil
+}
+
+func objectsOf[T any, PT interface {
+	*T
+	client.Object
+}](items []T) []client.Object {
+	res := make([]client.Object, 0, len(items))
+	for i := range items {
+		res = append(res, PT(&items[i]))
+	}
+	return res
+}
@@ -0,0 +1,136 @@
+// SPDX-License-Identifier:Apache-2.0
+
+package routerconfiguration
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/openperouter/openperouter/api/v1alpha1"
+	"github.com/openperouter/openperouter/internal/conversion"
+	openpeerrors "github.com/openperouter/openperouter/internal/errors"
+)
+
+// recordingEventRecorder records the events as "type reason kind/name: note".
+type recordingEventRecorder struct {
+	events []string
+}
+
+func (r *recordingEventRecorder) Eventf(regarding runtime.Object, _ runtime.Object, eventtype, reason, _, note string, args ...any) {
+	obj := regarding.(client.Object)
+	kind := fmt.Sprintf("%T", obj)
+	r.events = append(r.events, fmt.Sprintf("%s %s %s/%s: %s", eventtype, reason, kind, obj.GetName(), fmt.Sprintf(note, args...)))
+}
+
+func TestEmitResourceEvents(t *testing.T) {
+	config := conversion.APIConfigData{
+		L3VNIs: []v1alpha1.L3VNI{
+			{ObjectMeta: metav1.ObjectMeta{Name: "red", Namespace: testNamespace, UID: "red-uid"}},
+			{ObjectMeta: metav1.ObjectMeta{Name: "static"}},
+		},
+		L2VNIs: []v1alpha1.L2VNI{
+			{ObjectMeta: metav1.ObjectMeta{Name: "blue", Namespace: testNamespace, UID: "blue-uid"}},
+		},
+	}
+	redFailure := v1alpha1.FailedResource{Kind: openpeerrors.KindL3VNI, Name: "red",
+		Reason: v1alpha1.FailedResourceReasonValidationFailed, Message: "invalid vrf"}
+	blueFailure := v1alpha1.FailedResource{Kind: openpeerrors.KindL2VNI, Name: "blue",
+		Reason: v1alpha1.FailedResourceReasonValidationFailed, Message: "duplicate vni"}
+	staticFailure := v1alpha1.FailedResource{Kind: openpeerrors.KindL3VNI, Name: "static",
+		Reason: v1alpha1.FailedResourceReasonValidationFailed, Message: "invalid vrf"}
+	frrFailure := v1alpha1.FailedResource{Kind: openpeerrors.KindFrrConfiguration, Name: frrConfigurationName,
+		Reason: v1alpha1.FailedResourceReasonFrrConfigurationFailed, Message: "reload failed"}
+
+	errorFor := func(failures ...v1alpha1.FailedResource) error {
+		var errs []error
+		for _, f := range failures {
+			errs = append(errs, &openpeerrors.ResourceError{Obj: f})
+		}
+		return errors.Join(errs...)
+	}
+
+	tests := []struct {
+		name     string
+		config   conversion.APIConfigData
+		previous []v1alpha1.FailedResource
+		current  []v1alpha1.FailedResource
+		err      error
+		want     []string
+	}{
+		{
+			name:    "resource starts failing",
+			config:  config,
+			current: []v1alpha1.FailedResource{redFailure},
+			err:     errorFor(redFailure),
+			want: []string{
+				"Warning ConfigurationFailed *v1alpha1.L3VNI/red: ValidationFailed on node " + testNodeName + ": invalid vrf",
+			},
+		},
+		{
+			name:     "resource keeps failing",
+			config:   config,
+			previous: []v1alpha1.FailedResource{redFailure},
+			current:  []v1alpha1.FailedResource{redFailure},
+			err:      errorFor(redFailure),
+		},
+		{
+			name:     "resource recovers",
+			config:   config,
+			previous: []v1alpha1.FailedResource{redFailure, blueFailure},
+			current:  []v1alpha1.FailedResource{blueFailure},
+			err:      errorFor(blueFailure),
+			want: []string{
+				"Normal ConfigurationRecovered *v1alpha1.L3VNI/red: Configuration applied on node " + testNodeName,
+			},
+		},
+		{
+			name:     "no recovery when the reconciliation did not complete",
+			config:   config,
+			previous: []v1alpha1.FailedResource{redFailure},
+			err:      errors.New("failed to configure the datapath"),
+		},
+		{
+			name:     "no events when the configuration was not applied",
+			previous: []v1alpha1.FailedResource{blueFailure},
+			current:  []v1alpha1.FailedResource{redFailure},
+			err:      errorFor(redFailure),
+		},
+		{
+			name:    "resources from the static configuration are skipped",
+			config:  config,
+			current: []v1alpha1.FailedResource{staticFailure},
+			err:     errorFor(staticFailure),
+		},
+		{
+			name:     "frr configuration failure is reported on the node",
+			config:   config,
+			previous: []v1alpha1.FailedResource{redFailure},
+			current:  []v1alpha1.FailedResource{frrFailure},
+			err:      errorFor(frrFailure),
+			want: []string{
+				"Warning ConfigurationFailed *v1.Node/" + testNodeName + ": FrrConfigurationFailed on node " + testNodeName + ": reload failed",
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			recorder := &recordingEventRecorder{}
+			r := &PERouterReconciler{EventRecorder: recorder}
+			node := &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: testNodeName}}
+
+			r.emitResourceEvents(node, tc.config, tc.previous, tc.current, tc.err)
+
+			if diff := cmp.Diff(tc.want, recorder.events); diff != "" {
+				t.Errorf("unexpected events (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
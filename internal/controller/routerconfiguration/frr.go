@@ -8,8 +8,11 @@ import (
 	"fmt"
 	"log/slog"
 
+	"github.com/openperouter/openperouter/api/v1alpha1"
 	"github.com/openperouter/openperouter/internal/conversion"
+	openpeerrors "github.com/openperouter/openperouter/internal/errors"
 	"github.com/openperouter/openperouter/internal/frr"
+	"github.com/openperouter/openperouter/internal/frrconfig"
 )
 
 type frrConfigData struct {
@@ -35,8 +38,34 @@ func configureFRR(ctx context.Context, data frrConfigData) error {
 	}
 
 	err = frr.ApplyConfig(ctx, &frrConfig, data.updater)
+	var reloadErr *frrconfig.ReloadError
+	if errors.As(err, &reloadErr) {
+		return &openpeerrors.ResourceError{
+			Obj: v1alpha1.FailedResource{
+				Kind:    openpeerrors.KindFrrConfiguration,
+				Name:    frrConfigurationName,
+				Reason:  v1alpha1.FailedResourceReasonFrrConfigurationFailed,
+				Message: truncateMessage(reloadErr.Error()),
+			},
+		}
+	}
 	if err != nil {
 		return fmt.Errorf("failed to update the frr configuration: %w", err)
 	}
 	return nil
 }
+
+// frrConfigurationName is the name the FRR configuration of the node is
+// reported with among the failed resources.
+const frrConfigurationName = "frr.conf"
+
+// maxFailedResourceMessageLength is the maximum length of the message of a
+// failed resource allowed by the API.
+const maxFailedResourceMessageLength = 500
+
+func truncateMessage(msg string) string {
+	if len(msg) <= maxFailedResourceMessageLength {
+		return msg
+	}
+	return msg[:maxFailedResourceMessageLength]
+}
@@ -8,6 +8,8 @@ import (
 	"fmt"
 	"log/slog"
 
+	"k8s.io/apimachinery/pkg/util/sets"
+
 	"github.com/openperouter/openperouter/api/v1alpha1"
 	"github.com/openperouter/openperouter/internal/conversion"
 	openpeerrors "github.com/openperouter/openperouter/internal/errors"
@@ -36,7 +38,10 @@ func (g *GroutDatapathConfigurator) Configure(ctx context.Context, config interf
 		return fmt.Errorf("failed to check if target namespace %s has underlay: %w", config.targetNamespace, err)
 	}
 	if len(currentUnderlayIfaces) > 0 && len(config.Underlays) == 0 {
-		slog.InfoContext(ctx, "underlay removed, cleaning up grout underlay")
+		slog.InfoContext(ctx, "underlay removed, cleaning up grout VNIs and underlay")
+		if err := grout.RemoveAllVNIs(ctx, groutClient); err != nil {
+			slog.Warn("failed to remove vnis after underlay removal", "err", err)
+		}
 		if err := grout.RestoreUnderlay(ctx, groutClient, config.targetNamespace,
 			currentUnderlayIfaces); err != nil {
 			slog.Warn("failed to remove underlay after underlay removal", "err", err)
@@ -51,10 +56,11 @@ func (g *GroutDatapathConfigurator) Configure(ctx context.Context, config interf
 	slog.InfoContext(ctx, "configure interface start", "namespace", config.targetNamespace)
 	defer slog.InfoContext(ctx, "configure interface end", "namespace", config.targetNamespace)
 	apiConfig := conversion.APIConfigData{
-		Underlays:     config.Underlays,
-		L3VNIs:        config.L3VNIs,
-		L2VNIs:        config.L2VNIs,
-		L3Passthrough: config.L3Passthrough,
+		Underlays:       config.Underlays,
+		L3VNIs:          config.L3VNIs,
+		L2VNIs:          config.L2VNIs,
+		L3Passthrough:   config.L3Passthrough,
+		NodeAddressings: config.NodeAddressings,
 	}
 	hostConfig, err := conversion.APItoHostConfig(config.nodeIndex, config.targetNamespace, apiConfig)
 	if err != nil {
@@ -66,25 +72,117 @@ func (g *GroutDatapathConfigurator) Configure(ctx context.Context, config interf
 		return fmt.Errorf("failed to setup underlay: %w", err)
 	}
 
+	configuredL3VNIs, configuredL2VNIs, vniErrors := setupGroutVNIs(ctx, groutClient, hostConfig)
+	configuredPassthroughs, passthroughErrors := setupGroutPassthroughs(ctx, groutClient, hostConfig.L3Passthrough)
+
+	if err := removeNonConfiguredFromGrout(ctx, groutClient,
+		configuredL3VNIs, configuredL2VNIs, configuredPassthroughs); err != nil {
+		return err
+	}
+
+	return errors.Join(append(vniErrors, passthroughErrors...)...)
+}
+
+// setupGroutVNIs configures the L3VNIs and the L2VNIs of the host
+// configuration, returning the ones configured and a resource error for
+// each failure. An L2VNI routed through a failed L3VNI is not configured.
+func setupGroutVNIs(ctx context.Context, groutClient *grout.Client, hostConfig conversion.HostConfigData) (
+	[]hostnetwork.L3VNIParams, []hostnetwork.L2VNIParams, []error) {
 	var resourceErrors []error
+	failedL3Domains := sets.New[string]()
 	reason := v1alpha1.FailedResourceReasonOverlayAttachmentFailed
 
-	slog.InfoContext(ctx, "setting up passthrough")
-	if hostConfig.L3Passthrough != nil {
-		if err := grout.SetupPassthrough(ctx, groutClient, *hostConfig.L3Passthrough); err != nil {
+	var configuredL3VNIs []hostnetwork.L3VNIParams
+	for _, vni := range hostConfig.L3VNIs {
+		slog.InfoContext(ctx, "setting up VNI", "vni", vni.VRF)
+		if err := grout.SetupL3VNI(ctx, groutClient, vni); err != nil {
+			resourceErrors = append(resourceErrors, &openpeerrors.ResourceError{
+				Obj: v1alpha1.FailedResource{
+					Kind: openpeerrors.KindL3VNI, Name: vni.Name, Reason: reason, Message: err.Error(),
+				},
+			})
+			failedL3Domains.Insert(vni.VRF)
+			continue
+		}
+		configuredL3VNIs = append(configuredL3VNIs, vni)
+	}
+
+	var configuredL2VNIs []hostnetwork.L2VNIParams
+	for _, vni := range hostConfig.L2VNIs {
+		if failedL3Domains.Has(vni.VRF) {
+			resourceErrors = append(resourceErrors, &openpeerrors.ResourceError{
+				Obj: v1alpha1.FailedResource{
+					Kind: openpeerrors.KindL2VNI, Name: vni.Name, Reason: reason,
+					Message: fmt.Sprintf("L3 domain %q failed grout provisioning", vni.VRF),
+				},
+			})
+			continue
+		}
+		slog.InfoContext(ctx, "setting up L2VNI", "vni", vni.VNI)
+		if err := grout.SetupL2VNI(ctx, groutClient, vni); err != nil {
 			resourceErrors = append(resourceErrors, &openpeerrors.ResourceError{
 				Obj: v1alpha1.FailedResource{
-					Kind: openpeerrors.KindL3Passthrough, Name: config.L3Passthrough[0].Name, Reason: reason, Message: err.Error(),
+					Kind: openpeerrors.KindL2VNI, Name: vni.Name, Reason: reason, Message: err.Error(),
 				},
 			})
+			continue
 		}
+		configuredL2VNIs = append(configuredL2VNIs, vni)
 	}
+	return configuredL3VNIs, configuredL2VNIs, resourceErrors
+}
 
-	if len(apiConfig.L3Passthrough) == 0 {
-		if err := grout.RemovePassthrough(ctx, groutClient); err != nil {
-			return fmt.Errorf("failed to remove passthrough: %w", err)
+// setupGroutPassthroughs configures the given passthroughs, returning the
+// ones configured and a resource error for each failure.
+func setupGroutPassthroughs(ctx context.Context, groutClient *grout.Client,
+	passthroughs []hostnetwork.PassthroughParams) ([]hostnetwork.PassthroughParams, []error) {
+	var resourceErrors []error
+	var configured []hostnetwork.PassthroughParams
+	for _, passthrough := range passthroughs {
+		slog.InfoContext(ctx, "setting up passthrough", "passthrough", passthrough.Name)
+		if err := grout.SetupPassthrough(ctx, groutClient, passthrough); err != nil {
+			resourceErrors = append(resourceErrors, &openpeerrors.ResourceError{
+				Obj: v1alpha1.FailedResource{
+					Kind: openpeerrors.KindL3Passthrough, Name: passthrough.Name,
+					Reason: v1alpha1.FailedResourceReasonOverlayAttachmentFailed, Message: err.Error(),
+				},
+			})
+			continue
 		}
+		configured = append(configured, passthrough)
 	}
+	return configured, resourceErrors
+}
 
-	return errors.Join(resourceErrors...)
+// removeNonConfiguredFromGrout removes the VNIs, the VRFs and the
+// passthroughs that are not configured anymore.
+func removeNonConfiguredFromGrout(ctx context.Context, groutClient *grout.Client,
+	l3VNIs []hostnetwork.L3VNIParams, l2VNIs []hostnetwork.L2VNIParams,
+	passthroughs []hostnetwork.PassthroughParams) error {
+	configuredVNIs := make([]hostnetwork.VNIParams, 0, len(l3VNIs)+len(l2VNIs))
+	configuredVRFs := map[string]bool{}
+	for _, vni := range l3VNIs {
+		configuredVNIs = append(configuredVNIs, vni.VNIParams)
+		configuredVRFs[vni.VRF] = true
+	}
+	for _, l2vni := range l2VNIs {
+		configuredVNIs = append(configuredVNIs, l2vni.VNIParams)
+		configuredVRFs[l2vni.VRF] = true
+	}
+
+	slog.InfoContext(ctx, "removing deleted vnis")
+	if err := grout.RemoveNonConfiguredVNIs(ctx, groutClient, configuredVNIs); err != nil {
+		return fmt.Errorf("failed to remove deleted vnis: %w", err)
+	}
+
+	slog.InfoContext(ctx, "removing deleted vrfs")
+	if err := grout.RemoveNonConfiguredVRFs(ctx, groutClient, configuredVRFs); err != nil {
+		return fmt.Errorf("failed to remove deleted vrfs: %w", err)
+	}
+
+	slog.InfoContext(ctx, "removing deleted passthroughs")
+	if err := grout.RemoveNonConfiguredPassthroughs(ctx, groutClient, passthroughs); err != nil {
+		return fmt.Errorf("failed to remove deleted passthroughs: %w", err)
+	}
+	return nil
 }
@@ -7,6 +7,7 @@ import (
 	"errors"
 	"fmt"
 	"log/slog"
+	"slices"
 
 	"k8s.io/apimachinery/pkg/util/sets"
 
@@ -47,11 +48,12 @@ func (k *KernelDatapathConfigurator) Configure(ctx context.Context, config inter
 	slog.InfoContext(ctx, "configure interface start", "namespace", config.targetNamespace)
 	defer slog.InfoContext(ctx, "configure interface end", "namespace", config.targetNamespace)
 	apiConfig := conversion.APIConfigData{
-		Underlays:     config.Underlays,
-		L3VNIs:        config.L3VNIs,
-		L2VNIs:        config.L2VNIs,
-		L3VPNs:        config.L3VPNs,
-		L3Passthrough: config.L3Passthrough,
+		Underlays:       config.Underlays,
+		L3VNIs:          config.L3VNIs,
+		L2VNIs:          config.L2VNIs,
+		L3VPNs:          config.L3VPNs,
+		L3Passthrough:   config.L3Passthrough,
+		NodeAddressings: config.NodeAddressings,
 	}
 	hostConfig, err := conversion.APItoHostConfig(config.nodeIndex, config.targetNamespace, apiConfig)
 	if err != nil {
@@ -147,15 +149,18 @@ func (k *KernelDatapathConfigurator) Configure(ctx context.Context, config inter
 		configuredL2VNIs = append(configuredL2VNIs, vni)
 	}
 
-	slog.InfoContext(ctx, "setting up passthrough")
-	if hostConfig.L3Passthrough != nil {
-		if err := hostnetwork.SetupPassthrough(ctx, *hostConfig.L3Passthrough); err != nil {
+	var configuredPassthroughs []hostnetwork.PassthroughParams
+	for _, passthrough := range hostConfig.L3Passthrough {
+		slog.InfoContext(ctx, "setting up passthrough", "passthrough", passthrough.Name)
+		if err := hostnetwork.SetupPassthrough(ctx, passthrough); err != nil {
 			resourceErrors = append(resourceErrors, &openpeerrors.ResourceError{
 				Obj: v1alpha1.FailedResource{
-					Kind: openpeerrors.KindL3Passthrough, Name: config.L3Passthrough[0].Name, Reason: reason, Message: err.Error(),
+					Kind: openpeerrors.KindL3Passthrough, Name: passthrough.Name, Reason: reason, Message: err.Error(),
 				},
 			})
+			continue
 		}
+		configuredPassthroughs = append(configuredPassthroughs, passthrough)
 	}
 
 	configuredVNIs := make([]hostnetwork.VNIParams, 0, len(configuredL3VNIs)+len(configuredL2VNIs))
@@ -190,10 +195,9 @@ func (k *KernelDatapathConfigurator) Configure(ctx context.Context, config inter
 		return fmt.Errorf("failed to remove deleted vrfs: %w", err)
 	}
 
-	if len(config.L3Passthrough) == 0 {
-		if err := hostnetwork.RemovePassthrough(config.targetNamespace); err != nil {
-			return fmt.Errorf("failed to remove passthrough: %w", err)
-		}
+	slog.InfoContext(ctx, "removing deleted passthroughs")
+	if err := hostnetwork.RemoveNonConfiguredPassthroughs(config.targetNamespace, configuredPassthroughs); err != nil {
+		return fmt.Errorf("failed to remove deleted passthroughs: %w", err)
 	}
 	return errors.Join(resourceErrors...)
 }
@@ -234,7 +238,7 @@ func ensureSysctlsForConfig(ctx context.Context, config interfacesConfiguration)
 		sysctl.AcceptUntrackedNADefault(),
 		sysctl.AcceptUntrackedNAAll(),
 	}
-	if isSRV6(config.Underlays[0]) {
+	if slices.ContainsFunc(config.Underlays, isSRV6) {
 		sysctls = append(sysctls,
 			sysctl.Seg6MakeFlowLabel(),
 			sysctl.EnableSeg6All(),
@@ -0,0 +1,47 @@
+// SPDX-License-Identifier:Apache-2.0
+
+package routerconfiguration
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+const (
+	metricsNamespace = "openperouter"
+
+	perouterControllerLabel = "perouter"
+	staticControllerLabel   = "static"
+)
+
+var (
+	reconcileDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: metricsNamespace,
+		Subsystem: "reconcile",
+		Name:      "duration_seconds",
+		Help:      "Duration of the router configuration reconcile loop.",
+		Buckets:   []float64{0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30, 60},
+	}, []string{"controller"})
+
+	reconcileFailures = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metricsNamespace,
+		Subsystem: "reconcile",
+		Name:      "failures_total",
+		Help:      "Number of router configuration reconcile loops that ended with an error.",
+	}, []string{"controller"})
+)
+
+func init() {
+	metrics.Registry.MustRegister(reconcileDuration, reconcileFailures)
+}
+
+// observeReconcile records the outcome of a single reconcile loop of the
+// given controller, started at start.
+func observeReconcile(controller string, start time.Time, err error) {
+	reconcileDuration.WithLabelValues(controller).Observe(time.Since(start).Seconds())
+	if err != nil {
+		reconcileFailures.WithLabelValues(controller).Inc()
+	}
+}
@@ -16,11 +16,13 @@ import (
 	ctrlutil "sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 
 	"github.com/openperouter/openperouter/api/v1alpha1"
+	"github.com/openperouter/openperouter/internal/conversion"
 )
 
 // reconcileNodeStatus creates or updates the node status resource.
-// It sets the owner reference to the hosting node.
-func (r *PERouterReconciler) reconcileNodeStatus(ctx context.Context, reconcileErr error) error {
+// It sets the owner reference to the hosting node, and emits events on the
+// resources of config whose failures changed.
+func (r *PERouterReconciler) reconcileNodeStatus(ctx context.Context, config conversion.APIConfigData, reconcileErr error) error {
 	node := &corev1.Node{}
 	if err := r.Get(ctx, client.ObjectKey{Name: r.MyNode}, node); err != nil {
 		if k8serr.IsNotFound(err) {
@@ -41,6 +43,12 @@ func (r *PERouterReconciler) reconcileNodeStatus(ctx context.Context, reconcileE
 
 	newStatus := buildStatus(reconcileErr, nodeStatus.Status)
 
+	var previousFailures []v1alpha1.FailedResource
+	if nodeStatus.Status != nil {
+		previousFailures = nodeStatus.Status.FailedResources
+	}
+	r.emitResourceEvents(node, config, previousFailures, newStatus.FailedResources, reconcileErr)
+
 	if equality.Semantic.DeepEqual(nodeStatus.Status, &newStatus) {
 		return nil
 	}
@@ -0,0 +1,365 @@
+// SPDX-License-Identifier:Apache-2.0
+
+package routerconfiguration
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net"
+	"slices"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	k8serr "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/openperouter/openperouter/api/v1alpha1"
+	"github.com/openperouter/openperouter/internal/filter"
+	"github.com/openperouter/openperouter/internal/frr"
+	"github.com/openperouter/openperouter/internal/frr/vtysh"
+)
+
+// OperationalStatusReporter periodically retrieves the runtime state of the
+// router from FRR and publishes it in the RouterNodeConfigurationStatus
+// of the node.
+type OperationalStatusReporter struct {
+	client.Client
+	MyNode      string
+	MyNamespace string
+	Logger      *slog.Logger
+	Interval    time.Duration
+	FRRCli      vtysh.Cli
+}
+
+// Start reports the operational state until the context is cancelled.
+func (r *OperationalStatusReporter) Start(ctx context.Context) error {
+	ticker := time.NewTicker(r.Interval)
+	defer ticker.Stop()
+
+	for {
+		if err := r.report(ctx); err != nil {
+			r.Logger.Error("failed to report the router operational state", "error", err)
+		}
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// NeedLeaderElection tells the manager to run the reporter on every node.
+func (r *OperationalStatusReporter) NeedLeaderElection() bool {
+	return false
+}
+
+func (r *OperationalStatusReporter) report(ctx context.Context) error {
+	nodeStatus := &v1alpha1.RouterNodeConfigurationStatus{}
+	err := r.Get(ctx, client.ObjectKey{Name: r.MyNode, Namespace: r.MyNamespace}, nodeStatus)
+	if k8serr.IsNotFound(err) {
+		// the status resource is created by the router configuration controller.
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get node status: %w", err)
+	}
+
+	underlays, err := r.underlaysForNode(ctx)
+	if err != nil {
+		return err
+	}
+	state, err := operationalState(r.FRRCli, underlays)
+	if err != nil {
+		return err
+	}
+
+	var current *v1alpha1.RouterOperationalState
+	if nodeStatus.Status != nil {
+		current = nodeStatus.Status.OperationalState
+	}
+	if current != nil {
+		state.LastUpdateTime = current.LastUpdateTime
+		if equality.Semantic.DeepEqual(*current, state) {
+			return nil
+		}
+	}
+	state.LastUpdateTime = metav1.Now()
+
+	updated := nodeStatus.DeepCopy()
+	if updated.Status == nil {
+		updated.Status = &v1alpha1.RouterNodeConfigurationStatusStatus{}
+	}
+	updated.Status.OperationalState = &state
+	if err := r.Status().Patch(ctx, updated, client.MergeFrom(nodeStatus)); err != nil {
+		return fmt.Errorf("failed to patch operational state: %w", err)
+	}
+	return nil
+}
+
+func (r *OperationalStatusReporter) underlaysForNode(ctx context.Context) ([]v1alpha1.Underlay, error) {
+	node := &corev1.Node{}
+	if err := r.Get(ctx, client.ObjectKey{Name: r.MyNode}, node); err != nil {
+		return nil, fmt.Errorf("failed to get node %s: %w", r.MyNode, err)
+	}
+	var underlays v1alpha1.UnderlayList
+	if err := r.List(ctx, &underlays); err != nil {
+		return nil, fmt.Errorf("failed to list underlays: %w", err)
+	}
+	return filter.UnderlaysForNode(node, underlays.Items)
+}
+
+// operationalState builds the operational state of the router out of the
+// FRR show commands. The BGP sessions are reported only for the neighbors
+// of the given underlays.
+func operationalState(frrCli vtysh.Cli, underlays []v1alpha1.Underlay) (v1alpha1.RouterOperationalState, error) {
+	res := v1alpha1.RouterOperationalState{}
+
+	out, err := frrCli("show bgp neighbors json")
+	if err != nil {
+		return res, fmt.Errorf("failed to retrieve bgp neighbors: %w", err)
+	}
+	neighbors, err := frr.ParseNeighbours(out)
+	if err != nil {
+		return res, err
+	}
+	for _, n := range neighbors {
+		if !isUnderlayNeighbor(n, underlays) {
+			continue
+		}
+		res.UnderlayNeighbors = append(res.UnderlayNeighbors, v1alpha1.BGPNeighborState{
+			Peer:             n.ID(),
+			State:            n.State,
+			PrefixesReceived: int32(n.PrefixReceived),
+			PrefixesSent:     int32(n.PrefixSent),
+		})
+	}
+	slices.SortFunc(res.UnderlayNeighbors, func(a, b v1alpha1.BGPNeighborState) int {
+		return strings.Compare(a.Peer, b.Peer)
+	})
+
+	res.HostSessions, err = hostSessions(frrCli, underlays)
+	if err != nil {
+		return res, err
+	}
+
+	out, err = frrCli("show bfd peers json")
+	if err != nil {
+		return res, fmt.Errorf("failed to retrieve bfd peers: %w", err)
+	}
+	bfdPeers, err := frr.ParseBFDPeers(out)
+	if err != nil {
+		return res, err
+	}
+	for _, p := range bfdPeers {
+		res.BFDPeers = append(res.BFDPeers, v1alpha1.BFDPeerState{
+			Peer:      p.Peer,
+			VRF:       p.Vrf,
+			Interface: p.Interface,
+			Status:    p.Status,
+		})
+	}
+	slices.SortFunc(res.BFDPeers, func(a, b v1alpha1.BFDPeerState) int {
+		return strings.Compare(a.VRF+"/"+a.Peer+"/"+a.Interface, b.VRF+"/"+b.Peer+"/"+b.Interface)
+	})
+
+	out, err = frrCli("show bgp l2vpn evpn vni json")
+	if err != nil {
+		return res, fmt.Errorf("failed to retrieve evpn vnis: %w", err)
+	}
+	vnis, err := frr.ParseEVPNVNIs(out)
+	if err != nil {
+		return res, err
+	}
+	if len(vnis) == 0 {
+		return res, nil
+	}
+	res.LocalVTEPIP = localVTEPIP(vnis)
+
+	out, err = frrCli("show bgp l2vpn evpn json")
+	if err != nil {
+		return res, fmt.Errorf("failed to retrieve evpn routes: %w", err)
+	}
+	routes, err := frr.ParseEVPNRoutes(out)
+	if err != nil {
+		return res, err
+	}
+	res.VRFs = vrfRoutes(vnis, routes)
+	return res, nil
+}
+
+// hostSessions returns the state of the sessions with the hosts filtered by
+// an inbound prefix list, which are the ones with allowed prefixes, together
+// with the number of prefixes each of them rejected.
+func hostSessions(frrCli vtysh.Cli, underlays []v1alpha1.Underlay) ([]v1alpha1.HostSessionState, error) {
+	out, err := frrCli("show bgp vrf all json")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list bgp vrfs: %w", err)
+	}
+	vrfs, err := frr.ParseVRFs(out)
+	if err != nil {
+		return nil, err
+	}
+
+	var res []v1alpha1.HostSessionState
+	for _, vrf := range vrfs {
+		out, err := frrCli(fmt.Sprintf("show bgp vrf %s neighbors json", vrf))
+		if err != nil {
+			return nil, fmt.Errorf("failed to retrieve bgp neighbors for vrf %s: %w", vrf, err)
+		}
+		neighbors, err := frr.ParseNeighbours(out)
+		if err != nil {
+			return nil, err
+		}
+		for _, n := range neighbors {
+			if len(n.InboundPrefixListFamilies) == 0 || (vrf == defaultVRF && isUnderlayNeighbor(n, underlays)) {
+				continue
+			}
+			rejected := 0
+			for _, family := range n.InboundPrefixListFamilies {
+				afi, ok := unicastFamilies[family]
+				if !ok {
+					continue
+				}
+				out, err := frrCli(fmt.Sprintf("show bgp vrf %s %s unicast neighbors %s received-routes json", vrf, afi, n.ID()))
+				if err != nil {
+					return nil, fmt.Errorf("failed to retrieve received routes for neighbor %s in vrf %s: %w", n.ID(), vrf, err)
+				}
+				filtered, err := frr.ParseFilteredPrefixCount(out)
+				if err != nil {
+					return nil, err
+				}
+				rejected += filtered
+			}
+			res = append(res, v1alpha1.HostSessionState{
+				Peer:             n.ID(),
+				VRF:              vrf,
+				State:            n.State,
+				PrefixesReceived: int32(n.PrefixReceived),
+				PrefixesRejected: int32(rejected),
+			})
+		}
+	}
+	slices.SortFunc(res, func(a, b v1alpha1.HostSessionState) int {
+		return strings.Compare(a.VRF+"/"+a.Peer, b.VRF+"/"+b.Peer)
+	})
+	return res, nil
+}
+
+const defaultVRF = "default"
+
+// unicastFamilies maps the address families of the FRR json output to the
+// afi keyword of the show commands.
+var unicastFamilies = map[string]string{
+	"ipv4Unicast": "ipv4",
+	"ipv6Unicast": "ipv6",
+}
+
+func isUnderlayNeighbor(n *frr.Neighbor, underlays []v1alpha1.Underlay) bool {
+	for _, u := range underlays {
+		for _, un := range u.Spec.Neighbors {
+			if un.Interface != nil && *un.Interface == n.Interface && n.Interface != "" {
+				return true
+			}
+			if un.Address != nil && n.IP != nil && net.ParseIP(*un.Address).Equal(n.IP) {
+				return true
+			}
+			if un.ListenRange != nil && n.IP != nil {
+				_, cidr, err := net.ParseCIDR(*un.ListenRange)
+				if err == nil && cidr.Contains(n.IP) {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+func localVTEPIP(vnis []frr.EVPNVNI) string {
+	for _, v := range vnis {
+		if v.OriginatorIP != "" && v.OriginatorIP != "0.0.0.0" {
+			return v.OriginatorIP
+		}
+	}
+	return ""
+}
+
+// vrfRoutes counts the received type 5 and type 2 routes for each VRF. A route
+// is counted for a VRF when it carries a route target imported by one of the
+// VNIs of the VRF: the L3 VNI for type 5 routes, the L2 VNIs for type 2 routes.
+func vrfRoutes(vnis []frr.EVPNVNI, routes []frr.EVPNRoute) []v1alpha1.VRFRoutesState {
+	byVRF := map[string]*v1alpha1.VRFRoutesState{}
+	for _, v := range vnis {
+		if _, ok := byVRF[v.VRF]; !ok && v.VRF != "" {
+			byVRF[v.VRF] = &v1alpha1.VRFRoutesState{Name: v.VRF}
+		}
+	}
+
+	for _, r := range routes {
+		if !r.Received {
+			continue
+		}
+		var vniType string
+		switch r.Type {
+		case 5:
+			vniType = frr.EVPNVNITypeL3
+		case 2:
+			vniType = frr.EVPNVNITypeL2
+		default:
+			continue
+		}
+
+		counted := map[string]bool{}
+		for _, v := range vnis {
+			if v.Type != vniType || v.VRF == "" || counted[v.VRF] || !importsRoute(v, r) {
+				continue
+			}
+			counted[v.VRF] = true
+			if r.Type == 5 {
+				byVRF[v.VRF].Type5Routes++
+				continue
+			}
+			byVRF[v.VRF].Type2Routes++
+		}
+	}
+
+	res := make([]v1alpha1.VRFRoutesState, 0, len(byVRF))
+	for _, v := range byVRF {
+		res = append(res, *v)
+	}
+	slices.SortFunc(res, func(a, b v1alpha1.VRFRoutesState) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+	return res
+}
+
+func importsRoute(vni frr.EVPNVNI, route frr.EVPNRoute) bool {
+	for _, imported := range vni.ImportRTs {
+		for _, rt := range route.RouteTargets {
+			if imported == rt {
+				return true
+			}
+			// auto derived route targets may match any asn
+			if suffix, ok := strings.CutPrefix(imported, "*:"); ok && strings.HasSuffix(rt, ":"+suffix) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// operationalStateOnlyChange tells whether the only difference between the
+// two statuses is the operational state, which is refreshed periodically.
+func operationalStateOnlyChange(oldStatus, newStatus *v1alpha1.RouterNodeConfigurationStatus) bool {
+	if oldStatus.Status == nil || newStatus.Status == nil {
+		return false
+	}
+	o := oldStatus.Status.DeepCopy()
+	n := newStatus.Status.DeepCopy()
+	o.OperationalState = nil
+	n.OperationalState = nil
+	return equality.Semantic.DeepEqual(o, n) && !equality.Semantic.DeepEqual(oldStatus.Status, newStatus.Status)
+}
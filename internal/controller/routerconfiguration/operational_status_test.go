@@ -0,0 +1,171 @@
+// SPDX-License-Identifier:Apache-2.0
+
+package routerconfiguration
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"k8s.io/utils/ptr"
+
+	"github.com/openperouter/openperouter/api/v1alpha1"
+)
+
+const (
+	testNeighbors = `{
+  "192.168.11.2":{"remoteAs":64612,"localAs":64512,"bgpState":"Established",
+    "addressFamilyInfo":{"l2VpnEvpn":{"sentPrefixCounter":2,"acceptedPrefixCounter":3}}},
+  "192.168.11.3":{"remoteAs":64612,"localAs":64512,"bgpState":"Active"},
+  "192.169.10.2":{"remoteAs":64515,"localAs":64512,"bgpState":"Established"}
+}`
+	testRedNeighbors = `{
+  "192.169.10.2":{"remoteAs":64515,"localAs":64514,"bgpState":"Established",
+    "addressFamilyInfo":{
+      "ipv4Unicast":{"acceptedPrefixCounter":2,"incomingUpdatePrefixFilterList":"red-192.169.10.2-allowed-ipv4"},
+      "ipv6Unicast":{"acceptedPrefixCounter":0,"incomingUpdatePrefixFilterList":"red-192.169.10.2-allowed-ipv6"}}},
+  "192.169.10.3":{"remoteAs":64515,"localAs":64514,"bgpState":"Active",
+    "addressFamilyInfo":{"ipv4Unicast":{"acceptedPrefixCounter":0}}}
+}`
+	testBFDPeers = `[
+  {"peer":"192.168.11.2","vrf":"default","interface":"toswitch","status":"up"},
+  {"peer":"192.168.11.3","vrf":"default","interface":"toswitch","status":"down"}
+]`
+	testEVPNVNIs = `{
+  "numVnis":3,
+  "100":{"vni":100,"type":"L3","originatorIp":"100.65.0.1","tenantVrf":"red","importRTs":["64514:100"]},
+  "110":{"vni":110,"type":"L2","originatorIp":"100.65.0.1","tenantVrf":"red","importRTs":["64514:110"]},
+  "200":{"vni":200,"type":"L3","originatorIp":"100.65.0.1","tenantVrf":"blue","importRTs":["*:200"]}
+}`
+	testEVPNRoutes = `{
+  "10.0.0.1:2":{
+    "[5]:[0]:[24]:[192.169.10.0]":{"paths":[
+      {"routeType":5,"peerId":"(unspec)","extendedCommunity":{"string":"RT:64514:100 ET:8"}}]}
+  },
+  "10.0.0.2:2":{
+    "[5]:[0]:[24]:[192.170.10.0]":{"paths":[
+      {"routeType":5,"peerId":"192.168.11.2","extendedCommunity":{"string":"RT:64514:100 ET:8"}}]},
+    "[5]:[0]:[24]:[192.170.20.0]":{"paths":[
+      {"routeType":5,"peerId":"192.168.11.2","extendedCommunity":{"string":"RT:64612:200 ET:8"}}]},
+    "[2]:[0]:[48]:[aa:bb:cc:dd:ee:11]":{"paths":[
+      {"routeType":2,"peerId":"192.168.11.2","extendedCommunity":{"string":"RT:64514:110 ET:8"}}]},
+    "[3]:[0]:[32]:[100.65.0.2]":{"paths":[
+      {"routeType":3,"peerId":"192.168.11.2","extendedCommunity":{"string":"RT:64514:110 ET:8"}}]}
+  },
+  "numPrefix":5
+}`
+)
+
+func fakeFRRCli(outputs map[string]string) func(string) (string, error) {
+	return func(args string) (string, error) {
+		out, ok := outputs[args]
+		if !ok {
+			return "", fmt.Errorf("unexpected command %s", args)
+		}
+		return out, nil
+	}
+}
+
+func TestOperationalState(t *testing.T) {
+	underlays := []v1alpha1.Underlay{{
+		Spec: v1alpha1.UnderlaySpec{
+			Neighbors: []v1alpha1.Neighbor{
+				{Address: ptr.To("192.168.11.2")},
+				{ListenRange: ptr.To("192.168.11.0/29")},
+			},
+		},
+	}}
+
+	tests := []struct {
+		name     string
+		outputs  map[string]string
+		expected v1alpha1.RouterOperationalState
+	}{
+		{
+			name: "underlay only",
+			outputs: map[string]string{
+				"show bgp neighbors json":             testNeighbors,
+				"show bgp vrf all json":               `{"default":{}}`,
+				"show bgp vrf default neighbors json": testNeighbors,
+				"show bfd peers json":                 testBFDPeers,
+				"show bgp l2vpn evpn vni json":        `{"numVnis":0}`,
+			},
+			expected: v1alpha1.RouterOperationalState{
+				UnderlayNeighbors: []v1alpha1.BGPNeighborState{
+					{Peer: "192.168.11.2", State: "Established", PrefixesReceived: 3, PrefixesSent: 2},
+					{Peer: "192.168.11.3", State: "Active"},
+				},
+				BFDPeers: []v1alpha1.BFDPeerState{
+					{Peer: "192.168.11.2", VRF: "default", Interface: "toswitch", Status: "up"},
+					{Peer: "192.168.11.3", VRF: "default", Interface: "toswitch", Status: "down"},
+				},
+			},
+		},
+		{
+			name: "with evpn",
+			outputs: map[string]string{
+				"show bgp neighbors json":             testNeighbors,
+				"show bgp vrf all json":               `{"default":{}}`,
+				"show bgp vrf default neighbors json": testNeighbors,
+				"show bfd peers json":                 `[]`,
+				"show bgp l2vpn evpn vni json":        testEVPNVNIs,
+				"show bgp l2vpn evpn json":            testEVPNRoutes,
+			},
+			expected: v1alpha1.RouterOperationalState{
+				LocalVTEPIP: "100.65.0.1",
+				UnderlayNeighbors: []v1alpha1.BGPNeighborState{
+					{Peer: "192.168.11.2", State: "Established", PrefixesReceived: 3, PrefixesSent: 2},
+					{Peer: "192.168.11.3", State: "Active"},
+				},
+				VRFs: []v1alpha1.VRFRoutesState{
+					{Name: "blue", Type5Routes: 1},
+					{Name: "red", Type5Routes: 1, Type2Routes: 1},
+				},
+			},
+		},
+		{
+			name: "with host sessions filtered by allowed prefixes",
+			outputs: map[string]string{
+				"show bgp neighbors json":             testNeighbors,
+				"show bgp vrf all json":               `{"default":{},"red":{}}`,
+				"show bgp vrf default neighbors json": testNeighbors,
+				"show bgp vrf red neighbors json":     testRedNeighbors,
+				"show bfd peers json":                 `[]`,
+				"show bgp l2vpn evpn vni json":        `{"numVnis":0}`,
+				"show bgp vrf red ipv4 unicast neighbors 192.169.10.2 received-routes json": `{"totalPrefixCounter":5,"filteredPrefixCounter":3}`,
+				"show bgp vrf red ipv6 unicast neighbors 192.169.10.2 received-routes json": `{"totalPrefixCounter":1,"filteredPrefixCounter":1}`,
+			},
+			expected: v1alpha1.RouterOperationalState{
+				UnderlayNeighbors: []v1alpha1.BGPNeighborState{
+					{Peer: "192.168.11.2", State: "Established", PrefixesReceived: 3, PrefixesSent: 2},
+					{Peer: "192.168.11.3", State: "Active"},
+				},
+				HostSessions: []v1alpha1.HostSessionState{
+					{Peer: "192.169.10.2", VRF: "red", State: "Established", PrefixesReceived: 2, PrefixesRejected: 4},
+				},
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := operationalState(fakeFRRCli(tc.outputs), underlays)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if diff := cmp.Diff(tc.expected, got); diff != "" {
+				t.Fatalf("unexpected operational state (-want +got)\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestBuildStatusKeepsOperationalState(t *testing.T) {
+	state := &v1alpha1.RouterOperationalState{LocalVTEPIP: "100.65.0.1"}
+	existing := &v1alpha1.RouterNodeConfigurationStatusStatus{OperationalState: state}
+
+	got := buildStatus(nil, existing)
+	if !cmp.Equal(state, got.OperationalState) {
+		t.Fatalf("operational state not preserved (-want +got)\n%s", cmp.Diff(state, got.OperationalState))
+	}
+}
@@ -49,11 +49,13 @@ func Reconcile(ctx context.Context, apiConfig conversion.APIConfigData, nodeInde
 	validL3VPNs, err = conversion.FilterValidL3VPNs(apiConfig.L3VPNs)
 	resourceErrors = append(resourceErrors, err)
 
-	if err := conversion.DetectMutuallyExclusiveOverlays(validL3VNIs, validL3VPNs); err != nil {
-		validL3VNIs = []v1alpha1.L3VNI{}
-		validL3VPNs = []v1alpha1.L3VPN{}
-		resourceErrors = append(resourceErrors, err)
-	}
+	validL3VNIs, validL3VPNs, err = conversion.FilterMutuallyExclusiveOverlays(validL3VNIs, validL3VPNs)
+	resourceErrors = append(resourceErrors, err)
+
+	var validPassthrough []v1alpha1.L3Passthrough
+	validL3VNIs, validL3VPNs, validPassthrough, err = conversion.FilterHostSessionsWithValidSecret(
+		validL3VNIs, validL3VPNs, apiConfig.L3Passthrough, apiConfig.Secrets)
+	resourceErrors = append(resourceErrors, err)
 
 	if conversion.HasMissingSRv6ForL3VPNs(apiConfig.Underlays, validL3VPNs) {
 		resourceErrors = append(
@@ -67,6 +69,9 @@ func Reconcile(ctx context.Context, apiConfig conversion.APIConfigData, nodeInde
 	validL2VNIs, err = conversion.FilterValidL2VNIs(apiConfig.L2VNIs)
 	resourceErrors = append(resourceErrors, err)
 
+	validL3VNIs, validL2VNIs, err = conversion.FilterVNIsWithValidUnderlay(apiConfig.Underlays, validL3VNIs, validL2VNIs)
+	resourceErrors = append(resourceErrors, err)
+
 	var vnis map[int32]string
 	validL3VNIs, vnis, err = conversion.FilterUniqueL3VNIs(validL3VNIs)
 	resourceErrors = append(resourceErrors, err)
@@ -74,40 +79,51 @@ func Reconcile(ctx context.Context, apiConfig conversion.APIConfigData, nodeInde
 	var rdAssignedNumbers map[int32]string
 	validL3VPNs, rdAssignedNumbers, err = conversion.FilterUniqueL3VPNs(validL3VPNs)
 	resourceErrors = append(resourceErrors, err)
-	// TODO: This is safe today, but may cause issues when we change to per-VRF mutual exclusivity
-	// for L3VNI and L3VPN.
+	// L3VNIs and L3VPNs number their veths in different namespaces, so a VNI matching an
+	// rdAssignedNumber is allowed, but neither can be reused by an L2VNI.
 	maps.Copy(vnis, rdAssignedNumbers)
 
 	validL2VNIs, err = conversion.FilterUniqueL2VNIs(validL2VNIs, vnis)
 	resourceErrors = append(resourceErrors, err)
 
+	validL2VNIs, err = conversion.FilterUniqueEthernetSegments(validL2VNIs)
+	resourceErrors = append(resourceErrors, err)
+
 	validL3VNIs, err = conversion.FilterUniqueVRFsForL3VNIs(validL3VNIs)
 	resourceErrors = append(resourceErrors, err)
 
 	validL3VPNs, err = conversion.FilterUniqueVRFsForL3VPNs(validL3VPNs)
 	resourceErrors = append(resourceErrors, err)
 
+	validL3VNIs, validL3VPNs, err = conversion.FilterValidRouteLeaks(validL3VNIs, validL3VPNs)
+	resourceErrors = append(resourceErrors, err)
+
 	validL2VNIs, err = filterL2VNIsWithInvalidRoutingDomain(validL2VNIs, validL3VNIs, validL3VPNs)
 	resourceErrors = append(resourceErrors, err)
 
 	validL3VNIs, validL3VPNs, validL2VNIs, err = conversion.FilterValidVRFSubnets(validL3VNIs, validL3VPNs, validL2VNIs)
 	resourceErrors = append(resourceErrors, err)
 
-	var validPassthrough []v1alpha1.L3Passthrough
-	validPassthrough, err = conversion.FilterValidPassthroughs(apiConfig.L3Passthrough)
+	validPassthrough, err = conversion.FilterValidPassthroughs(validPassthrough)
 	resourceErrors = append(resourceErrors, err)
 
 	if err := conversion.ValidateHostSessions(validL3VNIs, validPassthrough); err != nil {
 		return fmt.Errorf("failed to validate host sessions: %w", err)
 	}
 
+	if err := conversion.ValidateNodeAddressings(apiConfig.NodeAddressings); err != nil {
+		return fmt.Errorf("failed to validate node addressings: %w", err)
+	}
+
 	config := conversion.APIConfigData{
-		Underlays:     apiConfig.Underlays,
-		L3VNIs:        validL3VNIs,
-		L3VPNs:        validL3VPNs,
-		L2VNIs:        validL2VNIs,
-		L3Passthrough: validPassthrough,
-		RawFRRConfigs: apiConfig.RawFRRConfigs,
+		Underlays:       apiConfig.Underlays,
+		L3VNIs:          validL3VNIs,
+		L3VPNs:          validL3VPNs,
+		L2VNIs:          validL2VNIs,
+		L3Passthrough:   validPassthrough,
+		RawFRRConfigs:   apiConfig.RawFRRConfigs,
+		NodeAddressings: apiConfig.NodeAddressings,
+		Secrets:         apiConfig.Secrets,
 	}
 
 	// The FRR configuration must be applied before the datapath creates the kernel
@@ -198,6 +214,10 @@ func filterL2VNIsWithInvalidRoutingDomain(
 
 // normalizeConfig sorts resources by namespace/name so validation order is deterministic.
 func normalizeConfig(config *conversion.APIConfigData) {
+	slices.SortFunc(config.Underlays, func(a, b v1alpha1.Underlay) int {
+		return cmp.Compare(objectKey(&a), objectKey(&b))
+	})
+
 	slices.SortFunc(config.L3VNIs, func(a, b v1alpha1.L3VNI) int {
 		return cmp.Compare(objectKey(&a), objectKey(&b))
 	})
@@ -213,6 +233,10 @@ func normalizeConfig(config *conversion.APIConfigData) {
 	slices.SortFunc(config.L3Passthrough, func(a, b v1alpha1.L3Passthrough) int {
 		return cmp.Compare(objectKey(&a), objectKey(&b))
 	})
+
+	slices.SortFunc(config.NodeAddressings, func(a, b v1alpha1.NodeAddressing) int {
+		return cmp.Compare(objectKey(&a), objectKey(&b))
+	})
 }
 
 func objectKey(o client.Object) string {
@@ -8,6 +8,7 @@ import (
 	"strings"
 	"testing"
 
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	"github.com/google/go-cmp/cmp"
@@ -15,6 +16,8 @@ import (
 	"github.com/openperouter/openperouter/internal/conversion"
 	openpeerrors "github.com/openperouter/openperouter/internal/errors"
 	"github.com/openperouter/openperouter/internal/frr"
+	"github.com/openperouter/openperouter/internal/frrconfig"
+	"github.com/openperouter/openperouter/internal/hostnetwork"
 )
 
 var noopUpdater = frr.ConfigUpdater(func(_ context.Context, _ string) error {
@@ -249,16 +252,32 @@ func TestReconcilePerResourceErrors(t *testing.T) {
 			},
 		},
 		{
-			name: "more than one passthrough skips all",
+			name: "multiple passthroughs pass through",
 			l3Passthroughs: []v1alpha1.L3Passthrough{
-				{ObjectMeta: metav1.ObjectMeta{Name: "pt-a"}},
-				{ObjectMeta: metav1.ObjectMeta{Name: "pt-b"}},
+				l3Passthrough("pt-a", "", "192.169.10.0/24"),
+				l3Passthrough("pt-b", "", "192.169.11.0/24"),
+			},
+			wantConfig: conversion.APIConfigData{
+				L3Passthrough: []v1alpha1.L3Passthrough{
+					l3Passthrough("pt-a", "", "192.169.10.0/24"),
+					l3Passthrough("pt-b", "", "192.169.11.0/24"),
+				},
+			},
+		},
+		{
+			name: "passthroughs with the same name skip the second",
+			l3Passthroughs: []v1alpha1.L3Passthrough{
+				l3Passthrough("pt-a", "ns1", "192.169.10.0/24"),
+				l3Passthrough("pt-a", "ns2", "192.169.11.0/24"),
 			},
 			expectedFailures: []v1alpha1.FailedResource{
 				{Kind: openpeerrors.KindL3Passthrough, Name: "pt-a", Reason: v1alpha1.FailedResourceReasonValidationFailed,
-					Message: "can't have more than one l3passthrough per node"},
-				{Kind: openpeerrors.KindL3Passthrough, Name: "pt-b", Reason: v1alpha1.FailedResourceReasonValidationFailed,
-					Message: "can't have more than one l3passthrough per node"},
+					Message: "l3passthrough veth " + hostnetwork.PassthroughVethNames("pt-a").HostSide + " is already used by l3passthrough pt-a"},
+			},
+			wantConfig: conversion.APIConfigData{
+				L3Passthrough: []v1alpha1.L3Passthrough{
+					l3Passthrough("pt-a", "ns1", "192.169.10.0/24"),
+				},
 			},
 		},
 		{
@@ -361,23 +380,27 @@ func TestReconcilePerResourceErrors(t *testing.T) {
 			},
 		},
 		{
-			name: "L3VPN with L3VNI reports failure but reconciles other resources",
+			name: "L3VPN with L3VNI in the same VRF reports failure but reconciles other resources",
 			l3VNIs: []v1alpha1.L3VNI{
 				l3VNI("bad-l3-vni", "vrfA", 100),
+				l3VNI("good-l3-vni", "vrfC", 102),
 			},
 			l3VPNs: []v1alpha1.L3VPN{
-				l3VPN("bad-l3-vpn", "vrfB", 101),
+				l3VPN("bad-l3-vpn", "vrfA", 101),
 			},
 			l2VNIs: []v1alpha1.L2VNI{
 				l2VNI("good-l2", nil, 200),
 			},
 			expectedFailures: []v1alpha1.FailedResource{
 				{Kind: openpeerrors.KindL3VNI, Name: "bad-l3-vni", Reason: v1alpha1.FailedResourceReasonValidationFailed,
-					Message: "cannot specify L3VNI resources and L3VPN resources at the same time"},
+					Message: `cannot specify an L3VNI in VRF "vrfA": L3VPN "/bad-l3-vpn" already uses it`},
 				{Kind: openpeerrors.KindL3VPN, Name: "bad-l3-vpn", Reason: v1alpha1.FailedResourceReasonValidationFailed,
-					Message: "cannot specify L3VPN resources and L3VNI resources at the same time"},
+					Message: `cannot specify an L3VPN in VRF "vrfA": L3VNI "/bad-l3-vni" already uses it`},
 			},
 			wantConfig: conversion.APIConfigData{
+				L3VNIs: []v1alpha1.L3VNI{
+					l3VNI("good-l3-vni", "vrfC", 102),
+				},
 				L2VNIs: []v1alpha1.L2VNI{
 					l2VNI("good-l2", nil, 200),
 				},
@@ -557,6 +580,34 @@ func TestReconcileFrrReloadFailure(t *testing.T) {
 	}
 }
 
+func TestReconcileFrrReloadRolledBack(t *testing.T) {
+	rolledBackUpdater := frr.ConfigUpdater(func(_ context.Context, _ string) error {
+		return &frrconfig.ReloadError{Message: "frr is not healthy after the reload", RolledBack: true}
+	})
+
+	var steps []string
+	reconcileErr := Reconcile(context.Background(), conversion.APIConfigData{}, 0, "",
+		"", "", rolledBackUpdater, &recordingDatapathConfigurator{steps: &steps}, configureFRR)
+	if reconcileErr == nil {
+		t.Fatal("expected error from FRR reload failure")
+	}
+	if len(steps) != 0 {
+		t.Errorf("expected the datapath not to be configured, got steps: %v", steps)
+	}
+
+	want := []v1alpha1.FailedResource{
+		{
+			Kind:    openpeerrors.KindFrrConfiguration,
+			Name:    frrConfigurationName,
+			Reason:  v1alpha1.FailedResourceReasonFrrConfigurationFailed,
+			Message: "frr is not healthy after the reload, rolled back to the last known good configuration",
+		},
+	}
+	if diff := cmp.Diff(want, openpeerrors.CollectFailures(reconcileErr)); diff != "" {
+		t.Errorf("unexpected failures (-want, +got):\n%s", diff)
+	}
+}
+
 // recordingDatapathConfigurator appends to a shared list every time the datapath
 // is configured, so that tests can assert the order of the reconcile steps.
 type recordingDatapathConfigurator struct {
@@ -620,6 +671,19 @@ func l3VNI(name, vrf string, vni int32) v1alpha1.L3VNI {
 	}
 }
 
+func l3Passthrough(name, namespace, localCIDR string) v1alpha1.L3Passthrough {
+	return v1alpha1.L3Passthrough{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+		Spec: v1alpha1.L3PassthroughSpec{
+			HostSession: v1alpha1.HostSession{
+				ASN:       100,
+				HostASN:   new(int64(200)),
+				LocalCIDR: v1alpha1.LocalCIDRConfig{IPv4: new(localCIDR)},
+			},
+		},
+	}
+}
+
 func l3VPN(name, vrf string, rdAssignedNumber int32) v1alpha1.L3VPN {
 	return v1alpha1.L3VPN{
 		ObjectMeta: metav1.ObjectMeta{Name: name},
@@ -683,3 +747,72 @@ func l2VNIWithGateway(name string, rd *v1alpha1.RoutingDomain, vni int32, gatewa
 	l2.Spec.GatewayIPs = gatewayIPs
 	return l2
 }
+
+// TestReconcileHostSessionPasswordSecret ensures that a host session
+// referencing a missing password secret fails only its own resource.
+func TestReconcileHostSessionPasswordSecret(t *testing.T) {
+	hostSessionVNI := func(name, vrf string, vni int32, cidr string, passwordSecret string) v1alpha1.L3VNI {
+		res := l3VNI(name, vrf, vni)
+		res.Spec.HostSession = &v1alpha1.HostSession{
+			ASN:            100,
+			HostASN:        new(int64(200)),
+			LocalCIDR:      v1alpha1.LocalCIDRConfig{IPv4: new(cidr)},
+			PasswordSecret: new(passwordSecret),
+		}
+		return res
+	}
+	underlays := []v1alpha1.Underlay{{
+		ObjectMeta: metav1.ObjectMeta{Name: "underlay"},
+		Spec: v1alpha1.UnderlaySpec{
+			ASN:            100,
+			Neighbors:      []v1alpha1.Neighbor{{ASN: new(int64(101)), Address: new("192.168.1.1")}},
+			TunnelEndpoint: &v1alpha1.TunnelEndpointConfig{CIDRs: []string{"100.64.0.0/24"}},
+		},
+	}}
+	config := conversion.APIConfigData{
+		Underlays: underlays,
+		L3VNIs: []v1alpha1.L3VNI{
+			hostSessionVNI("red", "red", 100, "192.169.10.0/24", "red-password"),
+			hostSessionVNI("blue", "blue", 200, "192.169.11.0/24", "missing"),
+		},
+		Secrets: []corev1.Secret{{
+			ObjectMeta: metav1.ObjectMeta{Name: "red-password"},
+			Type:       corev1.SecretTypeBasicAuth,
+			Data:       map[string][]byte{corev1.BasicAuthPasswordKey: []byte("secret")},
+		}},
+	}
+
+	var rendered frr.Config
+	renderFRR := func(_ context.Context, data frrConfigData) error {
+		var err error
+		rendered, err = conversion.APItoFRR(data.APIConfigData, data.nodeIndex, data.logLevel)
+		return err
+	}
+	reconcileErr := Reconcile(context.Background(), config, 0, "",
+		"", "", noopUpdater, &noopDatapathConfigurator{}, renderFRR)
+	if openpeerrors.IsNonResourceError(reconcileErr) {
+		t.Fatalf("unexpected reconcile error: %v", reconcileErr)
+	}
+
+	want := []v1alpha1.FailedResource{
+		{
+			Kind:    openpeerrors.KindL3VNI,
+			Name:    "blue",
+			Reason:  v1alpha1.FailedResourceReasonDependencyFailed,
+			Message: "invalid host session password: secret missing not found, it must be labeled openperouter.io/credentials",
+		},
+	}
+	if diff := cmp.Diff(want, openpeerrors.CollectFailures(reconcileErr)); diff != "" {
+		t.Errorf("unexpected failures (-want, +got):\n%s", diff)
+	}
+	vrfs := []string{}
+	for _, vni := range rendered.VNIs {
+		vrfs = append(vrfs, vni.VRF)
+	}
+	if diff := cmp.Diff([]string{"red"}, vrfs); diff != "" {
+		t.Errorf("unexpected rendered VRFs (-want, +got):\n%s", diff)
+	}
+	if rendered.VNIs[0].LocalNeighbor == nil || rendered.VNIs[0].LocalNeighbor.Password != "secret" {
+		t.Errorf("expecting the host session of red to use the password of its secret, got %+v", rendered.VNIs[0].LocalNeighbor)
+	}
+}
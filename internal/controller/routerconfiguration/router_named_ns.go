@@ -9,7 +9,7 @@ import (
 	"net"
 	"strconv"
 
-	"github.com/openperouter/openperouter/internal/controller/nodeindex"
+	"github.com/openperouter/openperouter/api/v1alpha1"
 	"github.com/openperouter/openperouter/internal/netnamespace"
 	"github.com/vishvananda/netns"
 	v1 "k8s.io/api/core/v1"
@@ -49,7 +49,7 @@ func (r *RouterNamedNSProvider) NodeIndex(ctx context.Context) (int, error) {
 	if node.Annotations == nil {
 		return 0, fmt.Errorf("node %s has no annotations", node.Name)
 	}
-	index, ok := node.Annotations[nodeindex.OpenpeNodeIndex]
+	index, ok := node.Annotations[v1alpha1.NodeIndexAnnotation]
 	if !ok {
 		return 0, fmt.Errorf("node %s has no index annotation", node.Name)
 	}
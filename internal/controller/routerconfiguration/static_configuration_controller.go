@@ -42,6 +42,13 @@ func (r *StaticConfigReconciler) Reconcile(ctx context.Context, req ctrl.Request
 	logger.Info("start reconcile")
 	defer logger.Info("end reconcile")
 
+	start := time.Now()
+	result, err := r.reconcile(ctx, logger)
+	observeReconcile(staticControllerLabel, start, err)
+	return result, err
+}
+
+func (r *StaticConfigReconciler) reconcile(ctx context.Context, logger *slog.Logger) (ctrl.Result, error) {
 	logger.Info("using config dir", "dir", r.ConfigDir)
 	// Read and merge router configs from directory
 	apiConfig, err := readStaticConfigs(r.ConfigDir, r.MyNode, r.MyNamespace)
@@ -20,12 +20,13 @@ import (
 )
 
 var (
-	underlayGVK      = schema.GroupVersionKind{Group: "network.openperouter.io", Version: "v1alpha1", Kind: "Underlay"}
-	l3vniGVK         = schema.GroupVersionKind{Group: "network.openperouter.io", Version: "v1alpha1", Kind: "L3VNI"}
-	l2vniGVK         = schema.GroupVersionKind{Group: "network.openperouter.io", Version: "v1alpha1", Kind: "L2VNI"}
-	l3vpnGVK         = schema.GroupVersionKind{Group: "network.openperouter.io", Version: "v1alpha1", Kind: "L3VPN"}
-	l3passthroughGVK = schema.GroupVersionKind{Group: "network.openperouter.io", Version: "v1alpha1", Kind: "L3Passthrough"}
-	rawFRRConfigGVK  = schema.GroupVersionKind{Group: "network.openperouter.io", Version: "v1alpha1", Kind: "RawFRRConfig"}
+	underlayGVK       = schema.GroupVersionKind{Group: "network.openperouter.io", Version: "v1alpha1", Kind: "Underlay"}
+	l3vniGVK          = schema.GroupVersionKind{Group: "network.openperouter.io", Version: "v1alpha1", Kind: "L3VNI"}
+	l2vniGVK          = schema.GroupVersionKind{Group: "network.openperouter.io", Version: "v1alpha1", Kind: "L2VNI"}
+	l3vpnGVK          = schema.GroupVersionKind{Group: "network.openperouter.io", Version: "v1alpha1", Kind: "L3VPN"}
+	l3passthroughGVK  = schema.GroupVersionKind{Group: "network.openperouter.io", Version: "v1alpha1", Kind: "L3Passthrough"}
+	rawFRRConfigGVK   = schema.GroupVersionKind{Group: "network.openperouter.io", Version: "v1alpha1", Kind: "RawFRRConfig"}
+	nodeAddressingGVK = schema.GroupVersionKind{Group: "network.openperouter.io", Version: "v1alpha1", Kind: "NodeAddressing"}
 )
 
 const (
@@ -240,17 +241,56 @@ func staticConfigToAPIConfig(staticConfig *static.PERouterConfig, nodeName, name
 		rawFRRConfigs[i] = *result
 	}
 
+	var nodeAddressings []v1alpha1.NodeAddressing
+	if staticConfig.NodeAddressing != nil {
+		spec := *staticConfig.NodeAddressing.DeepCopy()
+		if spec.NodeName == "" {
+			spec.NodeName = nodeName
+		}
+		// The addresses refer to the static resources by the name they are
+		// given in the static configuration.
+		for i := range spec.TunnelEndpoints {
+			spec.TunnelEndpoints[i].Underlay = staticName(spec.TunnelEndpoints[i].Underlay)
+		}
+		for i := range spec.HostSessions {
+			spec.HostSessions[i].Name = staticName(spec.HostSessions[i].Name)
+		}
+		na := v1alpha1.NodeAddressing{
+			TypeMeta: metav1.TypeMeta{
+				Kind:       "NodeAddressing",
+				APIVersion: "network.openperouter.io/v1alpha1",
+			},
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      fmt.Sprintf("static-%s-nodeaddressing", nodeName),
+				Namespace: namespace,
+				Labels: map[string]string{
+					StaticSourceLabel: StaticSourceValue,
+					StaticNodeLabel:   nodeName,
+				},
+			},
+			Spec: spec,
+		}
+		result, errs := applyDefaultsAndValidate(&na, nodeAddressingGVK)
+		if len(errs) > 0 {
+			allErrors = append(allErrors, errs...)
+		}
+		if result != nil {
+			nodeAddressings = []v1alpha1.NodeAddressing{*result}
+		}
+	}
+
 	if len(allErrors) > 0 {
 		return conversion.APIConfigData{}, fmt.Errorf("validation errors in static config: %v", allErrors.ToAggregate())
 	}
 
 	return conversion.APIConfigData{
-		Underlays:     underlays,
-		L3VNIs:        l3vnis,
-		L2VNIs:        l2vnis,
-		L3VPNs:        l3vpns,
-		L3Passthrough: l3passthrough,
-		RawFRRConfigs: rawFRRConfigs,
+		Underlays:       underlays,
+		L3VNIs:          l3vnis,
+		L2VNIs:          l2vnis,
+		L3VPNs:          l3vpns,
+		L3Passthrough:   l3passthrough,
+		RawFRRConfigs:   rawFRRConfigs,
+		NodeAddressings: nodeAddressings,
 	}, nil
 }
 
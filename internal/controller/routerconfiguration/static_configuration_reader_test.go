@@ -121,6 +121,57 @@ underlays:
 	}
 }
 
+func TestReadStaticConfigs_NodeAddressing(t *testing.T) {
+	dir := t.TempDir()
+	writeYAMLFile(t, dir, "openpe_nodeaddressing.yaml", `
+nodeaddressing:
+  routerID: "192.0.2.10"
+  tunnelEndpoints:
+  - underlay: underlay-0
+    ips:
+    - "100.65.0.10"
+`)
+
+	apiConfig, err := readStaticConfigs(dir, "test-node", "test-namespace")
+	if err != nil {
+		t.Fatalf("readStaticConfigs() unexpected error: %v", err)
+	}
+
+	if len(apiConfig.NodeAddressings) != 1 {
+		t.Fatalf("expected 1 NodeAddressing, got %d", len(apiConfig.NodeAddressings))
+	}
+	got := apiConfig.NodeAddressings[0]
+	if got.Name != "static-test-node-nodeaddressing" {
+		t.Errorf("expected name static-test-node-nodeaddressing, got %q", got.Name)
+	}
+	want := v1alpha1.NodeAddressingSpec{
+		NodeName: "test-node",
+		RouterID: new("192.0.2.10"),
+		TunnelEndpoints: []v1alpha1.TunnelEndpointAddresses{
+			{Underlay: "static-test-node-underlay-0", IPs: []string{"100.65.0.10"}},
+		},
+	}
+	if diff := cmp.Diff(want, got.Spec); diff != "" {
+		t.Errorf("unexpected NodeAddressing spec (-want +got):\n%s", diff)
+	}
+}
+
+func TestReadStaticConfigs_NodeAddressingCELValidation(t *testing.T) {
+	dir := t.TempDir()
+	writeYAMLFile(t, dir, "openpe_nodeaddressing.yaml", `
+nodeaddressing:
+  routerID: "2001:db8::10"
+`)
+
+	_, err := readStaticConfigs(dir, "test-node", "test-namespace")
+	if err == nil {
+		t.Fatal("expected error for an IPv6 routerID, got nil")
+	}
+	if !strings.Contains(err.Error(), "routerID must be a valid IPv4 address") {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
 func TestReadStaticConfigs_AllDefaults(t *testing.T) {
 	dir := t.TempDir()
 	writeYAMLFile(t, dir, "openpe_all.yaml", `
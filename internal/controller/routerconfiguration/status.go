@@ -16,6 +16,10 @@ func buildStatus(err error, existingStatus *v1alpha1.RouterNodeConfigurationStat
 		s.Conditions = make([]metav1.Condition, len(existingStatus.Conditions))
 		copy(s.Conditions, existingStatus.Conditions)
 	}
+	// the operational state is owned by the OperationalStatusReporter.
+	if existingStatus != nil {
+		s.OperationalState = existingStatus.OperationalState
+	}
 
 	if err == nil {
 		setReady(&s, v1alpha1.ConditionReasonConfigSuccessful, "All configuration applied successfully")
@@ -32,6 +36,11 @@ func degradedReason(err error, failures []v1alpha1.FailedResource) (string, stri
 	if openpeerrors.HasUnderlayFailure(err) {
 		return v1alpha1.ConditionReasonUnderlayFailed, "Underlay failed validation, existing FRR configuration left as-is"
 	}
+	for _, f := range failures {
+		if f.Kind == openpeerrors.KindFrrConfiguration {
+			return v1alpha1.ConditionReasonConfigFailed, "FRR configuration failed to apply, see status.failedResources for details"
+		}
+	}
 	if len(failures) > 0 {
 		return v1alpha1.ConditionReasonConfigFailed, "Some resources failed validation, see status.failedResources for details"
 	}
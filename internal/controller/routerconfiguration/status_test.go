@@ -38,6 +38,13 @@ func TestBuildStatus(t *testing.T) {
 		},
 	)
 
+	frrErr := &openpeerrors.ResourceError{
+		Obj: v1alpha1.FailedResource{
+			Kind: openpeerrors.KindFrrConfiguration, Name: frrConfigurationName,
+			Reason: v1alpha1.FailedResourceReasonFrrConfigurationFailed, Message: "frr update reload failed",
+		},
+	}
+
 	tests := []struct {
 		name                    string
 		reconcileErr            error
@@ -66,6 +73,18 @@ func TestBuildStatus(t *testing.T) {
 				{Kind: openpeerrors.KindUnderlay, Name: "my-underlay", Reason: v1alpha1.FailedResourceReasonValidationFailed, Message: "bad ASN"},
 			},
 		},
+		{
+			name:                 "frr configuration failed",
+			reconcileErr:         frrErr,
+			expectedReady:        metav1.ConditionFalse,
+			expectedDegraded:     metav1.ConditionTrue,
+			expectedReadyReason:  v1alpha1.ConditionReasonConfigFailed,
+			expectedReadyMessage: "FRR configuration failed to apply, see status.failedResources for details",
+			expectedFailedResources: []v1alpha1.FailedResource{
+				{Kind: openpeerrors.KindFrrConfiguration, Name: frrConfigurationName,
+					Reason: v1alpha1.FailedResourceReasonFrrConfigurationFailed, Message: "frr update reload failed"},
+			},
+		},
 		{
 			name:                 "partial failure",
 			reconcileErr:         partialErr,
@@ -26,6 +26,7 @@ import (
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/selection"
+	"k8s.io/client-go/tools/events"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/event"
@@ -55,6 +56,9 @@ type PERouterReconciler struct {
 	NodeConfigPath       string
 	RouterProvider       RouterProvider
 	DatapathConfigurator DatapathConfigurator
+	// EventRecorder emits events on the resources that fail or recover on
+	// the node. Events are not emitted when nil.
+	EventRecorder events.EventRecorder
 
 	// TriggerChan receives events from FileWatcher (in host mode)
 	TriggerChan chan event.GenericEvent
@@ -67,6 +71,7 @@ type PERouterReconciler struct {
 type requestKey string
 
 // +kubebuilder:rbac:groups="",resources=nodes,verbs=get;list;watch;patch
+// +kubebuilder:rbac:groups=events.k8s.io,resources=events,verbs=create;patch
 // +kubebuilder:rbac:groups=network.openperouter.io,resources=l3vnis,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=network.openperouter.io,resources=l3vnis/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=network.openperouter.io,resources=l3vnis/finalizers,verbs=update
@@ -85,6 +90,7 @@ type requestKey string
 // +kubebuilder:rbac:groups=network.openperouter.io,resources=rawfrrconfigs,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=network.openperouter.io,resources=rawfrrconfigs/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=network.openperouter.io,resources=rawfrrconfigs/finalizers,verbs=update
+// +kubebuilder:rbac:groups=network.openperouter.io,resources=nodeaddressings,verbs=get;list;watch
 // +kubebuilder:rbac:groups=network.openperouter.io,resources=routernodeconfigurationstatuses,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=network.openperouter.io,resources=routernodeconfigurationstatuses/status,verbs=get;update;patch
 
@@ -95,9 +101,11 @@ func (r *PERouterReconciler) Reconcile(ctx context.Context, req ctrl.Request) (c
 
 	ctx = context.WithValue(ctx, requestKey("request"), req.String())
 
-	result, err := r.reconcile(ctx, logger)
+	start := time.Now()
+	result, config, err := r.reconcile(ctx, logger)
+	observeReconcile(perouterControllerLabel, start, err)
 
-	if statusErr := r.reconcileNodeStatus(ctx, err); statusErr != nil {
+	if statusErr := r.reconcileNodeStatus(ctx, config, err); statusErr != nil {
 		return ctrl.Result{}, errors.Join(err, statusErr)
 	}
 
@@ -112,35 +120,37 @@ func (r *PERouterReconciler) Reconcile(ctx context.Context, req ctrl.Request) (c
 	return ctrl.Result{}, err
 }
 
-func (r *PERouterReconciler) reconcile(ctx context.Context, logger *slog.Logger) (ctrl.Result, error) {
+// reconcile applies the configuration of the node, returning the configuration
+// it was applied from, or an empty one if it didn't get to apply it.
+func (r *PERouterReconciler) reconcile(ctx context.Context, logger *slog.Logger) (ctrl.Result, conversion.APIConfigData, error) {
 	config, err := r.getConfigFromAPI(ctx, logger)
 	if err != nil {
-		return ctrl.Result{}, err
+		return ctrl.Result{}, conversion.APIConfigData{}, err
 	}
 
 	if r.StaticConfigDir != "" {
 		config, err = mergeStaticConfig(r.StaticConfigDir, r.MyNode, r.MyNamespace, config, logger)
 		if err != nil {
-			return ctrl.Result{}, fmt.Errorf("failed to merge static config: %w", err)
+			return ctrl.Result{}, conversion.APIConfigData{}, fmt.Errorf("failed to merge static config: %w", err)
 		}
 	}
 
 	router, err := r.RouterProvider.New(ctx)
 	if err != nil {
-		return ctrl.Result{}, fmt.Errorf("failed to get router pod instance: %w", err)
+		return ctrl.Result{}, conversion.APIConfigData{}, fmt.Errorf("failed to get router pod instance: %w", err)
 	}
 
 	targetNS, err := router.TargetNS(ctx)
 	if err != nil {
-		return ctrl.Result{}, fmt.Errorf("failed to retrieve target namespace: %w", err)
+		return ctrl.Result{}, conversion.APIConfigData{}, fmt.Errorf("failed to retrieve target namespace: %w", err)
 	}
 	canReconcile, err := router.CanReconcile()
 	if err != nil {
-		return ctrl.Result{}, fmt.Errorf("failed to check if router can be reconciled: %w", err)
+		return ctrl.Result{}, conversion.APIConfigData{}, fmt.Errorf("failed to check if router can be reconciled: %w", err)
 	}
 	if !canReconcile {
 		logger.Info("router is not ready for reconciliation, requeueing")
-		return ctrl.Result{RequeueAfter: 5 * time.Second}, nil
+		return ctrl.Result{RequeueAfter: 5 * time.Second}, conversion.APIConfigData{}, nil
 	}
 
 	updater := frrconfig.UpdaterForSocket(r.FRRReloadSocket, r.FRRConfigPath)
@@ -148,17 +158,17 @@ func (r *PERouterReconciler) reconcile(ctx context.Context, logger *slog.Logger)
 	nodeIndex, err := r.RouterProvider.NodeIndex(ctx)
 	if err != nil {
 		slog.Error("failed to get node index", "error", err)
-		return ctrl.Result{}, err
+		return ctrl.Result{}, conversion.APIConfigData{}, err
 	}
 
 	err = Reconcile(ctx, config, nodeIndex, r.LogLevel, r.FRRConfigPath, targetNS, updater,
 		r.DatapathConfigurator, configureFRR)
 	if err != nil {
 		logger.Error("failed to reconcile host configuration", "error", err)
-		return ctrl.Result{}, err
+		return ctrl.Result{}, config, err
 	}
 
-	return ctrl.Result{}, nil
+	return ctrl.Result{}, config, nil
 }
 
 func mergeStaticConfig(staticConfigDir, nodeName, namespace string, config conversion.APIConfigData, logger *slog.Logger) (conversion.APIConfigData, error) {
@@ -229,6 +239,21 @@ func (r *PERouterReconciler) getConfigFromAPI(ctx context.Context, logger *slog.
 		return conversion.APIConfigData{}, err
 	}
 
+	var nodeAddressings v1alpha1.NodeAddressingList
+	if err := r.List(ctx, &nodeAddressings, r.notStaticConfigsListOpts); err != nil {
+		slog.Error("failed to list nodeaddressings", "error", err)
+		return conversion.APIConfigData{}, err
+	}
+
+	// The passwords referenced by the resources are read
+	// from the Secrets of the namespace of the router. The cache holds only
+	// the ones carrying the credentials label.
+	var secrets v1.SecretList
+	if err := r.List(ctx, &secrets, client.InNamespace(r.MyNamespace)); err != nil {
+		slog.Error("failed to list secrets", "error", err)
+		return conversion.APIConfigData{}, err
+	}
+
 	node := &v1.Node{}
 	if err := r.Get(ctx, client.ObjectKey{Name: r.MyNode}, node); err != nil {
 		slog.Error("failed to get node", "node", r.MyNode, "error", err)
@@ -272,6 +297,8 @@ func (r *PERouterReconciler) getConfigFromAPI(ctx context.Context, logger *slog.
 		return conversion.APIConfigData{}, err
 	}
 
+	filteredNodeAddressings := filter.NodeAddressingsForNode(node, nodeAddressings.Items)
+
 	if len(filteredRawFRRConfigs) > 0 {
 		logger.Info("RawFRRConfig is applied, but please note that this feature is for experimentation only and not supported")
 	}
@@ -279,12 +306,14 @@ func (r *PERouterReconciler) getConfigFromAPI(ctx context.Context, logger *slog.
 	logger.Debug("using config", "l3vnis", l3vnis.Items, "l2vnis", l2vnis.Items, "underlays", underlays.Items, "l3passthrough", l3passthrough.Items, "rawfrrconfigs", rawFRRConfigs.Items)
 
 	apiConfig := conversion.APIConfigData{
-		Underlays:     filteredUnderlays,
-		L3VNIs:        filteredL3VNIs,
-		L2VNIs:        filteredL2VNIs,
-		L3VPNs:        filteredL3VPNs,
-		L3Passthrough: filteredL3Passthrough,
-		RawFRRConfigs: filteredRawFRRConfigs,
+		Underlays:       filteredUnderlays,
+		L3VNIs:          filteredL3VNIs,
+		L2VNIs:          filteredL2VNIs,
+		L3VPNs:          filteredL3VPNs,
+		L3Passthrough:   filteredL3Passthrough,
+		RawFRRConfigs:   filteredRawFRRConfigs,
+		NodeAddressings: filteredNodeAddressings,
+		Secrets:         secrets.Items,
 	}
 
 	return apiConfig, nil
@@ -342,6 +371,15 @@ func (r *PERouterReconciler) SetupWithManager(mgr ctrl.Manager) error {
 					return true
 				}
 				return false
+			case *v1alpha1.RouterNodeConfigurationStatus:
+				// the operational state is refreshed periodically and does not
+				// affect the router configuration.
+				return !operationalStateOnlyChange(e.ObjectOld.(*v1alpha1.RouterNodeConfigurationStatus), o)
+			case *v1alpha1.Underlay, *v1alpha1.L3VNI, *v1alpha1.L2VNI, *v1alpha1.L3VPN, *v1alpha1.L3Passthrough, *v1alpha1.NodeAddressing:
+				// the aggregated status of these resources is written by the nodemarker,
+				// status only updates are not relevant for the router configuration.
+				return e.ObjectOld.GetGeneration() != o.GetGeneration() ||
+					!labels.Equals(e.ObjectOld.GetLabels(), o.GetLabels())
 			}
 			return true
 		},
@@ -360,6 +398,8 @@ func (r *PERouterReconciler) SetupWithManager(mgr ctrl.Manager) error {
 		Watches(&v1alpha1.L3VPN{}, &handler.EnqueueRequestForObject{}).
 		Watches(&v1alpha1.L3Passthrough{}, &handler.EnqueueRequestForObject{}).
 		Watches(&v1alpha1.RawFRRConfig{}, &handler.EnqueueRequestForObject{}).
+		Watches(&v1alpha1.NodeAddressing{}, &handler.EnqueueRequestForObject{}).
+		Watches(&v1.Secret{}, &handler.EnqueueRequestForObject{}).
 		Watches(&v1alpha1.RouterNodeConfigurationStatus{}, &handler.EnqueueRequestForObject{}).
 		WithEventFilter(filterNonRouterPods).
 		WithEventFilter(filterLocalNodeStatus).
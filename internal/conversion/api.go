@@ -3,7 +3,9 @@
 package conversion
 
 import (
-	"errors"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
 
 	"github.com/openperouter/openperouter/api/v1alpha1"
 	"github.com/openperouter/openperouter/internal/hostnetwork"
@@ -16,6 +18,11 @@ type APIConfigData struct {
 	L3VPNs        []v1alpha1.L3VPN
 	L3Passthrough []v1alpha1.L3Passthrough
 	RawFRRConfigs []v1alpha1.RawFRRConfig
+	// NodeAddressings override the addresses derived from the node index.
+	NodeAddressings []v1alpha1.NodeAddressing
+	// Secrets are the Secrets of the namespace of the router, holding the
+	// passwords referenced by the resources.
+	Secrets []corev1.Secret
 }
 
 type HostConfigData struct {
@@ -23,7 +30,7 @@ type HostConfigData struct {
 	L3VNIs        []hostnetwork.L3VNIParams
 	L2VNIs        []hostnetwork.L2VNIParams
 	L3VPNs        []hostnetwork.L3VPNParams
-	L3Passthrough *hostnetwork.PassthroughParams
+	L3Passthrough []hostnetwork.PassthroughParams
 }
 
 func MergeAPIConfigs(configs ...APIConfigData) (APIConfigData, error) {
@@ -45,6 +52,8 @@ func MergeAPIConfigs(configs ...APIConfigData) (APIConfigData, error) {
 		merged.L3VPNs = append(merged.L3VPNs, config.L3VPNs...)
 		merged.L3Passthrough = append(merged.L3Passthrough, config.L3Passthrough...)
 		merged.RawFRRConfigs = append(merged.RawFRRConfigs, config.RawFRRConfigs...)
+		merged.NodeAddressings = append(merged.NodeAddressings, config.NodeAddressings...)
+		merged.Secrets = append(merged.Secrets, config.Secrets...)
 	}
 
 	return merged, nil
@@ -52,18 +61,14 @@ func MergeAPIConfigs(configs ...APIConfigData) (APIConfigData, error) {
 
 // validateAPIConfigData flags invalid config data.
 func validateAPIConfigData(config APIConfigData) error {
-	if len(config.L3Passthrough) > 1 {
-		return errors.New("multiple passthroughs defined, can only have one")
-	}
-
-	// TODO: This is a shortcut. We do not want L3VNIs and L3VPNs coexisting inside the same VRF. But across different
-	// VRFs, this should work, in theory, subject to testing.
-	if len(config.L3VNIs) > 0 && len(config.L3VPNs) > 0 {
-		return errors.New("cannot specify L3 VNI configuration and VPN configuration at the same time")
+	l3vpnVRFs := map[string]bool{}
+	for _, l3vpn := range config.L3VPNs {
+		l3vpnVRFs[l3vpn.Spec.VRF] = true
 	}
-
-	if len(config.Underlays) > 1 {
-		return errors.New("multiple underlays defined")
+	for _, l3vni := range config.L3VNIs {
+		if l3vpnVRFs[l3vni.Spec.VRF] {
+			return fmt.Errorf("cannot specify L3 VNI configuration and VPN configuration in the same VRF %s", l3vni.Spec.VRF)
+		}
 	}
 
 	if len(config.Underlays) == 0 {
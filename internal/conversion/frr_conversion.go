@@ -11,21 +11,27 @@ import (
 	"net"
 	"slices"
 	"sort"
+	"strconv"
+	"strings"
 
 	"github.com/openperouter/openperouter/api/v1alpha1"
 	"github.com/openperouter/openperouter/internal/frr"
+	"github.com/openperouter/openperouter/internal/hostnetwork"
 	"github.com/openperouter/openperouter/internal/ipam"
 	"github.com/openperouter/openperouter/internal/ipfamily"
 	"github.com/openperouter/openperouter/internal/networklayerprotocol"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/utils/ptr"
 )
 
 const (
-	isisProcessName      = "ISIS"
-	locatorName          = "MAIN"
-	loopbackName         = "lo"
-	advertisePassiveOnly = "advertisePassiveOnly"
-	passiveInterface     = "passive"
+	isisProcessName       = "ISIS"
+	locatorName           = "MAIN"
+	loopbackName          = "lo"
+	advertisePassiveOnly  = "advertisePassiveOnly"
+	passiveInterface      = "passive"
+	pointToPointInterface = "pointToPoint"
+	defaultOSPFArea       = "0.0.0.0"
 )
 
 var (
@@ -70,6 +76,17 @@ func WithGatewayIPs(cidrs []string) L3VNIOption {
 	}
 }
 
+// WithHostSessionPassword sets the TCP-MD5 password of the session with the
+// host.
+func WithHostSessionPassword(password string) L3VNIOption {
+	return func(cfg *frr.L3VNIConfig) error {
+		if cfg.LocalNeighbor != nil {
+			cfg.LocalNeighbor.Password = password
+		}
+		return nil
+	}
+}
+
 type L3VPNOption func(*frr.L3VPNConfig) error
 
 func L3VPNWithGatewayIPs(cidrs []string) L3VPNOption {
@@ -91,6 +108,17 @@ func L3VPNWithGatewayIPs(cidrs []string) L3VPNOption {
 	}
 }
 
+// L3VPNWithHostSessionPassword sets the TCP-MD5 password of the session with
+// the host.
+func L3VPNWithHostSessionPassword(password string) L3VPNOption {
+	return func(cfg *frr.L3VPNConfig) error {
+		if cfg.LocalNeighbor != nil {
+			cfg.LocalNeighbor.Password = password
+		}
+		return nil
+	}
+}
+
 func APItoFRR(config APIConfigData, nodeIndex int, logLevel string) (frr.Config, error) {
 	rawSnippets := rawConfigSnippets(config.RawFRRConfigs)
 	if len(rawSnippets) > 0 && len(config.Underlays) == 0 {
@@ -106,55 +134,116 @@ func APItoFRR(config APIConfigData, nodeIndex int, logLevel string) (frr.Config,
 		return frr.Config{}, err
 	}
 
+	// The underlays of a node share the BGP instance settings, see ValidateUnderlays.
 	underlay := config.Underlays[0]
+	addresses := nodeAddressesFor(nodeIndex, config.NodeAddressings)
 
-	routerID, err := routerIDFromUnderlay(underlay, nodeIndex)
+	routerID, err := addresses.routerID(underlay)
 	if err != nil {
 		return frr.Config{}, fmt.Errorf("failed to get routerID: %w", err)
 	}
 
-	tunnelEndpoint, err := tunnelEndpointToFRR(underlay.Spec.TunnelEndpoint, nodeIndex)
-	if err != nil {
-		return frr.Config{}, fmt.Errorf("failed to translate tunnel endpoint settings, err: %w", err)
+	var tunnelEndpoints []frr.TunnelEndpoint
+	for _, u := range config.Underlays {
+		tunnelEndpoint, err := tunnelEndpointToFRR(u.Name, u.Spec.TunnelEndpoint, addresses)
+		if err != nil {
+			return frr.Config{}, fmt.Errorf("failed to translate tunnel endpoint settings of underlay %s, err: %w", u.Name, err)
+		}
+		if tunnelEndpoint != nil {
+			tunnelEndpoints = append(tunnelEndpoints, *tunnelEndpoint)
+		}
 	}
 
-	underlayInterfaces, err := underlayNetworkDeviceInterfaceNames(underlay.Spec.Interfaces)
+	// ISIS, OSPF and SRv6 are configured by one underlay at most.
+	isisUnderlay := underlay
+	if i := slices.IndexFunc(config.Underlays, func(u v1alpha1.Underlay) bool { return u.Spec.ISIS != nil }); i >= 0 {
+		isisUnderlay = config.Underlays[i]
+	}
+
+	underlayInterfaces, err := underlayNetworkDeviceInterfaceNames(isisUnderlay.Spec.Interfaces)
 	if err != nil {
 		return frr.Config{}, err
 	}
 
-	underlayConfigISIS, err := underlayISISToFRR(underlay.Spec.ISIS, underlayInterfaces, nodeIndex)
+	underlayConfigISIS, err := underlayISISToFRR(isisUnderlay.Spec.ISIS, underlayInterfaces, nodeIndex, config.Secrets)
 	if err != nil {
 		return frr.Config{}, fmt.Errorf("failed to translate ISIS settings, err: %w", err)
 	}
 
-	underlayConfigSegmentRouting, err := underlaySegmentRoutingToFRR(underlay.Spec.SRV6, nodeIndex, tunnelEndpoint)
-	if err != nil {
-		return frr.Config{}, fmt.Errorf("failed to translate segment routing settings, err: %w", err)
+	ospfUnderlay := underlay
+	if i := slices.IndexFunc(config.Underlays, func(u v1alpha1.Underlay) bool { return u.Spec.OSPF != nil }); i >= 0 {
+		ospfUnderlay = config.Underlays[i]
 	}
 
-	neighbors, err := neighborsToFRR(
-		underlay.Spec.Neighbors,
-		underlayConfigSegmentRouting,
-		config.L2VNIs,
-		config.L3VNIs,
-		config.L3VPNs,
-		config.L3Passthrough,
-		underlay.Spec.TunnelEndpoint,
-	)
+	ospfInterfaces, err := underlayNetworkDeviceInterfaceNames(ospfUnderlay.Spec.Interfaces)
 	if err != nil {
 		return frr.Config{}, err
 	}
 
+	underlayConfigOSPF, err := underlayOSPFToFRR(ospfUnderlay.Spec.OSPF, ospfInterfaces, routerID)
+	if err != nil {
+		return frr.Config{}, fmt.Errorf("failed to translate OSPF settings, err: %w", err)
+	}
+
+	srv6Underlay := underlay
+	if i := slices.IndexFunc(config.Underlays, func(u v1alpha1.Underlay) bool { return u.Spec.SRV6 != nil }); i >= 0 {
+		srv6Underlay = config.Underlays[i]
+	}
+	srv6TunnelEndpoint, err := tunnelEndpointToFRR(srv6Underlay.Name, srv6Underlay.Spec.TunnelEndpoint, addresses)
+	if err != nil {
+		return frr.Config{}, fmt.Errorf("failed to translate tunnel endpoint settings, err: %w", err)
+	}
+
+	underlayConfigSegmentRouting, err := underlaySegmentRoutingToFRR(srv6Underlay.Spec.SRV6, nodeIndex, srv6TunnelEndpoint)
+	if err != nil {
+		return frr.Config{}, fmt.Errorf("failed to translate segment routing settings, err: %w", err)
+	}
+
+	neighbors := []frr.NeighborConfig{}
+	bfdProfiles := []frr.BFDProfile{}
+	for _, u := range config.Underlays {
+		// The neighbors of each underlay carry only the overlays riding on it.
+		l3vnis, l2vnis := vnisForUnderlay(u, config.Underlays, config.L3VNIs, config.L2VNIs)
+		var l3vpns []v1alpha1.L3VPN
+		if u.Name == srv6Underlay.Name {
+			l3vpns = config.L3VPNs
+		}
+		underlayNeighbors, err := neighborsToFRR(
+			u.Spec.Neighbors,
+			u.Spec.ASN,
+			underlayConfigSegmentRouting,
+			l2vnis,
+			l3vnis,
+			l3vpns,
+			config.L3Passthrough,
+			u.Spec.TunnelEndpoint,
+			config.Secrets,
+		)
+		if err != nil {
+			return frr.Config{}, err
+		}
+		if len(config.Underlays) > 1 {
+			cidrs, vnis := otherUnderlaysRoutes(u, config.Underlays, config.L3VNIs, config.L2VNIs)
+			for i := range underlayNeighbors {
+				underlayNeighbors[i].UnderlayFilters = underlayFiltersFor(underlayNeighbors[i], cidrs, vnis)
+			}
+		}
+		neighbors = append(neighbors, underlayNeighbors...)
+		bfdProfiles = append(bfdProfiles, bfdProfilesFromNeighbors(u.Spec.Neighbors)...)
+	}
+	bfdProfiles = append(bfdProfiles, bfdProfilesFromISIS(isisUnderlay.Spec.ISIS)...)
+	bfdProfiles = append(bfdProfiles, bfdProfilesFromHostSessions(config.L3VNIs, config.L3VPNs, config.L3Passthrough)...)
+
 	underlayConfig := frr.UnderlayConfig{
-		MyASN:          underlay.Spec.ASN,
-		RouterID:       routerID,
-		Neighbors:      neighbors,
-		TunnelEndpoint: tunnelEndpoint,
-		ISIS:           underlayConfigISIS,
-		SegmentRouting: underlayConfigSegmentRouting,
-		RouteReflector: routeReflectorToFRR(underlay.Spec.RouteReflector),
-		ListenLimit:    BGPListenLimit,
+		MyASN:           underlay.Spec.ASN,
+		RouterID:        routerID,
+		Neighbors:       neighbors,
+		TunnelEndpoints: tunnelEndpoints,
+		ISIS:            underlayConfigISIS,
+		OSPF:            underlayConfigOSPF,
+		SegmentRouting:  underlayConfigSegmentRouting,
+		RouteReflector:  routeReflectorToFRR(underlay.Spec.RouteReflector),
+		ListenLimit:     BGPListenLimit,
 	}
 
 	applyGracefulRestart(&underlayConfig, underlay.Spec.GracefulRestart)
@@ -169,43 +258,160 @@ func APItoFRR(config APIConfigData, nodeIndex int, logLevel string) (frr.Config,
 		config.L3VNIs,
 		routerID,
 		underlay.Spec.ASN,
-		nodeIndex,
+		addresses,
 		vrfsWithL2Gateway,
+		config.Secrets,
 	)
 	if err != nil {
 		return frr.Config{}, err
 	}
 
-	passthroughConfig, err := passthroughToFRR(config.L3Passthrough, nodeIndex)
+	passthroughConfigs, err := passthroughsToFRR(config.L3Passthrough, underlay.Spec.ASN, addresses, config.Secrets)
 	if err != nil {
-		return frr.Config{}, fmt.Errorf("failed to translate passthrough to frr: %w", err)
+		return frr.Config{}, err
 	}
 
 	vpnConfigs, err := l3vpnConfigsToFRR(
 		config.L3VPNs,
 		routerID,
 		underlay.Spec.ASN,
-		nodeIndex,
+		addresses,
 		vrfsWithL2Gateway,
+		config.Secrets,
 	)
 	if err != nil {
 		return frr.Config{}, err
 	}
 
 	return frr.Config{
-		Underlay:    underlayConfig,
-		VNIs:        vniConfigs,
-		Passthrough: passthroughConfig,
-		BFDProfiles: bfdProfilesFromNeighbors(underlay.Spec.Neighbors),
-		VPNs:        vpnConfigs,
-		Loglevel:    logLevel,
-		RawConfig:   rawSnippets,
+		Underlay:         underlayConfig,
+		VNIs:             vniConfigs,
+		Passthroughs:     passthroughConfigs,
+		BFDProfiles:      bfdProfiles,
+		VPNs:             vpnConfigs,
+		Loglevel:         logLevel,
+		RawConfig:        rawSnippets,
+		EthernetSegments: ethernetSegmentsToFRR(config.L2VNIs),
+		L2VNIs:           l2VNIsToFRR(config.L2VNIs),
+		VRFImports:       vrfImportsToFRR(config.L3VNIs, config.L3VPNs, underlay.Spec.ASN),
 	}, nil
 }
 
-func neighborsToFRR(apiNeighbors []v1alpha1.Neighbor, segmentRouting *frr.UnderlaySegmentRouting,
+// vnisForUnderlay returns the VNIs riding on the given underlay.
+func vnisForUnderlay(underlay v1alpha1.Underlay, underlays []v1alpha1.Underlay,
+	l3vnis []v1alpha1.L3VNI, l2vnis []v1alpha1.L2VNI) ([]v1alpha1.L3VNI, []v1alpha1.L2VNI) {
+	ridesOn := func(name *string) bool {
+		u, err := underlayForVNI(underlays, name)
+		return err == nil && u.Name == underlay.Name
+	}
+	var resL3 []v1alpha1.L3VNI
+	for _, l3 := range l3vnis {
+		if ridesOn(l3.Spec.Underlay) {
+			resL3 = append(resL3, l3)
+		}
+	}
+	var resL2 []v1alpha1.L2VNI
+	for _, l2 := range l2vnis {
+		if ridesOn(l2.Spec.Underlay) {
+			resL2 = append(resL2, l2)
+		}
+	}
+	return resL3, resL2
+}
+
+// otherUnderlaysRoutes returns the tunnel endpoint cidrs of the underlays
+// other than the given one, and the VNIs riding on them.
+func otherUnderlaysRoutes(underlay v1alpha1.Underlay, underlays []v1alpha1.Underlay,
+	l3vnis []v1alpha1.L3VNI, l2vnis []v1alpha1.L2VNI) ([]string, []int32) {
+	var cidrs []string
+	var vnis []int32
+	for _, u := range underlays {
+		if u.Name == underlay.Name {
+			continue
+		}
+		if u.Spec.TunnelEndpoint != nil {
+			cidrs = append(cidrs, u.Spec.TunnelEndpoint.CIDRs...)
+		}
+		otherL3VNIs, otherL2VNIs := vnisForUnderlay(u, underlays, l3vnis, l2vnis)
+		for _, l3 := range otherL3VNIs {
+			vnis = append(vnis, l3.Spec.VNI)
+		}
+		for _, l2 := range otherL2VNIs {
+			vnis = append(vnis, l2.Spec.VNI)
+		}
+	}
+	return cidrs, vnis
+}
+
+// underlayFiltersFor returns the outbound route maps keeping the tunnel
+// endpoints and the VNIs of the other underlays of the node from the
+// neighbor, for each address family it activates. Each one calls the export
+// policy of the neighbor for the same family, if any.
+func underlayFiltersFor(neighbor frr.NeighborConfig, cidrs []string, vnis []int32) []frr.RouteMap {
+	var res []frr.RouteMap
+	for _, nlp := range []networklayerprotocol.NLP{
+		{AFI: networklayerprotocol.IPv4, SAFI: networklayerprotocol.Unicast},
+		{AFI: networklayerprotocol.IPv6, SAFI: networklayerprotocol.Unicast},
+		{AFI: networklayerprotocol.L2VPN, SAFI: networklayerprotocol.EVPN},
+	} {
+		if !neighbor.ActivateFor(nlp.AFI, nlp.SAFI) {
+			continue
+		}
+		filter := frr.RouteMap{
+			Name:      fmt.Sprintf("%s-%s-%s-other-underlays", neighbor.ID, nlp.AFI, nlp.SAFI),
+			AFI:       nlp.AFI,
+			SAFI:      nlp.SAFI,
+			Direction: frr.RouteMapOut,
+		}
+		if nlp.AFI == networklayerprotocol.L2VPN {
+			for _, vni := range vnis {
+				filter.Entries = append(filter.Entries, frr.RouteMapEntry{
+					Seq:          (len(filter.Entries) + 1) * 10,
+					Action:       "deny",
+					MatchEVPNVNI: vni,
+				})
+			}
+		} else if pl := prefixListToFRR(filter.Name, tunnelEndpointMatches(cidrs), nlp.AFI); pl != nil {
+			filter.Entries = append(filter.Entries, frr.RouteMapEntry{
+				Seq:        10,
+				Action:     "deny",
+				PrefixList: pl,
+			})
+		}
+		if len(filter.Entries) == 0 {
+			continue
+		}
+		filter.Entries = append(filter.Entries, frr.RouteMapEntry{
+			Seq:    defaultActionSeq,
+			Action: "permit",
+			Call:   neighbor.RouteMapFor(nlp.AFI, nlp.SAFI, frr.RouteMapOut),
+		})
+		res = append(res, filter)
+	}
+	return res
+}
+
+// tunnelEndpointMatches returns the prefix matches of the tunnel endpoint
+// ips carved from the given cidrs.
+func tunnelEndpointMatches(cidrs []string) []v1alpha1.PrefixMatch {
+	var res []v1alpha1.PrefixMatch
+	for _, cidr := range cidrs {
+		_, ipNet, err := net.ParseCIDR(cidr)
+		if err != nil {
+			continue
+		}
+		match := v1alpha1.PrefixMatch{Prefix: ipNet.String()}
+		if length, maxLength := ipNet.Mask.Size(); length < maxLength {
+			match.LE = new(int32(maxLength))
+		}
+		res = append(res, match)
+	}
+	return res
+}
+
+func neighborsToFRR(apiNeighbors []v1alpha1.Neighbor, underlayASN int64, segmentRouting *frr.UnderlaySegmentRouting,
 	l2vnis []v1alpha1.L2VNI, l3vnis []v1alpha1.L3VNI, l3vpns []v1alpha1.L3VPN, l3passthroughs []v1alpha1.L3Passthrough,
-	tunnelEndpoint *v1alpha1.TunnelEndpointConfig,
+	tunnelEndpoint *v1alpha1.TunnelEndpointConfig, secrets []corev1.Secret,
 ) ([]frr.NeighborConfig, error) {
 	neighbors := make([]frr.NeighborConfig, 0, len(apiNeighbors))
 	for _, n := range apiNeighbors {
@@ -221,6 +427,16 @@ func neighborsToFRR(apiNeighbors []v1alpha1.Neighbor, segmentRouting *frr.Underl
 		if err != nil {
 			return nil, fmt.Errorf("failed to translate underlay neighbor %s to frr, err: %w", neighborID(n), err)
 		}
+		if n.PasswordSecret != nil {
+			frrNeigh.Password, err = secretPassword(secrets, *n.PasswordSecret)
+			if err != nil {
+				return nil, fmt.Errorf("failed to read the password of underlay neighbor %s, err: %w", neighborID(n), err)
+			}
+		}
+		frrNeigh.RouteMaps, err = routeMapsForNeighbor(n, frrNeigh.ID, underlayASN)
+		if err != nil {
+			return nil, fmt.Errorf("failed to translate route policies for underlay neighbor %s to frr, err: %w", neighborID(n), err)
+		}
 		neighbors = append(neighbors, *frrNeigh)
 	}
 	return neighbors, nil
@@ -268,7 +484,7 @@ func routeReflectorToFRR(rr *v1alpha1.RouteReflectorConfig) *frr.RouteReflector
 	}
 }
 
-func tunnelEndpointToFRR(tunnelEndpointConfig *v1alpha1.TunnelEndpointConfig, nodeIndex int) (*frr.TunnelEndpoint, error) {
+func tunnelEndpointToFRR(underlay string, tunnelEndpointConfig *v1alpha1.TunnelEndpointConfig, addresses nodeAddresses) (*frr.TunnelEndpoint, error) {
 	if tunnelEndpointConfig == nil {
 		return nil, nil
 	}
@@ -279,9 +495,9 @@ func tunnelEndpointToFRR(tunnelEndpointConfig *v1alpha1.TunnelEndpointConfig, no
 			return nil, fmt.Errorf("failed to determine address family for CIDR %q", cidr)
 		}
 
-		ip, err := ipam.TunnelEndpointIP(cidr, nodeIndex)
+		ip, err := addresses.tunnelEndpointIP(underlay, cidr)
 		if err != nil {
-			return nil, fmt.Errorf("failed to get vtep ip, cidr %s, nodeIndex %d: %w", cidr, nodeIndex, err)
+			return nil, err
 		}
 
 		if af == ipfamily.IPv4 {
@@ -301,16 +517,24 @@ func vniConfigsToFRR(
 	l3vnis []v1alpha1.L3VNI,
 	routerID string,
 	underlayASN int64,
-	nodeIndex int,
+	addresses nodeAddresses,
 	vrfsWithL2Gateway map[string][]string,
+	secrets []corev1.Secret,
 ) ([]frr.L3VNIConfig, error) {
 	configs := []frr.L3VNIConfig{}
 	for _, vni := range l3vnis {
 		var opts []L3VNIOption
 		if gatewayCIDRs, ok := vrfsWithL2Gateway[vni.Spec.VRF]; ok {
-			opts = []L3VNIOption{WithGatewayIPs(gatewayCIDRs)}
+			opts = append(opts, WithGatewayIPs(gatewayCIDRs))
 		}
-		frrVNI, err := l3vniToFRR(vni, routerID, underlayASN, nodeIndex, opts...)
+		password, err := hostSessionPassword(vni.Spec.HostSession, secrets)
+		if err != nil {
+			return nil, fmt.Errorf("failed to translate vni %s host session password: %w", vni.Name, err)
+		}
+		if password != "" {
+			opts = append(opts, WithHostSessionPassword(password))
+		}
+		frrVNI, err := l3vniToFRR(vni, routerID, underlayASN, addresses, opts...)
 		if err != nil {
 			return nil, fmt.Errorf("failed to translate vni to frr: %w, vni %v", err, vni)
 		}
@@ -359,7 +583,9 @@ func underlaySegmentRoutingToFRR(srv6Config *v1alpha1.SRV6Config, nodeIndex int,
 	}, nil
 }
 
-func underlayISISToFRR(isisConfig *v1alpha1.ISISConfig, interfaces []string, nodeIndex int) (*frr.UnderlayISIS, error) {
+func underlayISISToFRR(isisConfig *v1alpha1.ISISConfig, interfaces []string, nodeIndex int,
+	secrets []corev1.Secret,
+) (*frr.UnderlayISIS, error) {
 	if isisConfig == nil {
 		return nil, nil
 	}
@@ -406,12 +632,38 @@ func underlayISISToFRR(isisConfig *v1alpha1.ISISConfig, interfaces []string, nod
 		hasIPv6 := intf.IPFamily != nil &&
 			(*intf.IPFamily == v1alpha1.IPFamilyIPv6 || *intf.IPFamily == v1alpha1.IPFamilyDualStack)
 
-		isisInterfaces[intf.Name] = frr.ISISInterface{
-			Name:      intf.Name,
-			IPv4:      hasIPv4,
-			IPv6:      hasIPv6,
-			IsPassive: slices.Contains(intf.Features, passiveInterface),
+		password, err := isisPassword(intf.Authentication, secrets)
+		if err != nil {
+			return nil, fmt.Errorf("invalid authentication of ISIS interface %s: %w", intf.Name, err)
+		}
+
+		isisInterface := frr.ISISInterface{
+			Name:            intf.Name,
+			IPv4:            hasIPv4,
+			IPv6:            hasIPv6,
+			IsPassive:       slices.Contains(intf.Features, passiveInterface),
+			IsPointToPoint:  slices.Contains(intf.Features, pointToPointInterface),
+			Password:        password,
+			Metric:          intf.Metric,
+			HelloInterval:   intf.HelloIntervalSeconds,
+			HelloMultiplier: intf.HelloMultiplier,
+		}
+		if intf.BFD != nil {
+			isisInterface.BFDEnabled = true
+			if !ptr.AllPtrFieldsNil(intf.BFD) {
+				isisInterface.BFDProfile = bfdProfileNameForISISInterface(intf.Name)
+			}
 		}
+		isisInterfaces[intf.Name] = isisInterface
+	}
+
+	areaPassword, err := isisPassword(isisConfig.AreaAuthentication, secrets)
+	if err != nil {
+		return nil, fmt.Errorf("invalid ISIS area authentication: %w", err)
+	}
+	domainPassword, err := isisPassword(isisConfig.DomainAuthentication, secrets)
+	if err != nil {
+		return nil, fmt.Errorf("invalid ISIS domain authentication: %w", err)
 	}
 
 	return &frr.UnderlayISIS{
@@ -419,10 +671,108 @@ func underlayISISToFRR(isisConfig *v1alpha1.ISISConfig, interfaces []string, nod
 		Net:                  isisNet,
 		Level:                isisLevel,
 		AdvertisePassiveOnly: slices.Contains(isisConfig.Features, advertisePassiveOnly),
+		AreaPassword:         areaPassword,
+		DomainPassword:       domainPassword,
 		Interfaces:           mapOfInterfacesToSortedList(isisInterfaces),
 	}, nil
 }
 
+// isisPassword returns the key of the given ISIS authentication, read from
+// its Secret, or an empty string when the authentication is not set.
+func isisPassword(auth *v1alpha1.ISISAuthentication, secrets []corev1.Secret) (string, error) {
+	if auth == nil {
+		return "", nil
+	}
+	if auth.Type != "" && auth.Type != v1alpha1.ISISAuthenticationHMACMD5 {
+		return "", fmt.Errorf("unsupported authentication type %q", auth.Type)
+	}
+	return secretPassword(secrets, auth.PasswordSecret)
+}
+
+// bfdProfilesFromISIS returns the BFD profiles of the ISIS interfaces with
+// BFD settings.
+func bfdProfilesFromISIS(isisConfig *v1alpha1.ISISConfig) []frr.BFDProfile {
+	if isisConfig == nil {
+		return nil
+	}
+	var profiles []frr.BFDProfile
+	for _, intf := range isisConfig.Interfaces {
+		if intf.BFD == nil || ptr.AllPtrFieldsNil(intf.BFD) {
+			continue
+		}
+		profiles = append(profiles, bfdProfileFromSettings(bfdProfileNameForISISInterface(intf.Name), intf.BFD))
+	}
+	return profiles
+}
+
+func bfdProfileNameForISISInterface(name string) string {
+	return fmt.Sprintf("isis-%s", name)
+}
+
+func underlayOSPFToFRR(ospfConfig *v1alpha1.OSPFConfig, interfaces []string, routerID string) (*frr.UnderlayOSPF, error) {
+	if ospfConfig == nil {
+		return nil, nil
+	}
+
+	area, err := ospfArea(ospfConfig.Area)
+	if err != nil {
+		return nil, err
+	}
+
+	ipFamily := ptr.Deref(ospfConfig.IPFamily, v1alpha1.IPFamilyIPv4)
+
+	// Always add the loopback as a passive interface, so the router is
+	// reachable via its loopback addresses.
+	ospfInterfaces := map[string]frr.OSPFInterface{
+		loopbackName: {
+			Name:      loopbackName,
+			IsPassive: true,
+		},
+	}
+
+	for _, iface := range interfaces {
+		ospfInterfaces[iface] = frr.OSPFInterface{Name: iface}
+	}
+
+	// The OSPFInterface slice may override default settings from loopback and
+	// from interfaces. CEL enforces uniqueness by name.
+	for _, intf := range ospfConfig.Interfaces {
+		ospfInterfaces[intf.Name] = frr.OSPFInterface{
+			Name:           intf.Name,
+			IsPassive:      slices.Contains(intf.Features, passiveInterface),
+			IsPointToPoint: slices.Contains(intf.Features, pointToPointInterface),
+		}
+	}
+
+	res := &frr.UnderlayOSPF{
+		RouterID:   routerID,
+		Area:       area,
+		IPv4:       ipFamily == v1alpha1.IPFamilyIPv4 || ipFamily == v1alpha1.IPFamilyDualStack,
+		IPv6:       ipFamily == v1alpha1.IPFamilyIPv6 || ipFamily == v1alpha1.IPFamilyDualStack,
+		Interfaces: slices.Collect(maps.Values(ospfInterfaces)),
+	}
+	slices.SortFunc(res.Interfaces, func(x, y frr.OSPFInterface) int {
+		return cmp.Compare(x.Name, y.Name)
+	})
+	return res, nil
+}
+
+// ospfArea returns the given OSPF area in the dotted decimal format, accepting
+// also the decimal one.
+func ospfArea(area *v1alpha1.OSPFArea) (string, error) {
+	if area == nil {
+		return defaultOSPFArea, nil
+	}
+	if ip := net.ParseIP(string(*area)); ip != nil && ip.To4() != nil {
+		return ip.To4().String(), nil
+	}
+	id, err := strconv.ParseUint(string(*area), 10, 32)
+	if err != nil {
+		return "", fmt.Errorf("invalid OSPF area %q, must be a decimal number or a dotted decimal IPv4 address", *area)
+	}
+	return net.IPv4(byte(id>>24), byte(id>>16), byte(id>>8), byte(id)).String(), nil
+}
+
 func mapOfInterfacesToSortedList(m map[string]frr.ISISInterface) []frr.ISISInterface {
 	s := slices.Collect(maps.Values(m))
 	slices.SortFunc(s, func(x, y frr.ISISInterface) int {
@@ -448,18 +798,33 @@ func rawConfigSnippets(rawFRRConfigs []v1alpha1.RawFRRConfig) []frr.RawFRRSnippe
 	return snippets
 }
 
-func passthroughToFRR(l3Passthroughs []v1alpha1.L3Passthrough, nodeIndex int) (*frr.PassthroughConfig, error) {
-	if len(l3Passthroughs) == 0 {
-		return nil, nil
+func passthroughsToFRR(l3Passthroughs []v1alpha1.L3Passthrough, underlayASN int64, addresses nodeAddresses,
+	secrets []corev1.Secret,
+) ([]frr.PassthroughConfig, error) {
+	var res []frr.PassthroughConfig
+	for _, passthrough := range l3Passthroughs {
+		password, err := hostSessionPassword(&passthrough.Spec.HostSession, secrets)
+		if err != nil {
+			return nil, fmt.Errorf("failed to translate passthrough %s host session password: %w", passthrough.Name, err)
+		}
+		cfg, err := passthroughToFRR(passthrough, underlayASN, addresses, password)
+		if err != nil {
+			return nil, fmt.Errorf("failed to translate passthrough %s to frr: %w", passthrough.Name, err)
+		}
+		res = append(res, cfg)
 	}
-	passthrough := l3Passthroughs[0]
+	return res, nil
+}
 
-	vethIPs, err := ipam.VethIPsFromPool(passthrough.Spec.HostSession.LocalCIDR.IPv4, passthrough.Spec.HostSession.LocalCIDR.IPv6, nodeIndex)
+func passthroughToFRR(passthrough v1alpha1.L3Passthrough, underlayASN int64, addresses nodeAddresses,
+	password string,
+) (frr.PassthroughConfig, error) {
+	vethIPs, err := addresses.vethIPs(v1alpha1.HostSessionOwnerKindL3Passthrough, passthrough.Name, passthrough.Spec.HostSession.LocalCIDR)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get veth ips, cidr %v, nodeIndex %d", passthrough.Spec.HostSession.LocalCIDR, nodeIndex)
+		return frr.PassthroughConfig{}, err
 	}
 
-	res := &frr.PassthroughConfig{
+	res := frr.PassthroughConfig{
 		ToAdvertiseIPv4: []string{},
 		ToAdvertiseIPv6: []string{},
 	}
@@ -468,7 +833,7 @@ func passthroughToFRR(l3Passthroughs []v1alpha1.L3Passthrough, nodeIndex int) (*
 		passthrough.Spec.HostSession.HostType,
 	)
 	if err != nil {
-		return nil, fmt.Errorf("could not parse passthrough HostSession, err: %w", err)
+		return frr.PassthroughConfig{}, fmt.Errorf("could not parse passthrough HostSession, err: %w", err)
 	}
 
 	const passthroughConnectRetrySeconds = int64(5)
@@ -479,7 +844,14 @@ func passthroughToFRR(l3Passthroughs []v1alpha1.L3Passthrough, nodeIndex int) (*
 			Addr:        vethIPs.Ipv4.HostSide.IP.String(),
 			ID:          vethIPs.Ipv4.HostSide.IP.String(),
 			ConnectTime: new(passthroughConnectRetrySeconds),
+			Password:    password,
+			RouteMaps: routeMapsForHostSession(passthrough.Spec.HostSession,
+				"passthrough-"+vethIPs.Ipv4.HostSide.IP.String(), underlayASN, networklayerprotocol.IPv4),
+			AllowedPrefixesIPv4: allowedPrefixListToFRR(passthrough.Spec.HostSession,
+				"passthrough-"+vethIPs.Ipv4.HostSide.IP.String(), networklayerprotocol.IPv4),
 		}
+		applyHostSessionSettings(res.LocalNeighborV4, passthrough.Spec.HostSession,
+			v1alpha1.HostSessionOwnerKindL3Passthrough, passthrough.Name)
 		ipnet := net.IPNet{
 			IP:   vethIPs.Ipv4.HostSide.IP,
 			Mask: net.CIDRMask(32, 32),
@@ -493,7 +865,14 @@ func passthroughToFRR(l3Passthroughs []v1alpha1.L3Passthrough, nodeIndex int) (*
 			Addr:        vethIPs.Ipv6.HostSide.IP.String(),
 			ID:          vethIPs.Ipv6.HostSide.IP.String(),
 			ConnectTime: new(passthroughConnectRetrySeconds),
+			Password:    password,
+			RouteMaps: routeMapsForHostSession(passthrough.Spec.HostSession,
+				"passthrough-"+vethIPs.Ipv6.HostSide.IP.String(), underlayASN, networklayerprotocol.IPv6),
+			AllowedPrefixesIPv6: allowedPrefixListToFRR(passthrough.Spec.HostSession,
+				"passthrough-"+vethIPs.Ipv6.HostSide.IP.String(), networklayerprotocol.IPv6),
 		}
+		applyHostSessionSettings(res.LocalNeighborV6, passthrough.Spec.HostSession,
+			v1alpha1.HostSessionOwnerKindL3Passthrough, passthrough.Name)
 
 		ipnet := net.IPNet{
 			IP:   vethIPs.Ipv6.HostSide.IP,
@@ -509,7 +888,7 @@ func passthroughToFRR(l3Passthroughs []v1alpha1.L3Passthrough, nodeIndex int) (*
 // If no HostSession is defined, it returns a single config using the underlay ASN.
 // Otherwise, it derives veth IPs from the HostSession's local CIDR pool for the given node index
 // and creates a config per IP family (IPv4/IPv6), each with a local neighbor and the corresponding prefixes to advertise.
-func l3vniToFRR(vni v1alpha1.L3VNI, routerID string, underlayASN int64, nodeIndex int, opts ...L3VNIOption) ([]frr.L3VNIConfig, error) {
+func l3vniToFRR(vni v1alpha1.L3VNI, routerID string, underlayASN int64, addresses nodeAddresses, opts ...L3VNIOption) ([]frr.L3VNIConfig, error) {
 	exportRTs := convertRTsToSliceOfStrings(vni.Spec.ExportRTs)
 	importRTs := convertRTsToSliceOfStrings(vni.Spec.ImportRTs)
 
@@ -535,7 +914,7 @@ func l3vniToFRR(vni v1alpha1.L3VNI, routerID string, underlayASN int64, nodeInde
 		return nil, fmt.Errorf("could not parse HostSession, err: %w", err)
 	}
 
-	hostSideIPs, err := hostSessionToHostSideIPs(vni.Spec.HostSession, nodeIndex)
+	hostSideIPs, err := hostSessionToHostSideIPs(v1alpha1.HostSessionOwnerKindL3VNI, vni.Name, vni.Spec.HostSession, addresses)
 	if err != nil {
 		return nil, err
 	}
@@ -562,6 +941,13 @@ func l3vniToFRR(vni v1alpha1.L3VNI, routerID string, underlayASN int64, nodeInde
 				Addr: ipnet.IP.String(),
 				ID:   ipnet.IP.String(),
 				ASN:  hostASN,
+				// the host session activates both the ipv4 and the ipv6 unicast families
+				RouteMaps: routeMapsForHostSession(*vni.Spec.HostSession, vni.Spec.VRF+"-"+ipnet.IP.String(),
+					vni.Spec.HostSession.ASN, networklayerprotocol.IPv4, networklayerprotocol.IPv6),
+				AllowedPrefixesIPv4: allowedPrefixListToFRR(*vni.Spec.HostSession, vni.Spec.VRF+"-"+ipnet.IP.String(),
+					networklayerprotocol.IPv4),
+				AllowedPrefixesIPv6: allowedPrefixListToFRR(*vni.Spec.HostSession, vni.Spec.VRF+"-"+ipnet.IP.String(),
+					networklayerprotocol.IPv6),
 			},
 			ExportRTs:       exportRTs,
 			ImportRTs:       importRTs,
@@ -570,6 +956,7 @@ func l3vniToFRR(vni v1alpha1.L3VNI, routerID string, underlayASN int64, nodeInde
 		})
 	}
 	for i := range configs {
+		applyHostSessionSettings(configs[i].LocalNeighbor, *vni.Spec.HostSession, v1alpha1.HostSessionOwnerKindL3VNI, vni.Name)
 		for _, opt := range opts {
 			if err := opt(&configs[i]); err != nil {
 				return nil, err
@@ -583,16 +970,24 @@ func l3vpnConfigsToFRR(
 	l3VPNs []v1alpha1.L3VPN,
 	routerID string,
 	asn int64,
-	nodeIndex int,
+	addresses nodeAddresses,
 	vrfsWithL2Gateway map[string][]string,
+	secrets []corev1.Secret,
 ) ([]frr.L3VPNConfig, error) {
 	vpnConfigs := []frr.L3VPNConfig{}
 	for _, vpn := range l3VPNs {
 		var opts []L3VPNOption
 		if gatewayCIDRs, ok := vrfsWithL2Gateway[vpn.Spec.VRF]; ok {
-			opts = []L3VPNOption{L3VPNWithGatewayIPs(gatewayCIDRs)}
+			opts = append(opts, L3VPNWithGatewayIPs(gatewayCIDRs))
+		}
+		password, err := hostSessionPassword(vpn.Spec.HostSession, secrets)
+		if err != nil {
+			return []frr.L3VPNConfig{}, fmt.Errorf("failed to translate l3vpn %s host session password: %w", vpn.Name, err)
+		}
+		if password != "" {
+			opts = append(opts, L3VPNWithHostSessionPassword(password))
 		}
-		frrVNI, err := l3vpnToFRR(vpn, routerID, asn, nodeIndex, opts...)
+		frrVNI, err := l3vpnToFRR(vpn, routerID, asn, addresses, opts...)
 		if err != nil {
 			return []frr.L3VPNConfig{}, fmt.Errorf("failed to translate l3vpn to frr: %w, vni %v", err, vpn)
 		}
@@ -610,7 +1005,7 @@ func l3vpnToFRR(
 	vpn v1alpha1.L3VPN,
 	routerID string,
 	underlayASN int64,
-	nodeIndex int,
+	addresses nodeAddresses,
 	opts ...L3VPNOption,
 ) ([]frr.L3VPNConfig, error) {
 	// importRTs cannot be auto-derived. Unfortunately, FRR does not support wildcard notation, e.g. *:200. And
@@ -647,7 +1042,7 @@ func l3vpnToFRR(
 		return nil, fmt.Errorf("could not parse HostSession, err: %w", err)
 	}
 
-	hostSideIPs, err := hostSessionToHostSideIPs(vpn.Spec.HostSession, nodeIndex)
+	hostSideIPs, err := hostSessionToHostSideIPs(v1alpha1.HostSessionOwnerKindL3VPN, vpn.Name, vpn.Spec.HostSession, addresses)
 	if err != nil {
 		return nil, err
 	}
@@ -676,12 +1071,20 @@ func l3vpnToFRR(
 				Addr: ipnet.IP.String(),
 				ID:   ipnet.IP.String(),
 				ASN:  hostASN,
+				// the host session activates both the ipv4 and the ipv6 unicast families
+				RouteMaps: routeMapsForHostSession(*vpn.Spec.HostSession, vpn.Spec.VRF+"-"+ipnet.IP.String(),
+					vpn.Spec.HostSession.ASN, networklayerprotocol.IPv4, networklayerprotocol.IPv6),
+				AllowedPrefixesIPv4: allowedPrefixListToFRR(*vpn.Spec.HostSession, vpn.Spec.VRF+"-"+ipnet.IP.String(),
+					networklayerprotocol.IPv4),
+				AllowedPrefixesIPv6: allowedPrefixListToFRR(*vpn.Spec.HostSession, vpn.Spec.VRF+"-"+ipnet.IP.String(),
+					networklayerprotocol.IPv6),
 			},
 			ToAdvertiseIPv4: toAdvertiseIPv4,
 			ToAdvertiseIPv6: toAdvertiseIPv6,
 		})
 	}
 	for i := range configs {
+		applyHostSessionSettings(configs[i].LocalNeighbor, *vpn.Spec.HostSession, v1alpha1.HostSessionOwnerKindL3VPN, vpn.Name)
 		for _, opt := range opts {
 			if err := opt(&configs[i]); err != nil {
 				return nil, err
@@ -695,8 +1098,9 @@ func routeDistinguisher(left string, right int32) string {
 	return fmt.Sprintf("%s:%d", left, right)
 }
 
-func hostSessionToHostSideIPs(hostSession *v1alpha1.HostSession, nodeIndex int) (map[ipfamily.Family]net.IPNet, error) {
-	veths, err := ipam.VethIPsFromPool(hostSession.LocalCIDR.IPv4, hostSession.LocalCIDR.IPv6, nodeIndex)
+func hostSessionToHostSideIPs(kind v1alpha1.HostSessionOwnerKind, name string, hostSession *v1alpha1.HostSession,
+	addresses nodeAddresses) (map[ipfamily.Family]net.IPNet, error) {
+	veths, err := addresses.vethIPs(kind, name, hostSession.LocalCIDR)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get veths ips: %w", err)
 	}
@@ -896,20 +1300,9 @@ func neighborSessionIPFamily(neigh *frr.NeighborConfig) (ipfamily.Family, error)
 func nlpsForNeighbor(n v1alpha1.Neighbor) ([]networklayerprotocol.NLP, error) {
 	nlps := make([]networklayerprotocol.NLP, 0, len(n.AddressFamilies))
 	for _, af := range n.AddressFamilies {
-		var nlp networklayerprotocol.NLP
-		switch af.Type {
-		case "ipv4unicast":
-			nlp = networklayerprotocol.NLP{AFI: networklayerprotocol.IPv4, SAFI: networklayerprotocol.Unicast}
-		case "ipv6unicast":
-			nlp = networklayerprotocol.NLP{AFI: networklayerprotocol.IPv6, SAFI: networklayerprotocol.Unicast}
-		case "evpn":
-			nlp = networklayerprotocol.NLP{AFI: networklayerprotocol.L2VPN, SAFI: networklayerprotocol.EVPN}
-		case "ipv4vpn":
-			nlp = networklayerprotocol.NLP{AFI: networklayerprotocol.IPv4, SAFI: networklayerprotocol.VPN}
-		case "ipv6vpn":
-			nlp = networklayerprotocol.NLP{AFI: networklayerprotocol.IPv6, SAFI: networklayerprotocol.VPN}
-		default:
-			return nil, fmt.Errorf("unsupported address family type %q", af.Type)
+		nlp, err := nlpForAddressFamily(af.Type)
+		if err != nil {
+			return nil, err
 		}
 		if addressFamilyProperty(af.Properties, v1alpha1.AddressFamilyPropertyRouteReflectorClient) != nil {
 			nlp.Properties.RouteReflectorClient = true
@@ -919,6 +1312,273 @@ func nlpsForNeighbor(n v1alpha1.Neighbor) ([]networklayerprotocol.NLP, error) {
 	return nlps, nil
 }
 
+// nlpForAddressFamily converts an API address family type to the internal
+// network layer protocol.
+func nlpForAddressFamily(afType string) (networklayerprotocol.NLP, error) {
+	switch afType {
+	case "ipv4unicast":
+		return networklayerprotocol.NLP{AFI: networklayerprotocol.IPv4, SAFI: networklayerprotocol.Unicast}, nil
+	case "ipv6unicast":
+		return networklayerprotocol.NLP{AFI: networklayerprotocol.IPv6, SAFI: networklayerprotocol.Unicast}, nil
+	case "evpn":
+		return networklayerprotocol.NLP{AFI: networklayerprotocol.L2VPN, SAFI: networklayerprotocol.EVPN}, nil
+	case "ipv4vpn":
+		return networklayerprotocol.NLP{AFI: networklayerprotocol.IPv4, SAFI: networklayerprotocol.VPN}, nil
+	case "ipv6vpn":
+		return networklayerprotocol.NLP{AFI: networklayerprotocol.IPv6, SAFI: networklayerprotocol.VPN}, nil
+	}
+	return networklayerprotocol.NLP{}, fmt.Errorf("unsupported address family type %q", afType)
+}
+
+// routeMapsForNeighbor converts the import and export policies of each
+// address family of an underlay neighbor to FRR route maps.
+func routeMapsForNeighbor(n v1alpha1.Neighbor, id string, underlayASN int64) ([]frr.RouteMap, error) {
+	var res []frr.RouteMap
+	for _, af := range n.AddressFamilies {
+		nlp, err := nlpForAddressFamily(af.Type)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, routeMapsForPolicies(id, nlp, af.ImportPolicy, af.ExportPolicy, underlayASN)...)
+	}
+	return res, nil
+}
+
+// routeMapsForHostSession converts the import and export policies of a host
+// session to FRR route maps, one per given unicast family.
+func routeMapsForHostSession(session v1alpha1.HostSession, namePrefix string, localASN int64,
+	afis ...networklayerprotocol.AFI) []frr.RouteMap {
+	var res []frr.RouteMap
+	for _, afi := range afis {
+		nlp := networklayerprotocol.NLP{AFI: afi, SAFI: networklayerprotocol.Unicast}
+		res = append(res, routeMapsForPolicies(namePrefix, nlp, session.ImportPolicy, session.ExportPolicy, localASN)...)
+	}
+	return res
+}
+
+// applyHostSessionSettings sets the BFD, maximum prefix and graceful restart
+// settings of the host session on its local neighbor.
+func applyHostSessionSettings(neighbor *frr.NeighborConfig, session v1alpha1.HostSession,
+	kind v1alpha1.HostSessionOwnerKind, name string) {
+	if session.BFD != nil {
+		neighbor.BFDEnabled = true
+		if !ptr.AllPtrFieldsNil(session.BFD) {
+			neighbor.BFDProfile = bfdProfileNameForHostSession(kind, name)
+		}
+	}
+	if session.MaximumPrefix != nil {
+		neighbor.MaximumPrefixIPv4 = maximumPrefixToFRR(session.MaximumPrefix, session.MaximumPrefix.IPv4)
+		neighbor.MaximumPrefixIPv6 = maximumPrefixToFRR(session.MaximumPrefix, session.MaximumPrefix.IPv6)
+	}
+	if session.GracefulRestart != nil {
+		switch session.GracefulRestart.Mode {
+		case v1alpha1.HostSessionGracefulRestartHelper:
+			neighbor.GracefulRestart = frr.GracefulRestartHelper
+		case v1alpha1.HostSessionGracefulRestartDisabled:
+			neighbor.GracefulRestart = frr.GracefulRestartDisabled
+		}
+	}
+}
+
+func maximumPrefixToFRR(maximumPrefix *v1alpha1.MaximumPrefix, limit *int64) *frr.MaximumPrefix {
+	if limit == nil {
+		return nil
+	}
+	return &frr.MaximumPrefix{
+		Limit:       *limit,
+		Threshold:   maximumPrefix.WarningThresholdPercent,
+		WarningOnly: maximumPrefix.Action == v1alpha1.MaximumPrefixActionWarningOnly,
+	}
+}
+
+// hostSessionPassword returns the TCP-MD5 password of the host session, read
+// from its password secret.
+func hostSessionPassword(session *v1alpha1.HostSession, secrets []corev1.Secret) (string, error) {
+	if session == nil || session.PasswordSecret == nil {
+		return "", nil
+	}
+	return secretPassword(secrets, *session.PasswordSecret)
+}
+
+// bfdProfilesFromHostSessions returns the BFD profiles of the host sessions
+// with BFD settings.
+func bfdProfilesFromHostSessions(l3vnis []v1alpha1.L3VNI, l3vpns []v1alpha1.L3VPN,
+	l3Passthroughs []v1alpha1.L3Passthrough) []frr.BFDProfile {
+	var profiles []frr.BFDProfile
+	add := func(session *v1alpha1.HostSession, kind v1alpha1.HostSessionOwnerKind, name string) {
+		if session == nil || session.BFD == nil || ptr.AllPtrFieldsNil(session.BFD) {
+			return
+		}
+		profiles = append(profiles, bfdProfileFromSettings(bfdProfileNameForHostSession(kind, name), session.BFD))
+	}
+	for _, vni := range l3vnis {
+		add(vni.Spec.HostSession, v1alpha1.HostSessionOwnerKindL3VNI, vni.Name)
+	}
+	for _, vpn := range l3vpns {
+		add(vpn.Spec.HostSession, v1alpha1.HostSessionOwnerKindL3VPN, vpn.Name)
+	}
+	for _, passthrough := range l3Passthroughs {
+		add(&passthrough.Spec.HostSession, v1alpha1.HostSessionOwnerKindL3Passthrough, passthrough.Name)
+	}
+	return profiles
+}
+
+func bfdProfileNameForHostSession(kind v1alpha1.HostSessionOwnerKind, name string) string {
+	return fmt.Sprintf("%s-%s", strings.ToLower(string(kind)), name)
+}
+
+func routeMapsForPolicies(namePrefix string, nlp networklayerprotocol.NLP,
+	importPolicy, exportPolicy *v1alpha1.RoutePolicy, localASN int64) []frr.RouteMap {
+	var res []frr.RouteMap
+	if importPolicy != nil {
+		res = append(res, routePolicyToFRR(*importPolicy, namePrefix, nlp, frr.RouteMapIn, localASN))
+	}
+	if exportPolicy != nil {
+		res = append(res, routePolicyToFRR(*exportPolicy, namePrefix, nlp, frr.RouteMapOut, localASN))
+	}
+	return res
+}
+
+// defaultActionSeq is the sequence of the route map entry implementing the
+// default action of a policy, evaluated after all the rules.
+const defaultActionSeq = 65535
+
+// routePolicyToFRR converts a route policy to a route map for the given
+// address family. Each rule becomes an entry, in the same order. Prefix
+// matches only consider the prefixes of the family of the address family,
+// and a rule matching prefixes none of which belongs to the family is
+// skipped.
+func routePolicyToFRR(policy v1alpha1.RoutePolicy, namePrefix string, nlp networklayerprotocol.NLP,
+	direction frr.RouteMapDirection, localASN int64) frr.RouteMap {
+	res := frr.RouteMap{
+		Name:      fmt.Sprintf("%s-%s-%s-%s", namePrefix, nlp.AFI, nlp.SAFI, direction),
+		AFI:       nlp.AFI,
+		SAFI:      nlp.SAFI,
+		Direction: direction,
+	}
+	for i, rule := range policy.Rules {
+		seq := (i + 1) * 10
+		entry := frr.RouteMapEntry{
+			Seq:    seq,
+			Action: routeMapAction(rule.Action),
+		}
+		listName := fmt.Sprintf("%s-%d", res.Name, seq)
+		if rule.Match != nil {
+			if len(rule.Match.Prefixes) > 0 {
+				entry.PrefixList = prefixListToFRR(listName, rule.Match.Prefixes, nlp.AFI)
+				if entry.PrefixList == nil {
+					continue
+				}
+			}
+			entry.CommunityList = communityListToFRR(listName+"-c", rule.Match.Communities)
+			entry.LargeCommunityList = communityListToFRR(listName+"-lc", rule.Match.LargeCommunities)
+		}
+		if rule.Set != nil {
+			entry.SetLocalPreference = rule.Set.LocalPreference
+			entry.SetCommunities = communitiesToStrings(rule.Set.Communities)
+			entry.SetLargeCommunities = communitiesToStrings(rule.Set.LargeCommunities)
+			entry.SetASPathPrepend = asPathPrepend(rule.Set.ASPathPrepend, localASN)
+		}
+		res.Entries = append(res.Entries, entry)
+	}
+	res.Entries = append(res.Entries, frr.RouteMapEntry{
+		Seq:    defaultActionSeq,
+		Action: routeMapAction(ptr.Deref(policy.DefaultAction, v1alpha1.RoutePolicyActionDeny)),
+	})
+	return res
+}
+
+func routeMapAction(action v1alpha1.RoutePolicyAction) string {
+	if action == v1alpha1.RoutePolicyActionPermit {
+		return "permit"
+	}
+	return "deny"
+}
+
+// prefixListToFRR returns the prefix list matching the prefixes of the given
+// family, or nil if none of the prefixes belongs to it.
+func prefixListToFRR(name string, prefixes []v1alpha1.PrefixMatch, afi networklayerprotocol.AFI) *frr.PrefixList {
+	res := &frr.PrefixList{
+		Name: name,
+		IPv6: afi == networklayerprotocol.IPv6,
+	}
+	for _, p := range prefixes {
+		family := ipfamily.ForCIDRString(p.Prefix)
+		if (afi == networklayerprotocol.IPv4 && family != ipfamily.IPv4) ||
+			(afi == networklayerprotocol.IPv6 && family != ipfamily.IPv6) ||
+			afi == networklayerprotocol.L2VPN {
+			continue
+		}
+		// FRR shows the prefixes in their canonical form, rendering them
+		// the same way avoids spurious differences on reload.
+		prefix := p.Prefix
+		if _, ipNet, err := net.ParseCIDR(p.Prefix); err == nil {
+			prefix = ipNet.String()
+		}
+		res.Entries = append(res.Entries, frr.PrefixListEntry{
+			Seq:    (len(res.Entries) + 1) * 5,
+			Prefix: prefix,
+			GE:     p.GE,
+			LE:     p.LE,
+		})
+	}
+	if len(res.Entries) == 0 {
+		return nil
+	}
+	return res
+}
+
+// allowedPrefixListToFRR returns the prefix list restricting the routes
+// of the given family the host may advertise, or nil if the host session
+// has no allowed prefixes. The prefix list has no entries, and so rejects
+// all the routes, when none of the allowed prefixes belongs to the family.
+func allowedPrefixListToFRR(session v1alpha1.HostSession, namePrefix string, afi networklayerprotocol.AFI) *frr.PrefixList {
+	if len(session.AllowedPrefixes) == 0 {
+		return nil
+	}
+	name := fmt.Sprintf("%s-allowed-%s", namePrefix, afi)
+	if res := prefixListToFRR(name, session.AllowedPrefixes, afi); res != nil {
+		return res
+	}
+	return &frr.PrefixList{
+		Name: name,
+		IPv6: afi == networklayerprotocol.IPv6,
+	}
+}
+
+func communityListToFRR[T ~string](name string, communities []T) *frr.CommunityList {
+	if len(communities) == 0 {
+		return nil
+	}
+	res := &frr.CommunityList{Name: name}
+	for i, c := range communities {
+		res.Entries = append(res.Entries, frr.CommunityListEntry{
+			Seq:       (i + 1) * 5,
+			Community: string(c),
+		})
+	}
+	return res
+}
+
+func communitiesToStrings[T ~string](communities []T) []string {
+	if len(communities) == 0 {
+		return nil
+	}
+	res := make([]string, 0, len(communities))
+	for _, c := range communities {
+		res = append(res, string(c))
+	}
+	return res
+}
+
+func asPathPrepend(prepend *v1alpha1.ASPathPrepend, localASN int64) string {
+	if prepend == nil {
+		return ""
+	}
+	asn := strconv.FormatInt(ptr.Deref(prepend.ASN, localASN), 10)
+	return strings.Join(slices.Repeat([]string{asn}, int(prepend.Times)), " ")
+}
+
 // addressFamilyProperty returns the property matching propertyType
 // from the list, or nil when absent.
 func addressFamilyProperty(properties []v1alpha1.AddressFamilyProperty,
@@ -1042,17 +1702,19 @@ func bfdProfileForNeighbor(n v1alpha1.Neighbor) *frr.BFDProfile {
 		return nil
 	}
 
-	profileName := bfdProfileNameForNeighbor(n)
-	bfdProfile := &frr.BFDProfile{
-		Name:             profileName,
-		ReceiveInterval:  n.BFD.ReceiveInterval,
-		TransmitInterval: n.BFD.TransmitInterval,
-		DetectMultiplier: n.BFD.DetectMultiplier,
-		PassiveMode:      ptr.Deref(n.BFD.SessionMode, v1alpha1.BFDSessionModeActive) == v1alpha1.BFDSessionModePassive,
-		MinimumTTL:       n.BFD.MinimumTTL,
-	}
+	bfdProfile := bfdProfileFromSettings(bfdProfileNameForNeighbor(n), n.BFD)
+	return &bfdProfile
+}
 
-	return bfdProfile
+func bfdProfileFromSettings(name string, bfd *v1alpha1.BFDSettings) frr.BFDProfile {
+	return frr.BFDProfile{
+		Name:             name,
+		ReceiveInterval:  bfd.ReceiveInterval,
+		TransmitInterval: bfd.TransmitInterval,
+		DetectMultiplier: bfd.DetectMultiplier,
+		PassiveMode:      ptr.Deref(bfd.SessionMode, v1alpha1.BFDSessionModeActive) == v1alpha1.BFDSessionModePassive,
+		MinimumTTL:       bfd.MinimumTTL,
+	}
 }
 
 // ebgpMultiHopForNeighbor returns whether the ebgpMultiHop property is set on
@@ -1095,16 +1757,6 @@ func neighborName(asn frr.PeerASN, id string) string {
 	return fmt.Sprintf("%s@%s", asn, id)
 }
 
-func routerIDFromUnderlay(underlay v1alpha1.Underlay, nodeIndex int) (string, error) {
-	// RouterIDCIDR defaults are applied via CRD schema, so it should always be set
-	routerIDCidr := ptr.Deref(underlay.Spec.RouterIDCIDR, "10.0.0.0/24")
-	routerID, err := ipam.RouterID(routerIDCidr, nodeIndex)
-	if err != nil {
-		return "", fmt.Errorf("failed to get router id, cidr %s, nodeIndex %d: %w", routerIDCidr, nodeIndex, err)
-	}
-	return routerID, nil
-}
-
 func vrfsWithL2Gateways(l2vnis []v1alpha1.L2VNI, vrfMap map[string]string) (map[string][]string, error) {
 	res := make(map[string][]string)
 	for _, l2vni := range l2vnis {
@@ -1124,6 +1776,47 @@ func vrfsWithL2Gateways(l2vnis []v1alpha1.L2VNI, vrfMap map[string]string) (map[
 	return res, nil
 }
 
+// ethernetSegmentsToFRR returns the EVPN Ethernet Segments of the L2VNIs,
+// configured on the router side of the host facing veth, which is the bridge
+// port the multihomed workloads are reached through.
+func ethernetSegmentsToFRR(l2vnis []v1alpha1.L2VNI) []frr.EthernetSegment {
+	var res []frr.EthernetSegment
+	for _, l2vni := range l2vnis {
+		es := l2vni.Spec.EthernetSegment
+		if es == nil {
+			continue
+		}
+		frrES := frr.EthernetSegment{
+			Interface:    hostnetwork.VethNamesFromVNI(l2vni.Spec.VNI).NamespaceSide,
+			ESI:          strings.ToLower(ptr.Deref(es.ESI, "")),
+			SystemMAC:    strings.ToLower(ptr.Deref(es.SystemMAC, "")),
+			DFPreference: ptr.Deref(es.DFPreference, 0),
+		}
+		if es.LocalDiscriminator != nil {
+			frrES.LocalDiscriminator = uint32(*es.LocalDiscriminator)
+		}
+		res = append(res, frrES)
+	}
+	return res
+}
+
+// l2VNIsToFRR returns the L2VNIs advertising their distributed gateway,
+// the only ones with EVPN settings of their own.
+func l2VNIsToFRR(l2vnis []v1alpha1.L2VNI) []frr.L2VNIConfig {
+	var res []frr.L2VNIConfig
+	for _, l2vni := range l2vnis {
+		if len(l2vni.Spec.GatewayAdvertisements) == 0 {
+			continue
+		}
+		res = append(res, frr.L2VNIConfig{
+			VNI:                l2vni.Spec.VNI,
+			AdvertiseDefaultGW: slices.Contains(l2vni.Spec.GatewayAdvertisements, v1alpha1.GatewayAdvertisementDefaultGateway),
+			AdvertiseSVIIP:     slices.Contains(l2vni.Spec.GatewayAdvertisements, v1alpha1.GatewayAdvertisementSVIIP),
+		})
+	}
+	return res
+}
+
 func validateNeighbor(n v1alpha1.Neighbor) error {
 	intf := ptr.Deref(n.Interface, "")
 	addr := ptr.Deref(n.Address, "")
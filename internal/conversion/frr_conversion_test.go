@@ -6,6 +6,7 @@ import (
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	"github.com/openperouter/openperouter/api/v1alpha1"
@@ -22,6 +23,7 @@ func TestAPItoFRR(t *testing.T) {
 		l2vnis        []v1alpha1.L2VNI
 		vpns          []v1alpha1.L3VPN
 		l3Passthrough []v1alpha1.L3Passthrough
+		secrets       []corev1.Secret
 		logLevel      string
 		want          frr.Config
 		wantErr       bool
@@ -55,9 +57,9 @@ func TestAPItoFRR(t *testing.T) {
 			want: frr.Config{
 				Underlay: frr.UnderlayConfig{
 					MyASN: 65000,
-					TunnelEndpoint: &frr.TunnelEndpoint{
+					TunnelEndpoints: []frr.TunnelEndpoint{{
 						IPv4CIDR: "192.168.1.0/32",
-					},
+					}},
 					RouterID: "10.0.0.1",
 					Neighbors: []frr.NeighborConfig{
 						{
@@ -98,9 +100,9 @@ func TestAPItoFRR(t *testing.T) {
 			want: frr.Config{
 				Underlay: frr.UnderlayConfig{
 					MyASN: 65000,
-					TunnelEndpoint: &frr.TunnelEndpoint{
+					TunnelEndpoints: []frr.TunnelEndpoint{{
 						IPv4CIDR: "192.168.1.0/32",
-					},
+					}},
 					RouterID: "10.0.0.1",
 					Neighbors: []frr.NeighborConfig{
 						{
@@ -146,9 +148,9 @@ func TestAPItoFRR(t *testing.T) {
 			want: frr.Config{
 				Underlay: frr.UnderlayConfig{
 					MyASN: 65000,
-					TunnelEndpoint: &frr.TunnelEndpoint{
+					TunnelEndpoints: []frr.TunnelEndpoint{{
 						IPv4CIDR: "192.168.1.0/32",
-					},
+					}},
 					RouterID: "10.0.0.1",
 					Neighbors: []frr.NeighborConfig{
 						{
@@ -194,9 +196,9 @@ func TestAPItoFRR(t *testing.T) {
 			want: frr.Config{
 				Underlay: frr.UnderlayConfig{
 					MyASN: 65000,
-					TunnelEndpoint: &frr.TunnelEndpoint{
+					TunnelEndpoints: []frr.TunnelEndpoint{{
 						IPv4CIDR: "192.168.1.0/32",
-					},
+					}},
 					RouterID: "10.0.0.1",
 					Neighbors: []frr.NeighborConfig{
 						{
@@ -252,9 +254,9 @@ func TestAPItoFRR(t *testing.T) {
 			want: frr.Config{
 				Underlay: frr.UnderlayConfig{
 					MyASN: 65000,
-					TunnelEndpoint: &frr.TunnelEndpoint{
+					TunnelEndpoints: []frr.TunnelEndpoint{{
 						IPv4CIDR: "192.168.1.0/32",
-					},
+					}},
 					RouterID: "10.0.0.1",
 					Neighbors: []frr.NeighborConfig{
 						{
@@ -331,9 +333,9 @@ func TestAPItoFRR(t *testing.T) {
 			want: frr.Config{
 				Underlay: frr.UnderlayConfig{
 					MyASN: 65000,
-					TunnelEndpoint: &frr.TunnelEndpoint{
+					TunnelEndpoints: []frr.TunnelEndpoint{{
 						IPv4CIDR: "192.168.1.0/32",
-					},
+					}},
 					RouterID: "10.0.0.1",
 					Neighbors: []frr.NeighborConfig{
 						{
@@ -411,9 +413,9 @@ func TestAPItoFRR(t *testing.T) {
 			want: frr.Config{
 				Underlay: frr.UnderlayConfig{
 					MyASN: 65000,
-					TunnelEndpoint: &frr.TunnelEndpoint{
+					TunnelEndpoints: []frr.TunnelEndpoint{{
 						IPv4CIDR: "192.168.1.0/32",
-					},
+					}},
 					RouterID: "10.0.0.1",
 					Neighbors: []frr.NeighborConfig{
 						{
@@ -499,9 +501,9 @@ func TestAPItoFRR(t *testing.T) {
 			want: frr.Config{
 				Underlay: frr.UnderlayConfig{
 					MyASN: 65000,
-					TunnelEndpoint: &frr.TunnelEndpoint{
+					TunnelEndpoints: []frr.TunnelEndpoint{{
 						IPv4CIDR: "192.168.1.0/32",
-					},
+					}},
 					RouterID: "10.0.0.1",
 					Neighbors: []frr.NeighborConfig{
 						{
@@ -557,9 +559,9 @@ func TestAPItoFRR(t *testing.T) {
 			want: frr.Config{
 				Underlay: frr.UnderlayConfig{
 					MyASN: 65000,
-					TunnelEndpoint: &frr.TunnelEndpoint{
+					TunnelEndpoints: []frr.TunnelEndpoint{{
 						IPv4CIDR: "192.168.1.0/32",
-					},
+					}},
 					RouterID: "10.0.0.1",
 					Neighbors: []frr.NeighborConfig{
 						{
@@ -610,9 +612,9 @@ func TestAPItoFRR(t *testing.T) {
 			want: frr.Config{
 				Underlay: frr.UnderlayConfig{
 					MyASN: 65000,
-					TunnelEndpoint: &frr.TunnelEndpoint{
+					TunnelEndpoints: []frr.TunnelEndpoint{{
 						IPv4CIDR: "192.168.1.0/32",
-					},
+					}},
 					RouterID: "10.0.0.1",
 					Neighbors: []frr.NeighborConfig{
 						{
@@ -682,9 +684,9 @@ func TestAPItoFRR(t *testing.T) {
 			want: frr.Config{
 				Underlay: frr.UnderlayConfig{
 					MyASN: 65000,
-					TunnelEndpoint: &frr.TunnelEndpoint{
+					TunnelEndpoints: []frr.TunnelEndpoint{{
 						IPv4CIDR: "192.168.1.0/32",
-					},
+					}},
 					RouterID: "10.0.0.1",
 					Neighbors: []frr.NeighborConfig{
 						{
@@ -754,9 +756,9 @@ func TestAPItoFRR(t *testing.T) {
 			want: frr.Config{
 				Underlay: frr.UnderlayConfig{
 					MyASN: 65000,
-					TunnelEndpoint: &frr.TunnelEndpoint{
+					TunnelEndpoints: []frr.TunnelEndpoint{{
 						IPv4CIDR: "192.168.1.0/32",
-					},
+					}},
 					RouterID: "10.0.0.1",
 					Neighbors: []frr.NeighborConfig{
 						{
@@ -824,9 +826,9 @@ func TestAPItoFRR(t *testing.T) {
 			want: frr.Config{
 				Underlay: frr.UnderlayConfig{
 					MyASN: 65000,
-					TunnelEndpoint: &frr.TunnelEndpoint{
+					TunnelEndpoints: []frr.TunnelEndpoint{{
 						IPv4CIDR: "192.168.1.0/32",
-					},
+					}},
 					RouterID: "10.0.0.1",
 					Neighbors: []frr.NeighborConfig{
 						{
@@ -902,9 +904,9 @@ func TestAPItoFRR(t *testing.T) {
 			want: frr.Config{
 				Underlay: frr.UnderlayConfig{
 					MyASN: 65000,
-					TunnelEndpoint: &frr.TunnelEndpoint{
+					TunnelEndpoints: []frr.TunnelEndpoint{{
 						IPv4CIDR: "192.168.1.0/32",
-					},
+					}},
 					RouterID: "10.0.0.1",
 					Neighbors: []frr.NeighborConfig{
 						{
@@ -980,9 +982,9 @@ func TestAPItoFRR(t *testing.T) {
 			want: frr.Config{
 				Underlay: frr.UnderlayConfig{
 					MyASN: 65000,
-					TunnelEndpoint: &frr.TunnelEndpoint{
+					TunnelEndpoints: []frr.TunnelEndpoint{{
 						IPv4CIDR: "192.168.1.0/32",
-					},
+					}},
 					RouterID: "10.0.0.1",
 					Neighbors: []frr.NeighborConfig{
 						{
@@ -1092,9 +1094,9 @@ func TestAPItoFRR(t *testing.T) {
 			want: frr.Config{
 				Underlay: frr.UnderlayConfig{
 					MyASN: 65000,
-					TunnelEndpoint: &frr.TunnelEndpoint{
+					TunnelEndpoints: []frr.TunnelEndpoint{{
 						IPv4CIDR: "192.168.1.0/32",
-					},
+					}},
 					RouterID: "10.0.0.1",
 					Neighbors: []frr.NeighborConfig{
 						{
@@ -1110,7 +1112,7 @@ func TestAPItoFRR(t *testing.T) {
 						},
 					},
 				},
-				Passthrough: &frr.PassthroughConfig{
+				Passthroughs: []frr.PassthroughConfig{{
 					LocalNeighborV4: &frr.NeighborConfig{
 						ASN:         mustNewPeerASNFromNumber(65001),
 						Addr:        "192.168.2.2",
@@ -1125,6 +1127,99 @@ func TestAPItoFRR(t *testing.T) {
 					},
 					ToAdvertiseIPv4: []string{"192.168.2.2/32"},
 					ToAdvertiseIPv6: []string{"2001:db8::2/128"},
+				}},
+				VNIs:        []frr.L3VNIConfig{},
+				VPNs:        []frr.L3VPNConfig{},
+				BFDProfiles: []frr.BFDProfile{},
+				Loglevel:    "debug",
+			},
+			wantErr: false,
+		},
+		{
+			name:      "multiple L3 passthroughs",
+			nodeIndex: 0,
+			underlays: []v1alpha1.Underlay{
+				{
+					Spec: v1alpha1.UnderlaySpec{
+						ASN: 65000,
+						TunnelEndpoint: &v1alpha1.TunnelEndpointConfig{
+							CIDRs: []string{"192.168.1.0/24"},
+						},
+						RouterIDCIDR: new("10.0.0.0/24"),
+						Neighbors:    []v1alpha1.Neighbor{{Address: new("192.168.1.1"), ASN: new(int64(65001))}},
+					},
+				},
+			},
+			vnis: []v1alpha1.L3VNI{},
+			l3Passthrough: []v1alpha1.L3Passthrough{
+				{
+					ObjectMeta: metav1.ObjectMeta{Name: "infra"},
+					Spec: v1alpha1.L3PassthroughSpec{
+						HostSession: v1alpha1.HostSession{
+							HostASN: new(int64(65001)),
+							ASN:     65000,
+							LocalCIDR: v1alpha1.LocalCIDRConfig{
+								IPv4: new("192.168.2.0/24"),
+							},
+						},
+					},
+				},
+				{
+					ObjectMeta: metav1.ObjectMeta{Name: "storage"},
+					Spec: v1alpha1.L3PassthroughSpec{
+						HostSession: v1alpha1.HostSession{
+							HostASN: new(int64(65002)),
+							ASN:     65000,
+							LocalCIDR: v1alpha1.LocalCIDRConfig{
+								IPv6: new("2001:db8::/64"),
+							},
+						},
+					},
+				},
+			},
+			logLevel: "debug",
+			want: frr.Config{
+				Underlay: frr.UnderlayConfig{
+					MyASN: 65000,
+					TunnelEndpoints: []frr.TunnelEndpoint{{
+						IPv4CIDR: "192.168.1.0/32",
+					}},
+					RouterID: "10.0.0.1",
+					Neighbors: []frr.NeighborConfig{
+						{
+							Name: "65001@192.168.1.1",
+							ASN:  mustNewPeerASNFromNumber(65001),
+							Addr: "192.168.1.1",
+							ID:   "192.168.1.1",
+							NetworkLayerProtocols: []networklayerprotocol.NLP{
+								{AFI: networklayerprotocol.IPv4, SAFI: networklayerprotocol.Unicast},
+								{AFI: networklayerprotocol.IPv6, SAFI: networklayerprotocol.Unicast},
+							},
+							EBGPMultiHop: false,
+						},
+					},
+				},
+				Passthroughs: []frr.PassthroughConfig{
+					{
+						LocalNeighborV4: &frr.NeighborConfig{
+							ASN:         mustNewPeerASNFromNumber(65001),
+							Addr:        "192.168.2.2",
+							ID:          "192.168.2.2",
+							ConnectTime: new(int64(5)),
+						},
+						ToAdvertiseIPv4: []string{"192.168.2.2/32"},
+						ToAdvertiseIPv6: []string{},
+					},
+					{
+						LocalNeighborV6: &frr.NeighborConfig{
+							ASN:         mustNewPeerASNFromNumber(65002),
+							Addr:        "2001:db8::2",
+							ID:          "2001:db8::2",
+							ConnectTime: new(int64(5)),
+						},
+						ToAdvertiseIPv4: []string{},
+						ToAdvertiseIPv6: []string{"2001:db8::2/128"},
+					},
 				},
 				VNIs:        []frr.L3VNIConfig{},
 				VPNs:        []frr.L3VPNConfig{},
@@ -1166,9 +1261,9 @@ func TestAPItoFRR(t *testing.T) {
 			want: frr.Config{
 				Underlay: frr.UnderlayConfig{
 					MyASN: 65000,
-					TunnelEndpoint: &frr.TunnelEndpoint{
+					TunnelEndpoints: []frr.TunnelEndpoint{{
 						IPv4CIDR: "192.168.1.0/32",
-					},
+					}},
 					RouterID: "10.0.0.1",
 					Neighbors: []frr.NeighborConfig{
 						{
@@ -1183,7 +1278,7 @@ func TestAPItoFRR(t *testing.T) {
 						},
 					},
 				},
-				Passthrough: &frr.PassthroughConfig{
+				Passthroughs: []frr.PassthroughConfig{{
 					LocalNeighborV4: &frr.NeighborConfig{
 						ASN:         mustNewPeerASNFromNumber(65001),
 						Addr:        "192.168.2.2",
@@ -1192,7 +1287,7 @@ func TestAPItoFRR(t *testing.T) {
 					},
 					ToAdvertiseIPv4: []string{"192.168.2.2/32"},
 					ToAdvertiseIPv6: []string{},
-				},
+				}},
 				VNIs:        []frr.L3VNIConfig{},
 				VPNs:        []frr.L3VPNConfig{},
 				BFDProfiles: []frr.BFDProfile{},
@@ -1243,7 +1338,7 @@ func TestAPItoFRR(t *testing.T) {
 						},
 					},
 				},
-				Passthrough: &frr.PassthroughConfig{
+				Passthroughs: []frr.PassthroughConfig{{
 					LocalNeighborV6: &frr.NeighborConfig{
 						ASN:         mustNewPeerASNFromNumber(65001),
 						Addr:        "2001:db8::2",
@@ -1252,7 +1347,7 @@ func TestAPItoFRR(t *testing.T) {
 					},
 					ToAdvertiseIPv4: []string{},
 					ToAdvertiseIPv6: []string{"2001:db8::2/128"},
-				},
+				}},
 				VNIs:        []frr.L3VNIConfig{},
 				VPNs:        []frr.L3VPNConfig{},
 				BFDProfiles: []frr.BFDProfile{},
@@ -1293,9 +1388,9 @@ func TestAPItoFRR(t *testing.T) {
 			want: frr.Config{
 				Underlay: frr.UnderlayConfig{
 					MyASN: 65000,
-					TunnelEndpoint: &frr.TunnelEndpoint{
+					TunnelEndpoints: []frr.TunnelEndpoint{{
 						IPv4CIDR: "192.168.1.0/32",
-					},
+					}},
 					RouterID: "10.0.0.1",
 					Neighbors: []frr.NeighborConfig{
 						{
@@ -1309,7 +1404,7 @@ func TestAPItoFRR(t *testing.T) {
 						},
 					},
 				},
-				Passthrough: &frr.PassthroughConfig{
+				Passthroughs: []frr.PassthroughConfig{{
 					LocalNeighborV4: &frr.NeighborConfig{
 						ASN:         mustNewPeerASNFromNumber(65001),
 						Addr:        "192.168.2.2",
@@ -1318,7 +1413,7 @@ func TestAPItoFRR(t *testing.T) {
 					},
 					ToAdvertiseIPv4: []string{"192.168.2.2/32"},
 					ToAdvertiseIPv6: []string{},
-				},
+				}},
 				VNIs:        []frr.L3VNIConfig{},
 				VPNs:        []frr.L3VPNConfig{},
 				BFDProfiles: []frr.BFDProfile{},
@@ -1371,7 +1466,7 @@ func TestAPItoFRR(t *testing.T) {
 						},
 					},
 				},
-				Passthrough: &frr.PassthroughConfig{
+				Passthroughs: []frr.PassthroughConfig{{
 					LocalNeighborV6: &frr.NeighborConfig{
 						ASN:         mustNewPeerASNFromNumber(65001),
 						Addr:        "2001:db8::2",
@@ -1380,7 +1475,7 @@ func TestAPItoFRR(t *testing.T) {
 					},
 					ToAdvertiseIPv4: []string{},
 					ToAdvertiseIPv6: []string{"2001:db8::2/128"},
-				},
+				}},
 				VNIs:        []frr.L3VNIConfig{},
 				VPNs:        []frr.L3VPNConfig{},
 				BFDProfiles: []frr.BFDProfile{},
@@ -1440,7 +1535,7 @@ func TestAPItoFRR(t *testing.T) {
 						},
 					},
 				},
-				Passthrough: &frr.PassthroughConfig{
+				Passthroughs: []frr.PassthroughConfig{{
 					LocalNeighborV4: &frr.NeighborConfig{
 						ASN:         mustNewPeerASNFromNumber(65001),
 						Addr:        "192.168.2.2",
@@ -1449,7 +1544,7 @@ func TestAPItoFRR(t *testing.T) {
 					},
 					ToAdvertiseIPv4: []string{"192.168.2.2/32"},
 					ToAdvertiseIPv6: []string{},
-				},
+				}},
 				VNIs:        []frr.L3VNIConfig{},
 				VPNs:        []frr.L3VPNConfig{},
 				BFDProfiles: []frr.BFDProfile{},
@@ -1514,9 +1609,9 @@ func TestAPItoFRR(t *testing.T) {
 			want: frr.Config{
 				Underlay: frr.UnderlayConfig{
 					MyASN: 65000,
-					TunnelEndpoint: &frr.TunnelEndpoint{
+					TunnelEndpoints: []frr.TunnelEndpoint{{
 						IPv4CIDR: "192.168.1.0/32",
-					},
+					}},
 					RouterID: "10.0.0.1",
 					Neighbors: []frr.NeighborConfig{
 						{
@@ -1532,7 +1627,7 @@ func TestAPItoFRR(t *testing.T) {
 						},
 					},
 				},
-				Passthrough: &frr.PassthroughConfig{
+				Passthroughs: []frr.PassthroughConfig{{
 					LocalNeighborV4: &frr.NeighborConfig{
 						ASN:         mustNewPeerASNFromType("External"),
 						Addr:        "192.168.2.2",
@@ -1547,7 +1642,7 @@ func TestAPItoFRR(t *testing.T) {
 					},
 					ToAdvertiseIPv4: []string{"192.168.2.2/32"},
 					ToAdvertiseIPv6: []string{"2001:db8::2/128"},
-				},
+				}},
 				VNIs:        []frr.L3VNIConfig{},
 				VPNs:        []frr.L3VPNConfig{},
 				BFDProfiles: []frr.BFDProfile{},
@@ -1590,9 +1685,9 @@ func TestAPItoFRR(t *testing.T) {
 			want: frr.Config{
 				Underlay: frr.UnderlayConfig{
 					MyASN: 65000,
-					TunnelEndpoint: &frr.TunnelEndpoint{
+					TunnelEndpoints: []frr.TunnelEndpoint{{
 						IPv4CIDR: "192.168.1.0/32",
-					},
+					}},
 					RouterID: "10.0.0.1",
 					Neighbors: []frr.NeighborConfig{
 						{
@@ -1608,7 +1703,7 @@ func TestAPItoFRR(t *testing.T) {
 						},
 					},
 				},
-				Passthrough: &frr.PassthroughConfig{
+				Passthroughs: []frr.PassthroughConfig{{
 					LocalNeighborV4: &frr.NeighborConfig{
 						ASN:         mustNewPeerASNFromType("Internal"),
 						Addr:        "192.168.2.2",
@@ -1623,7 +1718,7 @@ func TestAPItoFRR(t *testing.T) {
 					},
 					ToAdvertiseIPv4: []string{"192.168.2.2/32"},
 					ToAdvertiseIPv6: []string{"2001:db8::2/128"},
-				},
+				}},
 				VNIs:        []frr.L3VNIConfig{},
 				VPNs:        []frr.L3VPNConfig{},
 				BFDProfiles: []frr.BFDProfile{},
@@ -1631,6 +1726,159 @@ func TestAPItoFRR(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name:      "multiple underlays",
+			nodeIndex: 0,
+			underlays: []v1alpha1.Underlay{
+				{
+					ObjectMeta: metav1.ObjectMeta{Name: "plane-a"},
+					Spec: v1alpha1.UnderlaySpec{
+						ASN: 65000,
+						TunnelEndpoint: &v1alpha1.TunnelEndpointConfig{
+							CIDRs: []string{"100.64.0.0/24"},
+						},
+						RouterIDCIDR: new("10.0.0.0/24"),
+						Neighbors:    []v1alpha1.Neighbor{{Address: new("192.168.1.1"), ASN: new(int64(65001))}},
+					},
+				},
+				{
+					ObjectMeta: metav1.ObjectMeta{Name: "plane-b"},
+					Spec: v1alpha1.UnderlaySpec{
+						ASN: 65000,
+						TunnelEndpoint: &v1alpha1.TunnelEndpointConfig{
+							CIDRs: []string{"100.65.0.0/24"},
+						},
+						RouterIDCIDR: new("10.0.0.0/24"),
+						Neighbors:    []v1alpha1.Neighbor{{Address: new("192.168.2.1"), ASN: new(int64(65002))}},
+					},
+				},
+			},
+			vnis: []v1alpha1.L3VNI{
+				{
+					ObjectMeta: metav1.ObjectMeta{Name: "vni1"},
+					Spec: v1alpha1.L3VNISpec{
+						VRF:      "red",
+						VNI:      200,
+						Underlay: new("plane-b"),
+					},
+				},
+			},
+			l2vnis: []v1alpha1.L2VNI{
+				{
+					ObjectMeta: metav1.ObjectMeta{Name: "l2vni1"},
+					Spec: v1alpha1.L2VNISpec{
+						VNI:      100,
+						Underlay: new("plane-a"),
+					},
+				},
+			},
+			l3Passthrough: []v1alpha1.L3Passthrough{},
+			logLevel:      "debug",
+			want: frr.Config{
+				Underlay: frr.UnderlayConfig{
+					MyASN: 65000,
+					TunnelEndpoints: []frr.TunnelEndpoint{
+						{IPv4CIDR: "100.64.0.0/32"},
+						{IPv4CIDR: "100.65.0.0/32"},
+					},
+					RouterID: "10.0.0.1",
+					Neighbors: []frr.NeighborConfig{
+						{
+							Name: "65001@192.168.1.1",
+							ASN:  mustNewPeerASNFromNumber(65001),
+							Addr: "192.168.1.1",
+							ID:   "192.168.1.1",
+							NetworkLayerProtocols: []networklayerprotocol.NLP{
+								{AFI: networklayerprotocol.IPv4, SAFI: networklayerprotocol.Unicast},
+								{AFI: networklayerprotocol.L2VPN, SAFI: networklayerprotocol.EVPN},
+							},
+							UnderlayFilters: []frr.RouteMap{
+								{
+									Name:      "192.168.1.1-ipv4-unicast-other-underlays",
+									AFI:       networklayerprotocol.IPv4,
+									SAFI:      networklayerprotocol.Unicast,
+									Direction: frr.RouteMapOut,
+									Entries: []frr.RouteMapEntry{
+										{
+											Seq:    10,
+											Action: "deny",
+											PrefixList: &frr.PrefixList{
+												Name:    "192.168.1.1-ipv4-unicast-other-underlays",
+												Entries: []frr.PrefixListEntry{{Seq: 5, Prefix: "100.65.0.0/24", LE: new(int32(32))}},
+											},
+										},
+										{Seq: 65535, Action: "permit"},
+									},
+								},
+								{
+									Name:      "192.168.1.1-l2vpn-evpn-other-underlays",
+									AFI:       networklayerprotocol.L2VPN,
+									SAFI:      networklayerprotocol.EVPN,
+									Direction: frr.RouteMapOut,
+									Entries: []frr.RouteMapEntry{
+										{Seq: 10, Action: "deny", MatchEVPNVNI: 200},
+										{Seq: 65535, Action: "permit"},
+									},
+								},
+							},
+						},
+						{
+							Name: "65002@192.168.2.1",
+							ASN:  mustNewPeerASNFromNumber(65002),
+							Addr: "192.168.2.1",
+							ID:   "192.168.2.1",
+							NetworkLayerProtocols: []networklayerprotocol.NLP{
+								{AFI: networklayerprotocol.IPv4, SAFI: networklayerprotocol.Unicast},
+								{AFI: networklayerprotocol.L2VPN, SAFI: networklayerprotocol.EVPN},
+							},
+							UnderlayFilters: []frr.RouteMap{
+								{
+									Name:      "192.168.2.1-ipv4-unicast-other-underlays",
+									AFI:       networklayerprotocol.IPv4,
+									SAFI:      networklayerprotocol.Unicast,
+									Direction: frr.RouteMapOut,
+									Entries: []frr.RouteMapEntry{
+										{
+											Seq:    10,
+											Action: "deny",
+											PrefixList: &frr.PrefixList{
+												Name:    "192.168.2.1-ipv4-unicast-other-underlays",
+												Entries: []frr.PrefixListEntry{{Seq: 5, Prefix: "100.64.0.0/24", LE: new(int32(32))}},
+											},
+										},
+										{Seq: 65535, Action: "permit"},
+									},
+								},
+								{
+									Name:      "192.168.2.1-l2vpn-evpn-other-underlays",
+									AFI:       networklayerprotocol.L2VPN,
+									SAFI:      networklayerprotocol.EVPN,
+									Direction: frr.RouteMapOut,
+									Entries: []frr.RouteMapEntry{
+										{Seq: 10, Action: "deny", MatchEVPNVNI: 100},
+										{Seq: 65535, Action: "permit"},
+									},
+								},
+							},
+						},
+					},
+				},
+				VNIs: []frr.L3VNIConfig{
+					{
+						ASN:       65000,
+						VNI:       200,
+						VRF:       "red",
+						RouterID:  "10.0.0.1",
+						ExportRTs: []string{},
+						ImportRTs: []string{},
+					},
+				},
+				VPNs:        []frr.L3VPNConfig{},
+				BFDProfiles: []frr.BFDProfile{},
+				Loglevel:    "debug",
+			},
+			wantErr: false,
+		},
 		{
 			name:      "l3vni with matching L2 gateway IPv4",
 			nodeIndex: 0,
@@ -2151,9 +2399,9 @@ func TestAPItoFRR(t *testing.T) {
 			want: frr.Config{
 				Underlay: frr.UnderlayConfig{
 					MyASN: 65000,
-					TunnelEndpoint: &frr.TunnelEndpoint{
+					TunnelEndpoints: []frr.TunnelEndpoint{{
 						IPv4CIDR: "192.168.1.0/32",
-					},
+					}},
 					RouterID: "10.0.0.1",
 					Neighbors: []frr.NeighborConfig{
 						{
@@ -2204,10 +2452,10 @@ func TestAPItoFRR(t *testing.T) {
 				Underlay: frr.UnderlayConfig{
 					MyASN:     65000,
 					Neighbors: []frr.NeighborConfig{},
-					TunnelEndpoint: &frr.TunnelEndpoint{
+					TunnelEndpoints: []frr.TunnelEndpoint{{
 						IPv4CIDR: "192.168.2.0/32",
 						IPv6CIDR: "2001:db8:192:168::/128",
-					},
+					}},
 					RouterID: "10.0.0.1",
 				},
 				VNIs:        []frr.L3VNIConfig{},
@@ -2241,9 +2489,9 @@ func TestAPItoFRR(t *testing.T) {
 			want: frr.Config{
 				Underlay: frr.UnderlayConfig{
 					Neighbors: []frr.NeighborConfig{},
-					TunnelEndpoint: &frr.TunnelEndpoint{
+					TunnelEndpoints: []frr.TunnelEndpoint{{
 						IPv6CIDR: "2001:db8:192:168::/128",
-					},
+					}},
 					RouterID: "10.0.0.1",
 				},
 				VNIs:        []frr.L3VNIConfig{},
@@ -2308,7 +2556,6 @@ func TestAPItoFRR(t *testing.T) {
 						},
 					},
 				},
-				Passthrough: nil,
 				VNIs:        []frr.L3VNIConfig{},
 				VPNs:        []frr.L3VPNConfig{},
 				BFDProfiles: []frr.BFDProfile{},
@@ -2387,7 +2634,6 @@ func TestAPItoFRR(t *testing.T) {
 						},
 					},
 				},
-				Passthrough: nil,
 				VNIs:        []frr.L3VNIConfig{},
 				VPNs:        []frr.L3VPNConfig{},
 				BFDProfiles: []frr.BFDProfile{},
@@ -2448,7 +2694,6 @@ func TestAPItoFRR(t *testing.T) {
 						},
 					},
 				},
-				Passthrough: nil,
 				VNIs:        []frr.L3VNIConfig{},
 				VPNs:        []frr.L3VPNConfig{},
 				BFDProfiles: []frr.BFDProfile{},
@@ -2457,7 +2702,7 @@ func TestAPItoFRR(t *testing.T) {
 			wantErr: false,
 		},
 		{
-			name:      "ISIS without interface configuration",
+			name:      "OSPF with defaults",
 			nodeIndex: 0,
 			underlays: []v1alpha1.Underlay{
 				{
@@ -2465,10 +2710,17 @@ func TestAPItoFRR(t *testing.T) {
 						ASN:          65000,
 						RouterIDCIDR: new("10.0.0.0/24"),
 						Neighbors:    []v1alpha1.Neighbor{{Address: new("192.168.1.1"), ASN: new(int64(65001))}},
-						ISIS: &v1alpha1.ISISConfig{
-							BaseNet: "49.0001.0002.0003.0004.00",
-							Level:   new(int32(2)),
+						Interfaces: []v1alpha1.UnderlayInterface{
+							{
+								Type:          v1alpha1.UnderlayInterfaceTypeNetworkDevice,
+								NetworkDevice: &v1alpha1.NetworkDevice{InterfaceName: "eth0"},
+							},
+							{
+								Type:          v1alpha1.UnderlayInterfaceTypeNetworkDevice,
+								NetworkDevice: &v1alpha1.NetworkDevice{InterfaceName: "eth10"},
+							},
 						},
+						OSPF: &v1alpha1.OSPFConfig{},
 					},
 				},
 			},
@@ -2477,12 +2729,14 @@ func TestAPItoFRR(t *testing.T) {
 			want: frr.Config{
 				Underlay: frr.UnderlayConfig{
 					MyASN: 65000,
-					ISIS: &frr.UnderlayISIS{
-						Name:  isisProcessName,
-						Net:   frr.MustParseISISNet("49.0001.0002.0003.0004.00"),
-						Level: 2,
-						Interfaces: []frr.ISISInterface{
-							{Name: "lo", IPv6: true, IsPassive: true},
+					OSPF: &frr.UnderlayOSPF{
+						RouterID: "10.0.0.1",
+						Area:     "0.0.0.0",
+						IPv4:     true,
+						Interfaces: []frr.OSPFInterface{
+							{Name: "eth0"},
+							{Name: "eth10"},
+							{Name: "lo", IsPassive: true},
 						},
 					},
 					RouterID: "10.0.0.1",
@@ -2499,7 +2753,6 @@ func TestAPItoFRR(t *testing.T) {
 						},
 					},
 				},
-				Passthrough: nil,
 				VNIs:        []frr.L3VNIConfig{},
 				VPNs:        []frr.L3VPNConfig{},
 				BFDProfiles: []frr.BFDProfile{},
@@ -2508,20 +2761,543 @@ func TestAPItoFRR(t *testing.T) {
 			wantErr: false,
 		},
 		{
-			name:      "ISIS invalid net",
-			nodeIndex: 0,
+			name:      "OSPF dual stack with interface overwrites",
+			nodeIndex: 1,
 			underlays: []v1alpha1.Underlay{
 				{
 					Spec: v1alpha1.UnderlaySpec{
 						ASN:          65000,
 						RouterIDCIDR: new("10.0.0.0/24"),
 						Neighbors:    []v1alpha1.Neighbor{{Address: new("192.168.1.1"), ASN: new(int64(65001))}},
-						ISIS: &v1alpha1.ISISConfig{
-							BaseNet: "49.0001.0002.0003.0004.000",
-							Level:   new(int32(1)),
-							Interfaces: []v1alpha1.ISISInterface{
-								{Name: "eth0", IPFamily: new(v1alpha1.IPFamilyDualStack)},
-								{Name: "eth1", IPFamily: new(v1alpha1.IPFamilyIPv6)},
+						Interfaces: []v1alpha1.UnderlayInterface{
+							{
+								Type:          v1alpha1.UnderlayInterfaceTypeNetworkDevice,
+								NetworkDevice: &v1alpha1.NetworkDevice{InterfaceName: "eth0"},
+							},
+							{
+								Type:          v1alpha1.UnderlayInterfaceTypeNetworkDevice,
+								NetworkDevice: &v1alpha1.NetworkDevice{InterfaceName: "eth10"},
+							},
+						},
+						OSPF: &v1alpha1.OSPFConfig{
+							Area:     new(v1alpha1.OSPFArea("10")),
+							IPFamily: new(v1alpha1.IPFamilyDualStack),
+							Interfaces: []v1alpha1.OSPFInterface{
+								{Name: "eth0", Features: []v1alpha1.OSPFInterfaceFeature{pointToPointInterface}},
+								{Name: "eth10", Features: []v1alpha1.OSPFInterfaceFeature{passiveInterface, pointToPointInterface}},
+								{Name: "lo"},
+							},
+						},
+					},
+				},
+			},
+			l3Passthrough: []v1alpha1.L3Passthrough{},
+			logLevel:      "debug",
+			want: frr.Config{
+				Underlay: frr.UnderlayConfig{
+					MyASN: 65000,
+					OSPF: &frr.UnderlayOSPF{
+						RouterID: "10.0.0.2",
+						Area:     "0.0.0.10",
+						IPv4:     true,
+						IPv6:     true,
+						Interfaces: []frr.OSPFInterface{
+							{Name: "eth0", IsPointToPoint: true},
+							{Name: "eth10", IsPassive: true, IsPointToPoint: true},
+							{Name: "lo"},
+						},
+					},
+					RouterID: "10.0.0.2",
+					Neighbors: []frr.NeighborConfig{
+						{
+							Name: "65001@192.168.1.1",
+							ASN:  mustNewPeerASNFromNumber(65001),
+							Addr: "192.168.1.1",
+							ID:   "192.168.1.1",
+							NetworkLayerProtocols: []networklayerprotocol.NLP{
+								{AFI: networklayerprotocol.IPv4, SAFI: networklayerprotocol.Unicast},
+							},
+							EBGPMultiHop: false,
+						},
+					},
+				},
+				VNIs:        []frr.L3VNIConfig{},
+				VPNs:        []frr.L3VPNConfig{},
+				BFDProfiles: []frr.BFDProfile{},
+				Loglevel:    "debug",
+			},
+			wantErr: false,
+		},
+		{
+			name:      "ISIS authentication, timers and BFD",
+			nodeIndex: 0,
+			underlays: []v1alpha1.Underlay{
+				{
+					Spec: v1alpha1.UnderlaySpec{
+						ASN:          65000,
+						RouterIDCIDR: new("10.0.0.0/24"),
+						Neighbors:    []v1alpha1.Neighbor{{Address: new("192.168.1.1"), ASN: new(int64(65001))}},
+						ISIS: &v1alpha1.ISISConfig{
+							BaseNet:              "49.0001.0002.0003.0004.00",
+							AreaAuthentication:   &v1alpha1.ISISAuthentication{PasswordSecret: "area"},
+							DomainAuthentication: &v1alpha1.ISISAuthentication{Type: v1alpha1.ISISAuthenticationHMACMD5, PasswordSecret: "domain"},
+							Interfaces: []v1alpha1.ISISInterface{
+								{
+									Name:                 "eth0",
+									IPFamily:             new(v1alpha1.IPFamilyIPv6),
+									Features:             []v1alpha1.ISISInterfaceFeature{pointToPointInterface},
+									Authentication:       &v1alpha1.ISISAuthentication{PasswordSecret: "eth0"},
+									Metric:               new(int32(100)),
+									HelloIntervalSeconds: new(int32(1)),
+									HelloMultiplier:      new(int32(3)),
+									BFD:                  &v1alpha1.BFDSettings{ReceiveInterval: new(int32(100))},
+								},
+								{
+									Name:     "eth1",
+									IPFamily: new(v1alpha1.IPFamilyIPv6),
+									BFD:      &v1alpha1.BFDSettings{},
+								},
+							},
+						},
+					},
+				},
+			},
+			secrets: []corev1.Secret{
+				basicAuthSecret("area", "areakey"),
+				basicAuthSecret("domain", "domainkey"),
+				basicAuthSecret("eth0", "eth0key"),
+			},
+			l3Passthrough: []v1alpha1.L3Passthrough{},
+			logLevel:      "debug",
+			want: frr.Config{
+				Underlay: frr.UnderlayConfig{
+					MyASN: 65000,
+					ISIS: &frr.UnderlayISIS{
+						Name:           isisProcessName,
+						Net:            frr.MustParseISISNet("49.0001.0002.0003.0004.00"),
+						AreaPassword:   "areakey",
+						DomainPassword: "domainkey",
+						Interfaces: []frr.ISISInterface{
+							{
+								Name:            "eth0",
+								IPv6:            true,
+								IsPointToPoint:  true,
+								Password:        "eth0key",
+								Metric:          new(int32(100)),
+								HelloInterval:   new(int32(1)),
+								HelloMultiplier: new(int32(3)),
+								BFDEnabled:      true,
+								BFDProfile:      "isis-eth0",
+							},
+							{Name: "eth1", IPv6: true, BFDEnabled: true},
+							{Name: "lo", IPv6: true, IsPassive: true},
+						},
+					},
+					RouterID: "10.0.0.1",
+					Neighbors: []frr.NeighborConfig{
+						{
+							Name: "65001@192.168.1.1",
+							ASN:  mustNewPeerASNFromNumber(65001),
+							Addr: "192.168.1.1",
+							ID:   "192.168.1.1",
+							NetworkLayerProtocols: []networklayerprotocol.NLP{
+								{AFI: networklayerprotocol.IPv4, SAFI: networklayerprotocol.Unicast},
+							},
+							EBGPMultiHop: false,
+						},
+					},
+				},
+				VNIs: []frr.L3VNIConfig{},
+				VPNs: []frr.L3VPNConfig{},
+				BFDProfiles: []frr.BFDProfile{
+					{Name: "isis-eth0", ReceiveInterval: new(int32(100))},
+				},
+				Loglevel: "debug",
+			},
+			wantErr: false,
+		},
+		{
+			name:      "ISIS authentication with a missing secret",
+			nodeIndex: 0,
+			underlays: []v1alpha1.Underlay{
+				{
+					Spec: v1alpha1.UnderlaySpec{
+						ASN:          65000,
+						RouterIDCIDR: new("10.0.0.0/24"),
+						Neighbors:    []v1alpha1.Neighbor{{Address: new("192.168.1.1"), ASN: new(int64(65001))}},
+						ISIS: &v1alpha1.ISISConfig{
+							BaseNet:            "49.0001.0002.0003.0004.00",
+							AreaAuthentication: &v1alpha1.ISISAuthentication{PasswordSecret: "missing"},
+						},
+					},
+				},
+			},
+			l3Passthrough: []v1alpha1.L3Passthrough{},
+			wantErr:       true,
+		},
+		{
+			name:      "neighbor password from secret",
+			nodeIndex: 0,
+			underlays: []v1alpha1.Underlay{
+				{
+					Spec: v1alpha1.UnderlaySpec{
+						ASN:          65000,
+						RouterIDCIDR: new("10.0.0.0/24"),
+						Neighbors: []v1alpha1.Neighbor{
+							{Address: new("192.168.1.1"), ASN: new(int64(65001)), PasswordSecret: new("bgp")},
+						},
+					},
+				},
+			},
+			secrets:       []corev1.Secret{basicAuthSecret("bgp", "bgpkey")},
+			l3Passthrough: []v1alpha1.L3Passthrough{},
+			logLevel:      "debug",
+			want: frr.Config{
+				Underlay: frr.UnderlayConfig{
+					MyASN:    65000,
+					RouterID: "10.0.0.1",
+					Neighbors: []frr.NeighborConfig{
+						{
+							Name:     "65001@192.168.1.1",
+							ASN:      mustNewPeerASNFromNumber(65001),
+							Addr:     "192.168.1.1",
+							ID:       "192.168.1.1",
+							Password: "bgpkey",
+							NetworkLayerProtocols: []networklayerprotocol.NLP{
+								{AFI: networklayerprotocol.IPv4, SAFI: networklayerprotocol.Unicast},
+							},
+							EBGPMultiHop: false,
+						},
+					},
+				},
+				VNIs:        []frr.L3VNIConfig{},
+				VPNs:        []frr.L3VPNConfig{},
+				BFDProfiles: []frr.BFDProfile{},
+				Loglevel:    "debug",
+			},
+			wantErr: false,
+		},
+		{
+			name:      "hostsession with BFD, password, maximum prefix and graceful restart",
+			nodeIndex: 0,
+			underlays: []v1alpha1.Underlay{
+				{
+					Spec: v1alpha1.UnderlaySpec{
+						ASN: 65000,
+						TunnelEndpoint: &v1alpha1.TunnelEndpointConfig{
+							CIDRs: []string{"192.168.1.0/24"},
+						},
+						RouterIDCIDR: new("10.0.0.0/24"),
+						Neighbors:    []v1alpha1.Neighbor{{Address: new("192.168.1.1"), ASN: new(int64(65001))}},
+					},
+				},
+			},
+			vnis: []v1alpha1.L3VNI{
+				{
+					ObjectMeta: metav1.ObjectMeta{Name: "vni1"},
+					Spec: v1alpha1.L3VNISpec{
+						HostSession: &v1alpha1.HostSession{
+							ASN:     65000,
+							HostASN: new(int64(65002)),
+							LocalCIDR: v1alpha1.LocalCIDRConfig{
+								IPv4: new("192.168.2.0/24"),
+							},
+							BFD:            &v1alpha1.BFDSettings{ReceiveInterval: new(int32(300))},
+							PasswordSecret: new("host"),
+							MaximumPrefix: &v1alpha1.MaximumPrefix{
+								IPv4:                    new(int64(100)),
+								Action:                  v1alpha1.MaximumPrefixActionWarningOnly,
+								WarningThresholdPercent: new(int32(80)),
+							},
+							GracefulRestart: &v1alpha1.HostSessionGracefulRestart{
+								Mode: v1alpha1.HostSessionGracefulRestartHelper,
+							},
+						},
+						VRF: "vni1",
+						VNI: 200,
+					},
+				},
+			},
+			l3Passthrough: []v1alpha1.L3Passthrough{
+				{
+					ObjectMeta: metav1.ObjectMeta{Name: "passthrough"},
+					Spec: v1alpha1.L3PassthroughSpec{
+						HostSession: v1alpha1.HostSession{
+							HostASN: new(int64(65001)),
+							ASN:     65000,
+							LocalCIDR: v1alpha1.LocalCIDRConfig{
+								IPv4: new("192.168.3.0/24"),
+							},
+							BFD: &v1alpha1.BFDSettings{},
+							MaximumPrefix: &v1alpha1.MaximumPrefix{
+								IPv6:   new(int64(50)),
+								Action: v1alpha1.MaximumPrefixActionTeardown,
+							},
+							GracefulRestart: &v1alpha1.HostSessionGracefulRestart{
+								Mode: v1alpha1.HostSessionGracefulRestartDisabled,
+							},
+						},
+					},
+				},
+			},
+			secrets:  []corev1.Secret{basicAuthSecret("host", "hostkey")},
+			logLevel: "debug",
+			want: frr.Config{
+				Underlay: frr.UnderlayConfig{
+					MyASN: 65000,
+					TunnelEndpoints: []frr.TunnelEndpoint{{
+						IPv4CIDR: "192.168.1.0/32",
+					}},
+					RouterID: "10.0.0.1",
+					Neighbors: []frr.NeighborConfig{
+						{
+							Name: "65001@192.168.1.1",
+							ASN:  mustNewPeerASNFromNumber(65001),
+							Addr: "192.168.1.1",
+							ID:   "192.168.1.1",
+							NetworkLayerProtocols: []networklayerprotocol.NLP{
+								{AFI: networklayerprotocol.IPv4, SAFI: networklayerprotocol.Unicast},
+								{AFI: networklayerprotocol.L2VPN, SAFI: networklayerprotocol.EVPN},
+							},
+							EBGPMultiHop: false,
+						},
+					},
+				},
+				VNIs: []frr.L3VNIConfig{
+					{
+						ASN:      65000,
+						VNI:      200,
+						VRF:      "vni1",
+						RouterID: "10.0.0.1",
+						LocalNeighbor: &frr.NeighborConfig{
+							Addr:       "192.168.2.2",
+							ID:         "192.168.2.2",
+							ASN:        mustNewPeerASNFromNumber(65002),
+							Password:   "hostkey",
+							BFDEnabled: true,
+							BFDProfile: "l3vni-vni1",
+							MaximumPrefixIPv4: &frr.MaximumPrefix{
+								Limit:       100,
+								Threshold:   new(int32(80)),
+								WarningOnly: true,
+							},
+							GracefulRestart: frr.GracefulRestartHelper,
+						},
+						ToAdvertiseIPv4: []string{"192.168.2.2/32"},
+						ToAdvertiseIPv6: []string{},
+						ExportRTs:       []string{},
+						ImportRTs:       []string{},
+					},
+				},
+				Passthroughs: []frr.PassthroughConfig{{
+					LocalNeighborV4: &frr.NeighborConfig{
+						ASN:               mustNewPeerASNFromNumber(65001),
+						Addr:              "192.168.3.2",
+						ID:                "192.168.3.2",
+						ConnectTime:       new(int64(5)),
+						BFDEnabled:        true,
+						MaximumPrefixIPv6: &frr.MaximumPrefix{Limit: 50},
+						GracefulRestart:   frr.GracefulRestartDisabled,
+					},
+					ToAdvertiseIPv4: []string{"192.168.3.2/32"},
+					ToAdvertiseIPv6: []string{},
+				}},
+				VPNs: []frr.L3VPNConfig{},
+				BFDProfiles: []frr.BFDProfile{
+					{Name: "l3vni-vni1", ReceiveInterval: new(int32(300))},
+				},
+				Loglevel: "debug",
+			},
+			wantErr: false,
+		},
+		{
+			name:      "hostsession with allowed prefixes",
+			nodeIndex: 0,
+			underlays: []v1alpha1.Underlay{
+				{
+					Spec: v1alpha1.UnderlaySpec{
+						ASN: 65000,
+						TunnelEndpoint: &v1alpha1.TunnelEndpointConfig{
+							CIDRs: []string{"192.168.1.0/24"},
+						},
+						RouterIDCIDR: new("10.0.0.0/24"),
+						Neighbors:    []v1alpha1.Neighbor{{Address: new("192.168.1.1"), ASN: new(int64(65001))}},
+					},
+				},
+			},
+			vnis: []v1alpha1.L3VNI{
+				{
+					ObjectMeta: metav1.ObjectMeta{Name: "vni1"},
+					Spec: v1alpha1.L3VNISpec{
+						HostSession: &v1alpha1.HostSession{
+							ASN:     65000,
+							HostASN: new(int64(65002)),
+							LocalCIDR: v1alpha1.LocalCIDRConfig{
+								IPv4: new("192.168.2.0/24"),
+							},
+							AllowedPrefixes: []v1alpha1.PrefixMatch{
+								{Prefix: "10.100.0.0/16", LE: new(int32(24))},
+								{Prefix: "10.200.0.0/24"},
+							},
+						},
+						VRF: "vni1",
+						VNI: 200,
+					},
+				},
+			},
+			l3Passthrough: []v1alpha1.L3Passthrough{},
+			logLevel:      "debug",
+			want: frr.Config{
+				Underlay: frr.UnderlayConfig{
+					MyASN: 65000,
+					TunnelEndpoints: []frr.TunnelEndpoint{{
+						IPv4CIDR: "192.168.1.0/32",
+					}},
+					RouterID: "10.0.0.1",
+					Neighbors: []frr.NeighborConfig{
+						{
+							Name: "65001@192.168.1.1",
+							ASN:  mustNewPeerASNFromNumber(65001),
+							Addr: "192.168.1.1",
+							ID:   "192.168.1.1",
+							NetworkLayerProtocols: []networklayerprotocol.NLP{
+								{AFI: networklayerprotocol.IPv4, SAFI: networklayerprotocol.Unicast},
+								{AFI: networklayerprotocol.L2VPN, SAFI: networklayerprotocol.EVPN},
+							},
+							EBGPMultiHop: false,
+						},
+					},
+				},
+				VNIs: []frr.L3VNIConfig{
+					{
+						ASN:      65000,
+						VNI:      200,
+						VRF:      "vni1",
+						RouterID: "10.0.0.1",
+						LocalNeighbor: &frr.NeighborConfig{
+							Addr: "192.168.2.2",
+							ID:   "192.168.2.2",
+							ASN:  mustNewPeerASNFromNumber(65002),
+							AllowedPrefixesIPv4: &frr.PrefixList{
+								Name: "vni1-192.168.2.2-allowed-ipv4",
+								Entries: []frr.PrefixListEntry{
+									{Seq: 5, Prefix: "10.100.0.0/16", LE: new(int32(24))},
+									{Seq: 10, Prefix: "10.200.0.0/24"},
+								},
+							},
+							AllowedPrefixesIPv6: &frr.PrefixList{
+								Name: "vni1-192.168.2.2-allowed-ipv6",
+								IPv6: true,
+							},
+						},
+						ToAdvertiseIPv4: []string{"192.168.2.2/32"},
+						ToAdvertiseIPv6: []string{},
+						ExportRTs:       []string{},
+						ImportRTs:       []string{},
+					},
+				},
+				VPNs:        []frr.L3VPNConfig{},
+				BFDProfiles: []frr.BFDProfile{},
+				Loglevel:    "debug",
+			},
+			wantErr: false,
+		},
+		{
+			name:      "hostsession with a missing password secret",
+			nodeIndex: 0,
+			underlays: []v1alpha1.Underlay{
+				{
+					Spec: v1alpha1.UnderlaySpec{
+						ASN:          65000,
+						RouterIDCIDR: new("10.0.0.0/24"),
+						Neighbors:    []v1alpha1.Neighbor{{Address: new("192.168.1.1"), ASN: new(int64(65001))}},
+					},
+				},
+			},
+			l3Passthrough: []v1alpha1.L3Passthrough{
+				{
+					ObjectMeta: metav1.ObjectMeta{Name: "passthrough"},
+					Spec: v1alpha1.L3PassthroughSpec{
+						HostSession: v1alpha1.HostSession{
+							HostASN: new(int64(65001)),
+							ASN:     65000,
+							LocalCIDR: v1alpha1.LocalCIDRConfig{
+								IPv4: new("192.168.3.0/24"),
+							},
+							PasswordSecret: new("missing"),
+						},
+					},
+				},
+			},
+			logLevel: "debug",
+			want:     frr.Config{},
+			wantErr:  true,
+		},
+		{
+			name:      "ISIS without interface configuration",
+			nodeIndex: 0,
+			underlays: []v1alpha1.Underlay{
+				{
+					Spec: v1alpha1.UnderlaySpec{
+						ASN:          65000,
+						RouterIDCIDR: new("10.0.0.0/24"),
+						Neighbors:    []v1alpha1.Neighbor{{Address: new("192.168.1.1"), ASN: new(int64(65001))}},
+						ISIS: &v1alpha1.ISISConfig{
+							BaseNet: "49.0001.0002.0003.0004.00",
+							Level:   new(int32(2)),
+						},
+					},
+				},
+			},
+			l3Passthrough: []v1alpha1.L3Passthrough{},
+			logLevel:      "debug",
+			want: frr.Config{
+				Underlay: frr.UnderlayConfig{
+					MyASN: 65000,
+					ISIS: &frr.UnderlayISIS{
+						Name:  isisProcessName,
+						Net:   frr.MustParseISISNet("49.0001.0002.0003.0004.00"),
+						Level: 2,
+						Interfaces: []frr.ISISInterface{
+							{Name: "lo", IPv6: true, IsPassive: true},
+						},
+					},
+					RouterID: "10.0.0.1",
+					Neighbors: []frr.NeighborConfig{
+						{
+							Name: "65001@192.168.1.1",
+							ASN:  mustNewPeerASNFromNumber(65001),
+							Addr: "192.168.1.1",
+							ID:   "192.168.1.1",
+							NetworkLayerProtocols: []networklayerprotocol.NLP{
+								{AFI: networklayerprotocol.IPv4, SAFI: networklayerprotocol.Unicast},
+							},
+							EBGPMultiHop: false,
+						},
+					},
+				},
+				VNIs:        []frr.L3VNIConfig{},
+				VPNs:        []frr.L3VPNConfig{},
+				BFDProfiles: []frr.BFDProfile{},
+				Loglevel:    "debug",
+			},
+			wantErr: false,
+		},
+		{
+			name:      "ISIS invalid net",
+			nodeIndex: 0,
+			underlays: []v1alpha1.Underlay{
+				{
+					Spec: v1alpha1.UnderlaySpec{
+						ASN:          65000,
+						RouterIDCIDR: new("10.0.0.0/24"),
+						Neighbors:    []v1alpha1.Neighbor{{Address: new("192.168.1.1"), ASN: new(int64(65001))}},
+						ISIS: &v1alpha1.ISISConfig{
+							BaseNet: "49.0001.0002.0003.0004.000",
+							Level:   new(int32(1)),
+							Interfaces: []v1alpha1.ISISInterface{
+								{Name: "eth0", IPFamily: new(v1alpha1.IPFamilyDualStack)},
+								{Name: "eth1", IPFamily: new(v1alpha1.IPFamilyIPv6)},
 							},
 						},
 					},
@@ -2662,9 +3438,9 @@ func TestAPItoFRR(t *testing.T) {
 							ExtendedNexthop: true,
 						},
 					},
-					TunnelEndpoint: &frr.TunnelEndpoint{
+					TunnelEndpoints: []frr.TunnelEndpoint{{
 						IPv6CIDR: "2001:db8:1234:5678::/128",
-					},
+					}},
 					SegmentRouting: &frr.UnderlaySegmentRouting{
 						SourceAddress: "2001:db8:1234:5678::",
 						Locator: frr.SRV6Locator{
@@ -2678,8 +3454,7 @@ func TestAPItoFRR(t *testing.T) {
 						EncapBehavior: frr.HEncaps,
 					},
 				},
-				Passthrough: nil,
-				VNIs:        []frr.L3VNIConfig{},
+				VNIs: []frr.L3VNIConfig{},
 				VPNs: []frr.L3VPNConfig{
 					{
 						ASN:             65000,
@@ -2799,9 +3574,9 @@ func TestAPItoFRR(t *testing.T) {
 							ExtendedNexthop: true,
 						},
 					},
-					TunnelEndpoint: &frr.TunnelEndpoint{
+					TunnelEndpoints: []frr.TunnelEndpoint{{
 						IPv6CIDR: "2001:db8:1234:5678::/128",
-					},
+					}},
 					SegmentRouting: &frr.UnderlaySegmentRouting{
 						SourceAddress: "2001:db8:1234:5678::",
 						Locator: frr.SRV6Locator{
@@ -2815,8 +3590,7 @@ func TestAPItoFRR(t *testing.T) {
 						EncapBehavior: frr.HEncapsRed,
 					},
 				},
-				Passthrough: nil,
-				VNIs:        []frr.L3VNIConfig{},
+				VNIs: []frr.L3VNIConfig{},
 				VPNs: []frr.L3VPNConfig{
 					{
 						ASN:             65000,
@@ -2919,9 +3693,9 @@ func TestAPItoFRR(t *testing.T) {
 						},
 					},
 					RouterID: "10.0.0.1",
-					TunnelEndpoint: &frr.TunnelEndpoint{
+					TunnelEndpoints: []frr.TunnelEndpoint{{
 						IPv6CIDR: "2001:db8:1234:5678::/128",
-					},
+					}},
 					Neighbors: []frr.NeighborConfig{
 						{
 							Name: "65001@2001:db8:192:168:1::1",
@@ -2950,8 +3724,7 @@ func TestAPItoFRR(t *testing.T) {
 						EncapBehavior: frr.HEncaps,
 					},
 				},
-				Passthrough: nil,
-				VNIs:        []frr.L3VNIConfig{},
+				VNIs: []frr.L3VNIConfig{},
 				VPNs: []frr.L3VPNConfig{
 					{
 						ASN:             65000,
@@ -3153,10 +3926,10 @@ func TestAPItoFRR(t *testing.T) {
 							UpdateSource:    "2001:db8:1234:5678::",
 						},
 					},
-					TunnelEndpoint: &frr.TunnelEndpoint{
+					TunnelEndpoints: []frr.TunnelEndpoint{{
 						IPv4CIDR: "192.168.123.0/32",
 						IPv6CIDR: "2001:db8:1234:5678::/128",
-					},
+					}},
 					SegmentRouting: &frr.UnderlaySegmentRouting{
 						SourceAddress: "2001:db8:1234:5678::",
 						Locator: frr.SRV6Locator{
@@ -3170,8 +3943,7 @@ func TestAPItoFRR(t *testing.T) {
 						EncapBehavior: frr.HEncaps,
 					},
 				},
-				Passthrough: nil,
-				VNIs:        []frr.L3VNIConfig{},
+				VNIs: []frr.L3VNIConfig{},
 				VPNs: []frr.L3VPNConfig{
 					{
 						ASN:             65000,
@@ -3307,6 +4079,7 @@ func TestAPItoFRR(t *testing.T) {
 				L2VNIs:        tt.l2vnis,
 				L3Passthrough: tt.l3Passthrough,
 				L3VPNs:        tt.vpns,
+				Secrets:       tt.secrets,
 			}
 			got, err := APItoFRR(apiConfig, tt.nodeIndex, tt.logLevel)
 			if (err != nil) != tt.wantErr {
@@ -3602,7 +4375,7 @@ func TestTunnelEndpointToFRRIPv6Only(t *testing.T) {
 		CIDRs: []string{ipv6TestCIDR},
 	}
 
-	got, err := tunnelEndpointToFRR(tunnelEndpoint, 0)
+	got, err := tunnelEndpointToFRR("underlay", tunnelEndpoint, nodeAddresses{})
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -3629,7 +4402,7 @@ func TestTunnelEndpointToFRRDualStack(t *testing.T) {
 		CIDRs: []string{ipv4TestCIDR, ipv6TestCIDR},
 	}
 
-	got, err := tunnelEndpointToFRR(tunnelEndpoint, 0)
+	got, err := tunnelEndpointToFRR("underlay", tunnelEndpoint, nodeAddresses{})
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -3644,6 +4417,47 @@ func TestTunnelEndpointToFRRDualStack(t *testing.T) {
 	}
 }
 
+func TestAPItoFRRNodeAddressing(t *testing.T) {
+	config := APIConfigData{
+		Underlays: []v1alpha1.Underlay{{
+			ObjectMeta: metav1.ObjectMeta{Name: "underlay", Namespace: "openperouter-system"},
+			Spec: v1alpha1.UnderlaySpec{
+				ASN:          64514,
+				RouterIDCIDR: new("10.0.0.0/24"),
+				Neighbors: []v1alpha1.Neighbor{
+					{ASN: new(int64(64517)), Address: new("192.168.11.2")},
+				},
+				TunnelEndpoint: &v1alpha1.TunnelEndpointConfig{CIDRs: []string{"100.65.0.0/24", "2001:db8::/64"}},
+			},
+		}},
+		NodeAddressings: []v1alpha1.NodeAddressing{{
+			ObjectMeta: metav1.ObjectMeta{Name: "node1", Namespace: "openperouter-system"},
+			Spec: v1alpha1.NodeAddressingSpec{
+				NodeName: "node1",
+				RouterID: new("192.0.2.10"),
+				TunnelEndpoints: []v1alpha1.TunnelEndpointAddresses{
+					{Underlay: "underlay", IPs: []string{"2001:db8:ffff::10"}},
+				},
+			},
+		}},
+	}
+
+	got, err := APItoFRR(config, 3, "")
+	if err != nil {
+		t.Fatalf("APItoFRR() unexpected error: %v", err)
+	}
+	if got.Underlay.RouterID != "192.0.2.10" {
+		t.Errorf("RouterID = %q, want %q", got.Underlay.RouterID, "192.0.2.10")
+	}
+	want := []frr.TunnelEndpoint{{
+		IPv4CIDR: "100.65.0.3/32",
+		IPv6CIDR: "2001:db8:ffff::10/128",
+	}}
+	if !cmp.Equal(got.Underlay.TunnelEndpoints, want) {
+		t.Errorf("TunnelEndpoints diff: %s", cmp.Diff(want, got.Underlay.TunnelEndpoints))
+	}
+}
+
 func TestVrfsWithL2Gateways(t *testing.T) {
 	vrfMap := map[string]string{
 		"L3VNI/red":  "red",
@@ -4056,3 +4870,385 @@ func TestAPItoFRRListenRange(t *testing.T) {
 		})
 	}
 }
+
+func TestAPItoFRRRoutePolicies(t *testing.T) {
+	permit := v1alpha1.RoutePolicyActionPermit
+	underlay := v1alpha1.Underlay{
+		ObjectMeta: metav1.ObjectMeta{Name: "underlay", Namespace: "openperouter-system"},
+		Spec: v1alpha1.UnderlaySpec{
+			ASN: 64514,
+			Neighbors: []v1alpha1.Neighbor{
+				{
+					ASN:     new(int64(64517)),
+					Address: new("192.168.11.2"),
+					AddressFamilies: []v1alpha1.NeighborAddressFamily{
+						{
+							Type: "ipv4unicast",
+							ImportPolicy: &v1alpha1.RoutePolicy{
+								Rules: []v1alpha1.RoutePolicyRule{
+									{
+										Action: v1alpha1.RoutePolicyActionPermit,
+										Match: &v1alpha1.RoutePolicyMatch{
+											Prefixes: []v1alpha1.PrefixMatch{
+												{Prefix: "10.0.0.0/8", LE: new(int32(24))},
+												{Prefix: "2001:db8::/32"},
+											},
+										},
+										Set: &v1alpha1.RoutePolicySet{LocalPreference: new(int64(200))},
+									},
+									{
+										Action: v1alpha1.RoutePolicyActionDeny,
+										Match: &v1alpha1.RoutePolicyMatch{
+											Prefixes: []v1alpha1.PrefixMatch{{Prefix: "2001:db9::/32"}},
+										},
+									},
+								},
+							},
+						},
+						{
+							Type: "evpn",
+							ExportPolicy: &v1alpha1.RoutePolicy{
+								Rules: []v1alpha1.RoutePolicyRule{
+									{
+										Action: v1alpha1.RoutePolicyActionPermit,
+										Match: &v1alpha1.RoutePolicyMatch{
+											Communities: []v1alpha1.BGPCommunity{"64514:1", "64514:2"},
+										},
+										Set: &v1alpha1.RoutePolicySet{
+											LargeCommunities: []v1alpha1.BGPLargeCommunity{"64514:1:1"},
+											ASPathPrepend:    &v1alpha1.ASPathPrepend{Times: 2},
+										},
+									},
+								},
+								DefaultAction: &permit,
+							},
+						},
+					},
+				},
+			},
+			TunnelEndpoint: &v1alpha1.TunnelEndpointConfig{CIDRs: []string{"100.65.0.0/24"}},
+		},
+	}
+	vni := v1alpha1.L3VNI{
+		ObjectMeta: metav1.ObjectMeta{Name: "red", Namespace: "openperouter-system"},
+		Spec: v1alpha1.L3VNISpec{
+			VRF: "red",
+			VNI: 100,
+			HostSession: &v1alpha1.HostSession{
+				ASN:       64520,
+				HostASN:   new(int64(64521)),
+				LocalCIDR: v1alpha1.LocalCIDRConfig{IPv4: new("192.169.10.0/24")},
+				ExportPolicy: &v1alpha1.RoutePolicy{
+					Rules: []v1alpha1.RoutePolicyRule{
+						{
+							Action: v1alpha1.RoutePolicyActionPermit,
+							Match: &v1alpha1.RoutePolicyMatch{
+								Prefixes: []v1alpha1.PrefixMatch{{Prefix: "192.170.0.0/16", GE: new(int32(24))}},
+							},
+							Set: &v1alpha1.RoutePolicySet{
+								ASPathPrepend: &v1alpha1.ASPathPrepend{ASN: new(int64(65000)), Times: 1},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	got, err := APItoFRR(APIConfigData{
+		Underlays: []v1alpha1.Underlay{underlay},
+		L3VNIs:    []v1alpha1.L3VNI{vni},
+	}, 0, "")
+	if err != nil {
+		t.Fatalf("APItoFRR() unexpected error: %v", err)
+	}
+
+	wantNeighborMaps := []frr.RouteMap{
+		{
+			Name:      "192.168.11.2-ipv4-unicast-in",
+			AFI:       networklayerprotocol.IPv4,
+			SAFI:      networklayerprotocol.Unicast,
+			Direction: frr.RouteMapIn,
+			Entries: []frr.RouteMapEntry{
+				{
+					Seq:    10,
+					Action: "permit",
+					PrefixList: &frr.PrefixList{
+						Name:    "192.168.11.2-ipv4-unicast-in-10",
+						Entries: []frr.PrefixListEntry{{Seq: 5, Prefix: "10.0.0.0/8", LE: new(int32(24))}},
+					},
+					SetLocalPreference: new(int64(200)),
+				},
+				{Seq: 65535, Action: "deny"},
+			},
+		},
+		{
+			Name:      "192.168.11.2-l2vpn-evpn-out",
+			AFI:       networklayerprotocol.L2VPN,
+			SAFI:      networklayerprotocol.EVPN,
+			Direction: frr.RouteMapOut,
+			Entries: []frr.RouteMapEntry{
+				{
+					Seq:    10,
+					Action: "permit",
+					CommunityList: &frr.CommunityList{
+						Name: "192.168.11.2-l2vpn-evpn-out-10-c",
+						Entries: []frr.CommunityListEntry{
+							{Seq: 5, Community: "64514:1"},
+							{Seq: 10, Community: "64514:2"},
+						},
+					},
+					SetLargeCommunities: []string{"64514:1:1"},
+					SetASPathPrepend:    "64514 64514",
+				},
+				{Seq: 65535, Action: "permit"},
+			},
+		},
+	}
+	if diff := cmp.Diff(wantNeighborMaps, got.Underlay.Neighbors[0].RouteMaps); diff != "" {
+		t.Errorf("unexpected neighbor route maps (-want +got)\n%s", diff)
+	}
+
+	exportEntry := frr.RouteMapEntry{
+		Seq:    10,
+		Action: "permit",
+		PrefixList: &frr.PrefixList{
+			Name:    "red-192.169.10.2-ipv4-unicast-out-10",
+			Entries: []frr.PrefixListEntry{{Seq: 5, Prefix: "192.170.0.0/16", GE: new(int32(24))}},
+		},
+		SetASPathPrepend: "65000",
+	}
+	wantHostMaps := []frr.RouteMap{
+		{
+			Name:      "red-192.169.10.2-ipv4-unicast-out",
+			AFI:       networklayerprotocol.IPv4,
+			SAFI:      networklayerprotocol.Unicast,
+			Direction: frr.RouteMapOut,
+			Entries:   []frr.RouteMapEntry{exportEntry, {Seq: 65535, Action: "deny"}},
+		},
+		{
+			Name:      "red-192.169.10.2-ipv6-unicast-out",
+			AFI:       networklayerprotocol.IPv6,
+			SAFI:      networklayerprotocol.Unicast,
+			Direction: frr.RouteMapOut,
+			Entries:   []frr.RouteMapEntry{{Seq: 65535, Action: "deny"}},
+		},
+	}
+	if len(got.VNIs) != 1 || got.VNIs[0].LocalNeighbor == nil {
+		t.Fatalf("expected one vni with a local neighbor, got %+v", got.VNIs)
+	}
+	if diff := cmp.Diff(wantHostMaps, got.VNIs[0].LocalNeighbor.RouteMaps); diff != "" {
+		t.Errorf("unexpected host session route maps (-want +got)\n%s", diff)
+	}
+}
+
+func TestPrefixListToFRRCanonicalPrefixes(t *testing.T) {
+	got := prefixListToFRR("list", []v1alpha1.PrefixMatch{
+		{Prefix: "10.0.0.0/8"},
+		{Prefix: "2001:DB8:0:0::/32", LE: new(int32(64))},
+	}, networklayerprotocol.IPv6)
+
+	want := &frr.PrefixList{
+		Name:    "list",
+		IPv6:    true,
+		Entries: []frr.PrefixListEntry{{Seq: 5, Prefix: "2001:db8::/32", LE: new(int32(64))}},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("unexpected prefix list (-want +got)\n%s", diff)
+	}
+}
+
+func TestAPItoFRRRouteLeaking(t *testing.T) {
+	underlay := v1alpha1.Underlay{
+		ObjectMeta: metav1.ObjectMeta{Name: "underlay", Namespace: "openperouter-system"},
+		Spec: v1alpha1.UnderlaySpec{
+			ASN:            64514,
+			Neighbors:      []v1alpha1.Neighbor{{ASN: new(int64(64517)), Address: new("192.168.11.2")}},
+			TunnelEndpoint: &v1alpha1.TunnelEndpointConfig{CIDRs: []string{"100.65.0.0/24"}},
+		},
+	}
+	shared := v1alpha1.L3VNI{
+		ObjectMeta: metav1.ObjectMeta{Name: "shared", Namespace: "openperouter-system"},
+		Spec: v1alpha1.L3VNISpec{
+			VRF: "shared",
+			VNI: 200,
+		},
+	}
+	red := v1alpha1.L3VNI{
+		ObjectMeta: metav1.ObjectMeta{Name: "red", Namespace: "openperouter-system"},
+		Spec: v1alpha1.L3VNISpec{
+			VRF: "red",
+			VNI: 100,
+			HostSession: &v1alpha1.HostSession{
+				ASN:       64520,
+				HostASN:   new(int64(64521)),
+				LocalCIDR: v1alpha1.LocalCIDRConfig{IPv4: new("192.169.10.0/24")},
+			},
+			RouteLeaking: &v1alpha1.RouteLeaking{
+				ImportFrom: []v1alpha1.VRFRouteLeak{
+					{
+						VRF: "shared",
+						Prefixes: []v1alpha1.PrefixMatch{
+							{Prefix: "192.172.0.0/24", LE: new(int32(32))},
+						},
+					},
+				},
+				ExportTo: []v1alpha1.VRFRouteLeak{
+					{
+						VRF:      v1alpha1.DefaultVRF,
+						Prefixes: []v1alpha1.PrefixMatch{{Prefix: "2001:db8:10::/48"}},
+					},
+				},
+			},
+		},
+	}
+
+	got, err := APItoFRR(APIConfigData{
+		Underlays: []v1alpha1.Underlay{underlay},
+		L3VNIs:    []v1alpha1.L3VNI{shared, red},
+	}, 0, "")
+	if err != nil {
+		t.Fatalf("APItoFRR() unexpected error: %v", err)
+	}
+
+	want := []frr.VRFImport{
+		{
+			ASN:        64514,
+			ImportIPv6: []string{"red"},
+			RouteMaps: []frr.RouteMap{
+				{
+					Name:      "default-import-vrf-ipv6",
+					AFI:       networklayerprotocol.IPv6,
+					SAFI:      networklayerprotocol.Unicast,
+					Direction: frr.RouteMapIn,
+					Entries: []frr.RouteMapEntry{
+						{
+							Seq:            10,
+							Action:         "permit",
+							MatchSourceVRF: "red",
+							PrefixList: &frr.PrefixList{
+								Name:    "default-import-vrf-ipv6-10",
+								IPv6:    true,
+								Entries: []frr.PrefixListEntry{{Seq: 5, Prefix: "2001:db8:10::/48"}},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			VRF:        "red",
+			ASN:        64520,
+			ImportIPv4: []string{"shared"},
+			RouteMaps: []frr.RouteMap{
+				{
+					Name:      "red-import-vrf-ipv4",
+					AFI:       networklayerprotocol.IPv4,
+					SAFI:      networklayerprotocol.Unicast,
+					Direction: frr.RouteMapIn,
+					Entries: []frr.RouteMapEntry{
+						{
+							Seq:            10,
+							Action:         "permit",
+							MatchSourceVRF: "shared",
+							PrefixList: &frr.PrefixList{
+								Name:    "red-import-vrf-ipv4-10",
+								Entries: []frr.PrefixListEntry{{Seq: 5, Prefix: "192.172.0.0/24", LE: new(int32(32))}},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	if diff := cmp.Diff(want, got.VRFImports); diff != "" {
+		t.Errorf("unexpected vrf imports (-want +got)\n%s", diff)
+	}
+}
+
+func TestEthernetSegmentsToFRR(t *testing.T) {
+	l2vnis := []v1alpha1.L2VNI{
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "no-segment"},
+			Spec:       v1alpha1.L2VNISpec{VNI: 100},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "type0"},
+			Spec: v1alpha1.L2VNISpec{
+				VNI:             200,
+				EthernetSegment: &v1alpha1.EthernetSegment{ESI: new("00:11:22:33:44:55:66:77:88:AA")},
+			},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "type3"},
+			Spec: v1alpha1.L2VNISpec{
+				VNI: 300,
+				EthernetSegment: &v1alpha1.EthernetSegment{
+					LocalDiscriminator: new(int32(300)),
+					SystemMAC:          new("44:38:39:FF:00:01"),
+					DFPreference:       new(int32(50000)),
+				},
+			},
+		},
+	}
+
+	expected := []frr.EthernetSegment{
+		{
+			Interface: "pe-e-200",
+			ESI:       "00:11:22:33:44:55:66:77:88:aa",
+		},
+		{
+			Interface:          "pe-e-300",
+			LocalDiscriminator: 300,
+			SystemMAC:          "44:38:39:ff:00:01",
+			DFPreference:       50000,
+		},
+	}
+	if diff := cmp.Diff(expected, ethernetSegmentsToFRR(l2vnis)); diff != "" {
+		t.Errorf("unexpected ethernet segments (-want +got):\n%s", diff)
+	}
+}
+
+func TestL2VNIsToFRR(t *testing.T) {
+	l2vnis := []v1alpha1.L2VNI{
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "no-advertisement"},
+			Spec:       v1alpha1.L2VNISpec{VNI: 100, GatewayIPs: []string{"192.168.100.1/24"}},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "default-gw"},
+			Spec: v1alpha1.L2VNISpec{
+				VNI:                   200,
+				GatewayIPs:            []string{"192.168.200.1/24"},
+				GatewayAdvertisements: []v1alpha1.GatewayAdvertisement{v1alpha1.GatewayAdvertisementDefaultGateway},
+			},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "both"},
+			Spec: v1alpha1.L2VNISpec{
+				VNI:        300,
+				GatewayIPs: []string{"192.168.30.1/24"},
+				GatewayAdvertisements: []v1alpha1.GatewayAdvertisement{
+					v1alpha1.GatewayAdvertisementSVIIP,
+					v1alpha1.GatewayAdvertisementDefaultGateway,
+				},
+			},
+		},
+	}
+
+	expected := []frr.L2VNIConfig{
+		{VNI: 200, AdvertiseDefaultGW: true},
+		{VNI: 300, AdvertiseDefaultGW: true, AdvertiseSVIIP: true},
+	}
+	if diff := cmp.Diff(expected, l2VNIsToFRR(l2vnis)); diff != "" {
+		t.Errorf("unexpected l2 vnis (-want +got):\n%s", diff)
+	}
+}
+
+func basicAuthSecret(name, password string) corev1.Secret {
+	return corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Type:       corev1.SecretTypeBasicAuth,
+		Data:       map[string][]byte{corev1.BasicAuthPasswordKey: []byte(password)},
+	}
+}
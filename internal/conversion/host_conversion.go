@@ -7,13 +7,13 @@ import (
 	"errors"
 	"fmt"
 	"net"
+	"slices"
 
 	"k8s.io/apimachinery/pkg/util/sets"
 
 	"github.com/openperouter/openperouter/api/v1alpha1"
 	"github.com/openperouter/openperouter/internal/cniinvoker"
 	"github.com/openperouter/openperouter/internal/hostnetwork"
-	"github.com/openperouter/openperouter/internal/ipam"
 	"github.com/openperouter/openperouter/internal/ipfamily"
 	"k8s.io/utils/ptr"
 
@@ -33,29 +33,46 @@ func APItoHostConfig(nodeIndex int, targetNS string, apiConfig APIConfigData) (H
 		return HostConfigData{}, err
 	}
 
-	underlay := apiConfig.Underlays[0]
-
-	if err := validateTunnelEndpointForHostConfig(underlay.Spec.TunnelEndpoint, apiConfig); err != nil {
+	if err := validateTunnelEndpointForHostConfig(apiConfig); err != nil {
 		return HostConfigData{}, err
 	}
 
-	if len(underlay.Spec.Interfaces) == 0 {
-		return HostConfigData{}, errors.New("underlay interface must be specified")
-	}
+	addresses := nodeAddressesFor(nodeIndex, apiConfig.NodeAddressings)
 
-	underlayInterfaces, err := underlayInterfacesToHost(underlay.Spec.Interfaces)
-	if err != nil {
-		return HostConfigData{}, err
+	underlayInterfaces := []hostnetwork.UnderlayInterface{}
+	for _, underlay := range apiConfig.Underlays {
+		if len(underlay.Spec.Interfaces) == 0 {
+			return HostConfigData{}, errors.New("underlay interface must be specified")
+		}
+		interfaces, err := underlayInterfacesToHost(underlay.Spec.Interfaces)
+		if err != nil {
+			return HostConfigData{}, err
+		}
+		underlayInterfaces = append(underlayInterfaces, interfaces...)
 	}
 
-	l3Passthrough, err := passthroughConfigToHost(apiConfig.L3Passthrough, targetNS, nodeIndex)
+	l3Passthrough, err := passthroughConfigToHost(apiConfig.L3Passthrough, targetNS, addresses)
 	if err != nil {
 		return HostConfigData{}, fmt.Errorf("failed to translate passthrough configuration to host, err: %w", err)
 	}
 
-	// Thanks to validateTunnelEndpointForHostConfig, we know that if the tunnel endpoint is nil, L3VNIs, L2VNIs and
-	// L3VPNs are empty, too, and we must return early here.
-	if underlay.Spec.TunnelEndpoint == nil {
+	var tunnelEndpoints []hostnetwork.UnderlayTunnelEndpointParams
+	tunnelEndpointByUnderlay := map[string]hostnetwork.UnderlayTunnelEndpointParams{}
+	for _, underlay := range apiConfig.Underlays {
+		if underlay.Spec.TunnelEndpoint == nil {
+			continue
+		}
+		tunnelEndpoint, err := tunnelEndpointToHost(underlay.Name, underlay.Spec.TunnelEndpoint, addresses)
+		if err != nil {
+			return HostConfigData{}, fmt.Errorf("failed to translate tunnel endpoint configuration to host, err: %w", err)
+		}
+		tunnelEndpoints = append(tunnelEndpoints, tunnelEndpoint)
+		tunnelEndpointByUnderlay[underlay.Name] = tunnelEndpoint
+	}
+
+	// Thanks to validateTunnelEndpointForHostConfig, we know that if there are no tunnel endpoints, L3VNIs, L2VNIs
+	// and L3VPNs are empty, too, and we must return early here.
+	if len(tunnelEndpoints) == 0 {
 		return HostConfigData{
 			Underlay: hostnetwork.UnderlayParams{
 				TargetNS:           targetNS,
@@ -67,20 +84,30 @@ func APItoHostConfig(nodeIndex int, targetNS string, apiConfig APIConfigData) (H
 		}, nil
 	}
 
-	underlayConfigTunnelEndpoint, err := tunnelEndpointToHost(underlay.Spec.TunnelEndpoint, nodeIndex)
-	if err != nil {
-		return HostConfigData{}, fmt.Errorf("failed to translate tunnel endpoint configuration to host, err: %w", err)
+	srv6Underlay := srv6UnderlayOf(apiConfig.Underlays)
+	if err := validateOverlayPrerequisitesForHost(apiConfig, srv6Underlay, tunnelEndpointByUnderlay[srv6Underlay.Name]); err != nil {
+		return HostConfigData{}, err
 	}
 
-	if err := validateOverlayPrerequisitesForHost(apiConfig, underlayConfigTunnelEndpoint); err != nil {
-		return HostConfigData{}, err
+	// tunnelEndpointFor returns the tunnel endpoint of the underlay a VNI rides on.
+	tunnelEndpointFor := func(underlayName *string) (hostnetwork.UnderlayTunnelEndpointParams, error) {
+		underlay, err := underlayForVNI(apiConfig.Underlays, underlayName)
+		if err != nil {
+			return hostnetwork.UnderlayTunnelEndpointParams{}, err
+		}
+		tunnelEndpoint, ok := tunnelEndpointByUnderlay[underlay.Name]
+		if !ok {
+			return hostnetwork.UnderlayTunnelEndpointParams{},
+				fmt.Errorf("underlay %s has no tunnel endpoint configuration", underlay.Name)
+		}
+		return tunnelEndpoint, nil
 	}
 
 	l3VNIs, err := l3vnisToHost(
 		apiConfig.L3VNIs,
-		underlayConfigTunnelEndpoint,
+		tunnelEndpointFor,
 		targetNS,
-		nodeIndex)
+		addresses)
 	if err != nil {
 		return HostConfigData{}, fmt.Errorf("failed to translate L3VNIs to host, err: %w", err)
 	}
@@ -88,27 +115,39 @@ func APItoHostConfig(nodeIndex int, targetNS string, apiConfig APIConfigData) (H
 	vrfMap := createVRFMap(apiConfig.L3VNIs, apiConfig.L3VPNs)
 	l2VNIs, err := l2vnisToHost(
 		apiConfig.L2VNIs,
-		underlayConfigTunnelEndpoint,
+		tunnelEndpointFor,
 		targetNS,
 		vrfMap)
 	if err != nil {
 		return HostConfigData{}, fmt.Errorf("failed to translate L2VNIs to host, err: %w", err)
 	}
 
+	if err := validateSharedVXLan(l3VNIs, l2VNIs); err != nil {
+		return HostConfigData{}, err
+	}
+
 	l3VPNs, err := l3vpnsToHost(
 		apiConfig.L3VPNs,
-		underlay.Spec.SRV6,
+		srv6Underlay.Spec.SRV6,
 		targetNS,
-		nodeIndex)
+		addresses)
 	if err != nil {
 		return HostConfigData{}, fmt.Errorf("failed to translate L3VPNs to host, err: %w", err)
 	}
 
+	leaking := leakingVRFs(apiConfig.L3VNIs, apiConfig.L3VPNs)
+	for i := range l3VNIs {
+		l3VNIs[i].LeakRoutes = leaking[l3VNIs[i].VRF]
+	}
+	for i := range l3VPNs {
+		l3VPNs[i].LeakRoutes = leaking[l3VPNs[i].VRF]
+	}
+
 	return HostConfigData{
 		Underlay: hostnetwork.UnderlayParams{
 			TargetNS:           targetNS,
 			UnderlayInterfaces: underlayInterfaces,
-			TunnelEndpoint:     &underlayConfigTunnelEndpoint,
+			TunnelEndpoints:    tunnelEndpoints,
 		},
 		L3VNIs:        l3VNIs,
 		L2VNIs:        l2VNIs,
@@ -117,11 +156,58 @@ func APItoHostConfig(nodeIndex int, targetNS string, apiConfig APIConfigData) (H
 	}, nil
 }
 
-// validateTunnelEndpointForHostConfig makes sure that whenever L3VNIs, L2VNIs or L3VPNs are set, the tunnelEndpoint
+// validateSharedVXLan checks that the VNIs carried by the single VXLan
+// device agree on its source address and port.
+func validateSharedVXLan(l3VNIs []hostnetwork.L3VNIParams, l2VNIs []hostnetwork.L2VNIParams) error {
+	var shared []hostnetwork.VNIParams
+	for _, vni := range l3VNIs {
+		if vni.SharedVXLan {
+			shared = append(shared, vni.VNIParams)
+		}
+	}
+	for _, vni := range l2VNIs {
+		if vni.SharedVXLan {
+			shared = append(shared, vni.VNIParams)
+		}
+	}
+	for _, vni := range shared {
+		if vni.NoNeighSuppression {
+			return fmt.Errorf("vni %d disables the neighbor suppression, which is not supported on the single vxlan device", vni.VNI)
+		}
+		if vni.VTEPIP != shared[0].VTEPIP {
+			return fmt.Errorf("vni %d uses vtep ip %s, while vni %d uses %s on the single vxlan device",
+				vni.VNI, vni.VTEPIP, shared[0].VNI, shared[0].VTEPIP)
+		}
+		if ptr.Deref(vni.VXLanPort, 0) != ptr.Deref(shared[0].VXLanPort, 0) {
+			return fmt.Errorf("vni %d uses vxlan port %d, while vni %d uses %d on the single vxlan device",
+				vni.VNI, ptr.Deref(vni.VXLanPort, 0), shared[0].VNI, ptr.Deref(shared[0].VXLanPort, 0))
+		}
+	}
+	return nil
+}
+
+// tunnelEndpointResolver returns the tunnel endpoint of the underlay with the
+// given name, or of the default one when the name is not set.
+type tunnelEndpointResolver func(underlayName *string) (hostnetwork.UnderlayTunnelEndpointParams, error)
+
+// srv6UnderlayOf returns the underlay configuring SRv6, or the first one if
+// none does.
+func srv6UnderlayOf(underlays []v1alpha1.Underlay) v1alpha1.Underlay {
+	for _, u := range underlays {
+		if u.Spec.SRV6 != nil {
+			return u
+		}
+	}
+	return underlays[0]
+}
+
+// validateTunnelEndpointForHostConfig makes sure that whenever L3VNIs, L2VNIs or L3VPNs are set, a tunnelEndpoint
 // must be configured, too.
-func validateTunnelEndpointForHostConfig(tunnelEndpoint *v1alpha1.TunnelEndpointConfig, apiConfig APIConfigData) error {
-	if tunnelEndpoint != nil {
-		return nil
+func validateTunnelEndpointForHostConfig(apiConfig APIConfigData) error {
+	for _, underlay := range apiConfig.Underlays {
+		if underlay.Spec.TunnelEndpoint != nil {
+			return nil
+		}
 	}
 
 	var errs []error
@@ -138,55 +224,47 @@ func validateTunnelEndpointForHostConfig(tunnelEndpoint *v1alpha1.TunnelEndpoint
 }
 
 func passthroughConfigToHost(l3Passthrough []v1alpha1.L3Passthrough, targetNS string,
-	nodeIndex int) (*hostnetwork.PassthroughParams, error) {
-	if len(l3Passthrough) != 1 {
-		return nil, nil
-	}
-	vethIPs, err := ipam.VethIPsFromPool(
-		l3Passthrough[0].Spec.HostSession.LocalCIDR.IPv4,
-		l3Passthrough[0].Spec.HostSession.LocalCIDR.IPv6,
-		nodeIndex,
-	)
-	if err != nil {
-		return nil, fmt.Errorf("failed to get veth ips, cidr %v, nodeIndex %d, err: %w",
-			l3Passthrough[0].Spec.HostSession.LocalCIDR, nodeIndex, err)
-	}
+	addresses nodeAddresses) ([]hostnetwork.PassthroughParams, error) {
+	var res []hostnetwork.PassthroughParams
+	for _, passthrough := range l3Passthrough {
+		vethIPs, err := addresses.vethIPs(v1alpha1.HostSessionOwnerKindL3Passthrough, passthrough.Name,
+			passthrough.Spec.HostSession.LocalCIDR)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get veth ips for passthrough %s, err: %w", passthrough.Name, err)
+		}
 
-	return &hostnetwork.PassthroughParams{
-		TargetNS: targetNS,
-		LinkIPs: hostnetwork.LinkIPs{
-			HostIPv4: ipNetToString(vethIPs.Ipv4.HostSide),
-			NSIPv4:   ipNetToString(vethIPs.Ipv4.PeSide),
-			HostIPv6: ipNetToString(vethIPs.Ipv6.HostSide),
-			NSIPv6:   ipNetToString(vethIPs.Ipv6.PeSide),
-		},
-	}, nil
+		res = append(res, hostnetwork.PassthroughParams{
+			Name:     passthrough.Name,
+			TargetNS: targetNS,
+			LinkIPs: hostnetwork.LinkIPs{
+				HostIPv4: ipNetToString(vethIPs.Ipv4.HostSide),
+				NSIPv4:   ipNetToString(vethIPs.Ipv4.PeSide),
+				HostIPv6: ipNetToString(vethIPs.Ipv6.HostSide),
+				NSIPv6:   ipNetToString(vethIPs.Ipv6.PeSide),
+			},
+		})
+	}
+	return res, nil
 }
 
-func validateOverlayPrerequisitesForHost(config APIConfigData, tunnelEndpoint hostnetwork.UnderlayTunnelEndpointParams) error {
-	underlay := config.Underlays[0]
-
+func validateOverlayPrerequisitesForHost(config APIConfigData, srv6Underlay v1alpha1.Underlay,
+	tunnelEndpoint hostnetwork.UnderlayTunnelEndpointParams) error {
 	var errs []error
-	if len(config.L3VNIs) > 0 && tunnelEndpoint.IPv4CIDR == "" && tunnelEndpoint.IPv6CIDR == "" {
-		errs = append(errs, errors.New("tunnel endpoint IPv4 or IPv6 configuration is required when L3VNIs are defined"))
-	}
-	if len(config.L2VNIs) > 0 && tunnelEndpoint.IPv4CIDR == "" && tunnelEndpoint.IPv6CIDR == "" {
-		errs = append(errs, errors.New("tunnel endpoint IPv4 or IPv6 configuration is required when L2VNIs are defined"))
-	}
 	if len(config.L3VPNs) > 0 && tunnelEndpoint.IPv6CIDR == "" {
 		errs = append(errs, errors.New("tunnel endpoint IPv6 configuration is required when L3VPNs are defined"))
 	}
-	if len(config.L3VPNs) > 0 && underlay.Spec.SRV6 == nil {
+	if len(config.L3VPNs) > 0 && srv6Underlay.Spec.SRV6 == nil {
 		errs = append(errs, errors.New("SRV6 configuration is required when L3VPNs are defined"))
 	}
-	if underlay.Spec.SRV6 != nil && underlay.Spec.ISIS == nil {
+	if srv6Underlay.Spec.SRV6 != nil && srv6Underlay.Spec.ISIS == nil {
 		errs = append(errs, errors.New("ISIS configuration is required when SRv6 is defined"))
 	}
 
 	return errors.Join(errs...)
 }
 
-func tunnelEndpointToHost(tunnelEndpointConfig *v1alpha1.TunnelEndpointConfig, nodeIndex int) (hostnetwork.UnderlayTunnelEndpointParams, error) {
+func tunnelEndpointToHost(underlay string, tunnelEndpointConfig *v1alpha1.TunnelEndpointConfig,
+	addresses nodeAddresses) (hostnetwork.UnderlayTunnelEndpointParams, error) {
 	tunnelEndpoint := hostnetwork.UnderlayTunnelEndpointParams{}
 	for _, cidr := range tunnelEndpointConfig.CIDRs {
 		af := ipfamily.ForCIDRString(cidr)
@@ -195,10 +273,9 @@ func tunnelEndpointToHost(tunnelEndpointConfig *v1alpha1.TunnelEndpointConfig, n
 				fmt.Errorf("failed to determine address family for CIDR %q", cidr)
 		}
 
-		ip, err := ipam.TunnelEndpointIP(cidr, nodeIndex)
+		ip, err := addresses.tunnelEndpointIP(underlay, cidr)
 		if err != nil {
-			return hostnetwork.UnderlayTunnelEndpointParams{},
-				fmt.Errorf("failed to get vtep ip, cidr %s, nodeIndex %d: %w", cidr, nodeIndex, err)
+			return hostnetwork.UnderlayTunnelEndpointParams{}, err
 		}
 
 		if af == ipfamily.IPv4 {
@@ -212,14 +289,19 @@ func tunnelEndpointToHost(tunnelEndpointConfig *v1alpha1.TunnelEndpointConfig, n
 			fmt.Errorf("no VTEP IP available after conversion from tunnel endpoint CIDRs: %v",
 				tunnelEndpointConfig.CIDRs)
 	}
+	tunnelEndpoint.SingleVXLanDevice = tunnelEndpointConfig.VXLANMode == v1alpha1.VXLANModeSingleVXLANDevice
 	return tunnelEndpoint, nil
 }
 
-func l3vnisToHost(l3vnis []v1alpha1.L3VNI, tunnelEndpoint hostnetwork.UnderlayTunnelEndpointParams,
-	targetNS string, nodeIndex int) ([]hostnetwork.L3VNIParams, error) {
+func l3vnisToHost(l3vnis []v1alpha1.L3VNI, tunnelEndpointFor tunnelEndpointResolver,
+	targetNS string, addresses nodeAddresses) ([]hostnetwork.L3VNIParams, error) {
 	hostL3VNIs := []hostnetwork.L3VNIParams{}
 	for _, l3vni := range l3vnis {
-		hostL3VNI, err := l3vniToHost(l3vni, tunnelEndpoint, targetNS, nodeIndex)
+		tunnelEndpoint, err := tunnelEndpointFor(l3vni.Spec.Underlay)
+		if err != nil {
+			return nil, fmt.Errorf("failed to translate L3VNI %s, err: %w", l3vni.Name, err)
+		}
+		hostL3VNI, err := l3vniToHost(l3vni, tunnelEndpoint, targetNS, addresses)
 		if err != nil {
 			return nil, fmt.Errorf("failed to translate L3VNI %s, err: %w", l3vni.Name, err)
 		}
@@ -228,7 +310,8 @@ func l3vnisToHost(l3vnis []v1alpha1.L3VNI, tunnelEndpoint hostnetwork.UnderlayTu
 	return hostL3VNIs, nil
 }
 
-func l3vniToHost(l3vni v1alpha1.L3VNI, tunnelEndpoint hostnetwork.UnderlayTunnelEndpointParams, targetNS string, nodeIndex int) (hostnetwork.L3VNIParams, error) {
+func l3vniToHost(l3vni v1alpha1.L3VNI, tunnelEndpoint hostnetwork.UnderlayTunnelEndpointParams, targetNS string,
+	addresses nodeAddresses) (hostnetwork.L3VNIParams, error) {
 	vtepIP, err := resolveVTEPIP(l3vni.Spec.UnderlayAddressFamily, tunnelEndpoint)
 	if err != nil {
 		return hostnetwork.L3VNIParams{}, fmt.Errorf("L2VNI %s: %w", l3vni.Name, err)
@@ -237,24 +320,21 @@ func l3vniToHost(l3vni v1alpha1.L3VNI, tunnelEndpoint hostnetwork.UnderlayTunnel
 	hostL3VNI := hostnetwork.L3VNIParams{
 		Name: l3vni.Name,
 		VNIParams: hostnetwork.VNIParams{
-			VRF:       l3vni.Spec.VRF,
-			TargetNS:  targetNS,
-			VTEPIP:    vtepIP,
-			VNI:       l3vni.Spec.VNI,
-			VXLanPort: vxlanPort(l3vni.Spec.VXLanPort),
+			VRF:         l3vni.Spec.VRF,
+			TargetNS:    targetNS,
+			VTEPIP:      vtepIP,
+			VNI:         l3vni.Spec.VNI,
+			VXLanPort:   vxlanPort(l3vni.Spec.VXLanPort),
+			SharedVXLan: tunnelEndpoint.SingleVXLanDevice,
 		},
 	}
 	if l3vni.Spec.HostSession == nil {
 		return hostL3VNI, nil
 	}
 
-	vethIPs, err := ipam.VethIPsFromPool(
-		l3vni.Spec.HostSession.LocalCIDR.IPv4,
-		l3vni.Spec.HostSession.LocalCIDR.IPv6,
-		nodeIndex)
+	vethIPs, err := addresses.vethIPs(v1alpha1.HostSessionOwnerKindL3VNI, l3vni.Name, l3vni.Spec.HostSession.LocalCIDR)
 	if err != nil {
-		return hostnetwork.L3VNIParams{}, fmt.Errorf("failed to get veth ips, cidr %v, nodeIndex %d, err: %w",
-			l3vni.Spec.HostSession.LocalCIDR, nodeIndex, err)
+		return hostnetwork.L3VNIParams{}, err
 	}
 
 	hostL3VNI.LinkIPs = &hostnetwork.LinkIPs{
@@ -269,12 +349,16 @@ func l3vniToHost(l3vni v1alpha1.L3VNI, tunnelEndpoint hostnetwork.UnderlayTunnel
 
 func l2vnisToHost(
 	l2vnis []v1alpha1.L2VNI,
-	tunnelEndpoint hostnetwork.UnderlayTunnelEndpointParams,
+	tunnelEndpointFor tunnelEndpointResolver,
 	targetNS string,
 	vrfMap map[string]string,
 ) ([]hostnetwork.L2VNIParams, error) {
 	hostL2VNIs := []hostnetwork.L2VNIParams{}
 	for _, l2vni := range l2vnis {
+		tunnelEndpoint, err := tunnelEndpointFor(l2vni.Spec.Underlay)
+		if err != nil {
+			return nil, fmt.Errorf("failed to translate L2VNI %s, err: %w", l2vni.Name, err)
+		}
 		vni, err := l2vniToHost(l2vni, tunnelEndpoint, targetNS, vrfMap)
 		if err != nil {
 			return nil, fmt.Errorf("failed to translate L2VNI %s, err: %w", l2vni.Name, err)
@@ -298,10 +382,11 @@ func l2vniToHost(
 	hostL2VNI := hostnetwork.L2VNIParams{
 		Name: l2vni.Name,
 		VNIParams: hostnetwork.VNIParams{
-			TargetNS:  targetNS,
-			VTEPIP:    vtepIP,
-			VNI:       l2vni.Spec.VNI,
-			VXLanPort: vxlanPort(l2vni.Spec.VXLanPort),
+			TargetNS:    targetNS,
+			VTEPIP:      vtepIP,
+			VNI:         l2vni.Spec.VNI,
+			VXLanPort:   vxlanPort(l2vni.Spec.VXLanPort),
+			SharedVXLan: tunnelEndpoint.SingleVXLanDevice,
 		},
 	}
 	if hasRoutingDomain(l2vni) {
@@ -311,6 +396,12 @@ func l2vniToHost(
 		hostL2VNI.L2GatewayIPs = make([]string, len(l2vni.Spec.GatewayIPs))
 		copy(hostL2VNI.L2GatewayIPs, l2vni.Spec.GatewayIPs)
 	}
+	if l2vni.Spec.GatewayMAC != nil {
+		hostL2VNI.GatewayMAC = *l2vni.Spec.GatewayMAC
+	}
+	if ptr.Deref(l2vni.Spec.NeighborSuppression, v1alpha1.NeighborSuppressionEnabled) == v1alpha1.NeighborSuppressionDisabled {
+		hostL2VNI.NoNeighSuppression = true
+	}
 	if l2vni.Spec.HostMaster != nil {
 		hm, err := convertHostMaster(&l2vni)
 		if err != nil {
@@ -322,13 +413,13 @@ func l2vniToHost(
 }
 
 func l3vpnsToHost(l3vpns []v1alpha1.L3VPN, srv6Config *v1alpha1.SRV6Config,
-	targetNS string, nodeIndex int) ([]hostnetwork.L3VPNParams, error) {
+	targetNS string, addresses nodeAddresses) ([]hostnetwork.L3VPNParams, error) {
 	if srv6Config == nil {
 		return []hostnetwork.L3VPNParams{}, nil
 	}
 	hostL3VPNs := []hostnetwork.L3VPNParams{}
 	for _, l3vpn := range l3vpns {
-		hostL3VPN, err := l3vpnToHost(l3vpn, targetNS, nodeIndex)
+		hostL3VPN, err := l3vpnToHost(l3vpn, targetNS, addresses)
 		if err != nil {
 			return nil, fmt.Errorf("failed to translate L3VPN %s, err: %w", l3vpn.Name, err)
 		}
@@ -340,7 +431,7 @@ func l3vpnsToHost(l3vpns []v1alpha1.L3VPN, srv6Config *v1alpha1.SRV6Config,
 // l3vpnToHost converts a single API L3VPN custom resource into a hostnetwork.L3VPNParams.
 // On the host side, we need to create unique interfaces. As RDAssignedNumber is a unique integer, we use that
 // as the numeric interface identifier, analogous to VNI for L2VNI / L3VNI.
-func l3vpnToHost(l3vpn v1alpha1.L3VPN, targetNS string, nodeIndex int) (hostnetwork.L3VPNParams, error) {
+func l3vpnToHost(l3vpn v1alpha1.L3VPN, targetNS string, addresses nodeAddresses) (hostnetwork.L3VPNParams, error) {
 	hostL3VPN := hostnetwork.L3VPNParams{
 		Name:             l3vpn.Name,
 		VRF:              l3vpn.Spec.VRF,
@@ -351,13 +442,9 @@ func l3vpnToHost(l3vpn v1alpha1.L3VPN, targetNS string, nodeIndex int) (hostnetw
 		return hostL3VPN, nil
 	}
 
-	vethIPs, err := ipam.VethIPsFromPool(
-		l3vpn.Spec.HostSession.LocalCIDR.IPv4,
-		l3vpn.Spec.HostSession.LocalCIDR.IPv6,
-		nodeIndex)
+	vethIPs, err := addresses.vethIPs(v1alpha1.HostSessionOwnerKindL3VPN, l3vpn.Name, l3vpn.Spec.HostSession.LocalCIDR)
 	if err != nil {
-		return hostnetwork.L3VPNParams{}, fmt.Errorf("failed to get veth ips, cidr %v, nodeIndex %d, err: %w",
-			l3vpn.Spec.HostSession.LocalCIDR, nodeIndex, err)
+		return hostnetwork.L3VPNParams{}, err
 	}
 
 	hostL3VPN.LinkIPs = &hostnetwork.LinkIPs{
@@ -393,6 +480,7 @@ func convertHostMaster(l2vni *v1alpha1.L2VNI) (*hostnetwork.HostMaster, error) {
 				Name:       l2vni.Spec.HostMaster.OVSBridge.Name,
 				Type:       l2vni.Spec.HostMaster.Type,
 				AutoCreate: new(l2vni.Spec.HostMaster.OVSBridge.Lifecycle == v1alpha1.BridgeLifecycleManaged),
+				Sampling:   ovsSamplingToHost(l2vni.Spec.HostMaster.OVSBridge.Sampling),
 			}, nil
 		}
 	default:
@@ -410,6 +498,28 @@ func convertHostMaster(l2vni *v1alpha1.L2VNI) (*hostnetwork.HostMaster, error) {
 	)
 }
 
+const (
+	defaultSamplingRate    = 400
+	defaultPollingInterval = 30
+)
+
+// ovsSamplingToHost converts the traffic sampling of an OVS bridge, applying
+// the defaults.
+func ovsSamplingToHost(sampling *v1alpha1.OVSSampling) *hostnetwork.OVSSampling {
+	if sampling == nil {
+		return nil
+	}
+	res := &hostnetwork.OVSSampling{
+		Protocol: string(sampling.Protocol),
+		Targets:  slices.Clone(sampling.Collectors),
+		Rate:     int(ptr.Deref(sampling.SamplingRate, defaultSamplingRate)),
+	}
+	if sampling.Protocol == v1alpha1.OVSSamplingProtocolSFlow {
+		res.Polling = int(ptr.Deref(sampling.PollingInterval, defaultPollingInterval))
+	}
+	return res
+}
+
 func resolveVTEPIP(
 	underlayAddressFamily *string,
 	tunnelEndpoint hostnetwork.UnderlayTunnelEndpointParams,
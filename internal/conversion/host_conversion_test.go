@@ -28,7 +28,7 @@ func TestAPItoHostConfig(t *testing.T) {
 		wantL2VNIParams []hostnetwork.L2VNIParams
 		wantL3VNIParams []hostnetwork.L3VNIParams
 		wantL3VPNParams []hostnetwork.L3VPNParams
-		wantPassthrough *hostnetwork.PassthroughParams
+		wantPassthrough []hostnetwork.PassthroughParams
 		wantErr         bool
 	}{
 		{
@@ -51,6 +51,7 @@ func TestAPItoHostConfig(t *testing.T) {
 			targetNS:  "namespace",
 			underlays: []v1alpha1.Underlay{
 				{
+					ObjectMeta: metav1.ObjectMeta{Name: "plane-a"},
 					Spec: v1alpha1.UnderlaySpec{
 						Interfaces: []v1alpha1.UnderlayInterface{
 							{
@@ -62,6 +63,7 @@ func TestAPItoHostConfig(t *testing.T) {
 					},
 				},
 				{
+					ObjectMeta: metav1.ObjectMeta{Name: "plane-b"},
 					Spec: v1alpha1.UnderlaySpec{
 						Interfaces: []v1alpha1.UnderlayInterface{
 							{
@@ -73,6 +75,89 @@ func TestAPItoHostConfig(t *testing.T) {
 					},
 				},
 			},
+			vnis: []v1alpha1.L3VNI{
+				{Spec: v1alpha1.L3VNISpec{VRF: "red", VNI: 100, VXLanPort: new(int32(4789)), Underlay: new("plane-a")}},
+				{Spec: v1alpha1.L3VNISpec{VRF: "blue", VNI: 200, VXLanPort: new(int32(4789)), Underlay: new("plane-b")}},
+			},
+			l2vnis: []v1alpha1.L2VNI{
+				{Spec: v1alpha1.L2VNISpec{VNI: 300, VXLanPort: new(int32(4789)), Underlay: new("plane-b")}},
+			},
+			l3Passthrough: []v1alpha1.L3Passthrough{},
+			wantUnderlay: hostnetwork.UnderlayParams{
+				UnderlayInterfaces: netdevInterfaces("eth0", "eth1"),
+				TargetNS:           "namespace",
+				TunnelEndpoints: []hostnetwork.UnderlayTunnelEndpointParams{
+					{IPv4CIDR: "10.0.0.0/32"},
+					{IPv4CIDR: "10.0.1.0/32"},
+				},
+			},
+			wantL3VNIParams: []hostnetwork.L3VNIParams{
+				{
+					VNIParams: hostnetwork.VNIParams{
+						VRF:       "red",
+						TargetNS:  "namespace",
+						VTEPIP:    "10.0.0.0/32",
+						VNI:       100,
+						VXLanPort: new(int32(4789)),
+					},
+				},
+				{
+					VNIParams: hostnetwork.VNIParams{
+						VRF:       "blue",
+						TargetNS:  "namespace",
+						VTEPIP:    "10.0.1.0/32",
+						VNI:       200,
+						VXLanPort: new(int32(4789)),
+					},
+				},
+			},
+			wantL2VNIParams: []hostnetwork.L2VNIParams{
+				{
+					VNIParams: hostnetwork.VNIParams{
+						TargetNS:  "namespace",
+						VTEPIP:    "10.0.1.0/32",
+						VNI:       300,
+						VXLanPort: new(int32(4789)),
+					},
+				},
+			},
+			wantL3VPNParams: []hostnetwork.L3VPNParams{},
+			wantPassthrough: nil,
+			wantErr:         false,
+		},
+		{
+			name:      "multiple underlays, vni without underlay",
+			nodeIndex: 0,
+			targetNS:  "namespace",
+			underlays: []v1alpha1.Underlay{
+				{
+					ObjectMeta: metav1.ObjectMeta{Name: "plane-a"},
+					Spec: v1alpha1.UnderlaySpec{
+						Interfaces: []v1alpha1.UnderlayInterface{
+							{
+								Type:          "NetworkDevice",
+								NetworkDevice: &v1alpha1.NetworkDevice{InterfaceName: "eth0"},
+							},
+						},
+						TunnelEndpoint: &v1alpha1.TunnelEndpointConfig{CIDRs: []string{"10.0.0.0/24"}},
+					},
+				},
+				{
+					ObjectMeta: metav1.ObjectMeta{Name: "plane-b"},
+					Spec: v1alpha1.UnderlaySpec{
+						Interfaces: []v1alpha1.UnderlayInterface{
+							{
+								Type:          "NetworkDevice",
+								NetworkDevice: &v1alpha1.NetworkDevice{InterfaceName: "eth1"},
+							},
+						},
+						TunnelEndpoint: &v1alpha1.TunnelEndpointConfig{CIDRs: []string{"10.0.1.0/24"}},
+					},
+				},
+			},
+			vnis: []v1alpha1.L3VNI{
+				{Spec: v1alpha1.L3VNISpec{VRF: "red", VNI: 100, VXLanPort: new(int32(4789))}},
+			},
 			wantErr: true,
 		},
 		{
@@ -100,9 +185,9 @@ func TestAPItoHostConfig(t *testing.T) {
 			wantUnderlay: hostnetwork.UnderlayParams{
 				UnderlayInterfaces: netdevInterfaces("eth0"),
 				TargetNS:           "namespace",
-				TunnelEndpoint: &hostnetwork.UnderlayTunnelEndpointParams{
+				TunnelEndpoints: []hostnetwork.UnderlayTunnelEndpointParams{{
 					IPv4CIDR: "10.0.0.0/32",
-				},
+				}},
 			},
 			wantL3VNIParams: []hostnetwork.L3VNIParams{
 				{
@@ -166,9 +251,9 @@ func TestAPItoHostConfig(t *testing.T) {
 			wantUnderlay: hostnetwork.UnderlayParams{
 				UnderlayInterfaces: netdevInterfaces("eth0", "eth1"),
 				TargetNS:           "namespace",
-				TunnelEndpoint: &hostnetwork.UnderlayTunnelEndpointParams{
+				TunnelEndpoints: []hostnetwork.UnderlayTunnelEndpointParams{{
 					IPv4CIDR: "10.0.0.0/32",
-				},
+				}},
 			},
 			wantL3VNIParams: []hostnetwork.L3VNIParams{
 				{
@@ -232,10 +317,10 @@ func TestAPItoHostConfig(t *testing.T) {
 			wantUnderlay: hostnetwork.UnderlayParams{
 				UnderlayInterfaces: netdevInterfaces("eth0"),
 				TargetNS:           "namespace",
-				TunnelEndpoint: &hostnetwork.UnderlayTunnelEndpointParams{
+				TunnelEndpoints: []hostnetwork.UnderlayTunnelEndpointParams{{
 					IPv4CIDR: "10.0.0.0/32",
 					IPv6CIDR: "2001:db8::/128",
-				},
+				}},
 			},
 			wantL3VNIParams: []hostnetwork.L3VNIParams{},
 			wantL3VPNParams: []hostnetwork.L3VPNParams{
@@ -279,9 +364,9 @@ func TestAPItoHostConfig(t *testing.T) {
 			wantUnderlay: hostnetwork.UnderlayParams{
 				UnderlayInterfaces: netdevInterfaces("eth0"),
 				TargetNS:           "namespace",
-				TunnelEndpoint: &hostnetwork.UnderlayTunnelEndpointParams{
+				TunnelEndpoints: []hostnetwork.UnderlayTunnelEndpointParams{{
 					IPv4CIDR: "10.0.0.0/32",
-				},
+				}},
 			},
 			wantL3VNIParams: []hostnetwork.L3VNIParams{
 				{
@@ -318,9 +403,9 @@ func TestAPItoHostConfig(t *testing.T) {
 			wantUnderlay: hostnetwork.UnderlayParams{
 				UnderlayInterfaces: netdevInterfaces("eth0"),
 				TargetNS:           "namespace",
-				TunnelEndpoint: &hostnetwork.UnderlayTunnelEndpointParams{
+				TunnelEndpoints: []hostnetwork.UnderlayTunnelEndpointParams{{
 					IPv4CIDR: "10.0.0.0/32",
-				},
+				}},
 			},
 			wantL3VNIParams: []hostnetwork.L3VNIParams{
 				{
@@ -359,9 +444,9 @@ func TestAPItoHostConfig(t *testing.T) {
 			wantUnderlay: hostnetwork.UnderlayParams{
 				UnderlayInterfaces: netdevInterfaces("eth0"),
 				TargetNS:           "namespace",
-				TunnelEndpoint: &hostnetwork.UnderlayTunnelEndpointParams{
+				TunnelEndpoints: []hostnetwork.UnderlayTunnelEndpointParams{{
 					IPv4CIDR: "10.0.0.0/32",
-				},
+				}},
 			},
 			wantL3VNIParams: []hostnetwork.L3VNIParams{},
 			wantL2VNIParams: []hostnetwork.L2VNIParams{
@@ -380,6 +465,66 @@ func TestAPItoHostConfig(t *testing.T) {
 			wantPassthrough: nil,
 			wantErr:         false,
 		},
+		{
+			name:      "l2 and l3 vnis on the single vxlan device",
+			nodeIndex: 0,
+			targetNS:  "namespace",
+			underlays: []v1alpha1.Underlay{
+				{Spec: v1alpha1.UnderlaySpec{Interfaces: []v1alpha1.UnderlayInterface{{Type: "NetworkDevice", NetworkDevice: &v1alpha1.NetworkDevice{InterfaceName: "eth0"}}}, TunnelEndpoint: &v1alpha1.TunnelEndpointConfig{CIDRs: []string{"10.0.0.0/24"}, VXLANMode: v1alpha1.VXLANModeSingleVXLANDevice}}},
+			},
+			vnis: []v1alpha1.L3VNI{
+				{Spec: v1alpha1.L3VNISpec{VRF: "red", VNI: 100, VXLanPort: new(int32(4789))}},
+			},
+			l2vnis: []v1alpha1.L2VNI{
+				{Spec: v1alpha1.L2VNISpec{VNI: 200, VXLanPort: new(int32(4789))}},
+			},
+			l3Passthrough: []v1alpha1.L3Passthrough{},
+			wantUnderlay: hostnetwork.UnderlayParams{
+				UnderlayInterfaces: netdevInterfaces("eth0"),
+				TargetNS:           "namespace",
+				TunnelEndpoints: []hostnetwork.UnderlayTunnelEndpointParams{{
+					IPv4CIDR:          "10.0.0.0/32",
+					SingleVXLanDevice: true,
+				}},
+			},
+			wantL3VNIParams: []hostnetwork.L3VNIParams{
+				{
+					VNIParams: hostnetwork.VNIParams{
+						VRF:         "red",
+						TargetNS:    "namespace",
+						VTEPIP:      "10.0.0.0/32",
+						VNI:         100,
+						VXLanPort:   new(int32(4789)),
+						SharedVXLan: true,
+					},
+				},
+			},
+			wantL2VNIParams: []hostnetwork.L2VNIParams{
+				{
+					VNIParams: hostnetwork.VNIParams{
+						TargetNS:    "namespace",
+						VTEPIP:      "10.0.0.0/32",
+						VNI:         200,
+						VXLanPort:   new(int32(4789)),
+						SharedVXLan: true,
+					},
+				},
+			},
+			wantL3VPNParams: []hostnetwork.L3VPNParams{},
+		},
+		{
+			name:      "vnis with different ports on the single vxlan device",
+			nodeIndex: 0,
+			targetNS:  "namespace",
+			underlays: []v1alpha1.Underlay{
+				{Spec: v1alpha1.UnderlaySpec{Interfaces: []v1alpha1.UnderlayInterface{{Type: "NetworkDevice", NetworkDevice: &v1alpha1.NetworkDevice{InterfaceName: "eth0"}}}, TunnelEndpoint: &v1alpha1.TunnelEndpointConfig{CIDRs: []string{"10.0.0.0/24"}, VXLANMode: v1alpha1.VXLANModeSingleVXLANDevice}}},
+			},
+			l2vnis: []v1alpha1.L2VNI{
+				{Spec: v1alpha1.L2VNISpec{VNI: 200, VXLanPort: new(int32(4789))}},
+				{Spec: v1alpha1.L2VNISpec{VNI: 201, VXLanPort: new(int32(4790))}},
+			},
+			wantErr: true,
+		},
 		{
 			name:      "disconnected l2 vni gets empty VRF in host config",
 			nodeIndex: 0,
@@ -398,9 +543,9 @@ func TestAPItoHostConfig(t *testing.T) {
 			wantUnderlay: hostnetwork.UnderlayParams{
 				UnderlayInterfaces: netdevInterfaces("eth0"),
 				TargetNS:           "namespace",
-				TunnelEndpoint: &hostnetwork.UnderlayTunnelEndpointParams{
+				TunnelEndpoints: []hostnetwork.UnderlayTunnelEndpointParams{{
 					IPv4CIDR: "10.0.0.0/32",
-				},
+				}},
 			},
 			wantL3VNIParams: []hostnetwork.L3VNIParams{},
 			wantL2VNIParams: []hostnetwork.L2VNIParams{
@@ -446,9 +591,9 @@ func TestAPItoHostConfig(t *testing.T) {
 			wantUnderlay: hostnetwork.UnderlayParams{
 				UnderlayInterfaces: netdevInterfaces("eth0"),
 				TargetNS:           "namespace",
-				TunnelEndpoint: &hostnetwork.UnderlayTunnelEndpointParams{
+				TunnelEndpoints: []hostnetwork.UnderlayTunnelEndpointParams{{
 					IPv4CIDR: "10.0.0.0/32",
-				},
+				}},
 			},
 			wantL3VNIParams: []hostnetwork.L3VNIParams{
 				{
@@ -479,6 +624,80 @@ func TestAPItoHostConfig(t *testing.T) {
 			wantPassthrough: nil,
 			wantErr:         false,
 		},
+		{
+			name:      "l2 vni with gateway mac and without neighbor suppression",
+			nodeIndex: 0,
+			targetNS:  "namespace",
+			underlays: []v1alpha1.Underlay{
+				{Spec: v1alpha1.UnderlaySpec{Interfaces: []v1alpha1.UnderlayInterface{{Type: "NetworkDevice", NetworkDevice: &v1alpha1.NetworkDevice{InterfaceName: "eth0"}}}, TunnelEndpoint: &v1alpha1.TunnelEndpointConfig{CIDRs: []string{"10.0.0.0/24"}}}},
+			},
+			vnis: []v1alpha1.L3VNI{
+				{ObjectMeta: metav1.ObjectMeta{Name: "gw-l3"}, Spec: v1alpha1.L3VNISpec{VRF: "red", VNI: 300}},
+			},
+			l2vnis: []v1alpha1.L2VNI{
+				{Spec: v1alpha1.L2VNISpec{
+					RoutingDomain: &v1alpha1.RoutingDomain{
+						Type:  v1alpha1.RoutingDomainTypeL3VNI,
+						L3VNI: &v1alpha1.L3VNIReference{Name: "gw-l3"},
+					},
+					VNI: 201, VXLanPort: new(int32(4789)),
+					HostMaster:          &v1alpha1.HostMaster{Type: "LinuxBridge", LinuxBridge: &v1alpha1.LinuxBridgeConfig{Name: new("br0")}},
+					GatewayIPs:          []string{"192.168.100.1/24"},
+					GatewayMAC:          new("00:00:5e:00:01:01"),
+					NeighborSuppression: new(v1alpha1.NeighborSuppressionDisabled),
+				}},
+			},
+			l3Passthrough: []v1alpha1.L3Passthrough{},
+			wantUnderlay: hostnetwork.UnderlayParams{
+				UnderlayInterfaces: netdevInterfaces("eth0"),
+				TargetNS:           "namespace",
+				TunnelEndpoints: []hostnetwork.UnderlayTunnelEndpointParams{{
+					IPv4CIDR: "10.0.0.0/32",
+				}},
+			},
+			wantL3VNIParams: []hostnetwork.L3VNIParams{
+				{
+					VNIParams: hostnetwork.VNIParams{
+						VRF:       "red",
+						TargetNS:  "namespace",
+						VTEPIP:    "10.0.0.0/32",
+						VNI:       300,
+						VXLanPort: new(int32(4789)),
+					},
+					Name: "gw-l3",
+				},
+			},
+			wantL2VNIParams: []hostnetwork.L2VNIParams{
+				{
+					VNIParams: hostnetwork.VNIParams{
+						VRF:                "red",
+						TargetNS:           "namespace",
+						VTEPIP:             "10.0.0.0/32",
+						VNI:                201,
+						VXLanPort:          new(int32(4789)),
+						NoNeighSuppression: true,
+					},
+					L2GatewayIPs: []string{"192.168.100.1/24"},
+					HostMaster:   &hostnetwork.HostMaster{Name: new("br0"), Type: "LinuxBridge", AutoCreate: new(false)},
+					GatewayMAC:   "00:00:5e:00:01:01",
+				},
+			},
+			wantL3VPNParams: []hostnetwork.L3VPNParams{},
+			wantPassthrough: nil,
+			wantErr:         false,
+		},
+		{
+			name:      "vni without neighbor suppression on the single vxlan device",
+			nodeIndex: 0,
+			targetNS:  "namespace",
+			underlays: []v1alpha1.Underlay{
+				{Spec: v1alpha1.UnderlaySpec{Interfaces: []v1alpha1.UnderlayInterface{{Type: "NetworkDevice", NetworkDevice: &v1alpha1.NetworkDevice{InterfaceName: "eth0"}}}, TunnelEndpoint: &v1alpha1.TunnelEndpointConfig{CIDRs: []string{"10.0.0.0/24"}, VXLANMode: v1alpha1.VXLANModeSingleVXLANDevice}}},
+			},
+			l2vnis: []v1alpha1.L2VNI{
+				{Spec: v1alpha1.L2VNISpec{VNI: 200, VXLanPort: new(int32(4789)), NeighborSuppression: new(v1alpha1.NeighborSuppressionDisabled)}},
+			},
+			wantErr: true,
+		},
 		{
 			name:      "l3 vni without hostsession",
 			nodeIndex: 0,
@@ -494,9 +713,9 @@ func TestAPItoHostConfig(t *testing.T) {
 			wantUnderlay: hostnetwork.UnderlayParams{
 				UnderlayInterfaces: netdevInterfaces("eth0"),
 				TargetNS:           "namespace",
-				TunnelEndpoint: &hostnetwork.UnderlayTunnelEndpointParams{
+				TunnelEndpoints: []hostnetwork.UnderlayTunnelEndpointParams{{
 					IPv4CIDR: "10.0.0.0/32",
-				},
+				}},
 			},
 			wantL3VNIParams: []hostnetwork.L3VNIParams{
 				{
@@ -515,6 +734,65 @@ func TestAPItoHostConfig(t *testing.T) {
 			wantPassthrough: nil,
 			wantErr:         false,
 		},
+		{
+			name:      "l3 vnis leaking routes",
+			nodeIndex: 0,
+			targetNS:  "namespace",
+			underlays: []v1alpha1.Underlay{
+				{Spec: v1alpha1.UnderlaySpec{Interfaces: []v1alpha1.UnderlayInterface{{Type: "NetworkDevice", NetworkDevice: &v1alpha1.NetworkDevice{InterfaceName: "eth0"}}}, TunnelEndpoint: &v1alpha1.TunnelEndpointConfig{CIDRs: []string{"10.0.0.0/24"}}}},
+			},
+			vnis: []v1alpha1.L3VNI{
+				{Spec: v1alpha1.L3VNISpec{VRF: "red", VNI: 100, RouteLeaking: &v1alpha1.RouteLeaking{
+					ImportFrom: []v1alpha1.VRFRouteLeak{{VRF: "shared"}},
+				}}},
+				{Spec: v1alpha1.L3VNISpec{VRF: "shared", VNI: 200}},
+				{Spec: v1alpha1.L3VNISpec{VRF: "blue", VNI: 300}},
+			},
+			l2vnis:        []v1alpha1.L2VNI{},
+			l3Passthrough: []v1alpha1.L3Passthrough{},
+			wantUnderlay: hostnetwork.UnderlayParams{
+				UnderlayInterfaces: netdevInterfaces("eth0"),
+				TargetNS:           "namespace",
+				TunnelEndpoints: []hostnetwork.UnderlayTunnelEndpointParams{{
+					IPv4CIDR: "10.0.0.0/32",
+				}},
+			},
+			wantL3VNIParams: []hostnetwork.L3VNIParams{
+				{
+					VNIParams: hostnetwork.VNIParams{
+						VRF:        "red",
+						TargetNS:   "namespace",
+						VTEPIP:     "10.0.0.0/32",
+						VNI:        100,
+						VXLanPort:  new(int32(4789)),
+						LeakRoutes: true,
+					},
+				},
+				{
+					VNIParams: hostnetwork.VNIParams{
+						VRF:        "shared",
+						TargetNS:   "namespace",
+						VTEPIP:     "10.0.0.0/32",
+						VNI:        200,
+						VXLanPort:  new(int32(4789)),
+						LeakRoutes: true,
+					},
+				},
+				{
+					VNIParams: hostnetwork.VNIParams{
+						VRF:       "blue",
+						TargetNS:  "namespace",
+						VTEPIP:    "10.0.0.0/32",
+						VNI:       300,
+						VXLanPort: new(int32(4789)),
+					},
+				},
+			},
+			wantL2VNIParams: []hostnetwork.L2VNIParams{},
+			wantL3VPNParams: []hostnetwork.L3VPNParams{},
+			wantPassthrough: nil,
+			wantErr:         false,
+		},
 		{
 			name:      "underlay without evpn or srv6",
 			nodeIndex: 0,
@@ -544,6 +822,7 @@ func TestAPItoHostConfig(t *testing.T) {
 			l2vnis: []v1alpha1.L2VNI{},
 			l3Passthrough: []v1alpha1.L3Passthrough{
 				{
+					ObjectMeta: metav1.ObjectMeta{Name: "passthrough"},
 					Spec: v1alpha1.L3PassthroughSpec{
 						HostSession: v1alpha1.HostSession{
 							ASN: 65000,
@@ -561,13 +840,75 @@ func TestAPItoHostConfig(t *testing.T) {
 			},
 			wantL3VNIParams: []hostnetwork.L3VNIParams{},
 			wantL2VNIParams: []hostnetwork.L2VNIParams{},
-			wantPassthrough: &hostnetwork.PassthroughParams{
-				TargetNS: "namespace",
-				LinkIPs: hostnetwork.LinkIPs{
-					HostIPv4: "192.168.2.2/24",
-					NSIPv4:   "192.168.2.1/24",
-					HostIPv6: "2001:db8::2/64",
-					NSIPv6:   "2001:db8::1/64",
+			wantPassthrough: []hostnetwork.PassthroughParams{
+				{
+					Name:     "passthrough",
+					TargetNS: "namespace",
+					LinkIPs: hostnetwork.LinkIPs{
+						HostIPv4: "192.168.2.2/24",
+						NSIPv4:   "192.168.2.1/24",
+						HostIPv6: "2001:db8::2/64",
+						NSIPv6:   "2001:db8::1/64",
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name:      "multiple L3 passthroughs",
+			nodeIndex: 1,
+			targetNS:  "namespace",
+			underlays: []v1alpha1.Underlay{
+				{Spec: v1alpha1.UnderlaySpec{Interfaces: []v1alpha1.UnderlayInterface{{Type: "NetworkDevice", NetworkDevice: &v1alpha1.NetworkDevice{InterfaceName: "eth0"}}}}},
+			},
+			vnis:   []v1alpha1.L3VNI{},
+			l2vnis: []v1alpha1.L2VNI{},
+			l3Passthrough: []v1alpha1.L3Passthrough{
+				{
+					ObjectMeta: metav1.ObjectMeta{Name: "infra"},
+					Spec: v1alpha1.L3PassthroughSpec{
+						HostSession: v1alpha1.HostSession{
+							ASN: 65000,
+							LocalCIDR: v1alpha1.LocalCIDRConfig{
+								IPv4: new("192.168.2.0/24"),
+							},
+						},
+					},
+				},
+				{
+					ObjectMeta: metav1.ObjectMeta{Name: "storage"},
+					Spec: v1alpha1.L3PassthroughSpec{
+						HostSession: v1alpha1.HostSession{
+							ASN: 65001,
+							LocalCIDR: v1alpha1.LocalCIDRConfig{
+								IPv6: new("2001:db8::/64"),
+							},
+						},
+					},
+				},
+			},
+			wantUnderlay: hostnetwork.UnderlayParams{
+				UnderlayInterfaces: netdevInterfaces("eth0"),
+				TargetNS:           "namespace",
+			},
+			wantL3VNIParams: []hostnetwork.L3VNIParams{},
+			wantL2VNIParams: []hostnetwork.L2VNIParams{},
+			wantPassthrough: []hostnetwork.PassthroughParams{
+				{
+					Name:     "infra",
+					TargetNS: "namespace",
+					LinkIPs: hostnetwork.LinkIPs{
+						HostIPv4: "192.168.2.3/24",
+						NSIPv4:   "192.168.2.1/24",
+					},
+				},
+				{
+					Name:     "storage",
+					TargetNS: "namespace",
+					LinkIPs: hostnetwork.LinkIPs{
+						HostIPv6: "2001:db8::3/64",
+						NSIPv6:   "2001:db8::1/64",
+					},
 				},
 			},
 			wantErr: false,
@@ -594,10 +935,10 @@ func TestAPItoHostConfig(t *testing.T) {
 			wantUnderlay: hostnetwork.UnderlayParams{
 				UnderlayInterfaces: netdevInterfaces("eth0"),
 				TargetNS:           "namespace",
-				TunnelEndpoint: &hostnetwork.UnderlayTunnelEndpointParams{
+				TunnelEndpoints: []hostnetwork.UnderlayTunnelEndpointParams{{
 					IPv4CIDR: "192.168.2.0/32",
 					IPv6CIDR: "2001:db8:192:168::/128",
-				},
+				}},
 			},
 			wantL3VNIParams: []hostnetwork.L3VNIParams{},
 			wantL2VNIParams: []hostnetwork.L2VNIParams{},
@@ -625,9 +966,9 @@ func TestAPItoHostConfig(t *testing.T) {
 			wantUnderlay: hostnetwork.UnderlayParams{
 				TargetNS:           "namespace",
 				UnderlayInterfaces: netdevInterfaces("eth0"),
-				TunnelEndpoint: &hostnetwork.UnderlayTunnelEndpointParams{
+				TunnelEndpoints: []hostnetwork.UnderlayTunnelEndpointParams{{
 					IPv6CIDR: "2001:db8:192:168::/128",
-				},
+				}},
 			},
 			wantL3VNIParams: []hostnetwork.L3VNIParams{},
 			wantL2VNIParams: []hostnetwork.L2VNIParams{},
@@ -852,7 +1193,7 @@ func TestTunnelEndpointToHost(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			tunnelEndpoint := &v1alpha1.TunnelEndpointConfig{CIDRs: tt.cidrs}
-			got, err := tunnelEndpointToHost(tunnelEndpoint, 0)
+			got, err := tunnelEndpointToHost("underlay", tunnelEndpoint, nodeAddresses{})
 			if tt.wantErr != "" {
 				if err == nil {
 					t.Fatalf("expected error containing %q, got nil", tt.wantErr)
@@ -875,6 +1216,53 @@ func TestTunnelEndpointToHost(t *testing.T) {
 	}
 }
 
+func TestOVSSamplingToHost(t *testing.T) {
+	tests := []struct {
+		name     string
+		sampling *v1alpha1.OVSSampling
+		want     *hostnetwork.OVSSampling
+	}{
+		{
+			name: "no sampling",
+		},
+		{
+			name: "sflow with defaults",
+			sampling: &v1alpha1.OVSSampling{
+				Protocol:   v1alpha1.OVSSamplingProtocolSFlow,
+				Collectors: []string{"192.168.1.10:6343"},
+			},
+			want: &hostnetwork.OVSSampling{
+				Protocol: hostnetwork.OVSSamplingSFlow,
+				Targets:  []string{"192.168.1.10:6343"},
+				Rate:     400,
+				Polling:  30,
+			},
+		},
+		{
+			name: "ipfix",
+			sampling: &v1alpha1.OVSSampling{
+				Protocol:     v1alpha1.OVSSamplingProtocolIPFIX,
+				Collectors:   []string{"192.168.1.10:4739", "[fd00::10]:4739"},
+				SamplingRate: new(int32(1000)),
+			},
+			want: &hostnetwork.OVSSampling{
+				Protocol: hostnetwork.OVSSamplingIPFIX,
+				Targets:  []string{"192.168.1.10:4739", "[fd00::10]:4739"},
+				Rate:     1000,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ovsSamplingToHost(tt.sampling)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ovsSamplingToHost() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
 func TestAPItoHostConfigAddressFamily(t *testing.T) {
 	tests := []struct {
 		name       string
@@ -1123,3 +1511,63 @@ func TestAPItoHostConfigCNIInterfaces(t *testing.T) {
 		})
 	}
 }
+
+func TestAPItoHostConfigNodeAddressing(t *testing.T) {
+	apiConfig := APIConfigData{
+		Underlays: []v1alpha1.Underlay{{
+			ObjectMeta: metav1.ObjectMeta{Name: "underlay"},
+			Spec: v1alpha1.UnderlaySpec{
+				Interfaces: []v1alpha1.UnderlayInterface{
+					{
+						Type:          "NetworkDevice",
+						NetworkDevice: &v1alpha1.NetworkDevice{InterfaceName: "eth0"},
+					},
+				},
+				TunnelEndpoint: &v1alpha1.TunnelEndpointConfig{CIDRs: []string{"10.0.0.0/24"}},
+			},
+		}},
+		L3VNIs: []v1alpha1.L3VNI{{
+			ObjectMeta: metav1.ObjectMeta{Name: "red"},
+			Spec: v1alpha1.L3VNISpec{
+				VRF: "red", VNI: 100, VXLanPort: new(int32(4789)),
+				HostSession: &v1alpha1.HostSession{
+					ASN:       64514,
+					HostASN:   new(int64(64515)),
+					LocalCIDR: v1alpha1.LocalCIDRConfig{IPv4: new("192.169.10.0/24")},
+				},
+			},
+		}},
+		NodeAddressings: []v1alpha1.NodeAddressing{{
+			ObjectMeta: metav1.ObjectMeta{Name: "node1"},
+			Spec: v1alpha1.NodeAddressingSpec{
+				NodeName: "node1",
+				TunnelEndpoints: []v1alpha1.TunnelEndpointAddresses{
+					{Underlay: "underlay", IPs: []string{"10.1.1.1"}},
+				},
+				HostSessions: []v1alpha1.HostSessionAddresses{
+					{Kind: v1alpha1.HostSessionOwnerKindL3VNI, Name: "red", IPv4: new("192.169.10.100")},
+				},
+			},
+		}},
+	}
+
+	got, err := APItoHostConfig(0, "namespace", apiConfig)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(got.Underlay.TunnelEndpoints) != 1 || got.Underlay.TunnelEndpoints[0].IPv4CIDR != "10.1.1.1/32" {
+		t.Errorf("unexpected tunnel endpoints %+v, want 10.1.1.1/32", got.Underlay.TunnelEndpoints)
+	}
+	if len(got.L3VNIs) != 1 {
+		t.Fatalf("expected 1 L3VNI, got %d", len(got.L3VNIs))
+	}
+	if got.L3VNIs[0].VTEPIP != "10.1.1.1/32" {
+		t.Errorf("L3VNI VTEPIP = %q, want %q", got.L3VNIs[0].VTEPIP, "10.1.1.1/32")
+	}
+	if got.L3VNIs[0].LinkIPs.HostIPv4 != "192.169.10.100/24" {
+		t.Errorf("L3VNI host ip = %q, want %q", got.L3VNIs[0].LinkIPs.HostIPv4, "192.169.10.100/24")
+	}
+	if got.L3VNIs[0].LinkIPs.NSIPv4 != "192.169.10.1/24" {
+		t.Errorf("L3VNI router ip = %q, want %q", got.L3VNIs[0].LinkIPs.NSIPv4, "192.169.10.1/24")
+	}
+}
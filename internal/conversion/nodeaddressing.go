@@ -0,0 +1,130 @@
+// SPDX-License-Identifier:Apache-2.0
+
+package conversion
+
+import (
+	"fmt"
+	"net"
+	"slices"
+
+	"github.com/openperouter/openperouter/api/v1alpha1"
+	"github.com/openperouter/openperouter/internal/ipam"
+	"github.com/openperouter/openperouter/internal/ipfamily"
+	"k8s.io/utils/ptr"
+)
+
+// nodeAddresses resolves the addresses of a node. They are derived from the
+// node index, unless explicitly set by the NodeAddressing of the node.
+type nodeAddresses struct {
+	index     int
+	overrides v1alpha1.NodeAddressingSpec
+}
+
+// nodeAddressesFor returns the addresses of the node with the given index and
+// NodeAddressings, expected to be at most one, see ValidateNodeAddressings.
+func nodeAddressesFor(nodeIndex int, addressings []v1alpha1.NodeAddressing) nodeAddresses {
+	res := nodeAddresses{index: nodeIndex}
+	if len(addressings) > 0 {
+		res.overrides = addressings[0].Spec
+	}
+	return res
+}
+
+// routerID returns the router ID of the node on the given underlay.
+func (a nodeAddresses) routerID(underlay v1alpha1.Underlay) (string, error) {
+	if a.overrides.RouterID != nil {
+		return *a.overrides.RouterID, nil
+	}
+	// RouterIDCIDR defaults are applied via CRD schema, so it should always be set
+	routerIDCidr := ptr.Deref(underlay.Spec.RouterIDCIDR, "10.0.0.0/24")
+	routerID, err := ipam.RouterID(routerIDCidr, a.index)
+	if err != nil {
+		return "", fmt.Errorf("failed to get router id, cidr %s, nodeIndex %d: %w", routerIDCidr, a.index, err)
+	}
+	return routerID, nil
+}
+
+// tunnelEndpointIP returns the tunnel endpoint IP of the node on the given
+// underlay, for the family of the given cidr.
+func (a nodeAddresses) tunnelEndpointIP(underlay, cidr string) (net.IPNet, error) {
+	family := ipfamily.ForCIDRString(cidr)
+	i := slices.IndexFunc(a.overrides.TunnelEndpoints, func(t v1alpha1.TunnelEndpointAddresses) bool {
+		return t.Underlay == underlay
+	})
+	if i >= 0 {
+		for _, addr := range a.overrides.TunnelEndpoints[i].IPs {
+			ip := net.ParseIP(addr)
+			if ip == nil {
+				return net.IPNet{}, fmt.Errorf("invalid tunnel endpoint ip %q for underlay %s", addr, underlay)
+			}
+			if ipfamily.ForAddress(ip) != family {
+				continue
+			}
+			if family == ipfamily.IPv4 {
+				return net.IPNet{IP: ip.To4(), Mask: net.CIDRMask(32, 32)}, nil
+			}
+			return net.IPNet{IP: ip, Mask: net.CIDRMask(128, 128)}, nil
+		}
+	}
+	ip, err := ipam.TunnelEndpointIP(cidr, a.index)
+	if err != nil {
+		return net.IPNet{}, fmt.Errorf("failed to get vtep ip, cidr %s, nodeIndex %d: %w", cidr, a.index, err)
+	}
+	return ip, nil
+}
+
+// vethIPs returns the IPs of the veth legs of the given host session. The PE
+// side ones are always derived from the localCIDR, while the host side ones
+// can be overridden.
+func (a nodeAddresses) vethIPs(kind v1alpha1.HostSessionOwnerKind, name string, localCIDR v1alpha1.LocalCIDRConfig) (ipam.VethIPs, error) {
+	veths, err := ipam.VethIPsFromPool(localCIDR.IPv4, localCIDR.IPv6, a.index)
+	if err != nil {
+		return ipam.VethIPs{}, fmt.Errorf("failed to get veth ips, cidr %v, nodeIndex %d: %w", localCIDR, a.index, err)
+	}
+	i := slices.IndexFunc(a.overrides.HostSessions, func(h v1alpha1.HostSessionAddresses) bool {
+		return h.Kind == kind && h.Name == name
+	})
+	if i < 0 {
+		return veths, nil
+	}
+	override := a.overrides.HostSessions[i]
+	if override.IPv4 != nil {
+		veths.Ipv4.HostSide, err = hostSideOverride(*override.IPv4, localCIDR.IPv4, veths.Ipv4.PeSide)
+		if err != nil {
+			return ipam.VethIPs{}, fmt.Errorf("invalid ipv4 override for %s %s: %w", kind, name, err)
+		}
+	}
+	if override.IPv6 != nil {
+		veths.Ipv6.HostSide, err = hostSideOverride(*override.IPv6, localCIDR.IPv6, veths.Ipv6.PeSide)
+		if err != nil {
+			return ipam.VethIPs{}, fmt.Errorf("invalid ipv6 override for %s %s: %w", kind, name, err)
+		}
+	}
+	return veths, nil
+}
+
+// hostSideOverride returns the host side IP set explicitly, checking it
+// belongs to the local cidr and doesn't clash with the PE side one.
+func hostSideOverride(addr string, localCIDR *string, peSide net.IPNet) (net.IPNet, error) {
+	if localCIDR == nil {
+		return net.IPNet{}, fmt.Errorf("no local cidr of the family of %s", addr)
+	}
+	ip := net.ParseIP(addr)
+	if ip == nil {
+		return net.IPNet{}, fmt.Errorf("invalid ip %q", addr)
+	}
+	_, cidr, err := net.ParseCIDR(*localCIDR)
+	if err != nil {
+		return net.IPNet{}, fmt.Errorf("failed to parse local cidr %s: %w", *localCIDR, err)
+	}
+	if !cidr.Contains(ip) {
+		return net.IPNet{}, fmt.Errorf("%s does not belong to the local cidr %s", addr, *localCIDR)
+	}
+	if ip.Equal(peSide.IP) {
+		return net.IPNet{}, fmt.Errorf("%s is the PERouter side ip", addr)
+	}
+	if ipfamily.ForAddress(ip) == ipfamily.IPv4 {
+		ip = ip.To4()
+	}
+	return net.IPNet{IP: ip, Mask: cidr.Mask}, nil
+}
@@ -0,0 +1,145 @@
+// SPDX-License-Identifier:Apache-2.0
+
+package conversion
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+
+	"github.com/openperouter/openperouter/api/v1alpha1"
+	"github.com/openperouter/openperouter/internal/frr"
+	"github.com/openperouter/openperouter/internal/networklayerprotocol"
+)
+
+// routeLeak is the set of routes leaked from a VRF to another one.
+type routeLeak struct {
+	from     string
+	to       string
+	prefixes []v1alpha1.PrefixMatch
+}
+
+// routeLeaksFor returns the route leaks declared by the given L3VNIs and L3VPNs,
+// turning the exports of a VRF into imports of the target VRF.
+func routeLeaksFor(l3vnis []v1alpha1.L3VNI, l3vpns []v1alpha1.L3VPN) []routeLeak {
+	var res []routeLeak
+	add := func(vrf string, leaking *v1alpha1.RouteLeaking) {
+		if leaking == nil {
+			return
+		}
+		for _, l := range leaking.ImportFrom {
+			res = append(res, routeLeak{from: l.VRF, to: vrf, prefixes: l.Prefixes})
+		}
+		for _, l := range leaking.ExportTo {
+			res = append(res, routeLeak{from: vrf, to: l.VRF, prefixes: l.Prefixes})
+		}
+	}
+	for _, l3vni := range l3vnis {
+		add(l3vni.Spec.VRF, l3vni.Spec.RouteLeaking)
+	}
+	for _, l3vpn := range l3vpns {
+		add(l3vpn.Spec.VRF, l3vpn.Spec.RouteLeaking)
+	}
+	return res
+}
+
+// leakingVRFs returns the VRFs leaking routes to or from another VRF.
+func leakingVRFs(l3vnis []v1alpha1.L3VNI, l3vpns []v1alpha1.L3VPN) map[string]bool {
+	res := map[string]bool{}
+	for _, l := range routeLeaksFor(l3vnis, l3vpns) {
+		res[l.from] = true
+		res[l.to] = true
+	}
+	return res
+}
+
+// vrfImportsToFRR converts the route leaks to the bgp import vrf configuration
+// of each receiving VRF. The routes imported in each address family are
+// filtered by a route map with an entry per leak, matching the source VRF and
+// the prefixes of the family. A leak whose prefixes all belong to the other
+// family is skipped.
+func vrfImportsToFRR(l3vnis []v1alpha1.L3VNI, l3vpns []v1alpha1.L3VPN, underlayASN int64) []frr.VRFImport {
+	leaks := routeLeaksFor(l3vnis, l3vpns)
+	if len(leaks) == 0 {
+		return nil
+	}
+
+	// The imports must be rendered in the BGP instance of the VRF, whose
+	// ASN is the one of the host session, if any.
+	asns := map[string]int64{v1alpha1.DefaultVRF: underlayASN}
+	for _, l3vni := range l3vnis {
+		asns[l3vni.Spec.VRF] = underlayASN
+		if l3vni.Spec.HostSession != nil {
+			asns[l3vni.Spec.VRF] = l3vni.Spec.HostSession.ASN
+		}
+	}
+	for _, l3vpn := range l3vpns {
+		asns[l3vpn.Spec.VRF] = underlayASN
+		if l3vpn.Spec.HostSession != nil {
+			asns[l3vpn.Spec.VRF] = l3vpn.Spec.HostSession.ASN
+		}
+	}
+
+	leaksByVRF := map[string][]routeLeak{}
+	for _, l := range leaks {
+		leaksByVRF[l.to] = append(leaksByVRF[l.to], l)
+	}
+
+	var res []frr.VRFImport
+	for _, vrf := range slices.Sorted(maps.Keys(leaksByVRF)) {
+		vrfImport := frr.VRFImport{
+			VRF: vrf,
+			ASN: asns[vrf],
+		}
+		if vrf == v1alpha1.DefaultVRF {
+			vrfImport.VRF = ""
+		}
+		for _, afi := range []networklayerprotocol.AFI{networklayerprotocol.IPv4, networklayerprotocol.IPv6} {
+			routeMap, sources := vrfImportRouteMap(vrf, afi, leaksByVRF[vrf])
+			if len(sources) == 0 {
+				continue
+			}
+			vrfImport.RouteMaps = append(vrfImport.RouteMaps, routeMap)
+			if afi == networklayerprotocol.IPv4 {
+				vrfImport.ImportIPv4 = sources
+			} else {
+				vrfImport.ImportIPv6 = sources
+			}
+		}
+		if len(vrfImport.RouteMaps) > 0 {
+			res = append(res, vrfImport)
+		}
+	}
+	return res
+}
+
+// vrfImportRouteMap returns the route map filtering the routes imported into the
+// given VRF in the given address family, alongside the VRFs to import from.
+func vrfImportRouteMap(vrf string, afi networklayerprotocol.AFI, leaks []routeLeak) (frr.RouteMap, []string) {
+	res := frr.RouteMap{
+		Name:      fmt.Sprintf("%s-import-vrf-%s", vrf, afi),
+		AFI:       afi,
+		SAFI:      networklayerprotocol.Unicast,
+		Direction: frr.RouteMapIn,
+	}
+	var sources []string
+	for _, l := range leaks {
+		seq := (len(res.Entries) + 1) * 10
+		entry := frr.RouteMapEntry{
+			Seq:            seq,
+			Action:         "permit",
+			MatchSourceVRF: l.from,
+		}
+		if len(l.prefixes) > 0 {
+			entry.PrefixList = prefixListToFRR(fmt.Sprintf("%s-%d", res.Name, seq), l.prefixes, afi)
+			if entry.PrefixList == nil {
+				continue
+			}
+		}
+		res.Entries = append(res.Entries, entry)
+		if !slices.Contains(sources, l.from) {
+			sources = append(sources, l.from)
+		}
+	}
+	return res, sources
+}
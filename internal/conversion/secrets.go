@@ -0,0 +1,46 @@
+// SPDX-License-Identifier:Apache-2.0
+
+package conversion
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"unicode"
+
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/openperouter/openperouter/api/v1alpha1"
+)
+
+// findSecret returns the Secret with the given name. Only the Secrets
+// carrying the credentials label are read by the router, so the error
+// reminds it.
+func findSecret(secrets []corev1.Secret, name string) (corev1.Secret, error) {
+	i := slices.IndexFunc(secrets, func(s corev1.Secret) bool { return s.Name == name })
+	if i < 0 {
+		return corev1.Secret{}, fmt.Errorf("secret %s not found, it must be labeled %s", name, v1alpha1.CredentialsSecretLabel)
+	}
+	return secrets[i], nil
+}
+
+// secretPassword returns the password stored in the basic-auth Secret with
+// the given name. The password is rendered verbatim in the FRR configuration,
+// so it can't contain whitespace.
+func secretPassword(secrets []corev1.Secret, name string) (string, error) {
+	secret, err := findSecret(secrets, name)
+	if err != nil {
+		return "", err
+	}
+	if secret.Type != corev1.SecretTypeBasicAuth {
+		return "", fmt.Errorf("secret %s must be of type %s, got %q", name, corev1.SecretTypeBasicAuth, secret.Type)
+	}
+	password := string(secret.Data[corev1.BasicAuthPasswordKey])
+	if password == "" {
+		return "", fmt.Errorf("secret %s has no %s key", name, corev1.BasicAuthPasswordKey)
+	}
+	if strings.ContainsFunc(password, unicode.IsSpace) {
+		return "", fmt.Errorf("the password of secret %s must not contain whitespace", name)
+	}
+	return password, nil
+}
@@ -0,0 +1,58 @@
+// SPDX-License-Identifier:Apache-2.0
+
+package conversion
+
+import (
+	"strings"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestSecretPassword(t *testing.T) {
+	secrets := []corev1.Secret{
+		basicAuthSecret("valid", "key"),
+		basicAuthSecret("whitespace", "the key"),
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "opaque"},
+			Type:       corev1.SecretTypeOpaque,
+			Data:       map[string][]byte{corev1.BasicAuthPasswordKey: []byte("key")},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "nopassword"},
+			Type:       corev1.SecretTypeBasicAuth,
+			Data:       map[string][]byte{corev1.BasicAuthUsernameKey: []byte("user")},
+		},
+	}
+
+	tests := []struct {
+		name       string
+		secret     string
+		want       string
+		wantErrStr string
+	}{
+		{name: "valid", secret: "valid", want: "key"},
+		{name: "not found", secret: "missing", wantErrStr: "secret missing not found"},
+		{name: "wrong type", secret: "opaque", wantErrStr: "must be of type kubernetes.io/basic-auth"},
+		{name: "no password", secret: "nopassword", wantErrStr: "has no password key"},
+		{name: "whitespace", secret: "whitespace", wantErrStr: "must not contain whitespace"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := secretPassword(secrets, tt.secret)
+			if tt.wantErrStr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErrStr) {
+					t.Fatalf("expected error containing %q, got %v", tt.wantErrStr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("expected password %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,374 @@
+// SPDX-License-Identifier:Apache-2.0
+
+package conversion
+
+import (
+	"fmt"
+	"net"
+	"slices"
+	"strconv"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+
+	"github.com/openperouter/openperouter/api/v1alpha1"
+	"github.com/openperouter/openperouter/internal/filter"
+	"github.com/openperouter/openperouter/internal/ipfamily"
+)
+
+// ValidateAddressPlanForNodes checks the address plan each node gets from the
+// resources selecting it, across resource kinds.
+//
+// It rejects the subnets overlapping in a routing table of the node: the
+// default VRF holds the tunnel endpoint cidrs and the local cidrs of the
+// L3Passthroughs, each VRF the local cidrs of its L3VNI or L3VPN and the
+// gateway subnets of its L2VNIs, plus the subnets leaked from other VRFs.
+// The host learns the routes of each VRF it has a session with. The router id
+// cidr must not overlap any of those subnets, in any VRF.
+//
+// It also returns a warning for each router id or tunnel endpoint ip the node
+// would get, as computed from the node index or its NodeAddressing, that is
+// used by another owner. The nodes without an index yet are not checked for those.
+func ValidateAddressPlanForNodes(nodes []corev1.Node, config APIConfigData) ([]string, error) {
+	addressings := map[string][]v1alpha1.NodeAddressing{}
+	for _, a := range config.NodeAddressings {
+		addressings[a.Spec.NodeName] = append(addressings[a.Spec.NodeName], a)
+	}
+
+	var warnings []string
+	for _, node := range nodes {
+		nodeConfig, err := nodeAddressingConfigForNode(&node, config)
+		if err != nil {
+			return nil, err
+		}
+		nodeConfig.L2VNIs, err = filter.L2VNIsForNode(&node, config.L2VNIs)
+		if err != nil {
+			return nil, fmt.Errorf("failed to filter L2 VNIs for node %q: %w", node.Name, err)
+		}
+
+		if err := validateRoutingTables(nodeConfig); err != nil {
+			return nil, fmt.Errorf("invalid address plan for node %s: %w", node.Name, err)
+		}
+
+		index, err := strconv.Atoi(node.Annotations[v1alpha1.NodeIndexAnnotation])
+		if err != nil {
+			continue
+		}
+		addresses := nodeAddressesFor(index, addressings[node.Name])
+		for _, w := range sharedAddresses(nodeConfig, addresses) {
+			warnings = append(warnings, fmt.Sprintf("node %s: %s", node.Name, w))
+		}
+	}
+	return warnings, nil
+}
+
+// validateRoutingTables checks that the subnets of each routing table of a
+// node don't overlap.
+func validateRoutingTables(config APIConfigData) error {
+	if err := validateRouterIDCIDR(config); err != nil {
+		return err
+	}
+
+	vrfMap := createVRFMap(config.L3VNIs, config.L3VPNs)
+	own := map[string]subnets{}
+	for _, u := range config.Underlays {
+		if u.Spec.TunnelEndpoint == nil {
+			continue
+		}
+		for _, cidr := range u.Spec.TunnelEndpoint.CIDRs {
+			if _, subnet, err := net.ParseCIDR(cidr); err == nil {
+				own[v1alpha1.DefaultVRF] = append(own[v1alpha1.DefaultVRF],
+					subnetWithSource{fmt.Sprintf("Underlay %s tunnel endpoints", u.Name), subnet})
+			}
+		}
+	}
+	hostSessionVRFs := []string{}
+	for _, p := range config.L3Passthrough {
+		source := fmt.Sprintf("L3Passthrough %s", types.NamespacedName{Namespace: p.Namespace, Name: p.Name})
+		own[v1alpha1.DefaultVRF] = append(own[v1alpha1.DefaultVRF], localCIDRSubnets(source, p.Spec.HostSession.LocalCIDR)...)
+		hostSessionVRFs = append(hostSessionVRFs, v1alpha1.DefaultVRF)
+	}
+	for _, l3vni := range config.L3VNIs {
+		if l3vni.Spec.HostSession == nil {
+			continue
+		}
+		source := fmt.Sprintf("L3VNI %s", types.NamespacedName{Namespace: l3vni.Namespace, Name: l3vni.Name})
+		own[l3vni.Spec.VRF] = append(own[l3vni.Spec.VRF], localCIDRSubnets(source, l3vni.Spec.HostSession.LocalCIDR)...)
+		hostSessionVRFs = append(hostSessionVRFs, l3vni.Spec.VRF)
+	}
+	for _, l3vpn := range config.L3VPNs {
+		if l3vpn.Spec.HostSession == nil {
+			continue
+		}
+		source := fmt.Sprintf("L3VPN %s", types.NamespacedName{Namespace: l3vpn.Namespace, Name: l3vpn.Name})
+		own[l3vpn.Spec.VRF] = append(own[l3vpn.Spec.VRF], localCIDRSubnets(source, l3vpn.Spec.HostSession.LocalCIDR)...)
+		hostSessionVRFs = append(hostSessionVRFs, l3vpn.Spec.VRF)
+	}
+	for _, l2vni := range config.L2VNIs {
+		vrf := resolveVRFForL2VNI(l2vni, vrfMap)
+		if vrf == "" {
+			continue
+		}
+		source := fmt.Sprintf("L2VNI %s", types.NamespacedName{Namespace: l2vni.Namespace, Name: l2vni.Name})
+		for _, subnet := range []*net.IPNet{v4SubnetForL2(l2vni), v6SubnetForL2(l2vni)} {
+			if subnet != nil {
+				own[vrf] = append(own[vrf], subnetWithSource{source, subnet})
+			}
+		}
+	}
+
+	tables := map[string]subnets{}
+	for vrf, s := range own {
+		tables[vrf] = append(tables[vrf], s...)
+	}
+	for _, l := range routeLeaksFor(config.L3VNIs, config.L3VPNs) {
+		for _, s := range own[l.from] {
+			if leakedSubnet(s.subnet, l.prefixes) {
+				tables[l.to] = append(tables[l.to], s)
+			}
+		}
+	}
+
+	vrfs := make([]string, 0, len(tables))
+	for vrf := range tables {
+		vrfs = append(vrfs, vrf)
+	}
+	slices.Sort(vrfs)
+	for _, vrf := range vrfs {
+		name := fmt.Sprintf("VRF %q", vrf)
+		if vrf == v1alpha1.DefaultVRF {
+			name = "the default VRF"
+		}
+		if err := validateRoutingTable(name, tables[vrf]); err != nil {
+			return err
+		}
+	}
+
+	var host subnets
+	for _, vrf := range hostSessionVRFs {
+		host = append(host, tables[vrf]...)
+	}
+	return validateRoutingTable("the host routing table", host)
+}
+
+// validateRouterIDCIDR checks that the router id cidr, shared by the underlays
+// of the node, doesn't overlap the local cidrs of the host sessions, the
+// gateway subnets of the L2VNIs and the tunnel endpoints of the other
+// underlays. The tunnel endpoints of the first underlay, which the router id
+// is taken from, are allowed to match it.
+func validateRouterIDCIDR(config APIConfigData) error {
+	if len(config.Underlays) == 0 {
+		return nil
+	}
+	underlay := config.Underlays[0]
+	_, routerIDs, err := net.ParseCIDR(ptr.Deref(underlay.Spec.RouterIDCIDR, ""))
+	if err != nil {
+		return nil
+	}
+
+	var others subnets
+	for _, u := range config.Underlays[1:] {
+		if u.Spec.TunnelEndpoint == nil {
+			continue
+		}
+		for _, cidr := range u.Spec.TunnelEndpoint.CIDRs {
+			if _, subnet, err := net.ParseCIDR(cidr); err == nil {
+				others = append(others, subnetWithSource{fmt.Sprintf("Underlay %s tunnel endpoints", u.Name), subnet})
+			}
+		}
+	}
+	for _, p := range config.L3Passthrough {
+		source := fmt.Sprintf("L3Passthrough %s", types.NamespacedName{Namespace: p.Namespace, Name: p.Name})
+		others = append(others, localCIDRSubnets(source, p.Spec.HostSession.LocalCIDR)...)
+	}
+	for _, l3vni := range config.L3VNIs {
+		if l3vni.Spec.HostSession == nil {
+			continue
+		}
+		source := fmt.Sprintf("L3VNI %s", types.NamespacedName{Namespace: l3vni.Namespace, Name: l3vni.Name})
+		others = append(others, localCIDRSubnets(source, l3vni.Spec.HostSession.LocalCIDR)...)
+	}
+	for _, l3vpn := range config.L3VPNs {
+		if l3vpn.Spec.HostSession == nil {
+			continue
+		}
+		source := fmt.Sprintf("L3VPN %s", types.NamespacedName{Namespace: l3vpn.Namespace, Name: l3vpn.Name})
+		others = append(others, localCIDRSubnets(source, l3vpn.Spec.HostSession.LocalCIDR)...)
+	}
+	for _, l2vni := range config.L2VNIs {
+		source := fmt.Sprintf("L2VNI %s", types.NamespacedName{Namespace: l2vni.Namespace, Name: l2vni.Name})
+		if subnet := v4SubnetForL2(l2vni); subnet != nil {
+			others = append(others, subnetWithSource{source, subnet})
+		}
+	}
+
+	for _, s := range others {
+		if s.subnet.Contains(routerIDs.IP) || routerIDs.Contains(s.subnet.IP) {
+			return fmt.Errorf("subnet overlap with the router ids: IPNet %s (%s) overlaps with IPNet %s (Underlay %s router ids)",
+				s.subnet.String(), s.source, routerIDs.String(), underlay.Name)
+		}
+	}
+	return nil
+}
+
+func validateRoutingTable(name string, table subnets) error {
+	v4, v6 := splitSubnetsByFamily(dedupSubnets(table))
+	for _, s := range []subnets{v4, v6} {
+		s.sort()
+		if err := hasSubnetOverlap(s); err != nil {
+			return fmt.Errorf("subnet overlap in %s: %w", name, err)
+		}
+	}
+	return nil
+}
+
+func localCIDRSubnets(source string, localCIDR v1alpha1.LocalCIDRConfig) subnets {
+	var res subnets
+	for _, cidr := range []string{ptr.Deref(localCIDR.IPv4, ""), ptr.Deref(localCIDR.IPv6, "")} {
+		if cidr == "" {
+			continue
+		}
+		if _, subnet, err := net.ParseCIDR(cidr); err == nil {
+			res = append(res, subnetWithSource{source, subnet})
+		}
+	}
+	return res
+}
+
+// leakedSubnet tells if the subnet is leaked by a leak restricted to the
+// given prefixes, meaning it overlaps with any of them.
+func leakedSubnet(subnet *net.IPNet, prefixes []v1alpha1.PrefixMatch) bool {
+	if len(prefixes) == 0 {
+		return true
+	}
+	for _, p := range prefixes {
+		overlap, err := cidrsOverlap(subnet.String(), p.Prefix)
+		if err == nil && overlap {
+			return true
+		}
+	}
+	return false
+}
+
+// dedupSubnets removes the subnets reaching a routing table more than once,
+// as when a VRF with a host session leaks into another one.
+func dedupSubnets(s subnets) subnets {
+	seen := map[string]bool{}
+	res := subnets{}
+	for _, e := range s {
+		key := e.source + " " + e.subnet.String()
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		res = append(res, e)
+	}
+	return res
+}
+
+func splitSubnetsByFamily(s subnets) (subnets, subnets) {
+	var v4, v6 subnets
+	for _, e := range s {
+		if ipfamily.ForCIDR(e.subnet) == ipfamily.IPv4 {
+			v4 = append(v4, e)
+			continue
+		}
+		v6 = append(v6, e)
+	}
+	return v4, v6
+}
+
+// sharedAddresses returns a description of each router id or tunnel endpoint
+// ip the node would get that is used by another owner. The same address used
+// in different VRFs is not reported, as they are isolated, and the tunnel
+// endpoint ip of an underlay is allowed to match its router id.
+func sharedAddresses(config APIConfigData, addresses nodeAddresses) []string {
+	type owner struct {
+		name     string
+		underlay string
+	}
+	owners := map[string][]owner{}
+	var order []string
+	add := func(ip net.IP, o owner) {
+		if ip == nil {
+			return
+		}
+		key := ip.String()
+		if _, ok := owners[key]; !ok {
+			order = append(order, key)
+		}
+		owners[key] = append(owners[key], o)
+	}
+
+	if len(config.Underlays) > 0 {
+		if routerID, err := addresses.routerID(config.Underlays[0]); err == nil {
+			add(net.ParseIP(routerID), owner{name: "the router id", underlay: config.Underlays[0].Name})
+		}
+	}
+	for _, u := range config.Underlays {
+		if u.Spec.TunnelEndpoint == nil {
+			continue
+		}
+		for _, cidr := range u.Spec.TunnelEndpoint.CIDRs {
+			if ip, err := addresses.tunnelEndpointIP(u.Name, cidr); err == nil {
+				add(ip.IP, owner{name: fmt.Sprintf("the tunnel endpoint of Underlay %s", u.Name), underlay: u.Name})
+			}
+		}
+	}
+	addHostSession := func(kind v1alpha1.HostSessionOwnerKind, name string, hostSession *v1alpha1.HostSession) {
+		if hostSession == nil {
+			return
+		}
+		veths, err := addresses.vethIPs(kind, name, hostSession.LocalCIDR)
+		if err != nil {
+			return
+		}
+		add(veths.Ipv4.PeSide.IP, owner{name: fmt.Sprintf("the router side of the host session of %s %s", kind, name)})
+		add(veths.Ipv4.HostSide.IP, owner{name: fmt.Sprintf("the host side of the host session of %s %s", kind, name)})
+		add(veths.Ipv6.PeSide.IP, owner{name: fmt.Sprintf("the router side of the host session of %s %s", kind, name)})
+		add(veths.Ipv6.HostSide.IP, owner{name: fmt.Sprintf("the host side of the host session of %s %s", kind, name)})
+	}
+	for _, l3vni := range config.L3VNIs {
+		addHostSession(v1alpha1.HostSessionOwnerKindL3VNI, l3vni.Name, l3vni.Spec.HostSession)
+	}
+	for _, l3vpn := range config.L3VPNs {
+		addHostSession(v1alpha1.HostSessionOwnerKindL3VPN, l3vpn.Name, l3vpn.Spec.HostSession)
+	}
+	for _, p := range config.L3Passthrough {
+		addHostSession(v1alpha1.HostSessionOwnerKindL3Passthrough, p.Name, &p.Spec.HostSession)
+	}
+	for _, l2vni := range config.L2VNIs {
+		for _, gw := range l2vni.Spec.GatewayIPs {
+			if ip, _, err := net.ParseCIDR(gw); err == nil {
+				add(ip, owner{name: fmt.Sprintf("the gateway of L2VNI %s", l2vni.Name)})
+			}
+		}
+	}
+
+	var res []string
+	for _, address := range order {
+		o := owners[address]
+		if len(o) < 2 {
+			continue
+		}
+		if !slices.ContainsFunc(o, func(e owner) bool { return e.underlay != "" }) {
+			continue
+		}
+		if len(o) == 2 && o[0].underlay == o[1].underlay {
+			continue
+		}
+		names := make([]string, 0, len(o))
+		for _, e := range o {
+			names = append(names, e.name)
+		}
+		res = append(res, fmt.Sprintf("address %s is used by %s", address, joinOwners(names)))
+	}
+	return res
+}
+
+func joinOwners(names []string) string {
+	last := len(names) - 1
+	return strings.Join(names[:last], ", ") + " and " + names[last]
+}
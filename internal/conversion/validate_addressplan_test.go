@@ -0,0 +1,250 @@
+// SPDX-License-Identifier:Apache-2.0
+
+package conversion
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	v1alpha1 "github.com/openperouter/openperouter/api/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestValidateAddressPlanForNodes(t *testing.T) {
+	underlay := v1alpha1.Underlay{
+		ObjectMeta: metav1.ObjectMeta{Name: "underlay"},
+		Spec: v1alpha1.UnderlaySpec{
+			RouterIDCIDR:   new("10.0.0.0/24"),
+			TunnelEndpoint: &v1alpha1.TunnelEndpointConfig{CIDRs: []string{"100.65.0.0/24"}},
+		},
+	}
+	l3vni := func(name, vrf, localCIDR string, leaking *v1alpha1.RouteLeaking) v1alpha1.L3VNI {
+		return v1alpha1.L3VNI{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "ns"},
+			Spec: v1alpha1.L3VNISpec{
+				VRF:          vrf,
+				HostSession:  &v1alpha1.HostSession{LocalCIDR: v1alpha1.LocalCIDRConfig{IPv4: new(localCIDR)}},
+				RouteLeaking: leaking,
+			},
+		}
+	}
+	passthrough := func(localCIDR string, selector *metav1.LabelSelector) v1alpha1.L3Passthrough {
+		return v1alpha1.L3Passthrough{
+			ObjectMeta: metav1.ObjectMeta{Name: "passthrough", Namespace: "ns"},
+			Spec: v1alpha1.L3PassthroughSpec{
+				NodeSelector: selector,
+				HostSession:  v1alpha1.HostSession{LocalCIDR: v1alpha1.LocalCIDRConfig{IPv4: new(localCIDR)}},
+			},
+		}
+	}
+	l2vni := func(name, l3vni, gateway string) v1alpha1.L2VNI {
+		return v1alpha1.L2VNI{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "ns"},
+			Spec: v1alpha1.L2VNISpec{
+				RoutingDomain: l3vniRoutingDomain(l3vni),
+				GatewayIPs:    []string{gateway},
+			},
+		}
+	}
+	routerIDOverride := func(node, routerID string) v1alpha1.NodeAddressing {
+		return v1alpha1.NodeAddressing{
+			ObjectMeta: metav1.ObjectMeta{Name: node, Namespace: "ns"},
+			Spec:       v1alpha1.NodeAddressingSpec{NodeName: node, RouterID: new(routerID)},
+		}
+	}
+	importFrom := func(vrf string, prefixes ...string) *v1alpha1.RouteLeaking {
+		leak := v1alpha1.VRFRouteLeak{VRF: vrf}
+		for _, p := range prefixes {
+			leak.Prefixes = append(leak.Prefixes, v1alpha1.PrefixMatch{Prefix: p})
+		}
+		return &v1alpha1.RouteLeaking{ImportFrom: []v1alpha1.VRFRouteLeak{leak}}
+	}
+
+	nodes := []corev1.Node{
+		indexedNode("node0", "0"),
+		indexedNode("node1", "1"),
+	}
+	nodes[1].Labels = map[string]string{"rack": "b"}
+
+	tests := []struct {
+		name         string
+		config       APIConfigData
+		nodes        []corev1.Node
+		wantErr      string
+		wantWarnings []string
+	}{
+		{
+			name: "valid plan",
+			config: APIConfigData{
+				Underlays:     []v1alpha1.Underlay{underlay},
+				L3VNIs:        []v1alpha1.L3VNI{l3vni("red", "red", "192.169.10.0/24", nil)},
+				L2VNIs:        []v1alpha1.L2VNI{l2vni("red-l2", "red", "192.170.1.1/24")},
+				L3Passthrough: []v1alpha1.L3Passthrough{passthrough("192.169.11.0/24", nil)},
+			},
+		},
+		{
+			name: "passthrough overlapping the tunnel endpoints",
+			config: APIConfigData{
+				Underlays:     []v1alpha1.Underlay{underlay},
+				L3Passthrough: []v1alpha1.L3Passthrough{passthrough("100.65.0.0/16", nil)},
+			},
+			wantErr: "invalid address plan for node node0: subnet overlap in the default VRF",
+		},
+		{
+			name: "l3vni overlapping the tunnel endpoints learned by the host",
+			config: APIConfigData{
+				Underlays:     []v1alpha1.Underlay{underlay},
+				L3VNIs:        []v1alpha1.L3VNI{l3vni("red", "red", "100.65.0.0/25", nil)},
+				L3Passthrough: []v1alpha1.L3Passthrough{passthrough("192.169.11.0/24", nil)},
+			},
+			wantErr: "subnet overlap in the host routing table",
+		},
+		{
+			name: "overlap only on the nodes selected by the passthrough",
+			config: APIConfigData{
+				Underlays: []v1alpha1.Underlay{underlay},
+				L3VNIs:    []v1alpha1.L3VNI{l3vni("red", "red", "100.65.0.0/25", nil)},
+				L3Passthrough: []v1alpha1.L3Passthrough{passthrough("192.169.11.0/24",
+					&metav1.LabelSelector{MatchLabels: map[string]string{"rack": "b"}})},
+			},
+			nodes:   []corev1.Node{{ObjectMeta: metav1.ObjectMeta{Name: "node0"}}, nodes[1]},
+			wantErr: "invalid address plan for node node1",
+		},
+		{
+			name: "overlapping subnets in isolated VRFs",
+			config: APIConfigData{
+				L3VNIs: []v1alpha1.L3VNI{
+					l3vni("red", "red", "192.169.10.0/24", nil),
+					{ObjectMeta: metav1.ObjectMeta{Name: "blue", Namespace: "ns"}, Spec: v1alpha1.L3VNISpec{VRF: "blue"}},
+				},
+				L2VNIs: []v1alpha1.L2VNI{l2vni("blue-l2", "blue", "192.169.10.1/24")},
+			},
+		},
+		{
+			name: "overlapping subnets leaked between VRFs",
+			config: APIConfigData{
+				L3VNIs: []v1alpha1.L3VNI{
+					l3vni("red", "red", "192.169.10.0/24", importFrom("blue")),
+					{ObjectMeta: metav1.ObjectMeta{Name: "blue", Namespace: "ns"}, Spec: v1alpha1.L3VNISpec{VRF: "blue"}},
+				},
+				L2VNIs: []v1alpha1.L2VNI{l2vni("blue-l2", "blue", "192.169.10.1/24")},
+			},
+			wantErr: `subnet overlap in VRF "red": IPNet 192.169.10.0/24 (L3VNI ns/red) overlaps with IPNet 192.169.10.0/24 (L2VNI ns/blue-l2)`,
+		},
+		{
+			name: "overlapping subnet not matching the leaked prefixes",
+			config: APIConfigData{
+				L3VNIs: []v1alpha1.L3VNI{
+					l3vni("red", "red", "192.169.10.0/24", importFrom("blue", "172.16.0.0/16")),
+					{ObjectMeta: metav1.ObjectMeta{Name: "blue", Namespace: "ns"}, Spec: v1alpha1.L3VNISpec{VRF: "blue"}},
+				},
+				L2VNIs: []v1alpha1.L2VNI{l2vni("blue-l2", "blue", "192.169.10.1/24")},
+			},
+		},
+		{
+			name: "l2vni gateway overlapping the passthrough on the host",
+			config: APIConfigData{
+				L3VNIs:        []v1alpha1.L3VNI{l3vni("red", "red", "192.169.10.0/24", nil)},
+				L2VNIs:        []v1alpha1.L2VNI{l2vni("red-l2", "red", "192.169.11.1/24")},
+				L3Passthrough: []v1alpha1.L3Passthrough{passthrough("192.169.11.0/24", nil)},
+			},
+			wantErr: "subnet overlap in the host routing table",
+		},
+		{
+			name: "host session overlapping the router id cidr",
+			config: APIConfigData{
+				Underlays: []v1alpha1.Underlay{underlay},
+				L3VNIs:    []v1alpha1.L3VNI{l3vni("red", "red", "10.0.0.0/25", nil)},
+			},
+			wantErr: "invalid address plan for node node0: subnet overlap with the router ids: " +
+				"IPNet 10.0.0.0/25 (L3VNI ns/red) overlaps with IPNet 10.0.0.0/24 (Underlay underlay router ids)",
+		},
+		{
+			name: "passthrough overlapping the router id cidr",
+			config: APIConfigData{
+				Underlays:     []v1alpha1.Underlay{underlay},
+				L3Passthrough: []v1alpha1.L3Passthrough{passthrough("10.0.0.0/16", nil)},
+			},
+			wantErr: "IPNet 10.0.0.0/16 (L3Passthrough ns/passthrough) overlaps with IPNet 10.0.0.0/24 (Underlay underlay router ids)",
+		},
+		{
+			name: "l2vni gateway overlapping the router id cidr",
+			config: APIConfigData{
+				Underlays: []v1alpha1.Underlay{underlay},
+				L3VNIs:    []v1alpha1.L3VNI{l3vni("red", "red", "192.169.10.0/24", nil)},
+				L2VNIs:    []v1alpha1.L2VNI{l2vni("red-l2", "red", "10.0.0.129/25")},
+			},
+			wantErr: "IPNet 10.0.0.128/25 (L2VNI ns/red-l2) overlaps with IPNet 10.0.0.0/24 (Underlay underlay router ids)",
+		},
+		{
+			name: "tunnel endpoints of another underlay overlapping the router id cidr",
+			config: APIConfigData{
+				Underlays: []v1alpha1.Underlay{underlay, {
+					ObjectMeta: metav1.ObjectMeta{Name: "other"},
+					Spec: v1alpha1.UnderlaySpec{
+						RouterIDCIDR:   new("10.0.0.0/24"),
+						TunnelEndpoint: &v1alpha1.TunnelEndpointConfig{CIDRs: []string{"10.0.0.0/24"}},
+					},
+				}},
+			},
+			wantErr: "IPNet 10.0.0.0/24 (Underlay other tunnel endpoints) overlaps with IPNet 10.0.0.0/24 (Underlay underlay router ids)",
+		},
+		{
+			name: "tunnel endpoints of the underlay matching the router id cidr",
+			config: APIConfigData{
+				Underlays: []v1alpha1.Underlay{{
+					ObjectMeta: metav1.ObjectMeta{Name: "underlay"},
+					Spec: v1alpha1.UnderlaySpec{
+						RouterIDCIDR:   new("10.0.0.0/24"),
+						TunnelEndpoint: &v1alpha1.TunnelEndpointConfig{CIDRs: []string{"10.0.0.0/24"}},
+					},
+				}},
+			},
+		},
+		{
+			name: "router id matching a host session ip",
+			config: APIConfigData{
+				Underlays:       []v1alpha1.Underlay{underlay},
+				L3VNIs:          []v1alpha1.L3VNI{l3vni("red", "red", "192.169.10.0/24", nil)},
+				NodeAddressings: []v1alpha1.NodeAddressing{routerIDOverride("node0", "192.169.10.1")},
+			},
+			wantWarnings: []string{
+				"node node0: address 192.169.10.1 is used by the router id and the router side of the host session of L3VNI red",
+			},
+		},
+		{
+			name: "tunnel endpoint matching a host session ip",
+			config: APIConfigData{
+				Underlays: []v1alpha1.Underlay{underlay},
+				L3VNIs:    []v1alpha1.L3VNI{l3vni("red", "red", "100.65.0.0/24", nil)},
+			},
+			wantWarnings: []string{
+				"node node1: address 100.65.0.1 is used by the tunnel endpoint of Underlay underlay and the router side of the host session of L3VNI red",
+			},
+		},
+		{
+			name: "nodes without index are not checked for shared addresses",
+			config: APIConfigData{
+				Underlays:       []v1alpha1.Underlay{underlay},
+				L3VNIs:          []v1alpha1.L3VNI{l3vni("red", "red", "192.169.10.0/24", nil)},
+				NodeAddressings: []v1alpha1.NodeAddressing{routerIDOverride("node0", "192.169.10.1")},
+			},
+			nodes: []corev1.Node{{ObjectMeta: metav1.ObjectMeta{Name: "node0"}}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			testNodes := tt.nodes
+			if testNodes == nil {
+				testNodes = nodes
+			}
+			warnings, err := ValidateAddressPlanForNodes(testNodes, tt.config)
+			checkNodeAddressingError(t, err, tt.wantErr)
+			if diff := cmp.Diff(tt.wantWarnings, warnings); diff != "" {
+				t.Fatalf("unexpected warnings (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
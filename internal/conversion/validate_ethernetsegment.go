@@ -0,0 +1,139 @@
+// SPDX-License-Identifier:Apache-2.0
+
+package conversion
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+
+	"github.com/openperouter/openperouter/api/v1alpha1"
+	openpeerrors "github.com/openperouter/openperouter/internal/errors"
+)
+
+const (
+	esiLength        = 10
+	esiTypeArbitrary = 0x00
+	esiTypeMACBased  = 0x03
+	maxDiscriminator = 1<<24 - 1
+	maxDFPreference  = 1<<16 - 1
+)
+
+// validateEthernetSegment validates the Ethernet Segment of an L2VNI.
+func validateEthernetSegment(es *v1alpha1.EthernetSegment) error {
+	if es == nil {
+		return nil
+	}
+	if (es.ESI != nil) == (es.LocalDiscriminator != nil) {
+		return errors.New("exactly one of esi and localDiscriminator must be set")
+	}
+	if (es.LocalDiscriminator != nil) != (es.SystemMAC != nil) {
+		return errors.New("systemMAC must be set together with localDiscriminator")
+	}
+	if es.ESI != nil {
+		if _, err := parseType0ESI(*es.ESI); err != nil {
+			return err
+		}
+	}
+	if es.LocalDiscriminator != nil && (*es.LocalDiscriminator < 1 || *es.LocalDiscriminator > maxDiscriminator) {
+		return fmt.Errorf("invalid localDiscriminator %d: must be between 1 and %d", *es.LocalDiscriminator, maxDiscriminator)
+	}
+	if es.SystemMAC != nil {
+		mac, err := net.ParseMAC(*es.SystemMAC)
+		if err != nil || len(mac) != 6 {
+			return fmt.Errorf("invalid systemMAC %q", *es.SystemMAC)
+		}
+		if mac[0]&0x01 != 0 || isZero(mac) {
+			return fmt.Errorf("invalid systemMAC %q: must be a non zero unicast address", *es.SystemMAC)
+		}
+	}
+	if es.DFPreference != nil && (*es.DFPreference < 1 || *es.DFPreference > maxDFPreference) {
+		return fmt.Errorf("invalid dfPreference %d: must be between 1 and %d", *es.DFPreference, maxDFPreference)
+	}
+	return nil
+}
+
+// parseType0ESI parses a type 0 ESI in the ten colon separated octets form.
+// The all zeros value is reserved (RFC 7432 section 5).
+func parseType0ESI(esi string) ([]byte, error) {
+	fields := strings.Split(esi, ":")
+	if len(fields) != esiLength {
+		return nil, fmt.Errorf("invalid esi %q: expected %d colon separated octets", esi, esiLength)
+	}
+	res := make([]byte, 0, esiLength)
+	for _, f := range fields {
+		b, err := strconv.ParseUint(f, 16, 8)
+		if err != nil || len(f) != 2 {
+			return nil, fmt.Errorf("invalid esi %q: octet %q is not two hex digits", esi, f)
+		}
+		res = append(res, byte(b))
+	}
+	if res[0] != esiTypeArbitrary {
+		return nil, fmt.Errorf("invalid esi %q: only type 0 ESIs are supported, the first octet must be 00", esi)
+	}
+	if isZero(res[1:]) {
+		return nil, fmt.Errorf("invalid esi %q: the all zeros ESI is reserved", esi)
+	}
+	return res, nil
+}
+
+// esiForSegment returns the canonical ESI of the segment, deriving the type 3
+// ESI from the system MAC and the local discriminator when needed. The
+// segment is expected to be valid.
+func esiForSegment(es v1alpha1.EthernetSegment) string {
+	var esi []byte
+	if es.ESI != nil {
+		esi, _ = parseType0ESI(*es.ESI)
+	} else {
+		mac, _ := net.ParseMAC(*es.SystemMAC)
+		disc := uint32(*es.LocalDiscriminator)
+		esi = append([]byte{esiTypeMACBased}, mac...)
+		esi = append(esi, byte(disc>>16), byte(disc>>8), byte(disc))
+	}
+	parts := make([]string, 0, len(esi))
+	for _, b := range esi {
+		parts = append(parts, fmt.Sprintf("%02x", b))
+	}
+	return strings.Join(parts, ":")
+}
+
+// FilterUniqueEthernetSegments removes the L2VNIs whose Ethernet Segment is
+// already used by another L2VNI. A segment is attached to a single interface
+// of a node, so two L2VNIs selecting the same node can't share an ESI.
+func FilterUniqueEthernetSegments(l2Vnis []v1alpha1.L2VNI) ([]v1alpha1.L2VNI, error) {
+	reason := v1alpha1.FailedResourceReasonValidationFailed
+	var allErrors []error
+
+	existingESIs := map[string]string{}
+	var valid []v1alpha1.L2VNI
+	for _, l2 := range l2Vnis {
+		if l2.Spec.EthernetSegment == nil {
+			valid = append(valid, l2)
+			continue
+		}
+		esi := esiForSegment(*l2.Spec.EthernetSegment)
+		if existing, ok := existingESIs[esi]; ok {
+			allErrors = append(allErrors, &openpeerrors.ResourceError{
+				Obj: v1alpha1.FailedResource{
+					Kind: "L2VNI", Name: l2.Name, Reason: reason,
+					Message: fmt.Sprintf("duplicate ethernet segment %s:%s", esi, existing),
+				},
+			})
+			continue
+		}
+		existingESIs[esi] = "L2VNI/" + l2.Name
+		valid = append(valid, l2)
+	}
+	return valid, errors.Join(allErrors...)
+}
+
+func isZero(b []byte) bool {
+	for _, v := range b {
+		if v != 0 {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,103 @@
+// SPDX-License-Identifier:Apache-2.0
+
+package conversion
+
+import (
+	"testing"
+
+	"github.com/openperouter/openperouter/api/v1alpha1"
+)
+
+func TestValidateEthernetSegment(t *testing.T) {
+	tests := []struct {
+		name    string
+		es      *v1alpha1.EthernetSegment
+		wantErr string
+	}{
+		{
+			name: "no segment",
+		},
+		{
+			name: "valid type 0 esi",
+			es:   &v1alpha1.EthernetSegment{ESI: new("00:11:22:33:44:55:66:77:88:AA")},
+		},
+		{
+			name: "valid type 3 esi",
+			es: &v1alpha1.EthernetSegment{
+				LocalDiscriminator: new(int32(1)),
+				SystemMAC:          new("44:38:39:ff:00:01"),
+				DFPreference:       new(int32(50000)),
+			},
+		},
+		{
+			name:    "neither esi nor local discriminator",
+			es:      &v1alpha1.EthernetSegment{DFPreference: new(int32(100))},
+			wantErr: "exactly one of esi and localDiscriminator must be set",
+		},
+		{
+			name: "both esi and local discriminator",
+			es: &v1alpha1.EthernetSegment{
+				ESI:                new("00:11:22:33:44:55:66:77:88:99"),
+				LocalDiscriminator: new(int32(1)),
+				SystemMAC:          new("44:38:39:ff:00:01"),
+			},
+			wantErr: "exactly one of esi and localDiscriminator must be set",
+		},
+		{
+			name:    "local discriminator without system mac",
+			es:      &v1alpha1.EthernetSegment{LocalDiscriminator: new(int32(1))},
+			wantErr: "systemMAC must be set together with localDiscriminator",
+		},
+		{
+			name:    "esi too short",
+			es:      &v1alpha1.EthernetSegment{ESI: new("00:11:22:33:44:55:66:77:88")},
+			wantErr: "invalid esi \"00:11:22:33:44:55:66:77:88\": expected 10 colon separated octets",
+		},
+		{
+			name:    "esi with invalid octet",
+			es:      &v1alpha1.EthernetSegment{ESI: new("00:11:22:33:44:55:66:77:88:9g")},
+			wantErr: "invalid esi \"00:11:22:33:44:55:66:77:88:9g\": octet \"9g\" is not two hex digits",
+		},
+		{
+			name:    "esi of another type",
+			es:      &v1alpha1.EthernetSegment{ESI: new("01:11:22:33:44:55:66:77:88:99")},
+			wantErr: "invalid esi \"01:11:22:33:44:55:66:77:88:99\": only type 0 ESIs are supported, the first octet must be 00",
+		},
+		{
+			name: "multicast system mac",
+			es: &v1alpha1.EthernetSegment{
+				LocalDiscriminator: new(int32(1)),
+				SystemMAC:          new("01:00:5e:00:00:01"),
+			},
+			wantErr: "invalid systemMAC \"01:00:5e:00:00:01\": must be a non zero unicast address",
+		},
+		{
+			name: "local discriminator out of range",
+			es: &v1alpha1.EthernetSegment{
+				LocalDiscriminator: new(int32(16777216)),
+				SystemMAC:          new("44:38:39:ff:00:01"),
+			},
+			wantErr: "invalid localDiscriminator 16777216: must be between 1 and 16777215",
+		},
+		{
+			name: "df preference out of range",
+			es: &v1alpha1.EthernetSegment{
+				ESI:          new("00:11:22:33:44:55:66:77:88:99"),
+				DFPreference: new(int32(65536)),
+			},
+			wantErr: "invalid dfPreference 65536: must be between 1 and 65535",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateEthernetSegment(tt.es)
+			gotErr := ""
+			if err != nil {
+				gotErr = err.Error()
+			}
+			if gotErr != tt.wantErr {
+				t.Errorf("validateEthernetSegment() error = %q, want %q", gotErr, tt.wantErr)
+			}
+		})
+	}
+}
@@ -15,11 +15,19 @@ func ValidateGroutL3Passthrough(l3Passthrough v1alpha1.L3Passthrough) error {
 }
 
 func ValidateGroutL3VNI(l3VNI v1alpha1.L3VNI) error {
-	return fmt.Errorf("L3VNI resources are not supported when grout datapath is enabled")
+	return nil
 }
 
 func ValidateGroutL2VNI(l2VNI v1alpha1.L2VNI) error {
-	return fmt.Errorf("L2VNI resources are not supported when grout datapath is enabled")
+	// grout always suppresses ARP and ND, so only disabling it is unsupported.
+	if l2VNI.Spec.NeighborSuppression != nil && *l2VNI.Spec.NeighborSuppression == v1alpha1.NeighborSuppressionDisabled {
+		return fmt.Errorf("neighborSuppression Disabled is not supported with the grout datapath")
+	}
+	// grout does not configure L3VPNs, so it has no VRF to route through.
+	if l2VNI.Spec.RoutingDomain != nil && l2VNI.Spec.RoutingDomain.Type == v1alpha1.RoutingDomainTypeL3VPN {
+		return fmt.Errorf("routingDomain %s is not supported with the grout datapath", v1alpha1.RoutingDomainTypeL3VPN)
+	}
+	return nil
 }
 
 func ValidateGroutUnderlay(underlay v1alpha1.Underlay) error {
@@ -28,6 +36,10 @@ func ValidateGroutUnderlay(underlay v1alpha1.Underlay) error {
 			return fmt.Errorf("CNI dev underlays are not supported with the grout datapath")
 		}
 	}
+	if underlay.Spec.TunnelEndpoint != nil &&
+		underlay.Spec.TunnelEndpoint.VXLANMode == v1alpha1.VXLANModeSingleVXLANDevice {
+		return fmt.Errorf("vxlanMode %s is not supported with the grout datapath", v1alpha1.VXLANModeSingleVXLANDevice)
+	}
 
 	// The grout port name is the interface name with the underlay prefix,
 	// so every interface name must leave room for it, regardless of how
@@ -11,15 +11,97 @@ import (
 
 func TestValidateGroutL2VNI(t *testing.T) {
 	err := ValidateGroutL2VNI(v1alpha1.L2VNI{})
-	if err == nil {
-		t.Error("ValidateGroutL2VNI() expected error, got nil")
+	if err != nil {
+		t.Errorf("ValidateGroutL2VNI() unexpected error: %v", err)
+	}
+}
+
+func TestValidateGroutL2VNINeighborSuppression(t *testing.T) {
+	tests := []struct {
+		name                string
+		neighborSuppression *v1alpha1.NeighborSuppression
+		wantErr             string
+	}{
+		{
+			name: "unset",
+		},
+		{
+			name:                "enabled",
+			neighborSuppression: new(v1alpha1.NeighborSuppressionEnabled),
+		},
+		{
+			name:                "disabled",
+			neighborSuppression: new(v1alpha1.NeighborSuppressionDisabled),
+			wantErr:             "neighborSuppression Disabled is not supported with the grout datapath",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l2vni := v1alpha1.L2VNI{
+				Spec: v1alpha1.L2VNISpec{
+					NeighborSuppression: tt.neighborSuppression,
+				},
+			}
+			obtainedErr := ""
+			err := ValidateGroutL2VNI(l2vni)
+			if err != nil {
+				obtainedErr = err.Error()
+			}
+			if obtainedErr != tt.wantErr {
+				t.Errorf("ValidateGroutL2VNI() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateGroutL2VNIRoutingDomain(t *testing.T) {
+	tests := []struct {
+		name          string
+		routingDomain *v1alpha1.RoutingDomain
+		wantErr       string
+	}{
+		{
+			name: "unset",
+		},
+		{
+			name: "l3vni",
+			routingDomain: &v1alpha1.RoutingDomain{
+				Type:  v1alpha1.RoutingDomainTypeL3VNI,
+				L3VNI: &v1alpha1.L3VNIReference{Name: "red"},
+			},
+		},
+		{
+			name: "l3vpn",
+			routingDomain: &v1alpha1.RoutingDomain{
+				Type:  v1alpha1.RoutingDomainTypeL3VPN,
+				L3VPN: &v1alpha1.L3VPNReference{Name: "red"},
+			},
+			wantErr: "routingDomain L3VPN is not supported with the grout datapath",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l2vni := v1alpha1.L2VNI{
+				Spec: v1alpha1.L2VNISpec{
+					RoutingDomain: tt.routingDomain,
+				},
+			}
+			obtainedErr := ""
+			err := ValidateGroutL2VNI(l2vni)
+			if err != nil {
+				obtainedErr = err.Error()
+			}
+			if obtainedErr != tt.wantErr {
+				t.Errorf("ValidateGroutL2VNI() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
 	}
 }
 
 func TestValidateGroutL3VNI(t *testing.T) {
 	err := ValidateGroutL3VNI(v1alpha1.L3VNI{})
-	if err == nil {
-		t.Error("ValidateGroutL3VNI() expected error, got nil")
+	if err != nil {
+		t.Errorf("ValidateGroutL3VNI() unexpected error: %v", err)
 	}
 }
 
@@ -133,3 +215,19 @@ func TestValidateGroutUnderlay(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateGroutUnderlaySingleVXLANDevice(t *testing.T) {
+	underlay := v1alpha1.Underlay{
+		Spec: v1alpha1.UnderlaySpec{
+			TunnelEndpoint: &v1alpha1.TunnelEndpointConfig{
+				CIDRs:     []string{"100.65.0.0/24"},
+				VXLANMode: v1alpha1.VXLANModeSingleVXLANDevice,
+			},
+		},
+	}
+	err := ValidateGroutUnderlay(underlay)
+	want := "vxlanMode SingleVXLANDevice is not supported with the grout datapath"
+	if err == nil || err.Error() != want {
+		t.Errorf("ValidateGroutUnderlay() error = %v, wantErr %v", err, want)
+	}
+}
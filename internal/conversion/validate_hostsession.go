@@ -3,12 +3,14 @@
 package conversion
 
 import (
+	"errors"
 	"fmt"
 
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/utils/ptr"
 
 	v1alpha1 "github.com/openperouter/openperouter/api/v1alpha1"
+	openpeerrors "github.com/openperouter/openperouter/internal/errors"
 	"github.com/openperouter/openperouter/internal/filter"
 )
 
@@ -66,6 +68,9 @@ func ValidateHostSessions(l3VNIs []v1alpha1.L3VNI, l3Passthrough []v1alpha1.L3Pa
 		if localIPv4CIDR == "" && localIPv6CIDR == "" {
 			return fmt.Errorf("at least one local CIDR (IPv4 or IPv6) must be provided for vni %s", s.name)
 		}
+		if err := validateHostSessionSettings(s.HostSession); err != nil {
+			return fmt.Errorf("invalid host session for %s: %w", s.name, err)
+		}
 	}
 	return nil
 }
@@ -86,3 +91,70 @@ func validateCIDR(session hostSessionInfo, cidr string, existingCIDRs map[string
 	}
 	return nil
 }
+
+// validateHostSessionSettings validates the policies, the allowed prefixes
+// and the maximum prefix settings of the host session.
+func validateHostSessionSettings(session v1alpha1.HostSession) error {
+	if err := validateHostSessionRoutePolicies(session); err != nil {
+		return err
+	}
+	for _, p := range session.AllowedPrefixes {
+		if err := validatePrefixMatch(p); err != nil {
+			return fmt.Errorf("invalid allowed prefix: %w", err)
+		}
+	}
+	return validateHostSessionMaximumPrefix(session.MaximumPrefix)
+}
+
+func validateHostSessionMaximumPrefix(maximumPrefix *v1alpha1.MaximumPrefix) error {
+	if maximumPrefix == nil {
+		return nil
+	}
+	if maximumPrefix.IPv4 == nil && maximumPrefix.IPv6 == nil {
+		return fmt.Errorf("maximumPrefix must set at least one of ipv4 or ipv6")
+	}
+	return nil
+}
+
+// FilterHostSessionsWithValidSecret removes the L3VNIs, L3VPNs and
+// L3Passthroughs whose host session password secret is missing or invalid,
+// so that it fails only the resource referencing it.
+func FilterHostSessionsWithValidSecret(l3VNIs []v1alpha1.L3VNI, l3VPNs []v1alpha1.L3VPN,
+	l3Passthroughs []v1alpha1.L3Passthrough, secrets []corev1.Secret,
+) ([]v1alpha1.L3VNI, []v1alpha1.L3VPN, []v1alpha1.L3Passthrough, error) {
+	var allErrors []error
+	validSecret := func(kind v1alpha1.FailedResourceKind, name string, session *v1alpha1.HostSession) bool {
+		if _, err := hostSessionPassword(session, secrets); err != nil {
+			allErrors = append(allErrors, &openpeerrors.ResourceError{
+				Obj: v1alpha1.FailedResource{
+					Kind:    kind,
+					Name:    name,
+					Reason:  v1alpha1.FailedResourceReasonDependencyFailed,
+					Message: fmt.Sprintf("invalid host session password: %v", err),
+				},
+			})
+			return false
+		}
+		return true
+	}
+
+	validL3VNIs := make([]v1alpha1.L3VNI, 0, len(l3VNIs))
+	for _, vni := range l3VNIs {
+		if validSecret(openpeerrors.KindL3VNI, vni.Name, vni.Spec.HostSession) {
+			validL3VNIs = append(validL3VNIs, vni)
+		}
+	}
+	validL3VPNs := make([]v1alpha1.L3VPN, 0, len(l3VPNs))
+	for _, vpn := range l3VPNs {
+		if validSecret(openpeerrors.KindL3VPN, vpn.Name, vpn.Spec.HostSession) {
+			validL3VPNs = append(validL3VPNs, vpn)
+		}
+	}
+	validPassthroughs := make([]v1alpha1.L3Passthrough, 0, len(l3Passthroughs))
+	for _, passthrough := range l3Passthroughs {
+		if validSecret(openpeerrors.KindL3Passthrough, passthrough.Name, &passthrough.Spec.HostSession) {
+			validPassthroughs = append(validPassthroughs, passthrough)
+		}
+	}
+	return validL3VNIs, validL3VPNs, validPassthroughs, errors.Join(allErrors...)
+}
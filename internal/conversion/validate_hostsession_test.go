@@ -5,8 +5,12 @@ package conversion
 import (
 	"testing"
 
-	v1alpha1 "github.com/openperouter/openperouter/api/v1alpha1"
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	v1alpha1 "github.com/openperouter/openperouter/api/v1alpha1"
+	openpeerrors "github.com/openperouter/openperouter/internal/errors"
 )
 
 func TestValidateHostSessions(t *testing.T) {
@@ -180,6 +184,77 @@ func TestValidateHostSessions(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "maximum prefix without families",
+			l3VNIs: []v1alpha1.L3VNI{
+				{
+					ObjectMeta: metav1.ObjectMeta{Name: "vni1"},
+					Spec: v1alpha1.L3VNISpec{
+						VNI: 1001,
+						HostSession: &v1alpha1.HostSession{
+							ASN:           65001,
+							HostASN:       new(int64(65002)),
+							LocalCIDR:     v1alpha1.LocalCIDRConfig{IPv4: new("192.168.1.0/24")},
+							MaximumPrefix: &v1alpha1.MaximumPrefix{Action: v1alpha1.MaximumPrefixActionWarningOnly},
+						},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "maximum prefix for ipv4",
+			l3Passthrough: []v1alpha1.L3Passthrough{
+				{
+					ObjectMeta: metav1.ObjectMeta{Name: "passthrough"},
+					Spec: v1alpha1.L3PassthroughSpec{
+						HostSession: v1alpha1.HostSession{
+							ASN:           65001,
+							HostASN:       new(int64(65002)),
+							LocalCIDR:     v1alpha1.LocalCIDRConfig{IPv4: new("192.168.1.0/24")},
+							MaximumPrefix: &v1alpha1.MaximumPrefix{IPv4: new(int64(100))},
+						},
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "allowed prefix with host bits",
+			l3VNIs: []v1alpha1.L3VNI{
+				{
+					ObjectMeta: metav1.ObjectMeta{Name: "vni1"},
+					Spec: v1alpha1.L3VNISpec{
+						VNI: 1001,
+						HostSession: &v1alpha1.HostSession{
+							ASN:             65001,
+							HostASN:         new(int64(65002)),
+							LocalCIDR:       v1alpha1.LocalCIDRConfig{IPv4: new("192.168.1.0/24")},
+							AllowedPrefixes: []v1alpha1.PrefixMatch{{Prefix: "10.1.1.1/24"}},
+						},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "allowed prefix not in canonical form",
+			l3VNIs: []v1alpha1.L3VNI{
+				{
+					ObjectMeta: metav1.ObjectMeta{Name: "vni1"},
+					Spec: v1alpha1.L3VNISpec{
+						VNI: 1001,
+						HostSession: &v1alpha1.HostSession{
+							ASN:             65001,
+							HostASN:         new(int64(65002)),
+							LocalCIDR:       v1alpha1.LocalCIDRConfig{IPv6: new("fd00:1::/64")},
+							AllowedPrefixes: []v1alpha1.PrefixMatch{{Prefix: "2001:DB8::/32"}},
+						},
+					},
+				},
+			},
+			wantErr: false,
+		},
 		{
 			name: "mixed IPv4 and IPv6",
 			l3VNIs: []v1alpha1.L3VNI{
@@ -288,3 +363,61 @@ func TestValidateHostSessions(t *testing.T) {
 		})
 	}
 }
+
+func TestFilterHostSessionsWithValidSecret(t *testing.T) {
+	hostSession := func(passwordSecret string) v1alpha1.HostSession {
+		session := v1alpha1.HostSession{ASN: 65001, HostASN: new(int64(65002))}
+		if passwordSecret != "" {
+			session.PasswordSecret = new(passwordSecret)
+		}
+		return session
+	}
+	secrets := []corev1.Secret{basicAuthSecret("valid", "key")}
+	l3VNIs := []v1alpha1.L3VNI{
+		{ObjectMeta: metav1.ObjectMeta{Name: "no-secret"}, Spec: v1alpha1.L3VNISpec{HostSession: new(hostSession(""))}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "valid"}, Spec: v1alpha1.L3VNISpec{HostSession: new(hostSession("valid"))}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "missing"}, Spec: v1alpha1.L3VNISpec{HostSession: new(hostSession("missing"))}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "no-session"}},
+	}
+	l3VPNs := []v1alpha1.L3VPN{
+		{ObjectMeta: metav1.ObjectMeta{Name: "valid"}, Spec: v1alpha1.L3VPNSpec{HostSession: new(hostSession("valid"))}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "missing"}, Spec: v1alpha1.L3VPNSpec{HostSession: new(hostSession("missing"))}},
+	}
+	l3Passthroughs := []v1alpha1.L3Passthrough{
+		{ObjectMeta: metav1.ObjectMeta{Name: "valid"}, Spec: v1alpha1.L3PassthroughSpec{HostSession: hostSession("valid")}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "missing"}, Spec: v1alpha1.L3PassthroughSpec{HostSession: hostSession("missing")}},
+	}
+
+	gotL3VNIs, gotL3VPNs, gotPassthroughs, err := FilterHostSessionsWithValidSecret(l3VNIs, l3VPNs, l3Passthroughs, secrets)
+
+	l3VNINames := []string{}
+	for _, vni := range gotL3VNIs {
+		l3VNINames = append(l3VNINames, vni.Name)
+	}
+	if diff := cmp.Diff([]string{"no-secret", "valid", "no-session"}, l3VNINames); diff != "" {
+		t.Errorf("unexpected L3VNIs (-want +got):\n%s", diff)
+	}
+	if len(gotL3VPNs) != 1 || gotL3VPNs[0].Name != "valid" {
+		t.Errorf("expecting only the valid L3VPN, got %v", gotL3VPNs)
+	}
+	if len(gotPassthroughs) != 1 || gotPassthroughs[0].Name != "valid" {
+		t.Errorf("expecting only the valid L3Passthrough, got %v", gotPassthroughs)
+	}
+
+	failure := func(kind v1alpha1.FailedResourceKind) v1alpha1.FailedResource {
+		return v1alpha1.FailedResource{
+			Kind:    kind,
+			Name:    "missing",
+			Reason:  v1alpha1.FailedResourceReasonDependencyFailed,
+			Message: "invalid host session password: secret missing not found, it must be labeled openperouter.io/credentials",
+		}
+	}
+	want := []v1alpha1.FailedResource{
+		failure(openpeerrors.KindL3VNI),
+		failure(openpeerrors.KindL3VPN),
+		failure(openpeerrors.KindL3Passthrough),
+	}
+	if diff := cmp.Diff(want, openpeerrors.CollectFailures(err)); diff != "" {
+		t.Errorf("unexpected failures (-want +got):\n%s", diff)
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"errors"
 	"fmt"
 	"net"
+	"slices"
 
 	"github.com/openperouter/openperouter/api/v1alpha1"
 	openpeerrors "github.com/openperouter/openperouter/internal/errors"
@@ -87,37 +88,56 @@ func FilterUniqueVRFsForL3VPNs(l3vpns []v1alpha1.L3VPN) ([]v1alpha1.L3VPN, error
 	return valid, errors.Join(allErrors...)
 }
 
-// DetectMutuallyExclusiveOverlays returns a joined error for each conflicting resource if L3VNIs and L3VPNs
-// coexist.
-// TODO: This is a shortcut. We do not want L3VNIs and L3VPNs coexisting inside the same VRF. But across different
-// VRFs, this should work, in theory, subject to testing, and must be changed in the future.
-func DetectMutuallyExclusiveOverlays(l3vnis []v1alpha1.L3VNI, l3vpns []v1alpha1.L3VPN) error {
-	if len(l3vnis) == 0 || len(l3vpns) == 0 {
-		return nil
+// FilterMutuallyExclusiveOverlays returns the L3VNIs and L3VPNs not sharing a VRF with a resource of
+// the other kind, alongside per-resource errors for the conflicting ones. L3VNIs and L3VPNs can
+// coexist on the same node, as long as they live in different VRFs.
+func FilterMutuallyExclusiveOverlays(l3vnis []v1alpha1.L3VNI, l3vpns []v1alpha1.L3VPN) ([]v1alpha1.L3VNI,
+	[]v1alpha1.L3VPN, error) {
+	reason := v1alpha1.FailedResourceReasonValidationFailed
+	var allErrors []error
+
+	vrfToL3VNI := map[string]types.NamespacedName{}
+	for _, l3vni := range l3vnis {
+		if _, ok := vrfToL3VNI[l3vni.Spec.VRF]; !ok {
+			vrfToL3VNI[l3vni.Spec.VRF] = types.NamespacedName{Namespace: l3vni.Namespace, Name: l3vni.Name}
+		}
+	}
+	vrfToL3VPN := map[string]types.NamespacedName{}
+	for _, l3vpn := range l3vpns {
+		if _, ok := vrfToL3VPN[l3vpn.Spec.VRF]; !ok {
+			vrfToL3VPN[l3vpn.Spec.VRF] = types.NamespacedName{Namespace: l3vpn.Namespace, Name: l3vpn.Name}
+		}
 	}
 
-	var errs []error
+	validL3VNIs := make([]v1alpha1.L3VNI, 0, len(l3vnis))
 	for _, l3vni := range l3vnis {
-		errs = append(errs, &openpeerrors.ResourceError{
-			Obj: v1alpha1.FailedResource{
-				Kind:    v1alpha1.FailedResourceKind("L3VNI"),
-				Name:    l3vni.Name,
-				Reason:  v1alpha1.FailedResourceReasonValidationFailed,
-				Message: "cannot specify L3VNI resources and L3VPN resources at the same time",
-			},
-		})
+		if existing, ok := vrfToL3VPN[l3vni.Spec.VRF]; ok {
+			allErrors = append(allErrors, &openpeerrors.ResourceError{
+				Obj: v1alpha1.FailedResource{
+					Kind: openpeerrors.KindL3VNI, Name: l3vni.Name, Reason: reason,
+					Message: fmt.Sprintf("cannot specify an L3VNI in VRF %q: L3VPN %q already uses it", l3vni.Spec.VRF, existing),
+				},
+			})
+			continue
+		}
+		validL3VNIs = append(validL3VNIs, l3vni)
 	}
+
+	validL3VPNs := make([]v1alpha1.L3VPN, 0, len(l3vpns))
 	for _, l3vpn := range l3vpns {
-		errs = append(errs, &openpeerrors.ResourceError{
-			Obj: v1alpha1.FailedResource{
-				Kind:    v1alpha1.FailedResourceKind("L3VPN"),
-				Name:    l3vpn.Name,
-				Reason:  v1alpha1.FailedResourceReasonValidationFailed,
-				Message: "cannot specify L3VPN resources and L3VNI resources at the same time",
-			},
-		})
+		if existing, ok := vrfToL3VNI[l3vpn.Spec.VRF]; ok {
+			allErrors = append(allErrors, &openpeerrors.ResourceError{
+				Obj: v1alpha1.FailedResource{
+					Kind: openpeerrors.KindL3VPN, Name: l3vpn.Name, Reason: reason,
+					Message: fmt.Sprintf("cannot specify an L3VPN in VRF %q: L3VNI %q already uses it", l3vpn.Spec.VRF, existing),
+				},
+			})
+			continue
+		}
+		validL3VPNs = append(validL3VPNs, l3vpn)
 	}
-	return errors.Join(errs...)
+
+	return validL3VNIs, validL3VPNs, errors.Join(allErrors...)
 }
 
 // ValidateSRv6ForNodes returns an error if, for any node, the L3VPN resources are present without
@@ -146,13 +166,9 @@ func HasMissingSRv6ForL3VPNs(underlays []v1alpha1.Underlay, l3vpns []v1alpha1.L3
 	if len(l3vpns) == 0 {
 		return false
 	}
-	if len(underlays) == 0 {
-		return true
-	}
-	if underlays[0].Spec.SRV6 == nil {
-		return true
-	}
-	return false
+	return !slices.ContainsFunc(underlays, func(u v1alpha1.Underlay) bool {
+		return u.Spec.SRV6 != nil
+	})
 }
 
 // MissingSRv6ForL3VPNErrors adds errors to all l3vpns about missing underlay SRv6 configuration.
@@ -179,7 +195,7 @@ func MissingSRv6ForL3VPNErrors(l3vpns []v1alpha1.L3VPN, node *corev1.Node) error
 	return errors.Join(errs...)
 }
 
-// validateL3VPN validates a single L3VPN's fields (VRF name, route targets).
+// validateL3VPN validates a single L3VPN's fields (VRF name, route targets, route leaking).
 func validateL3VPN(l3Vni v1alpha1.L3VPN) error {
 	vni := vniFromL3VPN(l3Vni)
 	if err := isValidInterfaceName(vni.vrfName); err != nil {
@@ -192,6 +208,14 @@ func validateL3VPN(l3Vni v1alpha1.L3VPN) error {
 	if err := ValidateRouteTargets(vni); err != nil {
 		return fmt.Errorf("invalid route targets for vpn %q: %w", vni.name, err)
 	}
+	if l3Vni.Spec.HostSession != nil {
+		if err := validateHostSessionSettings(*l3Vni.Spec.HostSession); err != nil {
+			return fmt.Errorf("invalid host session for vpn %q: %w", vni.name, err)
+		}
+	}
+	if err := validateRouteLeaking(l3Vni.Spec.VRF, l3Vni.Spec.RouteLeaking); err != nil {
+		return fmt.Errorf("invalid route leaking for vpn %q: %w", vni.name, err)
+	}
 	return nil
 }
 
@@ -400,38 +400,78 @@ func TestFilterUniqueVRFsForL3VPNs(t *testing.T) {
 	}
 }
 
-func TestDetectMutuallyExclusiveOverlays(t *testing.T) {
+func TestFilterMutuallyExclusiveOverlays(t *testing.T) {
+	l3vni := func(name, vrf string) v1alpha1.L3VNI {
+		return v1alpha1.L3VNI{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "test"}, Spec: v1alpha1.L3VNISpec{VRF: vrf}}
+	}
+	l3vpn := func(name, vrf string) v1alpha1.L3VPN {
+		return v1alpha1.L3VPN{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "test"}, Spec: v1alpha1.L3VPNSpec{VRF: vrf}}
+	}
+
 	tcs := []struct {
-		name     string
-		l3vnis   []v1alpha1.L3VNI
-		l3vpns   []v1alpha1.L3VPN
-		wantErrs []string
+		name       string
+		l3vnis     []v1alpha1.L3VNI
+		l3vpns     []v1alpha1.L3VPN
+		wantL3VNIs []string
+		wantL3VPNs []string
+		wantErrs   []string
 	}{
 		{
-			name: "both empty",
+			name:       "both empty",
+			wantL3VNIs: []string{},
+			wantL3VPNs: []string{},
+		},
+		{
+			name:       "only L3VNIs",
+			l3vnis:     []v1alpha1.L3VNI{l3vni("vni1", "red")},
+			wantL3VNIs: []string{"vni1"},
+			wantL3VPNs: []string{},
 		},
 		{
-			name:   "only L3VNIs",
-			l3vnis: []v1alpha1.L3VNI{{ObjectMeta: metav1.ObjectMeta{Name: "vni1"}}},
+			name:       "only L3VPNs",
+			l3vpns:     []v1alpha1.L3VPN{l3vpn("vpn1", "red")},
+			wantL3VNIs: []string{},
+			wantL3VPNs: []string{"vpn1"},
 		},
 		{
-			name:   "only L3VPNs",
-			l3vpns: []v1alpha1.L3VPN{{ObjectMeta: metav1.ObjectMeta{Name: "vpn1"}}},
+			name:       "both present in different VRFs",
+			l3vnis:     []v1alpha1.L3VNI{l3vni("vni1", "red")},
+			l3vpns:     []v1alpha1.L3VPN{l3vpn("vpn1", "blue")},
+			wantL3VNIs: []string{"vni1"},
+			wantL3VPNs: []string{"vpn1"},
 		},
 		{
-			name:   "both present",
-			l3vnis: []v1alpha1.L3VNI{{ObjectMeta: metav1.ObjectMeta{Name: "vni1"}}},
-			l3vpns: []v1alpha1.L3VPN{{ObjectMeta: metav1.ObjectMeta{Name: "vpn1"}}},
+			name:       "both present in the same VRF",
+			l3vnis:     []v1alpha1.L3VNI{l3vni("vni1", "red"), l3vni("vni2", "green")},
+			l3vpns:     []v1alpha1.L3VPN{l3vpn("vpn1", "red"), l3vpn("vpn2", "blue")},
+			wantL3VNIs: []string{"vni2"},
+			wantL3VPNs: []string{"vpn2"},
 			wantErrs: []string{
-				"L3VNI/vni1: cannot specify L3VNI resources and L3VPN resources at the same time",
-				"L3VPN/vpn1: cannot specify L3VPN resources and L3VNI resources at the same time",
+				"L3VNI/vni1: cannot specify an L3VNI in VRF \"red\": L3VPN \"test/vpn1\" already uses it",
+				"L3VPN/vpn1: cannot specify an L3VPN in VRF \"red\": L3VNI \"test/vni1\" already uses it",
 			},
 		},
 	}
 
 	for _, tc := range tcs {
 		t.Run(tc.name, func(t *testing.T) {
-			err := DetectMutuallyExclusiveOverlays(tc.l3vnis, tc.l3vpns)
+			gotL3VNIs, gotL3VPNs, err := FilterMutuallyExclusiveOverlays(tc.l3vnis, tc.l3vpns)
+
+			l3vniNames := []string{}
+			for _, l3vni := range gotL3VNIs {
+				l3vniNames = append(l3vniNames, l3vni.Name)
+			}
+			if diff := cmp.Diff(tc.wantL3VNIs, l3vniNames); diff != "" {
+				t.Errorf("unexpected L3VNIs (-want +got):\n%s", diff)
+			}
+			l3vpnNames := []string{}
+			for _, l3vpn := range gotL3VPNs {
+				l3vpnNames = append(l3vpnNames, l3vpn.Name)
+			}
+			if diff := cmp.Diff(tc.wantL3VPNs, l3vpnNames); diff != "" {
+				t.Errorf("unexpected L3VPNs (-want +got):\n%s", diff)
+			}
+
 			if len(tc.wantErrs) == 0 {
 				if err != nil {
 					t.Fatalf("expected no error but got %q", err)
@@ -0,0 +1,231 @@
+// SPDX-License-Identifier:Apache-2.0
+
+package conversion
+
+import (
+	"fmt"
+	"net"
+	"strconv"
+
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/openperouter/openperouter/api/v1alpha1"
+	"github.com/openperouter/openperouter/internal/filter"
+	"github.com/openperouter/openperouter/internal/ipfamily"
+)
+
+// ValidateNodeAddressings validates the NodeAddressings of a node.
+func ValidateNodeAddressings(addressings []v1alpha1.NodeAddressing) error {
+	if len(addressings) > 1 {
+		return fmt.Errorf("node %s has more than one NodeAddressing: %s, %s",
+			addressings[0].Spec.NodeName, addressings[0].Name, addressings[1].Name)
+	}
+	for _, a := range addressings {
+		if err := validateNodeAddressing(a); err != nil {
+			return fmt.Errorf("invalid NodeAddressing %s: %w", a.Name, err)
+		}
+	}
+	return nil
+}
+
+func validateNodeAddressing(addressing v1alpha1.NodeAddressing) error {
+	if addressing.Spec.NodeName == "" {
+		return fmt.Errorf("nodeName must be set")
+	}
+	if addressing.Spec.RouterID != nil {
+		if err := validateIPOfFamily(*addressing.Spec.RouterID, ipfamily.IPv4); err != nil {
+			return fmt.Errorf("invalid routerID: %w", err)
+		}
+	}
+
+	underlays := map[string]bool{}
+	for _, t := range addressing.Spec.TunnelEndpoints {
+		if underlays[t.Underlay] {
+			return fmt.Errorf("duplicate tunnel endpoints for underlay %s", t.Underlay)
+		}
+		underlays[t.Underlay] = true
+		families := map[ipfamily.Family]bool{}
+		for _, addr := range t.IPs {
+			ip := net.ParseIP(addr)
+			if ip == nil {
+				return fmt.Errorf("invalid tunnel endpoint ip %q for underlay %s", addr, t.Underlay)
+			}
+			family := ipfamily.ForAddress(ip)
+			if families[family] {
+				return fmt.Errorf("more than one %s tunnel endpoint ip for underlay %s", family, t.Underlay)
+			}
+			families[family] = true
+		}
+	}
+
+	type sessionKey struct {
+		kind v1alpha1.HostSessionOwnerKind
+		name string
+	}
+	sessions := map[sessionKey]bool{}
+	for _, h := range addressing.Spec.HostSessions {
+		key := sessionKey{h.Kind, h.Name}
+		if sessions[key] {
+			return fmt.Errorf("duplicate host session addresses for %s %s", h.Kind, h.Name)
+		}
+		sessions[key] = true
+		if h.IPv4 == nil && h.IPv6 == nil {
+			return fmt.Errorf("host session addresses for %s %s must have at least one of ipv4 or ipv6", h.Kind, h.Name)
+		}
+		if h.IPv4 != nil {
+			if err := validateIPOfFamily(*h.IPv4, ipfamily.IPv4); err != nil {
+				return fmt.Errorf("invalid ipv4 for %s %s: %w", h.Kind, h.Name, err)
+			}
+		}
+		if h.IPv6 != nil {
+			if err := validateIPOfFamily(*h.IPv6, ipfamily.IPv6); err != nil {
+				return fmt.Errorf("invalid ipv6 for %s %s: %w", h.Kind, h.Name, err)
+			}
+		}
+	}
+	return nil
+}
+
+func validateIPOfFamily(addr string, family ipfamily.Family) error {
+	ip := net.ParseIP(addr)
+	if ip == nil {
+		return fmt.Errorf("%q is not a valid ip", addr)
+	}
+	if ipfamily.ForAddress(ip) != family {
+		return fmt.Errorf("%s is not an %s address", addr, family)
+	}
+	return nil
+}
+
+// ValidateNodeAddressingsForNodes checks that the addresses of the nodes,
+// either set by a NodeAddressing or derived from the node index, don't
+// collide. The nodes without an index yet are not checked.
+func ValidateNodeAddressingsForNodes(nodes []corev1.Node, addressings []v1alpha1.NodeAddressing, config APIConfigData) error {
+	byNode := map[string][]v1alpha1.NodeAddressing{}
+	for _, a := range addressings {
+		byNode[a.Spec.NodeName] = append(byNode[a.Spec.NodeName], a)
+	}
+	for _, nodeAddressings := range byNode {
+		if err := ValidateNodeAddressings(nodeAddressings); err != nil {
+			return err
+		}
+	}
+
+	routerIDs := addressOwners{kind: "router id"}
+	tunnelEndpoints := addressOwners{kind: "tunnel endpoint ip"}
+	hostSessions := addressOwners{kind: "host session ip"}
+	for _, node := range nodes {
+		index, err := strconv.Atoi(node.Annotations[v1alpha1.NodeIndexAnnotation])
+		if err != nil {
+			continue
+		}
+		addresses := nodeAddressesFor(index, byNode[node.Name])
+		nodeConfig, err := nodeAddressingConfigForNode(&node, config)
+		if err != nil {
+			return err
+		}
+
+		if len(nodeConfig.Underlays) > 0 {
+			routerID, err := addresses.routerID(nodeConfig.Underlays[0])
+			if err != nil {
+				return fmt.Errorf("node %s: %w", node.Name, err)
+			}
+			if err := routerIDs.add(routerID, node.Name); err != nil {
+				return err
+			}
+		}
+		for _, u := range nodeConfig.Underlays {
+			if u.Spec.TunnelEndpoint == nil {
+				continue
+			}
+			for _, cidr := range u.Spec.TunnelEndpoint.CIDRs {
+				ip, err := addresses.tunnelEndpointIP(u.Name, cidr)
+				if err != nil {
+					return fmt.Errorf("node %s: %w", node.Name, err)
+				}
+				if err := tunnelEndpoints.add(ip.IP.String(), node.Name); err != nil {
+					return err
+				}
+			}
+		}
+
+		addHostSession := func(kind v1alpha1.HostSessionOwnerKind, name string, hostSession *v1alpha1.HostSession) error {
+			if hostSession == nil {
+				return nil
+			}
+			veths, err := addresses.vethIPs(kind, name, hostSession.LocalCIDR)
+			if err != nil {
+				return fmt.Errorf("node %s: %w", node.Name, err)
+			}
+			for _, ip := range []net.IP{veths.Ipv4.HostSide.IP, veths.Ipv6.HostSide.IP} {
+				if ip == nil {
+					continue
+				}
+				if err := hostSessions.add(fmt.Sprintf("%s of %s %s", ip, kind, name), node.Name); err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+		for _, vni := range nodeConfig.L3VNIs {
+			if err := addHostSession(v1alpha1.HostSessionOwnerKindL3VNI, vni.Name, vni.Spec.HostSession); err != nil {
+				return err
+			}
+		}
+		for _, vpn := range nodeConfig.L3VPNs {
+			if err := addHostSession(v1alpha1.HostSessionOwnerKindL3VPN, vpn.Name, vpn.Spec.HostSession); err != nil {
+				return err
+			}
+		}
+		for _, p := range nodeConfig.L3Passthrough {
+			if err := addHostSession(v1alpha1.HostSessionOwnerKindL3Passthrough, p.Name, &p.Spec.HostSession); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// nodeAddressingConfigForNode returns the resources selecting the node whose
+// addresses depend on the node.
+func nodeAddressingConfigForNode(node *corev1.Node, config APIConfigData) (APIConfigData, error) {
+	underlays, err := filter.UnderlaysForNode(node, config.Underlays)
+	if err != nil {
+		return APIConfigData{}, fmt.Errorf("failed to filter underlays for node %q: %w", node.Name, err)
+	}
+	l3vnis, err := filter.L3VNIsForNode(node, config.L3VNIs)
+	if err != nil {
+		return APIConfigData{}, fmt.Errorf("failed to filter L3 VNIs for node %q: %w", node.Name, err)
+	}
+	l3vpns, err := filter.L3VPNsForNode(node, config.L3VPNs)
+	if err != nil {
+		return APIConfigData{}, fmt.Errorf("failed to filter L3 VPNs for node %q: %w", node.Name, err)
+	}
+	l3passthroughs, err := filter.L3PassthroughsForNode(node, config.L3Passthrough)
+	if err != nil {
+		return APIConfigData{}, fmt.Errorf("failed to filter L3 Passthrough for node %q: %w", node.Name, err)
+	}
+	return APIConfigData{
+		Underlays:     underlays,
+		L3VNIs:        l3vnis,
+		L3VPNs:        l3vpns,
+		L3Passthrough: l3passthroughs,
+	}, nil
+}
+
+// addressOwners tracks the node each address of a kind is assigned to.
+type addressOwners struct {
+	kind   string
+	owners map[string]string
+}
+
+func (a *addressOwners) add(address, node string) error {
+	if a.owners == nil {
+		a.owners = map[string]string{}
+	}
+	if owner, ok := a.owners[address]; ok && owner != node {
+		return fmt.Errorf("%s %s is assigned to both node %s and node %s", a.kind, address, owner, node)
+	}
+	a.owners[address] = node
+	return nil
+}
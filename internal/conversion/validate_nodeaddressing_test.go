@@ -0,0 +1,218 @@
+// SPDX-License-Identifier:Apache-2.0
+
+package conversion
+
+import (
+	"strings"
+	"testing"
+
+	v1alpha1 "github.com/openperouter/openperouter/api/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestValidateNodeAddressings(t *testing.T) {
+	tests := []struct {
+		name        string
+		addressings []v1alpha1.NodeAddressing
+		wantErr     string
+	}{
+		{
+			name: "valid",
+			addressings: []v1alpha1.NodeAddressing{
+				nodeAddressing("node1", v1alpha1.NodeAddressingSpec{
+					RouterID: new("192.0.2.1"),
+					TunnelEndpoints: []v1alpha1.TunnelEndpointAddresses{
+						{Underlay: "underlay", IPs: []string{"100.65.0.10", "2001:db8::10"}},
+					},
+					HostSessions: []v1alpha1.HostSessionAddresses{
+						{Kind: v1alpha1.HostSessionOwnerKindL3VNI, Name: "red", IPv4: new("192.169.10.10")},
+						{Kind: v1alpha1.HostSessionOwnerKindL3Passthrough, Name: "red", IPv6: new("2001:db8:1::10")},
+					},
+				}),
+			},
+		},
+		{
+			name: "two addressings for the same node",
+			addressings: []v1alpha1.NodeAddressing{
+				nodeAddressing("node1", v1alpha1.NodeAddressingSpec{RouterID: new("192.0.2.1")}),
+				nodeAddressing("node1", v1alpha1.NodeAddressingSpec{RouterID: new("192.0.2.2")}),
+			},
+			wantErr: "more than one NodeAddressing",
+		},
+		{
+			name: "ipv6 router id",
+			addressings: []v1alpha1.NodeAddressing{
+				nodeAddressing("node1", v1alpha1.NodeAddressingSpec{RouterID: new("2001:db8::1")}),
+			},
+			wantErr: "invalid routerID",
+		},
+		{
+			name: "two tunnel endpoints of the same family",
+			addressings: []v1alpha1.NodeAddressing{
+				nodeAddressing("node1", v1alpha1.NodeAddressingSpec{
+					TunnelEndpoints: []v1alpha1.TunnelEndpointAddresses{
+						{Underlay: "underlay", IPs: []string{"100.65.0.10", "100.65.0.11"}},
+					},
+				}),
+			},
+			wantErr: "more than one ipv4 tunnel endpoint ip",
+		},
+		{
+			name: "host session ipv4 of the wrong family",
+			addressings: []v1alpha1.NodeAddressing{
+				nodeAddressing("node1", v1alpha1.NodeAddressingSpec{
+					HostSessions: []v1alpha1.HostSessionAddresses{
+						{Kind: v1alpha1.HostSessionOwnerKindL3VNI, Name: "red", IPv4: new("2001:db8::10")},
+					},
+				}),
+			},
+			wantErr: "invalid ipv4 for L3VNI red",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateNodeAddressings(tt.addressings)
+			checkNodeAddressingError(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestValidateNodeAddressingsForNodes(t *testing.T) {
+	config := APIConfigData{
+		Underlays: []v1alpha1.Underlay{{
+			ObjectMeta: metav1.ObjectMeta{Name: "underlay"},
+			Spec: v1alpha1.UnderlaySpec{
+				RouterIDCIDR:   new("10.0.0.0/24"),
+				TunnelEndpoint: &v1alpha1.TunnelEndpointConfig{CIDRs: []string{"100.65.0.0/24"}},
+			},
+		}},
+		L3VNIs: []v1alpha1.L3VNI{{
+			ObjectMeta: metav1.ObjectMeta{Name: "red"},
+			Spec: v1alpha1.L3VNISpec{
+				VNI: 100,
+				HostSession: &v1alpha1.HostSession{
+					LocalCIDR: v1alpha1.LocalCIDRConfig{IPv4: new("192.169.10.0/24")},
+				},
+			},
+		}},
+	}
+	nodes := []corev1.Node{
+		indexedNode("node0", "0"),
+		indexedNode("node1", "1"),
+		{ObjectMeta: metav1.ObjectMeta{Name: "node2"}},
+	}
+
+	tests := []struct {
+		name        string
+		addressings []v1alpha1.NodeAddressing
+		wantErr     string
+	}{
+		{
+			name: "no collisions",
+			addressings: []v1alpha1.NodeAddressing{
+				nodeAddressing("node1", v1alpha1.NodeAddressingSpec{
+					RouterID: new("192.0.2.1"),
+					TunnelEndpoints: []v1alpha1.TunnelEndpointAddresses{
+						{Underlay: "underlay", IPs: []string{"100.65.0.100"}},
+					},
+					HostSessions: []v1alpha1.HostSessionAddresses{
+						{Kind: v1alpha1.HostSessionOwnerKindL3VNI, Name: "red", IPv4: new("192.169.10.100")},
+					},
+				}),
+			},
+		},
+		{
+			name: "router id colliding with the one derived for another node",
+			addressings: []v1alpha1.NodeAddressing{
+				nodeAddressing("node1", v1alpha1.NodeAddressingSpec{RouterID: new("10.0.0.1")}),
+			},
+			wantErr: "router id 10.0.0.1 is assigned to both node node0 and node node1",
+		},
+		{
+			name: "tunnel endpoint colliding across overrides",
+			addressings: []v1alpha1.NodeAddressing{
+				nodeAddressing("node0", v1alpha1.NodeAddressingSpec{
+					TunnelEndpoints: []v1alpha1.TunnelEndpointAddresses{
+						{Underlay: "underlay", IPs: []string{"100.65.0.100"}},
+					},
+				}),
+				nodeAddressing("node1", v1alpha1.NodeAddressingSpec{
+					TunnelEndpoints: []v1alpha1.TunnelEndpointAddresses{
+						{Underlay: "underlay", IPs: []string{"100.65.0.100"}},
+					},
+				}),
+			},
+			wantErr: "tunnel endpoint ip 100.65.0.100",
+		},
+		{
+			name: "host session ip colliding with the one derived for another node",
+			addressings: []v1alpha1.NodeAddressing{
+				nodeAddressing("node1", v1alpha1.NodeAddressingSpec{
+					HostSessions: []v1alpha1.HostSessionAddresses{
+						{Kind: v1alpha1.HostSessionOwnerKindL3VNI, Name: "red", IPv4: new("192.169.10.2")},
+					},
+				}),
+			},
+			wantErr: "host session ip 192.169.10.2 of L3VNI red",
+		},
+		{
+			name: "host session ip outside of the local cidr",
+			addressings: []v1alpha1.NodeAddressing{
+				nodeAddressing("node1", v1alpha1.NodeAddressingSpec{
+					HostSessions: []v1alpha1.HostSessionAddresses{
+						{Kind: v1alpha1.HostSessionOwnerKindL3VNI, Name: "red", IPv4: new("192.169.11.2")},
+					},
+				}),
+			},
+			wantErr: "does not belong to the local cidr",
+		},
+		{
+			name: "node without index is not checked",
+			addressings: []v1alpha1.NodeAddressing{
+				nodeAddressing("node2", v1alpha1.NodeAddressingSpec{RouterID: new("10.0.0.1")}),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateNodeAddressingsForNodes(nodes, tt.addressings, config)
+			checkNodeAddressingError(t, err, tt.wantErr)
+		})
+	}
+}
+
+func nodeAddressing(node string, spec v1alpha1.NodeAddressingSpec) v1alpha1.NodeAddressing {
+	spec.NodeName = node
+	return v1alpha1.NodeAddressing{
+		ObjectMeta: metav1.ObjectMeta{Name: node, Namespace: "openperouter-system"},
+		Spec:       spec,
+	}
+}
+
+func indexedNode(name, index string) corev1.Node {
+	return corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        name,
+			Annotations: map[string]string{v1alpha1.NodeIndexAnnotation: index},
+		},
+	}
+}
+
+func checkNodeAddressingError(t *testing.T, err error, wantErr string) {
+	t.Helper()
+	if wantErr == "" {
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		return
+	}
+	if err == nil {
+		t.Fatalf("expected error containing %q, got nil", wantErr)
+	}
+	if !strings.Contains(err.Error(), wantErr) {
+		t.Fatalf("expected error containing %q, got %q", wantErr, err.Error())
+	}
+}
@@ -11,6 +11,7 @@ import (
 	"github.com/openperouter/openperouter/api/v1alpha1"
 	openpeerrors "github.com/openperouter/openperouter/internal/errors"
 	"github.com/openperouter/openperouter/internal/filter"
+	"github.com/openperouter/openperouter/internal/hostnetwork"
 )
 
 func ValidatePassthroughsForNodes(nodes []corev1.Node, underlays []v1alpha1.L3Passthrough) error {
@@ -26,20 +27,29 @@ func ValidatePassthroughsForNodes(nodes []corev1.Node, underlays []v1alpha1.L3Pa
 	return nil
 }
 
+// FilterValidPassthroughs returns the passthroughs that can be configured
+// together on a node. Each passthrough gets its own veth pair named after it,
+// so a passthrough whose veth names clash with the ones of a previous
+// passthrough is filtered out.
 func FilterValidPassthroughs(l3Passthrough []v1alpha1.L3Passthrough) ([]v1alpha1.L3Passthrough, error) {
-	if len(l3Passthrough) > 1 {
-		allErrors := make([]error, 0, len(l3Passthrough))
-		for _, pt := range l3Passthrough {
+	var res []v1alpha1.L3Passthrough
+	var allErrors []error
+	existing := map[string]string{}
+	for _, pt := range l3Passthrough {
+		vethName := hostnetwork.PassthroughVethNames(pt.Name).HostSide
+		if other, ok := existing[vethName]; ok {
 			allErrors = append(allErrors, &openpeerrors.ResourceError{
 				Obj: v1alpha1.FailedResource{
-					Kind:    v1alpha1.FailedResourceKind("L3Passthrough"),
+					Kind:    openpeerrors.KindL3Passthrough,
 					Name:    pt.Name,
 					Reason:  v1alpha1.FailedResourceReasonValidationFailed,
-					Message: "can't have more than one l3passthrough per node",
+					Message: fmt.Sprintf("l3passthrough veth %s is already used by l3passthrough %s", vethName, other),
 				},
 			})
+			continue
 		}
-		return nil, errors.Join(allErrors...)
+		existing[vethName] = pt.Name
+		res = append(res, pt)
 	}
-	return l3Passthrough, nil
+	return res, errors.Join(allErrors...)
 }
@@ -7,6 +7,8 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
@@ -56,7 +58,23 @@ func TestValidatePassthroughsForNodes(t *testing.T) {
 					},
 				},
 			},
-			expectedErr: fmt.Errorf("can't have more than one l3passthrough per node"),
+		},
+		{
+			name: "passthroughs with the same name in different namespaces on the same node",
+			nodes: []corev1.Node{
+				{ObjectMeta: metav1.ObjectMeta{Name: "node-1", Labels: map[string]string{"rack": "rack-1"}}},
+			},
+			l3Passthroughs: []v1alpha1.L3Passthrough{
+				{
+					ObjectMeta: metav1.ObjectMeta{Name: "pt-1", Namespace: "ns1"},
+					Spec:       v1alpha1.L3PassthroughSpec{NodeSelector: nil},
+				},
+				{
+					ObjectMeta: metav1.ObjectMeta{Name: "pt-1", Namespace: "ns2"},
+					Spec:       v1alpha1.L3PassthroughSpec{NodeSelector: nil},
+				},
+			},
+			expectedErr: fmt.Errorf("is already used by l3passthrough pt-1"),
 		},
 		{
 			name: "two nodes each with one matching passthrough",
@@ -119,7 +137,6 @@ func TestValidatePassthroughsForNodes(t *testing.T) {
 					},
 				},
 			},
-			expectedErr: fmt.Errorf("can't have more than one l3passthrough per node"),
 		},
 		{
 			name: "invalid node selector expression",
@@ -165,6 +182,7 @@ func TestFilterValidPassthroughs(t *testing.T) {
 	tests := []struct {
 		name           string
 		l3Passthroughs []v1alpha1.L3Passthrough
+		expectedValid  []string
 		expectedErr    error
 	}{
 		{
@@ -180,6 +198,7 @@ func TestFilterValidPassthroughs(t *testing.T) {
 			l3Passthroughs: []v1alpha1.L3Passthrough{
 				{ObjectMeta: metav1.ObjectMeta{Name: "pt-1"}},
 			},
+			expectedValid: []string{"pt-1"},
 		},
 		{
 			name: "two passthroughs",
@@ -187,13 +206,30 @@ func TestFilterValidPassthroughs(t *testing.T) {
 				{ObjectMeta: metav1.ObjectMeta{Name: "pt-1"}},
 				{ObjectMeta: metav1.ObjectMeta{Name: "pt-2"}},
 			},
-			expectedErr: fmt.Errorf("can't have more than one l3passthrough per node"),
+			expectedValid: []string{"pt-1", "pt-2"},
+		},
+		{
+			name: "two passthroughs with the same name",
+			l3Passthroughs: []v1alpha1.L3Passthrough{
+				{ObjectMeta: metav1.ObjectMeta{Name: "pt-1", Namespace: "ns1"}},
+				{ObjectMeta: metav1.ObjectMeta{Name: "pt-2", Namespace: "ns1"}},
+				{ObjectMeta: metav1.ObjectMeta{Name: "pt-1", Namespace: "ns2"}},
+			},
+			expectedValid: []string{"pt-1", "pt-2"},
+			expectedErr:   fmt.Errorf("is already used by l3passthrough pt-1"),
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			_, err := FilterValidPassthroughs(tt.l3Passthroughs)
+			valid, err := FilterValidPassthroughs(tt.l3Passthroughs)
+			validNames := []string{}
+			for _, pt := range valid {
+				validNames = append(validNames, pt.Name)
+			}
+			if diff := cmp.Diff(tt.expectedValid, validNames, cmpopts.EquateEmpty()); diff != "" {
+				t.Errorf("unexpected valid passthroughs (-want +got):\n%s", diff)
+			}
 			if tt.expectedErr != nil {
 				if err == nil {
 					t.Fatalf("expected error %q but got none", tt.expectedErr)
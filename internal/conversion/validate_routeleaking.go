@@ -0,0 +1,115 @@
+// SPDX-License-Identifier:Apache-2.0
+
+package conversion
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/openperouter/openperouter/api/v1alpha1"
+	openpeerrors "github.com/openperouter/openperouter/internal/errors"
+)
+
+// validateRouteLeaking validates the route leaking settings of the given VRF.
+func validateRouteLeaking(vrf string, leaking *v1alpha1.RouteLeaking) error {
+	if leaking == nil {
+		return nil
+	}
+	if vrf == v1alpha1.DefaultVRF {
+		return fmt.Errorf("vrf name %q is reserved for the default VRF", vrf)
+	}
+	directions := []struct {
+		name  string
+		leaks []v1alpha1.VRFRouteLeak
+	}{
+		{"importFrom", leaking.ImportFrom},
+		{"exportTo", leaking.ExportTo},
+	}
+	for _, d := range directions {
+		direction, leaks := d.name, d.leaks
+		seen := map[string]bool{}
+		for _, l := range leaks {
+			if err := isValidInterfaceName(l.VRF); err != nil {
+				return fmt.Errorf("invalid %s vrf %q: %w", direction, l.VRF, err)
+			}
+			if l.VRF == vrf {
+				return fmt.Errorf("invalid %s vrf %q: a vrf cannot leak routes to itself", direction, l.VRF)
+			}
+			if seen[l.VRF] {
+				return fmt.Errorf("duplicate %s vrf %q", direction, l.VRF)
+			}
+			seen[l.VRF] = true
+			for _, p := range l.Prefixes {
+				if err := validatePrefixMatch(p); err != nil {
+					return fmt.Errorf("invalid %s prefixes for vrf %q: %w", direction, l.VRF, err)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// FilterValidRouteLeaks removes the L3VNIs and L3VPNs leaking routes from or to a
+// VRF not configured on the node, alongside per-resource errors. As removing a
+// resource removes its VRF, the check is repeated until no more resources are removed.
+func FilterValidRouteLeaks(l3vnis []v1alpha1.L3VNI, l3vpns []v1alpha1.L3VPN) ([]v1alpha1.L3VNI,
+	[]v1alpha1.L3VPN, error) {
+	var allErrors []error
+	for {
+		vrfs := map[string]bool{v1alpha1.DefaultVRF: true}
+		for _, l3vni := range l3vnis {
+			vrfs[l3vni.Spec.VRF] = true
+		}
+		for _, l3vpn := range l3vpns {
+			vrfs[l3vpn.Spec.VRF] = true
+		}
+
+		removed := false
+		var validL3VNIs []v1alpha1.L3VNI
+		for _, l3vni := range l3vnis {
+			if missing := missingLeakingVRF(l3vni.Spec.RouteLeaking, vrfs); missing != "" {
+				allErrors = append(allErrors, routeLeakDependencyError(openpeerrors.KindL3VNI, l3vni.Name, missing))
+				removed = true
+				continue
+			}
+			validL3VNIs = append(validL3VNIs, l3vni)
+		}
+		var validL3VPNs []v1alpha1.L3VPN
+		for _, l3vpn := range l3vpns {
+			if missing := missingLeakingVRF(l3vpn.Spec.RouteLeaking, vrfs); missing != "" {
+				allErrors = append(allErrors, routeLeakDependencyError(openpeerrors.KindL3VPN, l3vpn.Name, missing))
+				removed = true
+				continue
+			}
+			validL3VPNs = append(validL3VPNs, l3vpn)
+		}
+		l3vnis, l3vpns = validL3VNIs, validL3VPNs
+		if !removed {
+			return l3vnis, l3vpns, errors.Join(allErrors...)
+		}
+	}
+}
+
+// missingLeakingVRF returns the first VRF referenced by the route leaking
+// settings which is not in the given set, or an empty string.
+func missingLeakingVRF(leaking *v1alpha1.RouteLeaking, vrfs map[string]bool) string {
+	if leaking == nil {
+		return ""
+	}
+	for _, l := range slices.Concat(leaking.ImportFrom, leaking.ExportTo) {
+		if !vrfs[l.VRF] {
+			return l.VRF
+		}
+	}
+	return ""
+}
+
+func routeLeakDependencyError(kind v1alpha1.FailedResourceKind, name, vrf string) error {
+	return &openpeerrors.ResourceError{
+		Obj: v1alpha1.FailedResource{
+			Kind: kind, Name: name, Reason: v1alpha1.FailedResourceReasonDependencyFailed,
+			Message: fmt.Sprintf("vrf %q referenced by routeLeaking not found", vrf),
+		},
+	}
+}
@@ -0,0 +1,187 @@
+// SPDX-License-Identifier:Apache-2.0
+
+package conversion
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/openperouter/openperouter/api/v1alpha1"
+)
+
+func TestValidateRouteLeaking(t *testing.T) {
+	tcs := []struct {
+		name    string
+		vrf     string
+		leaking *v1alpha1.RouteLeaking
+		wantErr string
+	}{
+		{
+			name: "no route leaking",
+			vrf:  "red",
+		},
+		{
+			name: "valid route leaking",
+			vrf:  "red",
+			leaking: &v1alpha1.RouteLeaking{
+				ImportFrom: []v1alpha1.VRFRouteLeak{
+					{VRF: "shared", Prefixes: []v1alpha1.PrefixMatch{{Prefix: "192.172.0.0/24", LE: new(int32(32))}}},
+				},
+				ExportTo: []v1alpha1.VRFRouteLeak{{VRF: v1alpha1.DefaultVRF}, {VRF: "shared"}},
+			},
+		},
+		{
+			name:    "vrf named default",
+			vrf:     v1alpha1.DefaultVRF,
+			leaking: &v1alpha1.RouteLeaking{ImportFrom: []v1alpha1.VRFRouteLeak{{VRF: "shared"}}},
+			wantErr: `vrf name "default" is reserved for the default VRF`,
+		},
+		{
+			name:    "leaking to itself",
+			vrf:     "red",
+			leaking: &v1alpha1.RouteLeaking{ExportTo: []v1alpha1.VRFRouteLeak{{VRF: "red"}}},
+			wantErr: `invalid exportTo vrf "red": a vrf cannot leak routes to itself`,
+		},
+		{
+			name:    "invalid vrf name",
+			vrf:     "red",
+			leaking: &v1alpha1.RouteLeaking{ImportFrom: []v1alpha1.VRFRouteLeak{{VRF: "1shared"}}},
+			wantErr: `invalid importFrom vrf "1shared"`,
+		},
+		{
+			name:    "duplicate vrf",
+			vrf:     "red",
+			leaking: &v1alpha1.RouteLeaking{ImportFrom: []v1alpha1.VRFRouteLeak{{VRF: "shared"}, {VRF: "shared"}}},
+			wantErr: `duplicate importFrom vrf "shared"`,
+		},
+		{
+			name: "invalid prefix",
+			vrf:  "red",
+			leaking: &v1alpha1.RouteLeaking{
+				ExportTo: []v1alpha1.VRFRouteLeak{
+					{VRF: v1alpha1.DefaultVRF, Prefixes: []v1alpha1.PrefixMatch{{Prefix: "192.172.0.1/24"}}},
+				},
+			},
+			wantErr: `invalid exportTo prefixes for vrf "default"`,
+		},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			err := validateRouteLeaking(tc.vrf, tc.leaking)
+			if tc.wantErr == "" {
+				if err != nil {
+					t.Fatalf("expected no error but got %q", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+				t.Fatalf("got error = %v, want it to contain %q", err, tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestFilterValidRouteLeaks(t *testing.T) {
+	l3vni := func(name, vrf string, importFrom ...string) v1alpha1.L3VNI {
+		res := v1alpha1.L3VNI{ObjectMeta: metav1.ObjectMeta{Name: name}, Spec: v1alpha1.L3VNISpec{VRF: vrf}}
+		if len(importFrom) > 0 {
+			res.Spec.RouteLeaking = &v1alpha1.RouteLeaking{}
+			for _, v := range importFrom {
+				res.Spec.RouteLeaking.ImportFrom = append(res.Spec.RouteLeaking.ImportFrom, v1alpha1.VRFRouteLeak{VRF: v})
+			}
+		}
+		return res
+	}
+	l3vpn := func(name, vrf string, exportTo ...string) v1alpha1.L3VPN {
+		res := v1alpha1.L3VPN{ObjectMeta: metav1.ObjectMeta{Name: name}, Spec: v1alpha1.L3VPNSpec{VRF: vrf}}
+		if len(exportTo) > 0 {
+			res.Spec.RouteLeaking = &v1alpha1.RouteLeaking{}
+			for _, v := range exportTo {
+				res.Spec.RouteLeaking.ExportTo = append(res.Spec.RouteLeaking.ExportTo, v1alpha1.VRFRouteLeak{VRF: v})
+			}
+		}
+		return res
+	}
+
+	tcs := []struct {
+		name       string
+		l3vnis     []v1alpha1.L3VNI
+		l3vpns     []v1alpha1.L3VPN
+		wantL3VNIs []string
+		wantL3VPNs []string
+		wantErrs   []string
+	}{
+		{
+			name:       "no route leaking",
+			l3vnis:     []v1alpha1.L3VNI{l3vni("red", "red")},
+			l3vpns:     []v1alpha1.L3VPN{l3vpn("blue", "blue")},
+			wantL3VNIs: []string{"red"},
+			wantL3VPNs: []string{"blue"},
+		},
+		{
+			name:       "leaking between existing vrfs and the default one",
+			l3vnis:     []v1alpha1.L3VNI{l3vni("red", "red", "shared"), l3vni("shared", "shared")},
+			l3vpns:     []v1alpha1.L3VPN{l3vpn("blue", "blue", "shared", v1alpha1.DefaultVRF)},
+			wantL3VNIs: []string{"red", "shared"},
+			wantL3VPNs: []string{"blue"},
+		},
+		{
+			name:       "leaking from a missing vrf",
+			l3vnis:     []v1alpha1.L3VNI{l3vni("red", "red", "shared")},
+			wantL3VNIs: []string{},
+			wantL3VPNs: []string{},
+			wantErrs:   []string{`L3VNI/red: vrf "shared" referenced by routeLeaking not found`},
+		},
+		{
+			name:       "leaking to a vrf removed for leaking from a missing vrf",
+			l3vnis:     []v1alpha1.L3VNI{l3vni("red", "red", "missing"), l3vni("green", "green")},
+			l3vpns:     []v1alpha1.L3VPN{l3vpn("blue", "blue", "red")},
+			wantL3VNIs: []string{"green"},
+			wantL3VPNs: []string{},
+			wantErrs: []string{
+				`L3VNI/red: vrf "missing" referenced by routeLeaking not found`,
+				`L3VPN/blue: vrf "red" referenced by routeLeaking not found`,
+			},
+		},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			gotL3VNIs, gotL3VPNs, err := FilterValidRouteLeaks(tc.l3vnis, tc.l3vpns)
+
+			l3vniNames := []string{}
+			for _, l3vni := range gotL3VNIs {
+				l3vniNames = append(l3vniNames, l3vni.Name)
+			}
+			if diff := cmp.Diff(tc.wantL3VNIs, l3vniNames); diff != "" {
+				t.Errorf("unexpected L3VNIs (-want +got):\n%s", diff)
+			}
+			l3vpnNames := []string{}
+			for _, l3vpn := range gotL3VPNs {
+				l3vpnNames = append(l3vpnNames, l3vpn.Name)
+			}
+			if diff := cmp.Diff(tc.wantL3VPNs, l3vpnNames); diff != "" {
+				t.Errorf("unexpected L3VPNs (-want +got):\n%s", diff)
+			}
+
+			if len(tc.wantErrs) == 0 {
+				if err != nil {
+					t.Fatalf("expected no error but got %q", err)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatalf("expected error but got nil")
+			}
+			for _, wantErr := range tc.wantErrs {
+				if !strings.Contains(err.Error(), wantErr) {
+					t.Errorf("got error = %q, want it to contain %q", err, wantErr)
+				}
+			}
+		})
+	}
+}
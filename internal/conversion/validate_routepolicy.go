@@ -0,0 +1,135 @@
+// SPDX-License-Identifier:Apache-2.0
+
+package conversion
+
+import (
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+
+	"github.com/openperouter/openperouter/api/v1alpha1"
+)
+
+// validateNeighborRoutePolicies validates the import and export policies
+// of each address family of an underlay neighbor. Prefix matches are not
+// supported in the evpn address family.
+func validateNeighborRoutePolicies(n v1alpha1.Neighbor) error {
+	for _, af := range n.AddressFamilies {
+		allowPrefixes := af.Type != "evpn"
+		if err := validateRoutePolicy(af.ImportPolicy, allowPrefixes); err != nil {
+			return fmt.Errorf("invalid %s import policy for neighbor %s: %w", af.Type, neighborID(n), err)
+		}
+		if err := validateRoutePolicy(af.ExportPolicy, allowPrefixes); err != nil {
+			return fmt.Errorf("invalid %s export policy for neighbor %s: %w", af.Type, neighborID(n), err)
+		}
+	}
+	return nil
+}
+
+// validateHostSessionRoutePolicies validates the import and export policies
+// of a host session.
+func validateHostSessionRoutePolicies(session v1alpha1.HostSession) error {
+	if err := validateRoutePolicy(session.ImportPolicy, true); err != nil {
+		return fmt.Errorf("invalid import policy: %w", err)
+	}
+	if err := validateRoutePolicy(session.ExportPolicy, true); err != nil {
+		return fmt.Errorf("invalid export policy: %w", err)
+	}
+	return nil
+}
+
+func validateRoutePolicy(policy *v1alpha1.RoutePolicy, allowPrefixes bool) error {
+	if policy == nil {
+		return nil
+	}
+	if len(policy.Rules) == 0 {
+		return fmt.Errorf("at least one rule must be provided")
+	}
+	for i, rule := range policy.Rules {
+		if err := validateRoutePolicyRule(rule, allowPrefixes); err != nil {
+			return fmt.Errorf("rule %d: %w", i, err)
+		}
+	}
+	return nil
+}
+
+func validateRoutePolicyRule(rule v1alpha1.RoutePolicyRule, allowPrefixes bool) error {
+	if rule.Action != v1alpha1.RoutePolicyActionPermit && rule.Action != v1alpha1.RoutePolicyActionDeny {
+		return fmt.Errorf("invalid action %q", rule.Action)
+	}
+	if rule.Set != nil && rule.Action != v1alpha1.RoutePolicyActionPermit {
+		return fmt.Errorf("set can only be used with the %s action", v1alpha1.RoutePolicyActionPermit)
+	}
+	if rule.Match != nil {
+		if len(rule.Match.Prefixes) > 0 && !allowPrefixes {
+			return fmt.Errorf("prefix matches are not supported in the evpn address family")
+		}
+		for _, p := range rule.Match.Prefixes {
+			if err := validatePrefixMatch(p); err != nil {
+				return err
+			}
+		}
+		if err := validateCommunities(rule.Match.Communities, 2, 16); err != nil {
+			return err
+		}
+		if err := validateCommunities(rule.Match.LargeCommunities, 3, 32); err != nil {
+			return err
+		}
+	}
+	if rule.Set != nil {
+		if err := validateCommunities(rule.Set.Communities, 2, 16); err != nil {
+			return err
+		}
+		if err := validateCommunities(rule.Set.LargeCommunities, 3, 32); err != nil {
+			return err
+		}
+		if rule.Set.ASPathPrepend != nil && (rule.Set.ASPathPrepend.Times < 1 || rule.Set.ASPathPrepend.Times > 10) {
+			return fmt.Errorf("asPathPrepend times must be between 1 and 10, got %d", rule.Set.ASPathPrepend.Times)
+		}
+	}
+	return nil
+}
+
+// validatePrefixMatch checks that the prefix is a valid network address and
+// that the ge / le range satisfies len < ge <= le <= max length, as FRR
+// requires.
+func validatePrefixMatch(p v1alpha1.PrefixMatch) error {
+	ip, ipNet, err := net.ParseCIDR(p.Prefix)
+	if err != nil {
+		return fmt.Errorf("invalid prefix %q: %w", p.Prefix, err)
+	}
+	if !ipNet.IP.Equal(ip) {
+		return fmt.Errorf("invalid prefix %q: host bits must be zero, expected %s", p.Prefix, ipNet.String())
+	}
+	length, maxLength := ipNet.Mask.Size()
+	if p.GE != nil && (int(*p.GE) <= length || int(*p.GE) > maxLength) {
+		return fmt.Errorf("invalid ge %d for prefix %s: must be greater than %d and at most %d",
+			*p.GE, p.Prefix, length, maxLength)
+	}
+	if p.LE != nil && (int(*p.LE) <= length || int(*p.LE) > maxLength) {
+		return fmt.Errorf("invalid le %d for prefix %s: must be greater than %d and at most %d",
+			*p.LE, p.Prefix, length, maxLength)
+	}
+	if p.GE != nil && p.LE != nil && *p.LE < *p.GE {
+		return fmt.Errorf("invalid range for prefix %s: le %d must not be less than ge %d", p.Prefix, *p.LE, *p.GE)
+	}
+	return nil
+}
+
+// validateCommunities checks that each community is made of the given
+// number of colon separated unsigned integers of bitSize bits.
+func validateCommunities[T ~string](communities []T, parts int, bitSize int) error {
+	for _, c := range communities {
+		fields := strings.Split(string(c), ":")
+		if len(fields) != parts {
+			return fmt.Errorf("invalid community %q: expected %d colon separated values", c, parts)
+		}
+		for _, f := range fields {
+			if _, err := strconv.ParseUint(f, 10, bitSize); err != nil {
+				return fmt.Errorf("invalid community %q: %w", c, err)
+			}
+		}
+	}
+	return nil
+}
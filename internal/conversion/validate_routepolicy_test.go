@@ -0,0 +1,169 @@
+// SPDX-License-Identifier:Apache-2.0
+
+package conversion
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/openperouter/openperouter/api/v1alpha1"
+)
+
+func TestValidateRoutePolicy(t *testing.T) {
+	prefixRule := func(prefixes ...v1alpha1.PrefixMatch) v1alpha1.RoutePolicyRule {
+		return v1alpha1.RoutePolicyRule{
+			Action: v1alpha1.RoutePolicyActionPermit,
+			Match:  &v1alpha1.RoutePolicyMatch{Prefixes: prefixes},
+		}
+	}
+
+	tests := []struct {
+		name          string
+		rules         []v1alpha1.RoutePolicyRule
+		allowPrefixes bool
+		wantErrStr    string
+	}{
+		{
+			name: "valid policy",
+			rules: []v1alpha1.RoutePolicyRule{
+				prefixRule(
+					v1alpha1.PrefixMatch{Prefix: "10.0.0.0/8", GE: new(int32(16)), LE: new(int32(24))},
+					v1alpha1.PrefixMatch{Prefix: "2001:db8::/32", LE: new(int32(128))},
+				),
+				{
+					Action: v1alpha1.RoutePolicyActionPermit,
+					Match: &v1alpha1.RoutePolicyMatch{
+						Communities:      []v1alpha1.BGPCommunity{"65535:1"},
+						LargeCommunities: []v1alpha1.BGPLargeCommunity{"4294967295:1:1"},
+					},
+					Set: &v1alpha1.RoutePolicySet{
+						LocalPreference: new(int64(100)),
+						ASPathPrepend:   &v1alpha1.ASPathPrepend{Times: 3},
+					},
+				},
+				{Action: v1alpha1.RoutePolicyActionDeny},
+			},
+			allowPrefixes: true,
+		},
+		{
+			name:       "no rules",
+			wantErrStr: "at least one rule",
+		},
+		{
+			name: "set on deny",
+			rules: []v1alpha1.RoutePolicyRule{{
+				Action: v1alpha1.RoutePolicyActionDeny,
+				Set:    &v1alpha1.RoutePolicySet{LocalPreference: new(int64(100))},
+			}},
+			wantErrStr: "set can only be used",
+		},
+		{
+			name:       "prefixes not allowed",
+			rules:      []v1alpha1.RoutePolicyRule{prefixRule(v1alpha1.PrefixMatch{Prefix: "10.0.0.0/8"})},
+			wantErrStr: "not supported in the evpn address family",
+		},
+		{
+			name:          "invalid prefix",
+			rules:         []v1alpha1.RoutePolicyRule{prefixRule(v1alpha1.PrefixMatch{Prefix: "10.0.0.0"})},
+			allowPrefixes: true,
+			wantErrStr:    "invalid prefix",
+		},
+		{
+			name:          "host bits set",
+			rules:         []v1alpha1.RoutePolicyRule{prefixRule(v1alpha1.PrefixMatch{Prefix: "10.0.0.1/8"})},
+			allowPrefixes: true,
+			wantErrStr:    "host bits must be zero",
+		},
+		{
+			name:          "non canonical ipv6 prefix",
+			rules:         []v1alpha1.RoutePolicyRule{prefixRule(v1alpha1.PrefixMatch{Prefix: "2001:DB8:0:0::/32"})},
+			allowPrefixes: true,
+		},
+		{
+			name:          "ipv6 host bits set",
+			rules:         []v1alpha1.RoutePolicyRule{prefixRule(v1alpha1.PrefixMatch{Prefix: "2001:DB8::1/32"})},
+			allowPrefixes: true,
+			wantErrStr:    "host bits must be zero",
+		},
+		{
+			name:          "ge not greater than the prefix length",
+			rules:         []v1alpha1.RoutePolicyRule{prefixRule(v1alpha1.PrefixMatch{Prefix: "10.0.0.0/8", GE: new(int32(8))})},
+			allowPrefixes: true,
+			wantErrStr:    "invalid ge",
+		},
+		{
+			name:          "le beyond the family length",
+			rules:         []v1alpha1.RoutePolicyRule{prefixRule(v1alpha1.PrefixMatch{Prefix: "10.0.0.0/8", LE: new(int32(33))})},
+			allowPrefixes: true,
+			wantErrStr:    "invalid le",
+		},
+		{
+			name: "le less than ge",
+			rules: []v1alpha1.RoutePolicyRule{prefixRule(
+				v1alpha1.PrefixMatch{Prefix: "10.0.0.0/8", GE: new(int32(24)), LE: new(int32(16))},
+			)},
+			allowPrefixes: true,
+			wantErrStr:    "must not be less than ge",
+		},
+		{
+			name: "community out of range",
+			rules: []v1alpha1.RoutePolicyRule{{
+				Action: v1alpha1.RoutePolicyActionPermit,
+				Match:  &v1alpha1.RoutePolicyMatch{Communities: []v1alpha1.BGPCommunity{"65536:1"}},
+			}},
+			wantErrStr: "invalid community",
+		},
+		{
+			name: "malformed large community",
+			rules: []v1alpha1.RoutePolicyRule{{
+				Action: v1alpha1.RoutePolicyActionPermit,
+				Set:    &v1alpha1.RoutePolicySet{LargeCommunities: []v1alpha1.BGPLargeCommunity{"1:2"}},
+			}},
+			wantErrStr: "expected 3 colon separated values",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := validateRoutePolicy(&v1alpha1.RoutePolicy{Rules: tc.rules}, tc.allowPrefixes)
+			if tc.wantErrStr == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tc.wantErrStr) {
+				t.Fatalf("expected error containing %q, got %v", tc.wantErrStr, err)
+			}
+		})
+	}
+}
+
+func TestValidateUnderlayRoutePolicies(t *testing.T) {
+	underlay := v1alpha1.Underlay{
+		Spec: v1alpha1.UnderlaySpec{
+			ASN: 65001,
+			Neighbors: []v1alpha1.Neighbor{{
+				ASN:     new(int64(65002)),
+				Address: new("192.168.1.1"),
+				AddressFamilies: []v1alpha1.NeighborAddressFamily{{
+					Type: "evpn",
+					ImportPolicy: &v1alpha1.RoutePolicy{
+						Rules: []v1alpha1.RoutePolicyRule{{
+							Action: v1alpha1.RoutePolicyActionDeny,
+							Match: &v1alpha1.RoutePolicyMatch{
+								Prefixes: []v1alpha1.PrefixMatch{{Prefix: "10.0.0.0/8"}},
+							},
+						}},
+					},
+				}},
+			}},
+		},
+	}
+	underlay.Name = "underlay"
+
+	err := validateUnderlay(underlay)
+	if err == nil || !strings.Contains(err.Error(), "invalid evpn import policy for neighbor 192.168.1.1") {
+		t.Fatalf("expected an invalid evpn import policy error, got %v", err)
+	}
+}
@@ -3,11 +3,14 @@
 package conversion
 
 import (
+	"errors"
 	"fmt"
 	"net/netip"
 	"slices"
 
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/utils/ptr"
 
 	"github.com/openperouter/openperouter/api/v1alpha1"
 	openpeerrors "github.com/openperouter/openperouter/internal/errors"
@@ -28,28 +31,126 @@ func ValidateUnderlaysForNodes(nodes []corev1.Node, underlays []v1alpha1.Underla
 	return nil
 }
 
+// ValidateUnderlays validates the underlays applying to a single node. A node
+// can have several underlays, for example one per fabric plane, as long as
+// they don't share interfaces, neighbors or tunnel endpoints, and agree on
+// the settings of the router as a whole.
 func ValidateUnderlays(underlays []v1alpha1.Underlay) error {
-	if len(underlays) == 0 {
+	for _, underlay := range underlays {
+		if err := validateUnderlay(underlay); err != nil {
+			return underlayValidationError(underlay.Name, err.Error())
+		}
+	}
+	for i := 1; i < len(underlays); i++ {
+		if err := validateUnderlayCompatibility(underlays[0], underlays[i]); err != nil {
+			return underlayValidationError(underlays[i].Name, err.Error())
+		}
+	}
+	if len(underlays) < 2 {
 		return nil
 	}
-	if len(underlays) > 1 {
-		return &openpeerrors.ResourceError{
-			Obj: v1alpha1.FailedResource{
-				Kind:    v1alpha1.FailedResourceKind("Underlay"),
-				Name:    underlays[0].Name,
-				Reason:  v1alpha1.FailedResourceReasonValidationFailed,
-				Message: "can't have more than one underlay per node",
-			},
+	if err := validateUnderlaysDisjoint(underlays); err != nil {
+		return underlayValidationError(underlays[len(underlays)-1].Name, err.Error())
+	}
+	return nil
+}
+
+func underlayValidationError(name, message string) error {
+	return &openpeerrors.ResourceError{
+		Obj: v1alpha1.FailedResource{
+			Kind:    openpeerrors.KindUnderlay,
+			Name:    name,
+			Reason:  v1alpha1.FailedResourceReasonValidationFailed,
+			Message: message,
+		},
+	}
+}
+
+// validateUnderlayCompatibility checks that the two underlays of the same
+// node agree on the settings of the single BGP instance of the router, and
+// that only one of them configures ISIS, OSPF and SRv6.
+func validateUnderlayCompatibility(first, other v1alpha1.Underlay) error {
+	if first.Spec.ASN != other.Spec.ASN {
+		return fmt.Errorf("underlay %s must have the same asn of underlay %s (%d), got %d",
+			other.Name, first.Name, first.Spec.ASN, other.Spec.ASN)
+	}
+	if ptr.Deref(first.Spec.RouterIDCIDR, "") != ptr.Deref(other.Spec.RouterIDCIDR, "") {
+		return fmt.Errorf("underlay %s must have the same routerIDCIDR of underlay %s", other.Name, first.Name)
+	}
+	if !equality.Semantic.DeepEqual(first.Spec.GracefulRestart, other.Spec.GracefulRestart) {
+		return fmt.Errorf("underlay %s must have the same gracefulRestart of underlay %s", other.Name, first.Name)
+	}
+	if !equality.Semantic.DeepEqual(first.Spec.RouteReflector, other.Spec.RouteReflector) {
+		return fmt.Errorf("underlay %s must have the same routeReflector of underlay %s", other.Name, first.Name)
+	}
+	if first.Spec.ISIS != nil && other.Spec.ISIS != nil {
+		return fmt.Errorf("underlay %s: isis is already configured by underlay %s", other.Name, first.Name)
+	}
+	if first.Spec.OSPF != nil && other.Spec.OSPF != nil {
+		return fmt.Errorf("underlay %s: ospf is already configured by underlay %s", other.Name, first.Name)
+	}
+	if (first.Spec.ISIS != nil && other.Spec.OSPF != nil) || (first.Spec.OSPF != nil && other.Spec.ISIS != nil) {
+		return fmt.Errorf("underlay %s: isis and ospf are mutually exclusive, conflicting with underlay %s", other.Name, first.Name)
+	}
+	if first.Spec.SRV6 != nil && other.Spec.SRV6 != nil {
+		return fmt.Errorf("underlay %s: srv6 is already configured by underlay %s", other.Name, first.Name)
+	}
+	return nil
+}
+
+// validateUnderlaysDisjoint checks that the underlays of the same node don't
+// share interfaces, neighbors and tunnel endpoints. An underlay carrying the
+// VNIs over the single VXLAN device must be the only one with a tunnel
+// endpoint, as the VXLAN interfaces of the other mode can't share its port.
+func validateUnderlaysDisjoint(underlays []v1alpha1.Underlay) error {
+	var (
+		interfaces      []v1alpha1.UnderlayInterface
+		neighbors       []v1alpha1.Neighbor
+		tunnelEndpoints []netip.Prefix
+		withEndpoint    []string
+		singleDevice    string
+	)
+	for _, underlay := range underlays {
+		interfaces = append(interfaces, underlay.Spec.Interfaces...)
+		neighbors = append(neighbors, underlay.Spec.Neighbors...)
+		if underlay.Spec.TunnelEndpoint == nil {
+			continue
+		}
+		withEndpoint = append(withEndpoint, underlay.Name)
+		if underlay.Spec.TunnelEndpoint.VXLANMode == v1alpha1.VXLANModeSingleVXLANDevice {
+			singleDevice = underlay.Name
+		}
+		for _, cidr := range underlay.Spec.TunnelEndpoint.CIDRs {
+			prefix, err := netip.ParsePrefix(cidr)
+			if err != nil {
+				return fmt.Errorf("invalid tunnel endpoint CIDR %s for underlay %s: %w", cidr, underlay.Name, err)
+			}
+			tunnelEndpoints = append(tunnelEndpoints, prefix.Masked())
 		}
 	}
-	if err := validateUnderlay(underlays[0]); err != nil {
-		return &openpeerrors.ResourceError{
-			Obj: v1alpha1.FailedResource{
-				Kind:    v1alpha1.FailedResourceKind("Underlay"),
-				Name:    underlays[0].Name,
-				Reason:  v1alpha1.FailedResourceReasonValidationFailed,
-				Message: err.Error(),
-			},
+
+	if singleDevice != "" && len(withEndpoint) > 1 {
+		return fmt.Errorf("underlay %s uses vxlanMode %s and must be the only underlay with a tunnel endpoint, got %v",
+			singleDevice, v1alpha1.VXLANModeSingleVXLANDevice, withEndpoint)
+	}
+	if _, err := underlayInterfacesToHost(interfaces); err != nil {
+		return fmt.Errorf("underlays have conflicting interfaces: %w", err)
+	}
+	if err := validateNoDuplicates(neighborAddressesOf(neighbors)); err != nil {
+		return fmt.Errorf("underlays have duplicate neighbor address: %w", err)
+	}
+	if err := validateNoDuplicates(interfaceNamesOf(neighbors)); err != nil {
+		return fmt.Errorf("underlays have duplicate neighbor interface names: %w", err)
+	}
+	if err := validateListenRanges(neighbors); err != nil {
+		return fmt.Errorf("underlays have conflicting neighbors: %w", err)
+	}
+
+	slices.SortFunc(tunnelEndpoints, netip.Prefix.Compare)
+	for i := 0; i < len(tunnelEndpoints)-1; i++ {
+		if tunnelEndpoints[i].Overlaps(tunnelEndpoints[i+1]) {
+			return fmt.Errorf("tunnel endpoint CIDR %s overlaps with tunnel endpoint CIDR %s",
+				tunnelEndpoints[i], tunnelEndpoints[i+1])
 		}
 	}
 	return nil
@@ -78,6 +179,16 @@ func validateUnderlay(underlay v1alpha1.Underlay) error {
 		return fmt.Errorf("underlay %s: %w", underlay.Name, err)
 	}
 
+	for _, n := range underlay.Spec.Neighbors {
+		if err := validateNeighborRoutePolicies(n); err != nil {
+			return fmt.Errorf("underlay %s: %w", underlay.Name, err)
+		}
+		if n.Password != nil && n.PasswordSecret != nil {
+			return fmt.Errorf("underlay %s: neighbor %s: password and passwordSecret are mutually exclusive",
+				underlay.Name, neighborID(n))
+		}
+	}
+
 	// do a no-op conversion to catch validation errors
 	if _, err := underlayInterfacesToHost(underlay.Spec.Interfaces); err != nil {
 		return fmt.Errorf("underlay %s has invalid interfaces: %w", underlay.Name, err)
@@ -89,6 +200,10 @@ func validateUnderlay(underlay v1alpha1.Underlay) error {
 		}
 	}
 
+	if err := validateUnderlayOSPF(underlay); err != nil {
+		return err
+	}
+
 	srv6Config := underlay.Spec.SRV6
 	if srv6Config == nil {
 		return nil
@@ -133,6 +248,28 @@ func validateUnderlayTunnelEndpoint(underlay *v1alpha1.Underlay) error {
 	return nil
 }
 
+// validateUnderlayOSPF checks that ospf is not combined with isis and has a
+// valid area.
+func validateUnderlayOSPF(underlay v1alpha1.Underlay) error {
+	if underlay.Spec.OSPF == nil {
+		return nil
+	}
+	if underlay.Spec.ISIS != nil {
+		return fmt.Errorf("underlay %s: isis and ospf are mutually exclusive", underlay.Name)
+	}
+	if _, err := ospfArea(underlay.Spec.OSPF.Area); err != nil {
+		return fmt.Errorf("underlay %s: %w", underlay.Name, err)
+	}
+	names := make([]string, 0, len(underlay.Spec.OSPF.Interfaces))
+	for _, intf := range underlay.Spec.OSPF.Interfaces {
+		names = append(names, intf.Name)
+	}
+	if err := validateNoDuplicates(names); err != nil {
+		return fmt.Errorf("underlay %s has duplicate ospf interfaces: %w", underlay.Name, err)
+	}
+	return nil
+}
+
 func neighborAddressesOf(neighbors []v1alpha1.Neighbor) []string {
 	res := []string{}
 	for _, n := range neighbors {
@@ -191,3 +328,110 @@ func validateNoDuplicates(items []string) error {
 	}
 	return nil
 }
+
+var (
+	errUnderlayNotFound              = errors.New("underlay not found on the node")
+	errUnderlayWithoutTunnelEndpoint = errors.New("underlay has no tunnel endpoint configuration")
+)
+
+// underlayForVNI returns the underlay a VNI referencing the given underlay
+// name rides on. When the name is not set, the VNI rides on the only underlay
+// with a tunnel endpoint.
+func underlayForVNI(underlays []v1alpha1.Underlay, name *string) (v1alpha1.Underlay, error) {
+	if name != nil {
+		for _, u := range underlays {
+			if u.Name == *name {
+				return u, nil
+			}
+		}
+		return v1alpha1.Underlay{}, fmt.Errorf("%w: %s", errUnderlayNotFound, *name)
+	}
+
+	var withTunnelEndpoint []v1alpha1.Underlay
+	for _, u := range underlays {
+		if u.Spec.TunnelEndpoint != nil {
+			withTunnelEndpoint = append(withTunnelEndpoint, u)
+		}
+	}
+	switch {
+	case len(withTunnelEndpoint) == 1:
+		return withTunnelEndpoint[0], nil
+	case len(withTunnelEndpoint) > 1:
+		return v1alpha1.Underlay{}, errors.New("more than one underlay with a tunnel endpoint, the underlay must be set")
+	case len(underlays) > 0:
+		return underlays[0], nil
+	}
+	return v1alpha1.Underlay{}, NoUnderlaysError("no underlays provided")
+}
+
+// tunnelUnderlayForVNI returns the underlay a VNI rides on, making sure it
+// has a tunnel endpoint.
+func tunnelUnderlayForVNI(underlays []v1alpha1.Underlay, name *string) (v1alpha1.Underlay, error) {
+	underlay, err := underlayForVNI(underlays, name)
+	if err != nil {
+		return v1alpha1.Underlay{}, err
+	}
+	if underlay.Spec.TunnelEndpoint == nil {
+		return v1alpha1.Underlay{}, fmt.Errorf("%w: %s", errUnderlayWithoutTunnelEndpoint, underlay.Name)
+	}
+	return underlay, nil
+}
+
+// FilterVNIsWithValidUnderlay removes the VNIs whose underlay can't be
+// determined or has no tunnel endpoint, and the L2VNIs not riding on the same underlay of the L3VNI
+// they are routed through.
+func FilterVNIsWithValidUnderlay(underlays []v1alpha1.Underlay, l3Vnis []v1alpha1.L3VNI,
+	l2Vnis []v1alpha1.L2VNI) ([]v1alpha1.L3VNI, []v1alpha1.L2VNI, error) {
+	if len(underlays) == 0 {
+		return l3Vnis, l2Vnis, nil
+	}
+
+	var allErrors []error
+	failure := func(kind v1alpha1.FailedResourceKind, name string, err error) {
+		reason := v1alpha1.FailedResourceReasonValidationFailed
+		if errors.Is(err, errUnderlayNotFound) || errors.Is(err, errUnderlayWithoutTunnelEndpoint) {
+			reason = v1alpha1.FailedResourceReasonDependencyFailed
+		}
+		allErrors = append(allErrors, &openpeerrors.ResourceError{
+			Obj: v1alpha1.FailedResource{Kind: kind, Name: name, Reason: reason, Message: err.Error()},
+		})
+	}
+
+	validL3VNIs := make([]v1alpha1.L3VNI, 0, len(l3Vnis))
+	underlayOfL3VNI := map[string]string{}
+	for _, l3 := range l3Vnis {
+		underlay, err := tunnelUnderlayForVNI(underlays, l3.Spec.Underlay)
+		if err != nil {
+			failure(openpeerrors.KindL3VNI, l3.Name, err)
+			continue
+		}
+		underlayOfL3VNI[l3.Name] = underlay.Name
+		validL3VNIs = append(validL3VNIs, l3)
+	}
+
+	validL2VNIs := make([]v1alpha1.L2VNI, 0, len(l2Vnis))
+	for _, l2 := range l2Vnis {
+		underlay, err := tunnelUnderlayForVNI(underlays, l2.Spec.Underlay)
+		if err != nil {
+			failure(openpeerrors.KindL2VNI, l2.Name, err)
+			continue
+		}
+		if l3Name := routingL3VNIName(l2); l3Name != "" {
+			if l3Underlay, ok := underlayOfL3VNI[l3Name]; ok && l3Underlay != underlay.Name {
+				failure(openpeerrors.KindL2VNI, l2.Name, fmt.Errorf("underlay %s differs from underlay %s of L3VNI %s",
+					underlay.Name, l3Underlay, l3Name))
+				continue
+			}
+		}
+		validL2VNIs = append(validL2VNIs, l2)
+	}
+	return validL3VNIs, validL2VNIs, errors.Join(allErrors...)
+}
+
+func routingL3VNIName(l2vni v1alpha1.L2VNI) string {
+	if l2vni.Spec.RoutingDomain == nil || l2vni.Spec.RoutingDomain.Type != v1alpha1.RoutingDomainTypeL3VNI ||
+		l2vni.Spec.RoutingDomain.L3VNI == nil {
+		return ""
+	}
+	return l2vni.Spec.RoutingDomain.L3VNI.Name
+}
@@ -6,7 +6,9 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/google/go-cmp/cmp"
 	"github.com/openperouter/openperouter/api/v1alpha1"
+	openpeerrors "github.com/openperouter/openperouter/internal/errors"
 	corev1 "k8s.io/api/core/v1"
 	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -763,7 +765,7 @@ func TestValidateUnderlay(t *testing.T) {
 				"duplicate entry eth0",
 		},
 		{
-			name: "multiple underlays",
+			name: "multiple underlays with different asn",
 			underlay: []v1alpha1.Underlay{
 				{
 					Spec: v1alpha1.UnderlaySpec{
@@ -806,7 +808,507 @@ func TestValidateUnderlay(t *testing.T) {
 					},
 				},
 			},
-			wantErrStr: "can't have more than one underlay per node",
+			wantErrStr: "must have the same asn",
+		},
+		{
+			name: "multiple underlays on separate planes",
+			underlay: []v1alpha1.Underlay{
+				{
+					ObjectMeta: metav1.ObjectMeta{Name: "plane-a"},
+					Spec: v1alpha1.UnderlaySpec{
+						TunnelEndpoint: &v1alpha1.TunnelEndpointConfig{
+							CIDRs: []string{"100.64.0.0/24"},
+						},
+						Interfaces: []v1alpha1.UnderlayInterface{
+							{
+								Type:          v1alpha1.UnderlayInterfaceTypeNetworkDevice,
+								NetworkDevice: &v1alpha1.NetworkDevice{InterfaceName: "eth0"},
+							},
+						},
+						ASN: 65001,
+						Neighbors: []v1alpha1.Neighbor{
+							{
+								ASN:     new(int64(65100)),
+								Address: new("192.168.1.1"),
+							},
+						},
+					},
+				},
+				{
+					ObjectMeta: metav1.ObjectMeta{Name: "plane-b"},
+					Spec: v1alpha1.UnderlaySpec{
+						TunnelEndpoint: &v1alpha1.TunnelEndpointConfig{
+							CIDRs: []string{"100.65.0.0/24"},
+						},
+						Interfaces: []v1alpha1.UnderlayInterface{
+							{
+								Type:          v1alpha1.UnderlayInterfaceTypeNetworkDevice,
+								NetworkDevice: &v1alpha1.NetworkDevice{InterfaceName: "eth1"},
+							},
+						},
+						ASN: 65001,
+						Neighbors: []v1alpha1.Neighbor{
+							{
+								ASN:     new(int64(65100)),
+								Address: new("192.168.2.1"),
+							},
+						},
+					},
+				},
+			},
+			wantErrStr: "",
+		},
+		{
+			name: "multiple underlays sharing an interface",
+			underlay: []v1alpha1.Underlay{
+				{
+					ObjectMeta: metav1.ObjectMeta{Name: "plane-a"},
+					Spec: v1alpha1.UnderlaySpec{
+						TunnelEndpoint: &v1alpha1.TunnelEndpointConfig{
+							CIDRs: []string{"100.64.0.0/24"},
+						},
+						Interfaces: []v1alpha1.UnderlayInterface{
+							{
+								Type:          v1alpha1.UnderlayInterfaceTypeNetworkDevice,
+								NetworkDevice: &v1alpha1.NetworkDevice{InterfaceName: "eth0"},
+							},
+						},
+						ASN: 65001,
+						Neighbors: []v1alpha1.Neighbor{
+							{
+								ASN:     new(int64(65100)),
+								Address: new("192.168.1.1"),
+							},
+						},
+					},
+				},
+				{
+					ObjectMeta: metav1.ObjectMeta{Name: "plane-b"},
+					Spec: v1alpha1.UnderlaySpec{
+						TunnelEndpoint: &v1alpha1.TunnelEndpointConfig{
+							CIDRs: []string{"100.65.0.0/24"},
+						},
+						Interfaces: []v1alpha1.UnderlayInterface{
+							{
+								Type:          v1alpha1.UnderlayInterfaceTypeNetworkDevice,
+								NetworkDevice: &v1alpha1.NetworkDevice{InterfaceName: "eth0"},
+							},
+						},
+						ASN: 65001,
+						Neighbors: []v1alpha1.Neighbor{
+							{
+								ASN:     new(int64(65100)),
+								Address: new("192.168.2.1"),
+							},
+						},
+					},
+				},
+			},
+			wantErrStr: "duplicate",
+		},
+		{
+			name: "multiple underlays sharing a neighbor",
+			underlay: []v1alpha1.Underlay{
+				{
+					ObjectMeta: metav1.ObjectMeta{Name: "plane-a"},
+					Spec: v1alpha1.UnderlaySpec{
+						TunnelEndpoint: &v1alpha1.TunnelEndpointConfig{
+							CIDRs: []string{"100.64.0.0/24"},
+						},
+						Interfaces: []v1alpha1.UnderlayInterface{
+							{
+								Type:          v1alpha1.UnderlayInterfaceTypeNetworkDevice,
+								NetworkDevice: &v1alpha1.NetworkDevice{InterfaceName: "eth0"},
+							},
+						},
+						ASN: 65001,
+						Neighbors: []v1alpha1.Neighbor{
+							{
+								ASN:     new(int64(65100)),
+								Address: new("192.168.1.1"),
+							},
+						},
+					},
+				},
+				{
+					ObjectMeta: metav1.ObjectMeta{Name: "plane-b"},
+					Spec: v1alpha1.UnderlaySpec{
+						TunnelEndpoint: &v1alpha1.TunnelEndpointConfig{
+							CIDRs: []string{"100.65.0.0/24"},
+						},
+						Interfaces: []v1alpha1.UnderlayInterface{
+							{
+								Type:          v1alpha1.UnderlayInterfaceTypeNetworkDevice,
+								NetworkDevice: &v1alpha1.NetworkDevice{InterfaceName: "eth1"},
+							},
+						},
+						ASN: 65001,
+						Neighbors: []v1alpha1.Neighbor{
+							{
+								ASN:     new(int64(65100)),
+								Address: new("192.168.1.1"),
+							},
+						},
+					},
+				},
+			},
+			wantErrStr: "192.168.1.1",
+		},
+		{
+			name: "multiple underlays with overlapping tunnel endpoints",
+			underlay: []v1alpha1.Underlay{
+				{
+					ObjectMeta: metav1.ObjectMeta{Name: "plane-a"},
+					Spec: v1alpha1.UnderlaySpec{
+						TunnelEndpoint: &v1alpha1.TunnelEndpointConfig{
+							CIDRs: []string{"100.64.0.0/24"},
+						},
+						Interfaces: []v1alpha1.UnderlayInterface{
+							{
+								Type:          v1alpha1.UnderlayInterfaceTypeNetworkDevice,
+								NetworkDevice: &v1alpha1.NetworkDevice{InterfaceName: "eth0"},
+							},
+						},
+						ASN: 65001,
+						Neighbors: []v1alpha1.Neighbor{
+							{
+								ASN:     new(int64(65100)),
+								Address: new("192.168.1.1"),
+							},
+						},
+					},
+				},
+				{
+					ObjectMeta: metav1.ObjectMeta{Name: "plane-b"},
+					Spec: v1alpha1.UnderlaySpec{
+						TunnelEndpoint: &v1alpha1.TunnelEndpointConfig{
+							CIDRs: []string{"100.64.0.0/16"},
+						},
+						Interfaces: []v1alpha1.UnderlayInterface{
+							{
+								Type:          v1alpha1.UnderlayInterfaceTypeNetworkDevice,
+								NetworkDevice: &v1alpha1.NetworkDevice{InterfaceName: "eth1"},
+							},
+						},
+						ASN: 65001,
+						Neighbors: []v1alpha1.Neighbor{
+							{
+								ASN:     new(int64(65100)),
+								Address: new("192.168.2.1"),
+							},
+						},
+					},
+				},
+			},
+			wantErrStr: "overlap",
+		},
+		{
+			name: "single vxlan device underlay with another tunnel endpoint",
+			underlay: []v1alpha1.Underlay{
+				{
+					ObjectMeta: metav1.ObjectMeta{Name: "plane-a"},
+					Spec: v1alpha1.UnderlaySpec{
+						TunnelEndpoint: &v1alpha1.TunnelEndpointConfig{
+							CIDRs:     []string{"100.64.0.0/24"},
+							VXLANMode: v1alpha1.VXLANModeSingleVXLANDevice,
+						},
+						Interfaces: []v1alpha1.UnderlayInterface{
+							{
+								Type:          v1alpha1.UnderlayInterfaceTypeNetworkDevice,
+								NetworkDevice: &v1alpha1.NetworkDevice{InterfaceName: "eth0"},
+							},
+						},
+						ASN: 65001,
+						Neighbors: []v1alpha1.Neighbor{
+							{
+								ASN:     new(int64(65100)),
+								Address: new("192.168.1.1"),
+							},
+						},
+					},
+				},
+				{
+					ObjectMeta: metav1.ObjectMeta{Name: "plane-b"},
+					Spec: v1alpha1.UnderlaySpec{
+						TunnelEndpoint: &v1alpha1.TunnelEndpointConfig{
+							CIDRs: []string{"100.65.0.0/24"},
+						},
+						Interfaces: []v1alpha1.UnderlayInterface{
+							{
+								Type:          v1alpha1.UnderlayInterfaceTypeNetworkDevice,
+								NetworkDevice: &v1alpha1.NetworkDevice{InterfaceName: "eth1"},
+							},
+						},
+						ASN: 65001,
+						Neighbors: []v1alpha1.Neighbor{
+							{
+								ASN:     new(int64(65100)),
+								Address: new("192.168.2.1"),
+							},
+						},
+					},
+				},
+			},
+			wantErrStr: "underlay plane-a uses vxlanMode SingleVXLANDevice and must be the only underlay with a tunnel endpoint",
+		},
+		{
+			name: "neighbor with both password and passwordSecret",
+			underlay: []v1alpha1.Underlay{
+				{
+					Spec: v1alpha1.UnderlaySpec{
+						TunnelEndpoint: &v1alpha1.TunnelEndpointConfig{
+							CIDRs: []string{"192.168.1.0/24"},
+						},
+						Interfaces: []v1alpha1.UnderlayInterface{
+							{
+								Type:          v1alpha1.UnderlayInterfaceTypeNetworkDevice,
+								NetworkDevice: &v1alpha1.NetworkDevice{InterfaceName: "eth0"},
+							},
+						},
+						ASN: 65001,
+						Neighbors: []v1alpha1.Neighbor{
+							{
+								ASN:            new(int64(65002)),
+								Address:        new("192.168.1.1"),
+								Password:       new("key"),
+								PasswordSecret: new("bgp"),
+							},
+						},
+					},
+				},
+			},
+			wantErrStr: "password and passwordSecret are mutually exclusive",
+		},
+		{
+			name: "valid ospf",
+			underlay: []v1alpha1.Underlay{
+				{
+					Spec: v1alpha1.UnderlaySpec{
+						TunnelEndpoint: &v1alpha1.TunnelEndpointConfig{
+							CIDRs: []string{"192.168.1.0/24"},
+						},
+						Interfaces: []v1alpha1.UnderlayInterface{
+							{
+								Type:          v1alpha1.UnderlayInterfaceTypeNetworkDevice,
+								NetworkDevice: &v1alpha1.NetworkDevice{InterfaceName: "eth0"},
+							},
+						},
+						ASN: 65001,
+						Neighbors: []v1alpha1.Neighbor{
+							{
+								ASN:     new(int64(65002)),
+								Address: new("192.168.1.1"),
+							},
+						},
+						OSPF: &v1alpha1.OSPFConfig{
+							Area:     new(v1alpha1.OSPFArea("0.0.0.1")),
+							IPFamily: new(v1alpha1.IPFamilyDualStack),
+							Interfaces: []v1alpha1.OSPFInterface{
+								{Name: "eth0", Features: []v1alpha1.OSPFInterfaceFeature{"pointToPoint"}},
+							},
+						},
+					},
+				},
+			},
+			wantErrStr: "",
+		},
+		{
+			name: "valid ospf with decimal area",
+			underlay: []v1alpha1.Underlay{
+				{
+					Spec: v1alpha1.UnderlaySpec{
+						TunnelEndpoint: &v1alpha1.TunnelEndpointConfig{
+							CIDRs: []string{"192.168.1.0/24"},
+						},
+						Interfaces: []v1alpha1.UnderlayInterface{
+							{
+								Type:          v1alpha1.UnderlayInterfaceTypeNetworkDevice,
+								NetworkDevice: &v1alpha1.NetworkDevice{InterfaceName: "eth0"},
+							},
+						},
+						ASN: 65001,
+						Neighbors: []v1alpha1.Neighbor{
+							{
+								ASN:     new(int64(65002)),
+								Address: new("192.168.1.1"),
+							},
+						},
+						OSPF: &v1alpha1.OSPFConfig{
+							Area: new(v1alpha1.OSPFArea("10")),
+						},
+					},
+				},
+			},
+			wantErrStr: "",
+		},
+		{
+			name: "ospf area out of range",
+			underlay: []v1alpha1.Underlay{
+				{
+					Spec: v1alpha1.UnderlaySpec{
+						TunnelEndpoint: &v1alpha1.TunnelEndpointConfig{
+							CIDRs: []string{"192.168.1.0/24"},
+						},
+						Interfaces: []v1alpha1.UnderlayInterface{
+							{
+								Type:          v1alpha1.UnderlayInterfaceTypeNetworkDevice,
+								NetworkDevice: &v1alpha1.NetworkDevice{InterfaceName: "eth0"},
+							},
+						},
+						ASN: 65001,
+						Neighbors: []v1alpha1.Neighbor{
+							{
+								ASN:     new(int64(65002)),
+								Address: new("192.168.1.1"),
+							},
+						},
+						OSPF: &v1alpha1.OSPFConfig{
+							Area: new(v1alpha1.OSPFArea("4294967296")),
+						},
+					},
+				},
+			},
+			wantErrStr: "invalid OSPF area",
+		},
+		{
+			name: "ospf with isis",
+			underlay: []v1alpha1.Underlay{
+				{
+					Spec: v1alpha1.UnderlaySpec{
+						TunnelEndpoint: &v1alpha1.TunnelEndpointConfig{
+							CIDRs: []string{"192.168.1.0/24"},
+						},
+						Interfaces: []v1alpha1.UnderlayInterface{
+							{
+								Type:          v1alpha1.UnderlayInterfaceTypeNetworkDevice,
+								NetworkDevice: &v1alpha1.NetworkDevice{InterfaceName: "eth0"},
+							},
+						},
+						ASN: 65001,
+						Neighbors: []v1alpha1.Neighbor{
+							{
+								ASN:     new(int64(65002)),
+								Address: new("192.168.1.1"),
+							},
+						},
+						OSPF: &v1alpha1.OSPFConfig{
+							Area: new(v1alpha1.OSPFArea("0")),
+						},
+						ISIS: &v1alpha1.ISISConfig{
+							BaseNet: "49.0001.0002.0003.0004.00",
+						},
+					},
+				},
+			},
+			wantErrStr: "isis and ospf are mutually exclusive",
+		},
+		{
+			name: "multiple underlays configuring ospf",
+			underlay: []v1alpha1.Underlay{
+				{
+					ObjectMeta: metav1.ObjectMeta{Name: "plane-a"},
+					Spec: v1alpha1.UnderlaySpec{
+						TunnelEndpoint: &v1alpha1.TunnelEndpointConfig{
+							CIDRs: []string{"100.64.0.0/24"},
+						},
+						Interfaces: []v1alpha1.UnderlayInterface{
+							{
+								Type:          v1alpha1.UnderlayInterfaceTypeNetworkDevice,
+								NetworkDevice: &v1alpha1.NetworkDevice{InterfaceName: "eth0"},
+							},
+						},
+						ASN: 65001,
+						Neighbors: []v1alpha1.Neighbor{
+							{
+								ASN:     new(int64(65100)),
+								Address: new("192.168.1.1"),
+							},
+						},
+						OSPF: &v1alpha1.OSPFConfig{
+							Area: new(v1alpha1.OSPFArea("0")),
+						},
+					},
+				},
+				{
+					ObjectMeta: metav1.ObjectMeta{Name: "plane-b"},
+					Spec: v1alpha1.UnderlaySpec{
+						TunnelEndpoint: &v1alpha1.TunnelEndpointConfig{
+							CIDRs: []string{"100.65.0.0/24"},
+						},
+						Interfaces: []v1alpha1.UnderlayInterface{
+							{
+								Type:          v1alpha1.UnderlayInterfaceTypeNetworkDevice,
+								NetworkDevice: &v1alpha1.NetworkDevice{InterfaceName: "eth1"},
+							},
+						},
+						ASN: 65001,
+						Neighbors: []v1alpha1.Neighbor{
+							{
+								ASN:     new(int64(65100)),
+								Address: new("192.168.2.1"),
+							},
+						},
+						OSPF: &v1alpha1.OSPFConfig{
+							Area: new(v1alpha1.OSPFArea("0")),
+						},
+					},
+				},
+			},
+			wantErrStr: "ospf is already configured by underlay plane-a",
+		},
+		{
+			name: "underlays configuring isis and ospf",
+			underlay: []v1alpha1.Underlay{
+				{
+					ObjectMeta: metav1.ObjectMeta{Name: "plane-a"},
+					Spec: v1alpha1.UnderlaySpec{
+						TunnelEndpoint: &v1alpha1.TunnelEndpointConfig{
+							CIDRs: []string{"100.64.0.0/24"},
+						},
+						Interfaces: []v1alpha1.UnderlayInterface{
+							{
+								Type:          v1alpha1.UnderlayInterfaceTypeNetworkDevice,
+								NetworkDevice: &v1alpha1.NetworkDevice{InterfaceName: "eth0"},
+							},
+						},
+						ASN: 65001,
+						Neighbors: []v1alpha1.Neighbor{
+							{
+								ASN:     new(int64(65100)),
+								Address: new("192.168.1.1"),
+							},
+						},
+						ISIS: &v1alpha1.ISISConfig{
+							BaseNet: "49.0001.0002.0003.0004.00",
+						},
+					},
+				},
+				{
+					ObjectMeta: metav1.ObjectMeta{Name: "plane-b"},
+					Spec: v1alpha1.UnderlaySpec{
+						TunnelEndpoint: &v1alpha1.TunnelEndpointConfig{
+							CIDRs: []string{"100.65.0.0/24"},
+						},
+						Interfaces: []v1alpha1.UnderlayInterface{
+							{
+								Type:          v1alpha1.UnderlayInterfaceTypeNetworkDevice,
+								NetworkDevice: &v1alpha1.NetworkDevice{InterfaceName: "eth1"},
+							},
+						},
+						ASN: 65001,
+						Neighbors: []v1alpha1.Neighbor{
+							{
+								ASN:     new(int64(65100)),
+								Address: new("192.168.2.1"),
+							},
+						},
+						OSPF: &v1alpha1.OSPFConfig{
+							Area: new(v1alpha1.OSPFArea("0")),
+						},
+					},
+				},
+			},
+			wantErrStr: "isis and ospf are mutually exclusive",
 		},
 		{
 			name: "duplicate listen range",
@@ -1017,7 +1519,7 @@ func TestValidateUnderlaysForNodes(t *testing.T) {
 			wantErr: false,
 		},
 		{
-			name: "single node matching multiple underlays - should error",
+			name: "single node matching multiple underlays with different asn - should error",
 			nodes: []corev1.Node{
 				{
 					ObjectMeta: metav1.ObjectMeta{
@@ -1061,7 +1563,7 @@ func TestValidateUnderlaysForNodes(t *testing.T) {
 				},
 			},
 			wantErr: true,
-			errMsg:  "can't have more than one underlay per node",
+			errMsg:  "must have the same asn",
 		},
 		{
 			name: "multiple nodes each with one matching underlay",
@@ -1255,7 +1757,7 @@ func TestValidateUnderlaysForNodes(t *testing.T) {
 			errMsg:  "failed to validate underlays for node",
 		},
 		{
-			name: "node with both nil selector and specific selector underlays - should error",
+			name: "node with both nil selector and specific selector underlays with different asn - should error",
 			nodes: []corev1.Node{
 				{
 					ObjectMeta: metav1.ObjectMeta{
@@ -1297,7 +1799,7 @@ func TestValidateUnderlaysForNodes(t *testing.T) {
 				},
 			},
 			wantErr: true,
-			errMsg:  "can't have more than one underlay per node",
+			errMsg:  "must have the same asn",
 		},
 		{
 			name:  "no nodes",
@@ -1468,3 +1970,158 @@ func TestValidateUnderlaysForNodes(t *testing.T) {
 		})
 	}
 }
+
+func TestFilterVNIsWithValidUnderlay(t *testing.T) {
+	underlay := func(name string, withTunnelEndpoint bool) v1alpha1.Underlay {
+		u := v1alpha1.Underlay{ObjectMeta: metav1.ObjectMeta{Name: name}}
+		if withTunnelEndpoint {
+			u.Spec.TunnelEndpoint = &v1alpha1.TunnelEndpointConfig{CIDRs: []string{"100.64.0.0/24"}}
+		}
+		return u
+	}
+	l3vni := func(name string, underlay *string) v1alpha1.L3VNI {
+		return v1alpha1.L3VNI{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Spec:       v1alpha1.L3VNISpec{Underlay: underlay},
+		}
+	}
+	l2vni := func(name string, underlay *string, l3vni string) v1alpha1.L2VNI {
+		res := v1alpha1.L2VNI{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Spec:       v1alpha1.L2VNISpec{Underlay: underlay},
+		}
+		if l3vni != "" {
+			res.Spec.RoutingDomain = &v1alpha1.RoutingDomain{
+				Type:  v1alpha1.RoutingDomainTypeL3VNI,
+				L3VNI: &v1alpha1.L3VNIReference{Name: l3vni},
+			}
+		}
+		return res
+	}
+
+	tests := []struct {
+		name       string
+		underlays  []v1alpha1.Underlay
+		l3vnis     []v1alpha1.L3VNI
+		l2vnis     []v1alpha1.L2VNI
+		wantL3VNIs []string
+		wantL2VNIs []string
+		wantFailed []v1alpha1.FailedResource
+	}{
+		{
+			name:       "single underlay, underlay not set",
+			underlays:  []v1alpha1.Underlay{underlay("plane-a", true)},
+			l3vnis:     []v1alpha1.L3VNI{l3vni("red", nil)},
+			l2vnis:     []v1alpha1.L2VNI{l2vni("blue", nil, "red")},
+			wantL3VNIs: []string{"red"},
+			wantL2VNIs: []string{"blue"},
+		},
+		{
+			name:       "single underlay with tunnel endpoint among many, underlay not set",
+			underlays:  []v1alpha1.Underlay{underlay("plane-a", false), underlay("plane-b", true)},
+			l3vnis:     []v1alpha1.L3VNI{l3vni("red", nil)},
+			l2vnis:     []v1alpha1.L2VNI{l2vni("blue", new("plane-b"), "red")},
+			wantL3VNIs: []string{"red"},
+			wantL2VNIs: []string{"blue"},
+		},
+		{
+			name:       "multiple underlays with tunnel endpoint, underlay not set",
+			underlays:  []v1alpha1.Underlay{underlay("plane-a", true), underlay("plane-b", true)},
+			l3vnis:     []v1alpha1.L3VNI{l3vni("red", nil), l3vni("green", new("plane-a"))},
+			l2vnis:     []v1alpha1.L2VNI{l2vni("blue", nil, "")},
+			wantL3VNIs: []string{"green"},
+			wantL2VNIs: []string{},
+			wantFailed: []v1alpha1.FailedResource{
+				{
+					Kind:    openpeerrors.KindL3VNI,
+					Name:    "red",
+					Reason:  v1alpha1.FailedResourceReasonValidationFailed,
+					Message: "more than one underlay with a tunnel endpoint, the underlay must be set",
+				},
+				{
+					Kind:    openpeerrors.KindL2VNI,
+					Name:    "blue",
+					Reason:  v1alpha1.FailedResourceReasonValidationFailed,
+					Message: "more than one underlay with a tunnel endpoint, the underlay must be set",
+				},
+			},
+		},
+		{
+			name:       "underlay not found",
+			underlays:  []v1alpha1.Underlay{underlay("plane-a", true)},
+			l3vnis:     []v1alpha1.L3VNI{l3vni("red", new("plane-b"))},
+			wantL3VNIs: []string{},
+			wantL2VNIs: []string{},
+			wantFailed: []v1alpha1.FailedResource{
+				{
+					Kind:    openpeerrors.KindL3VNI,
+					Name:    "red",
+					Reason:  v1alpha1.FailedResourceReasonDependencyFailed,
+					Message: "underlay not found on the node: plane-b",
+				},
+			},
+		},
+		{
+			name:       "underlay without tunnel endpoint",
+			underlays:  []v1alpha1.Underlay{underlay("plane-a", false), underlay("plane-b", true)},
+			l3vnis:     []v1alpha1.L3VNI{l3vni("red", new("plane-a")), l3vni("green", new("plane-b"))},
+			l2vnis:     []v1alpha1.L2VNI{l2vni("blue", new("plane-a"), ""), l2vni("yellow", nil, "green")},
+			wantL3VNIs: []string{"green"},
+			wantL2VNIs: []string{"yellow"},
+			wantFailed: []v1alpha1.FailedResource{
+				{
+					Kind:    openpeerrors.KindL3VNI,
+					Name:    "red",
+					Reason:  v1alpha1.FailedResourceReasonDependencyFailed,
+					Message: "underlay has no tunnel endpoint configuration: plane-a",
+				},
+				{
+					Kind:    openpeerrors.KindL2VNI,
+					Name:    "blue",
+					Reason:  v1alpha1.FailedResourceReasonDependencyFailed,
+					Message: "underlay has no tunnel endpoint configuration: plane-a",
+				},
+			},
+		},
+		{
+			name:       "l2vni on a different underlay of its l3vni",
+			underlays:  []v1alpha1.Underlay{underlay("plane-a", true), underlay("plane-b", true)},
+			l3vnis:     []v1alpha1.L3VNI{l3vni("red", new("plane-a"))},
+			l2vnis:     []v1alpha1.L2VNI{l2vni("blue", new("plane-b"), "red")},
+			wantL3VNIs: []string{"red"},
+			wantL2VNIs: []string{},
+			wantFailed: []v1alpha1.FailedResource{
+				{
+					Kind:    openpeerrors.KindL2VNI,
+					Name:    "blue",
+					Reason:  v1alpha1.FailedResourceReasonValidationFailed,
+					Message: "underlay plane-b differs from underlay plane-a of L3VNI red",
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotL3VNIs, gotL2VNIs, err := FilterVNIsWithValidUnderlay(tt.underlays, tt.l3vnis, tt.l2vnis)
+
+			l3Names := []string{}
+			for _, l3 := range gotL3VNIs {
+				l3Names = append(l3Names, l3.Name)
+			}
+			if diff := cmp.Diff(tt.wantL3VNIs, l3Names); diff != "" {
+				t.Errorf("unexpected L3VNIs (-want +got):\n%s", diff)
+			}
+			l2Names := []string{}
+			for _, l2 := range gotL2VNIs {
+				l2Names = append(l2Names, l2.Name)
+			}
+			if diff := cmp.Diff(tt.wantL2VNIs, l2Names); diff != "" {
+				t.Errorf("unexpected L2VNIs (-want +got):\n%s", diff)
+			}
+			if diff := cmp.Diff(tt.wantFailed, openpeerrors.CollectFailures(err)); diff != "" {
+				t.Errorf("unexpected failures (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
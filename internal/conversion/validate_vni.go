@@ -53,7 +53,7 @@ func FilterValidL3VNIs(l3Vnis []v1alpha1.L3VNI) ([]v1alpha1.L3VNI, error) {
 	return valid, errors.Join(allErrors...)
 }
 
-// validateL3VNI validates a single L3VNI's fields (VRF name, route targets).
+// validateL3VNI validates a single L3VNI's fields (VRF name, route targets, route leaking).
 func validateL3VNI(l3Vni v1alpha1.L3VNI) error {
 	vni := vniFromL3VNI(l3Vni)
 	if err := isValidInterfaceName(vni.vrfName); err != nil {
@@ -62,6 +62,9 @@ func validateL3VNI(l3Vni v1alpha1.L3VNI) error {
 	if err := ValidateRouteTargets(vni); err != nil {
 		return fmt.Errorf("invalid route targets for vni %q: %w", vni.name, err)
 	}
+	if err := validateRouteLeaking(l3Vni.Spec.VRF, l3Vni.Spec.RouteLeaking); err != nil {
+		return fmt.Errorf("invalid route leaking for vni %q: %w", vni.name, err)
+	}
 	return nil
 }
 
@@ -135,7 +138,7 @@ func FilterUniqueL2VNIs(l2Vnis []v1alpha1.L2VNI, existingVNIs map[int32]string)
 	return validL2, errors.Join(allErrors...)
 }
 
-// validateL2VNI validates a single L2VNI's fields (HostMaster, GatewayIPs).
+// validateL2VNI validates a single L2VNI's fields (HostMaster, GatewayIPs, EthernetSegment).
 func validateL2VNI(l2Vni v1alpha1.L2VNI) error {
 	if l2Vni.Spec.HostMaster != nil {
 		if err := validateHostMaster(l2Vni.Name, l2Vni.Spec.HostMaster); err != nil {
@@ -147,6 +150,48 @@ func validateL2VNI(l2Vni v1alpha1.L2VNI) error {
 			return fmt.Errorf("invalid gatewayIPs for vni %q = %v: %w", l2Vni.Name, l2Vni.Spec.GatewayIPs, err)
 		}
 	}
+	if err := validateEthernetSegment(l2Vni.Spec.EthernetSegment); err != nil {
+		return fmt.Errorf("invalid ethernetSegment for vni %q: %w", l2Vni.Name, err)
+	}
+	if err := validateL2VNIGateway(l2Vni.Spec); err != nil {
+		return fmt.Errorf("invalid gateway for vni %q: %w", l2Vni.Name, err)
+	}
+	return nil
+}
+
+// validateL2VNIGateway validates the distributed anycast gateway settings of
+// an L2VNI, which require its gateway IPs.
+func validateL2VNIGateway(spec v1alpha1.L2VNISpec) error {
+	if spec.GatewayMAC != nil {
+		if len(spec.GatewayIPs) == 0 {
+			return errors.New("gatewayMAC cannot be set without gatewayIPs")
+		}
+		mac, err := net.ParseMAC(*spec.GatewayMAC)
+		if err != nil || len(mac) != 6 {
+			return fmt.Errorf("invalid gatewayMAC %q", *spec.GatewayMAC)
+		}
+		if mac[0]&0x01 != 0 || isZero(mac) {
+			return fmt.Errorf("invalid gatewayMAC %q: must be a non zero unicast address", *spec.GatewayMAC)
+		}
+	}
+	if len(spec.GatewayAdvertisements) > 0 && len(spec.GatewayIPs) == 0 {
+		return errors.New("gatewayAdvertisements cannot be set without gatewayIPs")
+	}
+	seen := map[v1alpha1.GatewayAdvertisement]bool{}
+	for _, a := range spec.GatewayAdvertisements {
+		if a != v1alpha1.GatewayAdvertisementDefaultGateway && a != v1alpha1.GatewayAdvertisementSVIIP {
+			return fmt.Errorf("invalid gatewayAdvertisement %q", a)
+		}
+		if seen[a] {
+			return fmt.Errorf("duplicate gatewayAdvertisement %q", a)
+		}
+		seen[a] = true
+	}
+	if spec.NeighborSuppression != nil &&
+		*spec.NeighborSuppression != v1alpha1.NeighborSuppressionEnabled &&
+		*spec.NeighborSuppression != v1alpha1.NeighborSuppressionDisabled {
+		return fmt.Errorf("invalid neighborSuppression %q", *spec.NeighborSuppression)
+	}
 	return nil
 }
 
@@ -212,6 +257,11 @@ func validateOverlayResourcesForNode(node corev1.Node, l2vnis []v1alpha1.L2VNI,
 		return fmt.Errorf("duplicate VNIs found in L2VNIs for node %q: %w", node.Name, err)
 	}
 
+	validL2VNIs, err = FilterUniqueEthernetSegments(validL2VNIs)
+	if err != nil {
+		return fmt.Errorf("duplicate ethernet segments found in L2VNIs for node %q: %w", node.Name, err)
+	}
+
 	validL3VNIs, err = FilterUniqueVRFsForL3VNIs(validL3VNIs)
 	if err != nil {
 		return fmt.Errorf("duplicate L3VNI VRFs found for node %q: %w", node.Name, err)
@@ -222,6 +272,11 @@ func validateOverlayResourcesForNode(node corev1.Node, l2vnis []v1alpha1.L2VNI,
 		return fmt.Errorf("duplicate L3VPN VRFs found for node %q: %w", node.Name, err)
 	}
 
+	validL3VNIs, validL3VPNs, err = FilterMutuallyExclusiveOverlays(validL3VNIs, validL3VPNs)
+	if err != nil {
+		return fmt.Errorf("L3VNIs and L3VPNs sharing a VRF found for node %q: %w", node.Name, err)
+	}
+
 	_, _, _, err = FilterValidVRFSubnets(validL3VNIs, validL3VPNs, validL2VNIs)
 	if err != nil {
 		return fmt.Errorf("subnet overlaps found in VRFs for node %q: %w", node.Name, err)
@@ -468,6 +523,9 @@ func validateHostMaster(vniName string, hostConfig *v1alpha1.HostMaster) error {
 	case v1alpha1.OVSBridge:
 		if hostConfig.OVSBridge != nil {
 			name = ptr.Deref(hostConfig.OVSBridge.Name, "")
+			if err := validateOVSSampling(*hostConfig.OVSBridge); err != nil {
+				return fmt.Errorf("invalid sampling for vni %s: %w", vniName, err)
+			}
 		}
 	default:
 		return fmt.Errorf("invalid hostmaster type %q", hostConfig.Type)
@@ -484,6 +542,49 @@ func validateHostMaster(vniName string, hostConfig *v1alpha1.HostMaster) error {
 	return nil
 }
 
+// validateOVSSampling validates the traffic sampling of an OVS bridge, which
+// is allowed only on the bridges managed by the controller.
+func validateOVSSampling(config v1alpha1.OVSBridgeConfig) error {
+	sampling := config.Sampling
+	if sampling == nil {
+		return nil
+	}
+	if config.Lifecycle != v1alpha1.BridgeLifecycleManaged {
+		return errors.New("sampling can be set only when lifecycle is Managed")
+	}
+	switch sampling.Protocol {
+	case v1alpha1.OVSSamplingProtocolSFlow:
+	case v1alpha1.OVSSamplingProtocolIPFIX:
+		if sampling.PollingInterval != nil {
+			return errors.New("pollingInterval can be set only with the SFlow protocol")
+		}
+	default:
+		return fmt.Errorf("invalid protocol %q", sampling.Protocol)
+	}
+	if len(sampling.Collectors) == 0 {
+		return errors.New("at least one collector must be set")
+	}
+	for _, c := range sampling.Collectors {
+		host, port, err := net.SplitHostPort(c)
+		if err != nil {
+			return fmt.Errorf("invalid collector %q: %w", c, err)
+		}
+		if net.ParseIP(host) == nil {
+			return fmt.Errorf("invalid collector %q: %q is not an ip address", c, host)
+		}
+		if p, err := strconv.ParseUint(port, 10, 16); err != nil || p == 0 {
+			return fmt.Errorf("invalid collector %q: invalid port %q", c, port)
+		}
+	}
+	if sampling.SamplingRate != nil && *sampling.SamplingRate < 1 {
+		return fmt.Errorf("invalid samplingRate %d: must be positive", *sampling.SamplingRate)
+	}
+	if sampling.PollingInterval != nil && *sampling.PollingInterval < 1 {
+		return fmt.Errorf("invalid pollingInterval %d: must be positive", *sampling.PollingInterval)
+	}
+	return nil
+}
+
 // v4SubnetForL2 extracts the first valid IPv4 subnet from the l2vni, or returns nil.
 func v4SubnetForL2(l2vni v1alpha1.L2VNI) *net.IPNet {
 	for _, subnet := range l2vni.Spec.GatewayIPs {
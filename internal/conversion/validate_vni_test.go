@@ -215,6 +215,124 @@ func TestFilterValidL2VNIs(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "valid sflow sampling on a managed OVS bridge",
+			vnis: []v1alpha1.L2VNI{
+				{
+					ObjectMeta: metav1.ObjectMeta{Name: "vni1"},
+					Spec: v1alpha1.L2VNISpec{
+						VNI: 1001,
+						HostMaster: &v1alpha1.HostMaster{
+							Type: "OVSBridge",
+							OVSBridge: &v1alpha1.OVSBridgeConfig{
+								Lifecycle: v1alpha1.BridgeLifecycleManaged,
+								Sampling: &v1alpha1.OVSSampling{
+									Protocol:        v1alpha1.OVSSamplingProtocolSFlow,
+									Collectors:      []string{"192.168.1.10:6343", "[fd00::10]:6343"},
+									PollingInterval: new(int32(10)),
+								},
+							},
+						},
+					},
+					Status: &v1alpha1.L2VNIStatus{},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "sampling on an external OVS bridge",
+			vnis: []v1alpha1.L2VNI{
+				{
+					ObjectMeta: metav1.ObjectMeta{Name: "vni1"},
+					Spec: v1alpha1.L2VNISpec{
+						VNI: 1001,
+						HostMaster: &v1alpha1.HostMaster{
+							Type: "OVSBridge",
+							OVSBridge: &v1alpha1.OVSBridgeConfig{
+								Lifecycle: v1alpha1.BridgeLifecycleExternal,
+								Name:      new("ovsbr"),
+								Sampling: &v1alpha1.OVSSampling{
+									Protocol:   v1alpha1.OVSSamplingProtocolSFlow,
+									Collectors: []string{"192.168.1.10:6343"},
+								},
+							},
+						},
+					},
+					Status: &v1alpha1.L2VNIStatus{},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "ipfix sampling with polling interval",
+			vnis: []v1alpha1.L2VNI{
+				{
+					ObjectMeta: metav1.ObjectMeta{Name: "vni1"},
+					Spec: v1alpha1.L2VNISpec{
+						VNI: 1001,
+						HostMaster: &v1alpha1.HostMaster{
+							Type: "OVSBridge",
+							OVSBridge: &v1alpha1.OVSBridgeConfig{
+								Lifecycle: v1alpha1.BridgeLifecycleManaged,
+								Sampling: &v1alpha1.OVSSampling{
+									Protocol:        v1alpha1.OVSSamplingProtocolIPFIX,
+									Collectors:      []string{"192.168.1.10:4739"},
+									PollingInterval: new(int32(10)),
+								},
+							},
+						},
+					},
+					Status: &v1alpha1.L2VNIStatus{},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "sampling collector without port",
+			vnis: []v1alpha1.L2VNI{
+				{
+					ObjectMeta: metav1.ObjectMeta{Name: "vni1"},
+					Spec: v1alpha1.L2VNISpec{
+						VNI: 1001,
+						HostMaster: &v1alpha1.HostMaster{
+							Type: "OVSBridge",
+							OVSBridge: &v1alpha1.OVSBridgeConfig{
+								Lifecycle: v1alpha1.BridgeLifecycleManaged,
+								Sampling: &v1alpha1.OVSSampling{
+									Protocol:   v1alpha1.OVSSamplingProtocolIPFIX,
+									Collectors: []string{"192.168.1.10"},
+								},
+							},
+						},
+					},
+					Status: &v1alpha1.L2VNIStatus{},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "sampling collector with a hostname",
+			vnis: []v1alpha1.L2VNI{
+				{
+					ObjectMeta: metav1.ObjectMeta{Name: "vni1"},
+					Spec: v1alpha1.L2VNISpec{
+						VNI: 1001,
+						HostMaster: &v1alpha1.HostMaster{
+							Type: "OVSBridge",
+							OVSBridge: &v1alpha1.OVSBridgeConfig{
+								Lifecycle: v1alpha1.BridgeLifecycleManaged,
+								Sampling: &v1alpha1.OVSSampling{
+									Protocol:   v1alpha1.OVSSamplingProtocolIPFIX,
+									Collectors: []string{"collector:4739"},
+								},
+							},
+						},
+					},
+					Status: &v1alpha1.L2VNIStatus{},
+				},
+			},
+			wantErr: true,
+		},
 		{
 			name: "nil hostmaster name with Managed lifecycle",
 			vnis: []v1alpha1.L2VNI{
@@ -354,6 +472,107 @@ func TestFilterValidL2VNIs(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "valid gateway MAC and advertisements",
+			vnis: []v1alpha1.L2VNI{
+				{
+					ObjectMeta: metav1.ObjectMeta{Name: "vni1"},
+					Spec: v1alpha1.L2VNISpec{
+						VNI: 1001,
+						RoutingDomain: &v1alpha1.RoutingDomain{
+							Type:  v1alpha1.RoutingDomainTypeL3VNI,
+							L3VNI: &v1alpha1.L3VNIReference{Name: "test-l3vni"},
+						},
+						GatewayIPs: []string{"192.168.1.0/24"},
+						GatewayMAC: new("00:00:5e:00:01:01"),
+						GatewayAdvertisements: []v1alpha1.GatewayAdvertisement{
+							v1alpha1.GatewayAdvertisementDefaultGateway,
+							v1alpha1.GatewayAdvertisementSVIIP,
+						},
+						NeighborSuppression: new(v1alpha1.NeighborSuppressionDisabled),
+					},
+					Status: &v1alpha1.L2VNIStatus{},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "multicast gateway MAC",
+			vnis: []v1alpha1.L2VNI{
+				{
+					ObjectMeta: metav1.ObjectMeta{Name: "vni1"},
+					Spec: v1alpha1.L2VNISpec{
+						VNI: 1001,
+						RoutingDomain: &v1alpha1.RoutingDomain{
+							Type:  v1alpha1.RoutingDomainTypeL3VNI,
+							L3VNI: &v1alpha1.L3VNIReference{Name: "test-l3vni"},
+						},
+						GatewayIPs: []string{"192.168.1.0/24"},
+						GatewayMAC: new("01:00:5e:00:01:01"),
+					},
+					Status: &v1alpha1.L2VNIStatus{},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "gateway MAC without GatewayIPs",
+			vnis: []v1alpha1.L2VNI{
+				{
+					ObjectMeta: metav1.ObjectMeta{Name: "vni1"},
+					Spec: v1alpha1.L2VNISpec{
+						VNI: 1001,
+						RoutingDomain: &v1alpha1.RoutingDomain{
+							Type:  v1alpha1.RoutingDomainTypeL3VNI,
+							L3VNI: &v1alpha1.L3VNIReference{Name: "test-l3vni"},
+						},
+						GatewayMAC: new("00:00:5e:00:01:01"),
+					},
+					Status: &v1alpha1.L2VNIStatus{},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "gateway advertisements without GatewayIPs",
+			vnis: []v1alpha1.L2VNI{
+				{
+					ObjectMeta: metav1.ObjectMeta{Name: "vni1"},
+					Spec: v1alpha1.L2VNISpec{
+						VNI: 1001,
+						RoutingDomain: &v1alpha1.RoutingDomain{
+							Type:  v1alpha1.RoutingDomainTypeL3VNI,
+							L3VNI: &v1alpha1.L3VNIReference{Name: "test-l3vni"},
+						},
+						GatewayAdvertisements: []v1alpha1.GatewayAdvertisement{v1alpha1.GatewayAdvertisementSVIIP},
+					},
+					Status: &v1alpha1.L2VNIStatus{},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "duplicate gateway advertisements",
+			vnis: []v1alpha1.L2VNI{
+				{
+					ObjectMeta: metav1.ObjectMeta{Name: "vni1"},
+					Spec: v1alpha1.L2VNISpec{
+						VNI: 1001,
+						RoutingDomain: &v1alpha1.RoutingDomain{
+							Type:  v1alpha1.RoutingDomainTypeL3VNI,
+							L3VNI: &v1alpha1.L3VNIReference{Name: "test-l3vni"},
+						},
+						GatewayIPs: []string{"192.168.1.0/24"},
+						GatewayAdvertisements: []v1alpha1.GatewayAdvertisement{
+							v1alpha1.GatewayAdvertisementSVIIP,
+							v1alpha1.GatewayAdvertisementSVIIP,
+						},
+					},
+					Status: &v1alpha1.L2VNIStatus{},
+				},
+			},
+			wantErr: true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -1466,6 +1685,79 @@ func TestValidateOverlayResourcesForNodes(t *testing.T) {
 			},
 			wantErrStr: "duplicate L3VPNs found for node \"node1\": L3VPN/vpn2: duplicate rdAssignedNumber 100:L3VPN/vpn1",
 		},
+		{
+			name: "same ethernet segment on L2VNIs selecting different nodes",
+			nodes: []corev1.Node{
+				{ObjectMeta: metav1.ObjectMeta{Name: "node1", Labels: map[string]string{"rack": "a"}}},
+				{ObjectMeta: metav1.ObjectMeta{Name: "node2", Labels: map[string]string{"rack": "b"}}},
+			},
+			l2vnis: []v1alpha1.L2VNI{
+				{
+					ObjectMeta: metav1.ObjectMeta{Name: "l2vni1"},
+					Spec: v1alpha1.L2VNISpec{
+						VNI:             300,
+						NodeSelector:    &metav1.LabelSelector{MatchLabels: map[string]string{"rack": "a"}},
+						EthernetSegment: &v1alpha1.EthernetSegment{ESI: new("00:11:22:33:44:55:66:77:88:99")},
+					},
+				},
+				{
+					ObjectMeta: metav1.ObjectMeta{Name: "l2vni2"},
+					Spec: v1alpha1.L2VNISpec{
+						VNI:             400,
+						NodeSelector:    &metav1.LabelSelector{MatchLabels: map[string]string{"rack": "b"}},
+						EthernetSegment: &v1alpha1.EthernetSegment{ESI: new("00:11:22:33:44:55:66:77:88:99")},
+					},
+				},
+			},
+		},
+		{
+			name: "same ethernet segment on L2VNIs sharing a node",
+			nodes: []corev1.Node{
+				{ObjectMeta: metav1.ObjectMeta{Name: "node1", Labels: map[string]string{"rack": "a"}}},
+				{ObjectMeta: metav1.ObjectMeta{Name: "node2", Labels: map[string]string{"rack": "b"}}},
+			},
+			l2vnis: []v1alpha1.L2VNI{
+				{
+					ObjectMeta: metav1.ObjectMeta{Name: "l2vni1"},
+					Spec: v1alpha1.L2VNISpec{
+						VNI: 300,
+						EthernetSegment: &v1alpha1.EthernetSegment{
+							LocalDiscriminator: new(int32(258)),
+							SystemMAC:          new("44:38:39:FF:00:01"),
+						},
+					},
+				},
+				{
+					ObjectMeta: metav1.ObjectMeta{Name: "l2vni2"},
+					Spec: v1alpha1.L2VNISpec{
+						VNI:          400,
+						NodeSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"rack": "b"}},
+						EthernetSegment: &v1alpha1.EthernetSegment{
+							LocalDiscriminator: new(int32(258)),
+							SystemMAC:          new("44:38:39:ff:00:01"),
+						},
+					},
+				},
+			},
+			wantErrStr: "duplicate ethernet segments found in L2VNIs for node \"node2\": " +
+				"L2VNI/l2vni2: duplicate ethernet segment 03:44:38:39:ff:00:01:00:01:02:L2VNI/l2vni1",
+		},
+		{
+			name:  "invalid ethernet segment",
+			nodes: []corev1.Node{{ObjectMeta: metav1.ObjectMeta{Name: "node1"}}},
+			l2vnis: []v1alpha1.L2VNI{
+				{
+					ObjectMeta: metav1.ObjectMeta{Name: "l2vni1"},
+					Spec: v1alpha1.L2VNISpec{
+						VNI:             300,
+						EthernetSegment: &v1alpha1.EthernetSegment{ESI: new("00:00:00:00:00:00:00:00:00:00")},
+					},
+				},
+			},
+			wantErrStr: "failed to validate l2vnis for node \"node1\": L2VNI/l2vni1: " +
+				"invalid ethernetSegment for vni \"l2vni1\": " +
+				"invalid esi \"00:00:00:00:00:00:00:00:00:00\": the all zeros ESI is reserved",
+		},
 	}
 
 	for _, tt := range tests {
@@ -16,6 +16,9 @@ const (
 	KindL3VPN         = v1alpha1.FailedResourceKind("L3VPN")
 	KindL2VNI         = v1alpha1.FailedResourceKind("L2VNI")
 	KindL3Passthrough = v1alpha1.FailedResourceKind("L3Passthrough")
+	// KindFrrConfiguration is the kind of the failures affecting the FRR
+	// configuration of the node as a whole.
+	KindFrrConfiguration = v1alpha1.FailedResourceKind("FrrConfiguration")
 )
 
 // ResourceError represents a per-resource error (e.g., bad VRF name, duplicate VNI).
@@ -52,6 +52,17 @@ func RawFRRConfigsForNode(node *corev1.Node, rawFRRConfigs []v1alpha1.RawFRRConf
 	})
 }
 
+// NodeAddressingsForNode returns NodeAddressings that apply to the given node's name.
+func NodeAddressingsForNode(node *corev1.Node, addressings []v1alpha1.NodeAddressing) []v1alpha1.NodeAddressing {
+	var result []v1alpha1.NodeAddressing
+	for _, a := range addressings {
+		if a.Spec.NodeName == node.Name {
+			result = append(result, a)
+		}
+	}
+	return result
+}
+
 // filterForNode is a generic function that filters items based on node label selectors.
 // It takes a selector function that extracts the NodeSelector from each item.
 func filterForNode[T any](node *corev1.Node, items []T, getSelector func(T) *metav1.LabelSelector) ([]T, error) {
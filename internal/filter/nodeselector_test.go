@@ -237,3 +237,27 @@ func TestFilterUnderlaysForNode(t *testing.T) {
 		})
 	}
 }
+
+func TestFilterNodeAddressingsForNode(t *testing.T) {
+	addressings := []v1alpha1.NodeAddressing{
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "addressing-1"},
+			Spec:       v1alpha1.NodeAddressingSpec{NodeName: "node-1"},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "addressing-2"},
+			Spec:       v1alpha1.NodeAddressingSpec{NodeName: "node-2"},
+		},
+	}
+	node := &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: "node-1"},
+	}
+
+	filtered := filter.NodeAddressingsForNode(node, addressings)
+	if len(filtered) != 1 {
+		t.Fatalf("expected 1 node addressing, got %d", len(filtered))
+	}
+	if filtered[0].Name != "addressing-1" {
+		t.Errorf("expected node addressing name addressing-1, got %s", filtered[0].Name)
+	}
+}
@@ -9,6 +9,7 @@ import (
 	"errors"
 	"fmt"
 	"log/slog"
+	"slices"
 	"strings"
 	"text/template"
 
@@ -26,14 +27,21 @@ type RawFRRSnippet struct {
 }
 
 type Config struct {
-	Loglevel    string
-	Hostname    string
-	Underlay    UnderlayConfig
-	VNIs        []L3VNIConfig
-	VPNs        []L3VPNConfig
-	Passthrough *PassthroughConfig
-	BFDProfiles []BFDProfile
-	RawConfig   []RawFRRSnippet
+	Loglevel     string
+	Hostname     string
+	Underlay     UnderlayConfig
+	VNIs         []L3VNIConfig
+	VPNs         []L3VPNConfig
+	Passthroughs []PassthroughConfig
+	BFDProfiles  []BFDProfile
+	// EthernetSegments are the EVPN multihoming segments of the host facing
+	// interfaces.
+	EthernetSegments []EthernetSegment
+	// L2VNIs are the L2 VNIs with EVPN settings of their own.
+	L2VNIs []L2VNIConfig
+	// VRFImports are the routes leaked between the VRFs of the router.
+	VRFImports []VRFImport
+	RawConfig  []RawFRRSnippet
 }
 
 type GracefulRestart struct {
@@ -45,9 +53,10 @@ type UnderlayConfig struct {
 	MyASN           int64
 	RouterID        string
 	Neighbors       []NeighborConfig
-	TunnelEndpoint  *TunnelEndpoint
+	TunnelEndpoints []TunnelEndpoint
 	GracefulRestart *GracefulRestart
 	ISIS            *UnderlayISIS
+	OSPF            *UnderlayOSPF
 	SegmentRouting  *UnderlaySegmentRouting
 	RouteReflector  *RouteReflector
 	// ListenLimit caps the number of dynamic sessions accepted via bgp
@@ -91,12 +100,55 @@ type TunnelEndpoint struct {
 	IPv6CIDR string
 }
 
+// TunnelEndpointIPv4CIDRs returns the IPv4 addresses of the VTEPs.
+func (u UnderlayConfig) TunnelEndpointIPv4CIDRs() []string {
+	var res []string
+	for _, t := range u.TunnelEndpoints {
+		if t.IPv4CIDR != "" {
+			res = append(res, t.IPv4CIDR)
+		}
+	}
+	return res
+}
+
+// TunnelEndpointIPv6CIDRs returns the IPv6 addresses of the VTEPs.
+func (u UnderlayConfig) TunnelEndpointIPv6CIDRs() []string {
+	var res []string
+	for _, t := range u.TunnelEndpoints {
+		if t.IPv6CIDR != "" {
+			res = append(res, t.IPv6CIDR)
+		}
+	}
+	return res
+}
+
 type UnderlayISIS struct {
 	Name                 string
 	Net                  ISISNet
 	Level                int32
 	AdvertisePassiveOnly bool
-	Interfaces           []ISISInterface
+	// AreaPassword and DomainPassword are the HMAC-MD5 keys of the level-1
+	// and level-2 LSPs and SNPs.
+	AreaPassword   string
+	DomainPassword string
+	Interfaces     []ISISInterface
+}
+
+// UnderlayOSPF holds the OSPFv2 (IPv4) and OSPFv3 (IPv6) instances of the
+// underlay, sharing the same area and interfaces.
+type UnderlayOSPF struct {
+	RouterID   string
+	Area       string
+	IPv4       bool
+	IPv6       bool
+	Interfaces []OSPFInterface
+}
+
+// OSPFInterface holds the internal representation of an interface's OSPF configuration.
+type OSPFInterface struct {
+	Name           string
+	IsPassive      bool
+	IsPointToPoint bool
 }
 
 type UnderlaySegmentRouting struct {
@@ -114,6 +166,26 @@ type SRV6Locator struct {
 	Format   string
 }
 
+// EthernetSegment is an EVPN Ethernet Segment configured on an interface.
+// Either ESI or both LocalDiscriminator and SystemMAC are set.
+type EthernetSegment struct {
+	Interface          string
+	ESI                string
+	LocalDiscriminator uint32
+	SystemMAC          string
+	DFPreference       int32
+}
+
+// L2VNIConfig holds the EVPN settings of an L2 VNI.
+type L2VNIConfig struct {
+	VNI int32
+	// AdvertiseDefaultGW advertises the gateway MAC and IPs of the VNI with
+	// the default gateway extended community.
+	AdvertiseDefaultGW bool
+	// AdvertiseSVIIP advertises the gateway MAC and IPs of the VNI.
+	AdvertiseSVIIP bool
+}
+
 type PassthroughConfig struct {
 	LocalNeighborV4 *NeighborConfig
 	LocalNeighborV6 *NeighborConfig
@@ -145,6 +217,28 @@ type L3VPNConfig struct {
 	RouteDistinguisher string
 }
 
+// VRFImport lists the VRFs a VRF imports routes from, via bgp import vrf.
+// An empty VRF stands for the default VRF. The imported routes are filtered
+// by the route map of the address family, matching their source VRF.
+type VRFImport struct {
+	VRF        string
+	ASN        int64
+	ImportIPv4 []string
+	ImportIPv6 []string
+	RouteMaps  []RouteMap
+}
+
+// RouteMapFor returns the name of the route map filtering the routes
+// imported in the given address family, or an empty string if none is set.
+func (v VRFImport) RouteMapFor(afi networklayerprotocol.AFI) string {
+	for _, rm := range v.RouteMaps {
+		if rm.AFI == afi {
+			return rm.Name
+		}
+	}
+	return ""
+}
+
 type BFDProfile struct {
 	Name             string
 	ReceiveInterval  *int32
@@ -180,6 +274,172 @@ type NeighborConfig struct {
 	// with v6 nexthops if you do not have v4 configured on interfaces.
 	ExtendedNexthop bool
 	UpdateSource    string
+	// RouteMaps are the route maps filtering the routes exchanged with the
+	// neighbor, at most one per address family and direction.
+	RouteMaps []RouteMap
+	// UnderlayFilters are the outbound route maps keeping the routes of the
+	// other underlays of the node from the neighbor, at most one per address
+	// family. They are applied in place of the outbound RouteMaps of the same
+	// family, which they call.
+	UnderlayFilters []RouteMap
+	// MaximumPrefixIPv4 and MaximumPrefixIPv6 limit the number of unicast
+	// prefixes accepted from the neighbor in each address family.
+	MaximumPrefixIPv4 *MaximumPrefix
+	MaximumPrefixIPv6 *MaximumPrefix
+	// AllowedPrefixesIPv4 and AllowedPrefixesIPv6 are the prefix lists
+	// restricting the unicast routes accepted from the neighbor. A prefix
+	// list with no entries rejects all the routes of the family.
+	AllowedPrefixesIPv4 *PrefixList
+	AllowedPrefixesIPv6 *PrefixList
+	// GracefulRestart is the graceful restart role of the router on the
+	// session. Empty keeps the router's global behaviour.
+	GracefulRestart GracefulRestartMode
+}
+
+// MaximumPrefix limits the number of prefixes accepted from a neighbor.
+type MaximumPrefix struct {
+	Limit       int64
+	Threshold   *int32
+	WarningOnly bool
+}
+
+// GracefulRestartMode is the per neighbor graceful restart role.
+type GracefulRestartMode string
+
+const (
+	GracefulRestartHelper   GracefulRestartMode = "helper"
+	GracefulRestartDisabled GracefulRestartMode = "disable"
+)
+
+// RouteMapDirection tells whether a route map applies to the received or to
+// the advertised routes.
+type RouteMapDirection string
+
+const (
+	RouteMapIn  RouteMapDirection = "in"
+	RouteMapOut RouteMapDirection = "out"
+)
+
+// RouteMap is a route map applied to a neighbor in the given address family
+// and direction.
+type RouteMap struct {
+	Name      string
+	AFI       networklayerprotocol.AFI
+	SAFI      networklayerprotocol.SAFI
+	Direction RouteMapDirection
+	Entries   []RouteMapEntry
+}
+
+// RouteMapEntry is a single sequence of a route map. The match lists are
+// optional, and an entry with no match list matches all the routes.
+type RouteMapEntry struct {
+	Seq                 int
+	Action              string
+	MatchSourceVRF      string
+	MatchEVPNVNI        int32
+	PrefixList          *PrefixList
+	CommunityList       *CommunityList
+	LargeCommunityList  *CommunityList
+	SetLocalPreference  *int64
+	SetCommunities      []string
+	SetLargeCommunities []string
+	SetASPathPrepend    string
+	// Call is the route map the routes matching the entry go through, the
+	// ones it denies being denied.
+	Call string
+}
+
+// PrefixList is a prefix list referenced by a route map entry or applied
+// to a neighbor.
+type PrefixList struct {
+	Name    string
+	IPv6    bool
+	Entries []PrefixListEntry
+}
+
+type PrefixListEntry struct {
+	Seq    int
+	Prefix string
+	GE     *int32
+	LE     *int32
+}
+
+// CommunityList is a standard (large) community list referenced by a route
+// map entry. It matches the routes carrying any of the communities.
+type CommunityList struct {
+	Name    string
+	Entries []CommunityListEntry
+}
+
+type CommunityListEntry struct {
+	Seq       int
+	Community string
+}
+
+// RouteMaps returns the route maps of all the neighbors and of the VRF
+// imports of the configuration.
+func (c Config) RouteMaps() []RouteMap {
+	res := []RouteMap{}
+	for _, n := range c.Underlay.Neighbors {
+		res = append(res, n.RouteMaps...)
+		res = append(res, n.UnderlayFilters...)
+	}
+	for _, n := range c.localNeighbors() {
+		res = append(res, n.RouteMaps...)
+	}
+	for _, i := range c.VRFImports {
+		res = append(res, i.RouteMaps...)
+	}
+	return res
+}
+
+// AllowedPrefixLists returns the prefix lists restricting the routes the
+// hosts may advertise to the router.
+func (c Config) AllowedPrefixLists() []PrefixList {
+	res := []PrefixList{}
+	for _, n := range c.localNeighbors() {
+		for _, pl := range []*PrefixList{n.AllowedPrefixesIPv4, n.AllowedPrefixesIPv6} {
+			if pl != nil {
+				res = append(res, *pl)
+			}
+		}
+	}
+	return res
+}
+
+// localNeighbors returns the neighbors of the sessions with the hosts.
+func (c Config) localNeighbors() []*NeighborConfig {
+	res := []*NeighborConfig{}
+	for _, p := range c.Passthroughs {
+		for _, n := range []*NeighborConfig{p.LocalNeighborV4, p.LocalNeighborV6} {
+			if n != nil {
+				res = append(res, n)
+			}
+		}
+	}
+	for _, v := range c.VNIs {
+		if v.LocalNeighbor != nil {
+			res = append(res, v.LocalNeighbor)
+		}
+	}
+	for _, v := range c.VPNs {
+		if v.LocalNeighbor != nil {
+			res = append(res, v.LocalNeighbor)
+		}
+	}
+	return res
+}
+
+// RouteMapFor returns the name of the route map applied to the neighbor in
+// the given address family and direction, or an empty string if none is set.
+// The underlay filters take precedence over the route maps they call.
+func (n NeighborConfig) RouteMapFor(afi networklayerprotocol.AFI, safi networklayerprotocol.SAFI, direction RouteMapDirection) string {
+	for _, rm := range slices.Concat(n.UnderlayFilters, n.RouteMaps) {
+		if rm.AFI == afi && rm.SAFI == safi && rm.Direction == direction {
+			return rm.Name
+		}
+	}
+	return ""
 }
 
 // ActivateFor tells whether the neighbor activates the given address family.
@@ -210,7 +470,7 @@ func (n NeighborConfig) IsRouteReflectorClientFor(afi networklayerprotocol.AFI,
 // endpoint exists; route-reflector-only nodes render it when a neighbor
 // activates the l2vpn evpn family without a tunnel endpoint.
 func shouldRenderUnderlayEVPN(underlay UnderlayConfig) bool {
-	if underlay.TunnelEndpoint != nil {
+	if len(underlay.TunnelEndpoints) > 0 {
 		return true
 	}
 	for _, n := range underlay.Neighbors {
@@ -32,9 +32,9 @@ func TestBasic(t *testing.T) {
 	config := Config{
 		Underlay: UnderlayConfig{
 			MyASN: 64512,
-			TunnelEndpoint: &TunnelEndpoint{
+			TunnelEndpoints: []TunnelEndpoint{{
 				IPv4CIDR: "100.64.0.1/32",
-			},
+			}},
 			RouterID: "10.0.0.1",
 			Neighbors: []NeighborConfig{
 				{
@@ -79,9 +79,9 @@ func TestBasicWithASNRT(t *testing.T) {
 	config := Config{
 		Underlay: UnderlayConfig{
 			MyASN: 64512,
-			TunnelEndpoint: &TunnelEndpoint{
+			TunnelEndpoints: []TunnelEndpoint{{
 				IPv4CIDR: "100.64.0.1/32",
-			},
+			}},
 			RouterID: "10.0.0.1",
 			Neighbors: []NeighborConfig{
 				{
@@ -128,9 +128,9 @@ func TestBasicWithIPRT(t *testing.T) {
 	config := Config{
 		Underlay: UnderlayConfig{
 			MyASN: 64512,
-			TunnelEndpoint: &TunnelEndpoint{
+			TunnelEndpoints: []TunnelEndpoint{{
 				IPv4CIDR: "100.64.0.1/32",
-			},
+			}},
 			RouterID: "10.0.0.1",
 			Neighbors: []NeighborConfig{
 				{
@@ -177,9 +177,9 @@ func TestExternal(t *testing.T) {
 	config := Config{
 		Underlay: UnderlayConfig{
 			MyASN: 64512,
-			TunnelEndpoint: &TunnelEndpoint{
+			TunnelEndpoints: []TunnelEndpoint{{
 				IPv4CIDR: "100.64.0.1/32",
-			},
+			}},
 			RouterID: "10.0.0.1",
 			Neighbors: []NeighborConfig{
 				{
@@ -224,9 +224,9 @@ func TestInternal(t *testing.T) {
 	config := Config{
 		Underlay: UnderlayConfig{
 			MyASN: 64512,
-			TunnelEndpoint: &TunnelEndpoint{
+			TunnelEndpoints: []TunnelEndpoint{{
 				IPv4CIDR: "100.64.0.1/32",
-			},
+			}},
 			RouterID: "10.0.0.1",
 			Neighbors: []NeighborConfig{
 				{
@@ -271,9 +271,9 @@ func TestDualStack(t *testing.T) {
 	config := Config{
 		Underlay: UnderlayConfig{
 			MyASN: 64512,
-			TunnelEndpoint: &TunnelEndpoint{
+			TunnelEndpoints: []TunnelEndpoint{{
 				IPv4CIDR: "100.64.0.1/32",
-			},
+			}},
 			RouterID: "10.0.0.1",
 			Neighbors: []NeighborConfig{
 				{
@@ -321,9 +321,9 @@ func TestDualStackWithRT(t *testing.T) {
 	config := Config{
 		Underlay: UnderlayConfig{
 			MyASN: 64512,
-			TunnelEndpoint: &TunnelEndpoint{
+			TunnelEndpoints: []TunnelEndpoint{{
 				IPv4CIDR: "100.64.0.1/32",
-			},
+			}},
 			RouterID: "10.0.0.1",
 			Neighbors: []NeighborConfig{
 				{
@@ -373,9 +373,9 @@ func TestIPv6Only(t *testing.T) {
 	config := Config{
 		Underlay: UnderlayConfig{
 			MyASN: 64512,
-			TunnelEndpoint: &TunnelEndpoint{
+			TunnelEndpoints: []TunnelEndpoint{{
 				IPv4CIDR: "100.64.0.1/32",
-			},
+			}},
 			RouterID: "10.0.0.1",
 			Neighbors: []NeighborConfig{
 				{
@@ -422,9 +422,9 @@ func TestBGPUnnumbered(t *testing.T) {
 	config := Config{
 		Underlay: UnderlayConfig{
 			MyASN: 64512,
-			TunnelEndpoint: &TunnelEndpoint{
+			TunnelEndpoints: []TunnelEndpoint{{
 				IPv4CIDR: "100.64.0.1/32",
-			},
+			}},
 			RouterID: "10.0.0.1",
 			Neighbors: []NeighborConfig{
 				{
@@ -474,9 +474,9 @@ func TestBGPUnnumberedEBGPIPv6(t *testing.T) {
 	config := Config{
 		Underlay: UnderlayConfig{
 			MyASN: 64512,
-			TunnelEndpoint: &TunnelEndpoint{
+			TunnelEndpoints: []TunnelEndpoint{{
 				IPv4CIDR: "100.64.0.1/32",
-			},
+			}},
 			RouterID: "10.0.0.1",
 			Neighbors: []NeighborConfig{
 				{
@@ -507,9 +507,9 @@ func TestIPv6OnlyWithRT(t *testing.T) {
 	config := Config{
 		Underlay: UnderlayConfig{
 			MyASN: 64512,
-			TunnelEndpoint: &TunnelEndpoint{
+			TunnelEndpoints: []TunnelEndpoint{{
 				IPv4CIDR: "100.64.0.1/32",
-			},
+			}},
 			RouterID: "10.0.0.1",
 			Neighbors: []NeighborConfig{
 				{
@@ -569,9 +569,9 @@ func TestNoVNIs(t *testing.T) {
 	config := Config{
 		Underlay: UnderlayConfig{
 			MyASN: 64512,
-			TunnelEndpoint: &TunnelEndpoint{
+			TunnelEndpoints: []TunnelEndpoint{{
 				IPv4CIDR: "100.64.0.1/32",
-			},
+			}},
 			RouterID: "10.0.0.1",
 			Neighbors: []NeighborConfig{
 				{
@@ -599,9 +599,9 @@ func TestBFDEnabled(t *testing.T) {
 	config := Config{
 		Underlay: UnderlayConfig{
 			MyASN: 64512,
-			TunnelEndpoint: &TunnelEndpoint{
+			TunnelEndpoints: []TunnelEndpoint{{
 				IPv4CIDR: "100.64.0.1/32",
-			},
+			}},
 			RouterID: "10.0.0.1",
 			Neighbors: []NeighborConfig{
 				{
@@ -630,9 +630,9 @@ func TestBFDProfile(t *testing.T) {
 	config := Config{
 		Underlay: UnderlayConfig{
 			MyASN: 64512,
-			TunnelEndpoint: &TunnelEndpoint{
+			TunnelEndpoints: []TunnelEndpoint{{
 				IPv4CIDR: "100.64.0.1/32",
-			},
+			}},
 			RouterID: "10.0.0.1",
 			Neighbors: []NeighborConfig{
 				{
@@ -669,9 +669,9 @@ func TestL3VNIWithoutLocalNeighborAndAdvertise(t *testing.T) {
 		Underlay: UnderlayConfig{
 			MyASN:    64512,
 			RouterID: "10.0.0.1",
-			TunnelEndpoint: &TunnelEndpoint{
+			TunnelEndpoints: []TunnelEndpoint{{
 				IPv4CIDR: "100.64.0.1/32",
-			},
+			}},
 			Neighbors: []NeighborConfig{
 				{
 					ASN:  mustNewPeerASNFromNumber(64513),
@@ -708,9 +708,9 @@ func TestL3VNIWithLocalNeighborAndRedistributeConnected(t *testing.T) {
 		Underlay: UnderlayConfig{
 			MyASN:    64512,
 			RouterID: "10.0.0.1",
-			TunnelEndpoint: &TunnelEndpoint{
+			TunnelEndpoints: []TunnelEndpoint{{
 				IPv4CIDR: "100.64.0.1/32",
-			},
+			}},
 			Neighbors: []NeighborConfig{
 				{
 					ASN:  mustNewPeerASNFromNumber(64513),
@@ -747,6 +747,130 @@ func TestL3VNIWithLocalNeighborAndRedistributeConnected(t *testing.T) {
 	testCheckConfigFile(t)
 }
 
+func TestL3VNILocalNeighborHardening(t *testing.T) {
+	configFile := testSetup(t)
+	updater := testUpdater(configFile)
+
+	config := Config{
+		Underlay: UnderlayConfig{
+			MyASN:    64512,
+			RouterID: "10.0.0.1",
+			TunnelEndpoints: []TunnelEndpoint{{
+				IPv4CIDR: "100.64.0.1/32",
+			}},
+			Neighbors: []NeighborConfig{
+				{
+					ASN:  mustNewPeerASNFromNumber(64513),
+					Addr: "192.168.1.2",
+					ID:   "192.168.1.2",
+					NetworkLayerProtocols: []networklayerprotocol.NLP{
+						{AFI: networklayerprotocol.IPv4, SAFI: networklayerprotocol.Unicast},
+						{AFI: networklayerprotocol.L2VPN, SAFI: networklayerprotocol.EVPN},
+					},
+				},
+			},
+		},
+		VNIs: []L3VNIConfig{
+			{
+				VRF:      "red",
+				ASN:      64512,
+				VNI:      100,
+				RouterID: "10.0.0.1",
+				LocalNeighbor: &NeighborConfig{
+					ASN:             mustNewPeerASNFromNumber(64515),
+					Addr:            "192.169.10.2",
+					ID:              "192.169.10.2",
+					Password:        "hostsecret",
+					BFDEnabled:      true,
+					BFDProfile:      "l3vni-red",
+					GracefulRestart: GracefulRestartHelper,
+					MaximumPrefixIPv4: &MaximumPrefix{
+						Limit:     100,
+						Threshold: new(int32(80)),
+					},
+					MaximumPrefixIPv6: &MaximumPrefix{
+						Limit:       50,
+						WarningOnly: true,
+					},
+				},
+				ToAdvertiseIPv4: []string{
+					"192.169.10.0/24",
+				},
+			},
+		},
+		BFDProfiles: []BFDProfile{
+			{
+				Name:             "l3vni-red",
+				ReceiveInterval:  new(int32(300)),
+				TransmitInterval: new(int32(300)),
+			},
+		},
+	}
+	if err := ApplyConfig(context.Background(), &config, updater); err != nil {
+		t.Fatalf("Failed to apply config: %s", err)
+	}
+
+	testCheckConfigFile(t)
+}
+
+func TestL3VNILocalNeighborAllowedPrefixes(t *testing.T) {
+	configFile := testSetup(t)
+	updater := testUpdater(configFile)
+
+	config := Config{
+		Underlay: UnderlayConfig{
+			MyASN:    64512,
+			RouterID: "10.0.0.1",
+			TunnelEndpoints: []TunnelEndpoint{{
+				IPv4CIDR: "100.64.0.1/32",
+			}},
+			Neighbors: []NeighborConfig{
+				{
+					ASN:  mustNewPeerASNFromNumber(64513),
+					Addr: "192.168.1.2",
+					ID:   "192.168.1.2",
+					NetworkLayerProtocols: []networklayerprotocol.NLP{
+						{AFI: networklayerprotocol.IPv4, SAFI: networklayerprotocol.Unicast},
+						{AFI: networklayerprotocol.L2VPN, SAFI: networklayerprotocol.EVPN},
+					},
+				},
+			},
+		},
+		VNIs: []L3VNIConfig{
+			{
+				VRF:      "red",
+				ASN:      64512,
+				VNI:      100,
+				RouterID: "10.0.0.1",
+				LocalNeighbor: &NeighborConfig{
+					ASN:  mustNewPeerASNFromNumber(64515),
+					Addr: "192.169.10.2",
+					ID:   "192.169.10.2",
+					AllowedPrefixesIPv4: &PrefixList{
+						Name: "red-192.169.10.2-allowed-ipv4",
+						Entries: []PrefixListEntry{
+							{Seq: 5, Prefix: "10.100.0.0/16", LE: new(int32(24))},
+							{Seq: 10, Prefix: "10.200.0.0/24"},
+						},
+					},
+					AllowedPrefixesIPv6: &PrefixList{
+						Name: "red-192.169.10.2-allowed-ipv6",
+						IPv6: true,
+					},
+				},
+				ToAdvertiseIPv4: []string{
+					"192.169.10.0/24",
+				},
+			},
+		},
+	}
+	if err := ApplyConfig(context.Background(), &config, updater); err != nil {
+		t.Fatalf("Failed to apply config: %s", err)
+	}
+
+	testCheckConfigFile(t)
+}
+
 func TestPassthroughNoEVPN(t *testing.T) {
 	configFile := testSetup(t)
 	updater := testUpdater(configFile)
@@ -764,7 +888,7 @@ func TestPassthroughNoEVPN(t *testing.T) {
 				},
 			},
 		},
-		Passthrough: &PassthroughConfig{
+		Passthroughs: []PassthroughConfig{{
 			LocalNeighborV4: &NeighborConfig{
 				ASN:         mustNewPeerASNFromNumber(64513),
 				Addr:        "192.168.1.3",
@@ -775,7 +899,7 @@ func TestPassthroughNoEVPN(t *testing.T) {
 				"192.169.20.0/24",
 				"192.169.21.0/24",
 			},
-		},
+		}},
 	}
 	if err := ApplyConfig(context.TODO(), &config, updater); err != nil {
 		t.Fatalf("Failed to apply config: %s", err)
@@ -801,7 +925,7 @@ func TestPassthroughExternal(t *testing.T) {
 				},
 			},
 		},
-		Passthrough: &PassthroughConfig{
+		Passthroughs: []PassthroughConfig{{
 			LocalNeighborV4: &NeighborConfig{
 				ASN:         mustNewPeerASNFromType("External"),
 				Addr:        "192.168.1.3",
@@ -812,7 +936,7 @@ func TestPassthroughExternal(t *testing.T) {
 				"192.169.20.0/24",
 				"192.169.21.0/24",
 			},
-		},
+		}},
 	}
 	if err := ApplyConfig(context.Background(), &config, updater); err != nil {
 		t.Fatalf("Failed to apply config: %s", err)
@@ -821,6 +945,58 @@ func TestPassthroughExternal(t *testing.T) {
 	testCheckConfigFile(t)
 }
 
+func TestMultiplePassthroughs(t *testing.T) {
+	configFile := testSetup(t)
+	updater := testUpdater(configFile)
+
+	config := Config{
+		Underlay: UnderlayConfig{
+			MyASN:    64512,
+			RouterID: "10.0.0.1",
+			Neighbors: []NeighborConfig{
+				{
+					ASN:                   mustNewPeerASNFromNumber(64513),
+					Addr:                  "192.168.1.2",
+					ID:                    "192.168.1.2",
+					NetworkLayerProtocols: []networklayerprotocol.NLP{{AFI: networklayerprotocol.IPv4, SAFI: networklayerprotocol.Unicast}},
+				},
+			},
+		},
+		Passthroughs: []PassthroughConfig{
+			{
+				LocalNeighborV4: &NeighborConfig{
+					ASN:         mustNewPeerASNFromNumber(64514),
+					Addr:        "192.169.10.2",
+					ID:          "192.169.10.2",
+					ConnectTime: new(int64(5)),
+				},
+				ToAdvertiseIPv4: []string{"192.169.10.2/32"},
+			},
+			{
+				LocalNeighborV4: &NeighborConfig{
+					ASN:         mustNewPeerASNFromNumber(64515),
+					Addr:        "192.169.11.2",
+					ID:          "192.169.11.2",
+					ConnectTime: new(int64(5)),
+				},
+				LocalNeighborV6: &NeighborConfig{
+					ASN:         mustNewPeerASNFromNumber(64515),
+					Addr:        "2001:db8:11::2",
+					ID:          "2001:db8:11::2",
+					ConnectTime: new(int64(5)),
+				},
+				ToAdvertiseIPv4: []string{"192.169.11.2/32"},
+				ToAdvertiseIPv6: []string{"2001:db8:11::2/128"},
+			},
+		},
+	}
+	if err := ApplyConfig(context.TODO(), &config, updater); err != nil {
+		t.Fatalf("Failed to apply config: %s", err)
+	}
+
+	testCheckConfigFile(t)
+}
+
 func TestPassthroughV4(t *testing.T) {
 	configFile := testSetup(t)
 	updater := testUpdater(configFile)
@@ -838,7 +1014,7 @@ func TestPassthroughV4(t *testing.T) {
 				},
 			},
 		},
-		Passthrough: &PassthroughConfig{
+		Passthroughs: []PassthroughConfig{{
 			LocalNeighborV4: &NeighborConfig{
 				ASN:         mustNewPeerASNFromNumber(64513),
 				Addr:        "192.168.1.3",
@@ -849,7 +1025,60 @@ func TestPassthroughV4(t *testing.T) {
 				"192.169.20.0/24",
 				"192.169.21.0/24",
 			},
+		}},
+	}
+	if err := ApplyConfig(context.Background(), &config, updater); err != nil {
+		t.Fatalf("Failed to apply config: %s", err)
+	}
+
+	testCheckConfigFile(t)
+}
+
+func TestPassthroughHardening(t *testing.T) {
+	configFile := testSetup(t)
+	updater := testUpdater(configFile)
+
+	config := Config{
+		Underlay: UnderlayConfig{
+			MyASN:    64512,
+			RouterID: "10.0.0.1",
+			Neighbors: []NeighborConfig{
+				{
+					ASN:                   mustNewPeerASNFromNumber(64513),
+					Addr:                  "192.168.1.2",
+					ID:                    "192.168.1.2",
+					NetworkLayerProtocols: []networklayerprotocol.NLP{{AFI: networklayerprotocol.IPv4, SAFI: networklayerprotocol.Unicast}},
+				},
+			},
 		},
+		Passthroughs: []PassthroughConfig{{
+			LocalNeighborV4: &NeighborConfig{
+				ASN:               mustNewPeerASNFromNumber(64513),
+				Addr:              "192.168.1.3",
+				ID:                "192.168.1.3",
+				ConnectTime:       new(int64(5)),
+				Password:          "hostsecret",
+				BFDEnabled:        true,
+				GracefulRestart:   GracefulRestartDisabled,
+				MaximumPrefixIPv4: &MaximumPrefix{Limit: 1000},
+			},
+			LocalNeighborV6: &NeighborConfig{
+				ASN:               mustNewPeerASNFromNumber(64513),
+				Addr:              "2001:db8::3",
+				ID:                "2001:db8::3",
+				ConnectTime:       new(int64(5)),
+				Password:          "hostsecret",
+				BFDEnabled:        true,
+				GracefulRestart:   GracefulRestartDisabled,
+				MaximumPrefixIPv6: &MaximumPrefix{Limit: 1000, WarningOnly: true},
+			},
+			ToAdvertiseIPv4: []string{
+				"192.168.1.3/32",
+			},
+			ToAdvertiseIPv6: []string{
+				"2001:db8::3/128",
+			},
+		}},
 	}
 	if err := ApplyConfig(context.Background(), &config, updater); err != nil {
 		t.Fatalf("Failed to apply config: %s", err)
@@ -887,7 +1116,7 @@ func TestPassthroughDual(t *testing.T) {
 				},
 			},
 		},
-		Passthrough: &PassthroughConfig{
+		Passthroughs: []PassthroughConfig{{
 			LocalNeighborV4: &NeighborConfig{
 				ASN:         mustNewPeerASNFromNumber(64513),
 				Addr:        "192.168.1.3",
@@ -908,7 +1137,7 @@ func TestPassthroughDual(t *testing.T) {
 				"2001:db8:20::/64",
 				"2001:db8:21::/64",
 			},
-		},
+		}},
 		VNIs: []L3VNIConfig{},
 	}
 	if err := ApplyConfig(context.Background(), &config, updater); err != nil {
@@ -967,10 +1196,10 @@ func TestTunnelEndpointConfig(t *testing.T) {
 					},
 				},
 			},
-			TunnelEndpoint: &TunnelEndpoint{
+			TunnelEndpoints: []TunnelEndpoint{{
 				IPv4CIDR: "192.168.10.1/24",
 				IPv6CIDR: "2001:db8:192:168::1/64",
-			},
+			}},
 		},
 		VNIs: []L3VNIConfig{
 			{
@@ -996,6 +1225,138 @@ func TestTunnelEndpointConfig(t *testing.T) {
 	testCheckConfigFile(t)
 }
 
+// TestMultipleTunnelEndpoints tests that the VTEPs of all the underlays of a dual-plane node are advertised.
+func TestMultipleTunnelEndpoints(t *testing.T) {
+	configFile := testSetup(t)
+	updater := testUpdater(configFile)
+
+	config := Config{
+		Underlay: UnderlayConfig{
+			MyASN:    64512,
+			RouterID: "10.0.0.1",
+			Neighbors: []NeighborConfig{
+				{
+					ASN:  mustNewPeerASNFromNumber(64513),
+					Addr: "192.168.1.2",
+					ID:   "192.168.1.2",
+					NetworkLayerProtocols: []networklayerprotocol.NLP{
+						{AFI: networklayerprotocol.IPv4, SAFI: networklayerprotocol.Unicast},
+						{AFI: networklayerprotocol.L2VPN, SAFI: networklayerprotocol.EVPN},
+					},
+				},
+				{
+					ASN:  mustNewPeerASNFromNumber(64514),
+					Addr: "192.168.2.2",
+					ID:   "192.168.2.2",
+					NetworkLayerProtocols: []networklayerprotocol.NLP{
+						{AFI: networklayerprotocol.IPv4, SAFI: networklayerprotocol.Unicast},
+						{AFI: networklayerprotocol.L2VPN, SAFI: networklayerprotocol.EVPN},
+					},
+				},
+			},
+			TunnelEndpoints: []TunnelEndpoint{
+				{IPv4CIDR: "100.64.0.1/32"},
+				{IPv4CIDR: "100.65.0.1/32", IPv6CIDR: "2001:db8:65::1/128"},
+			},
+		},
+	}
+	if err := ApplyConfig(context.Background(), &config, updater); err != nil {
+		t.Fatalf("Failed to apply config: %s", err)
+	}
+
+	testCheckConfigFile(t)
+}
+
+func TestMultipleUnderlays(t *testing.T) {
+	configFile := testSetup(t)
+	updater := testUpdater(configFile)
+
+	evpnNLPs := []networklayerprotocol.NLP{
+		{AFI: networklayerprotocol.IPv4, SAFI: networklayerprotocol.Unicast},
+		{AFI: networklayerprotocol.L2VPN, SAFI: networklayerprotocol.EVPN},
+	}
+	otherUnderlays := func(id, cidr string, vni int32, call string) []RouteMap {
+		return []RouteMap{
+			{
+				Name:      id + "-ipv4-unicast-other-underlays",
+				AFI:       networklayerprotocol.IPv4,
+				SAFI:      networklayerprotocol.Unicast,
+				Direction: RouteMapOut,
+				Entries: []RouteMapEntry{
+					{
+						Seq:    10,
+						Action: "deny",
+						PrefixList: &PrefixList{
+							Name:    id + "-ipv4-unicast-other-underlays",
+							Entries: []PrefixListEntry{{Seq: 5, Prefix: cidr, LE: new(int32(32))}},
+						},
+					},
+					{Seq: 65535, Action: "permit"},
+				},
+			},
+			{
+				Name:      id + "-l2vpn-evpn-other-underlays",
+				AFI:       networklayerprotocol.L2VPN,
+				SAFI:      networklayerprotocol.EVPN,
+				Direction: RouteMapOut,
+				Entries: []RouteMapEntry{
+					{Seq: 10, Action: "deny", MatchEVPNVNI: vni},
+					{Seq: 65535, Action: "permit", Call: call},
+				},
+			},
+		}
+	}
+
+	config := Config{
+		Underlay: UnderlayConfig{
+			MyASN:    64512,
+			RouterID: "10.0.0.1",
+			Neighbors: []NeighborConfig{
+				{
+					ASN:                   mustNewPeerASNFromNumber(64513),
+					Addr:                  "192.168.1.2",
+					ID:                    "192.168.1.2",
+					NetworkLayerProtocols: evpnNLPs,
+					UnderlayFilters:       otherUnderlays("192.168.1.2", "100.65.0.0/24", 200, ""),
+				},
+				{
+					ASN:                   mustNewPeerASNFromNumber(64514),
+					Addr:                  "192.168.2.2",
+					ID:                    "192.168.2.2",
+					NetworkLayerProtocols: evpnNLPs,
+					RouteMaps: []RouteMap{{
+						Name:      "192.168.2.2-l2vpn-evpn-out",
+						AFI:       networklayerprotocol.L2VPN,
+						SAFI:      networklayerprotocol.EVPN,
+						Direction: RouteMapOut,
+						Entries: []RouteMapEntry{
+							{Seq: 10, Action: "permit", SetCommunities: []string{"64512:200"}},
+							{Seq: 65535, Action: "deny"},
+						},
+					}},
+					UnderlayFilters: otherUnderlays("192.168.2.2", "100.64.0.0/24", 100, "192.168.2.2-l2vpn-evpn-out"),
+				},
+			},
+			TunnelEndpoints: []TunnelEndpoint{
+				{IPv4CIDR: "100.64.0.1/32"},
+				{IPv4CIDR: "100.65.0.1/32"},
+			},
+		},
+		VNIs: []L3VNIConfig{{
+			ASN:      64512,
+			VNI:      200,
+			VRF:      "red",
+			RouterID: "10.0.0.1",
+		}},
+		L2VNIs: []L2VNIConfig{{VNI: 100}},
+	}
+	if err := ApplyConfig(context.Background(), &config, updater); err != nil {
+		t.Fatalf("Failed to apply config: %s", err)
+	}
+
+	testCheckConfigFile(t)
+}
+
 func TestISIS(t *testing.T) {
 	configFile := testSetup(t)
 	updater := testUpdater(configFile)
@@ -1069,7 +1430,7 @@ func TestISISAdvertisePassiveOnly(t *testing.T) {
 	testCheckConfigFile(t)
 }
 
-func TestSegmentRouting(t *testing.T) {
+func TestISISAuthenticationAndTimers(t *testing.T) {
 	configFile := testSetup(t)
 	updater := testUpdater(configFile)
 
@@ -1079,18 +1440,141 @@ func TestSegmentRouting(t *testing.T) {
 			RouterID: "10.0.0.1",
 			Neighbors: []NeighborConfig{
 				{
-					ASN:  mustNewPeerASNFromNumber(64513),
-					Addr: "fc00::2:172:31:1:12",
-					ID:   "fc00::2:172:31:1:12",
-					NetworkLayerProtocols: []networklayerprotocol.NLP{
-						{AFI: networklayerprotocol.IPv4, SAFI: networklayerprotocol.VPN},
-						{AFI: networklayerprotocol.IPv6, SAFI: networklayerprotocol.VPN},
-					},
-					ExtendedNexthop: true,
-					UpdateSource:    "fc00::2:172:31:1:32",
-				},
-			},
-			ISIS: &UnderlayISIS{
+					ASN:                   mustNewPeerASNFromNumber(64512),
+					Addr:                  "192.168.1.2",
+					ID:                    "192.168.1.2",
+					NetworkLayerProtocols: []networklayerprotocol.NLP{{AFI: networklayerprotocol.IPv4, SAFI: networklayerprotocol.Unicast}},
+				},
+			},
+			ISIS: &UnderlayISIS{
+				Net:            MustParseISISNet("49.0001.0002.0003.0004.00"),
+				Name:           isisProcessName,
+				AreaPassword:   "areakey",
+				DomainPassword: "domainkey",
+				Interfaces: []ISISInterface{
+					{Name: "lo", IPv6: true, IsPassive: true},
+					{
+						Name:            "eth0",
+						IPv6:            true,
+						IsPointToPoint:  true,
+						Password:        "eth0key",
+						Metric:          new(int32(100)),
+						HelloInterval:   new(int32(1)),
+						HelloMultiplier: new(int32(3)),
+						BFDEnabled:      true,
+						BFDProfile:      "isis-eth0",
+					},
+					{Name: "eth1", IPv6: true, BFDEnabled: true},
+				},
+			},
+		},
+		BFDProfiles: []BFDProfile{
+			{
+				Name:             "isis-eth0",
+				ReceiveInterval:  new(int32(100)),
+				TransmitInterval: new(int32(100)),
+			},
+		},
+	}
+	if err := ApplyConfig(context.TODO(), &config, updater); err != nil {
+		t.Fatalf("Failed to apply config: %s", err)
+	}
+
+	testCheckConfigFile(t)
+}
+
+func TestOSPF(t *testing.T) {
+	configFile := testSetup(t)
+	updater := testUpdater(configFile)
+
+	config := Config{
+		Underlay: UnderlayConfig{
+			MyASN:    64512,
+			RouterID: "10.0.0.1",
+			Neighbors: []NeighborConfig{
+				{
+					ASN:                   mustNewPeerASNFromNumber(64512),
+					Addr:                  "192.168.1.2",
+					ID:                    "192.168.1.2",
+					NetworkLayerProtocols: []networklayerprotocol.NLP{{AFI: networklayerprotocol.IPv4, SAFI: networklayerprotocol.Unicast}},
+				},
+			},
+			OSPF: &UnderlayOSPF{
+				RouterID: "10.0.0.1",
+				Area:     "0.0.0.0",
+				IPv4:     true,
+				IPv6:     true,
+				Interfaces: []OSPFInterface{
+					{Name: "eth0", IsPointToPoint: true},
+					{Name: "eth1"},
+					{Name: "lo", IsPassive: true},
+				},
+			},
+		},
+	}
+	if err := ApplyConfig(context.TODO(), &config, updater); err != nil {
+		t.Fatalf("Failed to apply config: %s", err)
+	}
+
+	testCheckConfigFile(t)
+}
+
+func TestOSPFv2Only(t *testing.T) {
+	configFile := testSetup(t)
+	updater := testUpdater(configFile)
+
+	config := Config{
+		Underlay: UnderlayConfig{
+			MyASN:    64512,
+			RouterID: "10.0.0.1",
+			Neighbors: []NeighborConfig{
+				{
+					ASN:                   mustNewPeerASNFromNumber(64512),
+					Addr:                  "192.168.1.2",
+					ID:                    "192.168.1.2",
+					NetworkLayerProtocols: []networklayerprotocol.NLP{{AFI: networklayerprotocol.IPv4, SAFI: networklayerprotocol.Unicast}},
+				},
+			},
+			OSPF: &UnderlayOSPF{
+				RouterID: "10.0.0.1",
+				Area:     "0.0.0.1",
+				IPv4:     true,
+				Interfaces: []OSPFInterface{
+					{Name: "eth0", IsPointToPoint: true},
+					{Name: "lo", IsPassive: true},
+				},
+			},
+		},
+	}
+	if err := ApplyConfig(context.TODO(), &config, updater); err != nil {
+		t.Fatalf("Failed to apply config: %s", err)
+	}
+
+	testCheckConfigFile(t)
+}
+
+func TestSegmentRouting(t *testing.T) {
+	configFile := testSetup(t)
+	updater := testUpdater(configFile)
+
+	config := Config{
+		Underlay: UnderlayConfig{
+			MyASN:    64512,
+			RouterID: "10.0.0.1",
+			Neighbors: []NeighborConfig{
+				{
+					ASN:  mustNewPeerASNFromNumber(64513),
+					Addr: "fc00::2:172:31:1:12",
+					ID:   "fc00::2:172:31:1:12",
+					NetworkLayerProtocols: []networklayerprotocol.NLP{
+						{AFI: networklayerprotocol.IPv4, SAFI: networklayerprotocol.VPN},
+						{AFI: networklayerprotocol.IPv6, SAFI: networklayerprotocol.VPN},
+					},
+					ExtendedNexthop: true,
+					UpdateSource:    "fc00::2:172:31:1:32",
+				},
+			},
+			ISIS: &UnderlayISIS{
 				Net:   MustParseISISNet("49.0001.0002.0003.0004.00"),
 				Name:  isisProcessName,
 				Level: 1,
@@ -1152,6 +1636,95 @@ func TestSegmentRouting(t *testing.T) {
 	testCheckConfigFile(t)
 }
 
+// TestSegmentRoutingWithL3VNI tests that L3VNIs and L3VPNs are rendered side by side in different VRFs.
+func TestSegmentRoutingWithL3VNI(t *testing.T) {
+	configFile := testSetup(t)
+	updater := testUpdater(configFile)
+
+	config := Config{
+		Underlay: UnderlayConfig{
+			MyASN:    64512,
+			RouterID: "10.0.0.1",
+			Neighbors: []NeighborConfig{
+				{
+					ASN:  mustNewPeerASNFromNumber(64513),
+					Addr: "fc00::2:172:31:1:12",
+					ID:   "fc00::2:172:31:1:12",
+					NetworkLayerProtocols: []networklayerprotocol.NLP{
+						{AFI: networklayerprotocol.IPv4, SAFI: networklayerprotocol.Unicast},
+						{AFI: networklayerprotocol.L2VPN, SAFI: networklayerprotocol.EVPN},
+						{AFI: networklayerprotocol.IPv4, SAFI: networklayerprotocol.VPN},
+						{AFI: networklayerprotocol.IPv6, SAFI: networklayerprotocol.VPN},
+					},
+					ExtendedNexthop: true,
+					UpdateSource:    "fc00::2:172:31:1:32",
+				},
+			},
+			TunnelEndpoints: []TunnelEndpoint{{
+				IPv4CIDR: "100.64.0.1/32",
+				IPv6CIDR: "fc00::2:172:31:1:32/128",
+			}},
+			ISIS: &UnderlayISIS{
+				Net:   MustParseISISNet("49.0001.0002.0003.0004.00"),
+				Name:  isisProcessName,
+				Level: 1,
+				Interfaces: []ISISInterface{
+					{Name: "lo", IPv6: true, IsPassive: true},
+					{Name: "eth0", IPv4: false, IPv6: true},
+				},
+			},
+			SegmentRouting: &UnderlaySegmentRouting{
+				SourceAddress: "fc00::2:172:31:1:32",
+				Locator: SRV6Locator{
+					Name:     locatorName,
+					Prefix:   "fd00:0:32::/48",
+					BlockLen: 32,
+					NodeLen:  16,
+					Behavior: "usid",
+					Format:   "usid-f3216",
+				},
+				EncapBehavior: HEncaps,
+			},
+		},
+		VNIs: []L3VNIConfig{
+			{
+				VRF:      "red",
+				ASN:      64512,
+				VNI:      100,
+				RouterID: "10.0.0.1",
+				LocalNeighbor: &NeighborConfig{
+					ASN:  mustNewPeerASNFromNumber(64515),
+					Addr: "192.169.10.2",
+					ID:   "192.169.10.2",
+				},
+				ToAdvertiseIPv4: []string{"192.169.10.0/24"},
+			},
+		},
+		VPNs: []L3VPNConfig{
+			{
+				ASN:             64512,
+				ToAdvertiseIPv4: []string{"192.168.2.2/32"},
+				ToAdvertiseIPv6: []string{},
+				LocalNeighbor: &NeighborConfig{
+					ASN:  mustNewPeerASNFromNumber(65001),
+					Addr: "192.168.2.2",
+					ID:   "192.168.2.2",
+				},
+				VRF:                "blue",
+				ExportRTs:          []string{"64512:200"},
+				ImportRTs:          []string{"64512:200"},
+				RouteDistinguisher: "10.0.0.1:200",
+				RouterID:           "10.0.0.1",
+			},
+		},
+	}
+	if err := ApplyConfig(context.TODO(), &config, updater); err != nil {
+		t.Fatalf("Failed to apply config: %s", err)
+	}
+
+	testCheckConfigFile(t)
+}
+
 func TestSegmentRoutingWithL2VNI(t *testing.T) {
 	configFile := testSetup(t)
 	updater := testUpdater(configFile)
@@ -1198,10 +1771,10 @@ func TestSegmentRoutingWithL2VNI(t *testing.T) {
 					UpdateSource:    "2001:db8:1234:5678::",
 				},
 			},
-			TunnelEndpoint: &TunnelEndpoint{
+			TunnelEndpoints: []TunnelEndpoint{{
 				IPv4CIDR: "192.168.123.0/32",
 				IPv6CIDR: "2001:db8:1234:5678::/128",
-			},
+			}},
 			SegmentRouting: &UnderlaySegmentRouting{
 				SourceAddress: "2001:db8:1234:5678::",
 				Locator: SRV6Locator{
@@ -1326,9 +1899,9 @@ func TestGracefulRestart(t *testing.T) {
 	config := Config{
 		Underlay: UnderlayConfig{
 			MyASN: 64512,
-			TunnelEndpoint: &TunnelEndpoint{
+			TunnelEndpoints: []TunnelEndpoint{{
 				IPv4CIDR: "100.64.0.1/32",
-			},
+			}},
 			RouterID: "10.0.0.1",
 			Neighbors: []NeighborConfig{
 				{
@@ -1358,9 +1931,9 @@ func TestGracefulRestartCustomTimers(t *testing.T) {
 	config := Config{
 		Underlay: UnderlayConfig{
 			MyASN: 64512,
-			TunnelEndpoint: &TunnelEndpoint{
+			TunnelEndpoints: []TunnelEndpoint{{
 				IPv4CIDR: "100.64.0.1/32",
-			},
+			}},
 			RouterID: "10.0.0.1",
 			Neighbors: []NeighborConfig{
 				{
@@ -1395,9 +1968,9 @@ func TestRouteReflector(t *testing.T) {
 	config := Config{
 		Underlay: UnderlayConfig{
 			MyASN: 64514,
-			TunnelEndpoint: &TunnelEndpoint{
+			TunnelEndpoints: []TunnelEndpoint{{
 				IPv4CIDR: "100.65.0.0/32",
-			},
+			}},
 			RouterID: "10.0.0.1",
 			RouteReflector: &RouteReflector{
 				ClusterID: "192.0.2.1",
@@ -1533,9 +2106,9 @@ func TestListenRangeEBGP(t *testing.T) {
 	config := Config{
 		Underlay: UnderlayConfig{
 			MyASN: 64514,
-			TunnelEndpoint: &TunnelEndpoint{
+			TunnelEndpoints: []TunnelEndpoint{{
 				IPv4CIDR: "100.65.0.0/32",
-			},
+			}},
 			RouterID: "10.0.0.1",
 			Neighbors: []NeighborConfig{
 				{
@@ -1569,9 +2142,9 @@ func TestListenRangeCustomLimit(t *testing.T) {
 	config := Config{
 		Underlay: UnderlayConfig{
 			MyASN: 64514,
-			TunnelEndpoint: &TunnelEndpoint{
+			TunnelEndpoints: []TunnelEndpoint{{
 				IPv4CIDR: "100.65.0.0/32",
-			},
+			}},
 			RouterID:    "10.0.0.1",
 			ListenLimit: 512,
 			Neighbors: []NeighborConfig{
@@ -1627,9 +2200,9 @@ func TestBFDProfilePassiveMode(t *testing.T) {
 	config := Config{
 		Underlay: UnderlayConfig{
 			MyASN: 64512,
-			TunnelEndpoint: &TunnelEndpoint{
+			TunnelEndpoints: []TunnelEndpoint{{
 				IPv4CIDR: "100.64.0.1/32",
-			},
+			}},
 			RouterID: "10.0.0.1",
 			Neighbors: []NeighborConfig{
 				{
@@ -1665,9 +2238,9 @@ func TestEBGPMultiHopWithTTL(t *testing.T) {
 	config := Config{
 		Underlay: UnderlayConfig{
 			MyASN: 64512,
-			TunnelEndpoint: &TunnelEndpoint{
+			TunnelEndpoints: []TunnelEndpoint{{
 				IPv4CIDR: "100.64.0.1/32",
-			},
+			}},
 			RouterID: "10.0.0.1",
 			Neighbors: []NeighborConfig{
 				{
@@ -1689,3 +2262,370 @@ func TestEBGPMultiHopWithTTL(t *testing.T) {
 
 	testCheckConfigFile(t)
 }
+
+func TestRouteMaps(t *testing.T) {
+	configFile := testSetup(t)
+	updater := testUpdater(configFile)
+
+	config := Config{
+		Underlay: UnderlayConfig{
+			MyASN: 64512,
+			TunnelEndpoints: []TunnelEndpoint{{
+				IPv4CIDR: "100.64.0.1/32",
+			}},
+			RouterID: "10.0.0.1",
+			Neighbors: []NeighborConfig{
+				{
+					ASN:  mustNewPeerASNFromNumber(64513),
+					Addr: "192.168.1.2",
+					ID:   "192.168.1.2",
+					NetworkLayerProtocols: []networklayerprotocol.NLP{
+						{AFI: networklayerprotocol.IPv4, SAFI: networklayerprotocol.Unicast},
+						{AFI: networklayerprotocol.L2VPN, SAFI: networklayerprotocol.EVPN},
+					},
+					RouteMaps: []RouteMap{
+						{
+							Name:      "192.168.1.2-ipv4-unicast-in",
+							AFI:       networklayerprotocol.IPv4,
+							SAFI:      networklayerprotocol.Unicast,
+							Direction: RouteMapIn,
+							Entries: []RouteMapEntry{
+								{
+									Seq:    10,
+									Action: "permit",
+									PrefixList: &PrefixList{
+										Name: "192.168.1.2-ipv4-unicast-in-10",
+										Entries: []PrefixListEntry{
+											{Seq: 5, Prefix: "10.0.0.0/8", LE: new(int32(24))},
+											{Seq: 10, Prefix: "172.16.0.0/12", GE: new(int32(16)), LE: new(int32(24))},
+										},
+									},
+									SetLocalPreference: new(int64(200)),
+								},
+								{Seq: 65535, Action: "deny"},
+							},
+						},
+						{
+							Name:      "192.168.1.2-l2vpn-evpn-out",
+							AFI:       networklayerprotocol.L2VPN,
+							SAFI:      networklayerprotocol.EVPN,
+							Direction: RouteMapOut,
+							Entries: []RouteMapEntry{
+								{
+									Seq:    10,
+									Action: "deny",
+									CommunityList: &CommunityList{
+										Name: "192.168.1.2-l2vpn-evpn-out-10-c",
+										Entries: []CommunityListEntry{
+											{Seq: 5, Community: "64512:666"},
+										},
+									},
+								},
+								{
+									Seq:    20,
+									Action: "permit",
+									LargeCommunityList: &CommunityList{
+										Name: "192.168.1.2-l2vpn-evpn-out-20-lc",
+										Entries: []CommunityListEntry{
+											{Seq: 5, Community: "64512:1:1"},
+											{Seq: 10, Community: "64512:1:2"},
+										},
+									},
+									SetCommunities:      []string{"64512:100", "64512:200"},
+									SetLargeCommunities: []string{"64512:2:1"},
+									SetASPathPrepend:    "64512 64512",
+								},
+								{Seq: 65535, Action: "permit"},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	if err := ApplyConfig(context.Background(), &config, updater); err != nil {
+		t.Fatalf("Failed to apply config: %s", err)
+	}
+
+	testCheckConfigFile(t)
+}
+
+func TestRouteMapsLocalNeighbor(t *testing.T) {
+	configFile := testSetup(t)
+	updater := testUpdater(configFile)
+
+	importMap := RouteMap{
+		Name:      "red-192.169.10.2-ipv4-unicast-in",
+		AFI:       networklayerprotocol.IPv4,
+		SAFI:      networklayerprotocol.Unicast,
+		Direction: RouteMapIn,
+		Entries: []RouteMapEntry{
+			{
+				Seq:    10,
+				Action: "permit",
+				PrefixList: &PrefixList{
+					Name: "red-192.169.10.2-ipv4-unicast-in-10",
+					Entries: []PrefixListEntry{
+						{Seq: 5, Prefix: "192.170.0.0/16", LE: new(int32(32))},
+					},
+				},
+			},
+			{Seq: 65535, Action: "deny"},
+		},
+	}
+	exportMap := RouteMap{
+		Name:      "passthrough-2001:db8::2-ipv6-unicast-out",
+		AFI:       networklayerprotocol.IPv6,
+		SAFI:      networklayerprotocol.Unicast,
+		Direction: RouteMapOut,
+		Entries: []RouteMapEntry{
+			{
+				Seq:    10,
+				Action: "deny",
+				PrefixList: &PrefixList{
+					Name: "passthrough-2001:db8::2-ipv6-unicast-out-10",
+					IPv6: true,
+					Entries: []PrefixListEntry{
+						{Seq: 5, Prefix: "2001:db8:ffff::/48", GE: new(int32(64))},
+					},
+				},
+			},
+			{Seq: 65535, Action: "permit"},
+		},
+	}
+
+	config := Config{
+		Underlay: UnderlayConfig{
+			MyASN:    64512,
+			RouterID: "10.0.0.1",
+			TunnelEndpoints: []TunnelEndpoint{{
+				IPv4CIDR: "100.64.0.1/32",
+			}},
+			Neighbors: []NeighborConfig{
+				{
+					ASN:  mustNewPeerASNFromNumber(64513),
+					Addr: "192.168.1.2",
+					ID:   "192.168.1.2",
+					NetworkLayerProtocols: []networklayerprotocol.NLP{
+						{AFI: networklayerprotocol.IPv4, SAFI: networklayerprotocol.Unicast},
+						{AFI: networklayerprotocol.IPv6, SAFI: networklayerprotocol.Unicast},
+						{AFI: networklayerprotocol.L2VPN, SAFI: networklayerprotocol.EVPN},
+					},
+				},
+			},
+		},
+		Passthroughs: []PassthroughConfig{{
+			LocalNeighborV6: &NeighborConfig{
+				ASN:       mustNewPeerASNFromNumber(64515),
+				Addr:      "2001:db8::2",
+				ID:        "2001:db8::2",
+				RouteMaps: []RouteMap{exportMap},
+			},
+			ToAdvertiseIPv6: []string{"2001:db8::2/128"},
+		}},
+		VNIs: []L3VNIConfig{
+			{
+				VRF:      "red",
+				ASN:      64512,
+				VNI:      100,
+				RouterID: "10.0.0.1",
+				LocalNeighbor: &NeighborConfig{
+					ASN:       mustNewPeerASNFromNumber(64514),
+					Addr:      "192.169.10.2",
+					ID:        "192.169.10.2",
+					RouteMaps: []RouteMap{importMap},
+				},
+				ToAdvertiseIPv4: []string{
+					"192.169.10.2/32",
+				},
+			},
+		},
+	}
+	if err := ApplyConfig(context.Background(), &config, updater); err != nil {
+		t.Fatalf("Failed to apply config: %s", err)
+	}
+
+	testCheckConfigFile(t)
+}
+
+func TestEthernetSegments(t *testing.T) {
+	configFile := testSetup(t)
+	updater := testUpdater(configFile)
+
+	config := Config{
+		Underlay: UnderlayConfig{
+			MyASN:    64512,
+			RouterID: "10.0.0.1",
+			TunnelEndpoints: []TunnelEndpoint{{
+				IPv4CIDR: "100.64.0.1/32",
+			}},
+			Neighbors: []NeighborConfig{
+				{
+					ASN:  mustNewPeerASNFromNumber(64513),
+					Addr: "192.168.1.2",
+					ID:   "192.168.1.2",
+					NetworkLayerProtocols: []networklayerprotocol.NLP{
+						{AFI: networklayerprotocol.IPv4, SAFI: networklayerprotocol.Unicast},
+						{AFI: networklayerprotocol.L2VPN, SAFI: networklayerprotocol.EVPN},
+					},
+				},
+			},
+		},
+		EthernetSegments: []EthernetSegment{
+			{
+				Interface: "pe-e-200",
+				ESI:       "00:11:22:33:44:55:66:77:88:99",
+			},
+			{
+				Interface:          "pe-e-201",
+				LocalDiscriminator: 201,
+				SystemMAC:          "44:38:39:ff:00:01",
+				DFPreference:       50000,
+			},
+			{
+				Interface:          "pe-e-202",
+				LocalDiscriminator: 202,
+				SystemMAC:          "44:38:39:ff:00:01",
+				DFPreference:       32767,
+			},
+		},
+	}
+	if err := ApplyConfig(context.Background(), &config, updater); err != nil {
+		t.Fatalf("Failed to apply config: %s", err)
+	}
+
+	testCheckConfigFile(t)
+}
+
+func TestL2VNIGatewayAdvertisements(t *testing.T) {
+	configFile := testSetup(t)
+	updater := testUpdater(configFile)
+
+	config := Config{
+		Underlay: UnderlayConfig{
+			MyASN:    64512,
+			RouterID: "10.0.0.1",
+			TunnelEndpoints: []TunnelEndpoint{{
+				IPv4CIDR: "100.64.0.1/32",
+			}},
+			Neighbors: []NeighborConfig{
+				{
+					ASN:  mustNewPeerASNFromNumber(64513),
+					Addr: "192.168.1.2",
+					ID:   "192.168.1.2",
+					NetworkLayerProtocols: []networklayerprotocol.NLP{
+						{AFI: networklayerprotocol.IPv4, SAFI: networklayerprotocol.Unicast},
+						{AFI: networklayerprotocol.L2VPN, SAFI: networklayerprotocol.EVPN},
+					},
+				},
+			},
+		},
+		L2VNIs: []L2VNIConfig{
+			{VNI: 200, AdvertiseDefaultGW: true},
+			{VNI: 300, AdvertiseSVIIP: true},
+		},
+	}
+	if err := ApplyConfig(context.Background(), &config, updater); err != nil {
+		t.Fatalf("Failed to apply config: %s", err)
+	}
+
+	testCheckConfigFile(t)
+}
+
+func TestVRFRouteLeaking(t *testing.T) {
+	configFile := testSetup(t)
+	updater := testUpdater(configFile)
+
+	redImportMap := RouteMap{
+		Name:      "red-import-vrf-ipv4",
+		AFI:       networklayerprotocol.IPv4,
+		SAFI:      networklayerprotocol.Unicast,
+		Direction: RouteMapIn,
+		Entries: []RouteMapEntry{
+			{
+				Seq:            10,
+				Action:         "permit",
+				MatchSourceVRF: "shared",
+				PrefixList: &PrefixList{
+					Name: "red-import-vrf-ipv4-10",
+					Entries: []PrefixListEntry{
+						{Seq: 5, Prefix: "192.172.0.0/24", LE: new(int32(32))},
+					},
+				},
+			},
+		},
+	}
+	defaultImportV4Map := RouteMap{
+		Name:      "default-import-vrf-ipv4",
+		AFI:       networklayerprotocol.IPv4,
+		SAFI:      networklayerprotocol.Unicast,
+		Direction: RouteMapIn,
+		Entries: []RouteMapEntry{
+			{Seq: 10, Action: "permit", MatchSourceVRF: "red"},
+		},
+	}
+	defaultImportV6Map := RouteMap{
+		Name:      "default-import-vrf-ipv6",
+		AFI:       networklayerprotocol.IPv6,
+		SAFI:      networklayerprotocol.Unicast,
+		Direction: RouteMapIn,
+		Entries: []RouteMapEntry{
+			{Seq: 10, Action: "permit", MatchSourceVRF: "red"},
+		},
+	}
+
+	config := Config{
+		Underlay: UnderlayConfig{
+			MyASN:    64512,
+			RouterID: "10.0.0.1",
+			TunnelEndpoints: []TunnelEndpoint{{
+				IPv4CIDR: "100.64.0.1/32",
+			}},
+			Neighbors: []NeighborConfig{
+				{
+					ASN:  mustNewPeerASNFromNumber(64513),
+					Addr: "192.168.1.2",
+					ID:   "192.168.1.2",
+					NetworkLayerProtocols: []networklayerprotocol.NLP{
+						{AFI: networklayerprotocol.IPv4, SAFI: networklayerprotocol.Unicast},
+						{AFI: networklayerprotocol.L2VPN, SAFI: networklayerprotocol.EVPN},
+					},
+				},
+			},
+		},
+		VNIs: []L3VNIConfig{
+			{
+				VRF:      "red",
+				ASN:      64512,
+				VNI:      100,
+				RouterID: "10.0.0.1",
+			},
+			{
+				VRF:      "shared",
+				ASN:      64512,
+				VNI:      200,
+				RouterID: "10.0.0.1",
+			},
+		},
+		VRFImports: []VRFImport{
+			{
+				VRF:        "",
+				ASN:        64512,
+				ImportIPv4: []string{"red"},
+				ImportIPv6: []string{"red"},
+				RouteMaps:  []RouteMap{defaultImportV4Map, defaultImportV6Map},
+			},
+			{
+				VRF:        "red",
+				ASN:        64512,
+				ImportIPv4: []string{"shared"},
+				RouteMaps:  []RouteMap{redImportMap},
+			},
+		},
+	}
+	if err := ApplyConfig(context.Background(), &config, updater); err != nil {
+		t.Fatalf("Failed to apply config: %s", err)
+	}
+
+	testCheckConfigFile(t)
+}
@@ -12,10 +12,18 @@ import (
 
 // ISISInterface holds the internal representation of an interface's ISIS configuration.
 type ISISInterface struct {
-	Name      string
-	IPv4      bool
-	IPv6      bool
-	IsPassive bool
+	Name           string
+	IPv4           bool
+	IPv6           bool
+	IsPassive      bool
+	IsPointToPoint bool
+	// Password is the HMAC-MD5 key of the hellos of the interface.
+	Password        string
+	Metric          *int32
+	HelloInterval   *int32
+	HelloMultiplier *int32
+	BFDEnabled      bool
+	BFDProfile      string
 }
 
 // ISISAreaID is the area ID part of an ISIS net address.
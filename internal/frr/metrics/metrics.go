@@ -0,0 +1,302 @@
+// SPDX-License-Identifier:Apache-2.0
+
+// inspired by https://github.com/metallb/metallb/tree/main/frr-tools/metrics/collector
+package metrics
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/openperouter/openperouter/internal/frr"
+	"github.com/openperouter/openperouter/internal/frr/vtysh"
+)
+
+const (
+	namespace    = "openperouter"
+	bgpSubsystem = "bgp"
+	bfdSubsystem = "bfd"
+
+	bfdStatusUp = "up"
+)
+
+var (
+	bgpLabels = []string{"peer", "vrf"}
+	bfdLabels = []string{"peer", "local", "vrf", "interface"}
+
+	sessionUpDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, bgpSubsystem, "session_up"),
+		"BGP session state (1 is up, 0 is down)",
+		bgpLabels,
+		nil,
+	)
+
+	prefixesSentDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, bgpSubsystem, "announced_prefixes"),
+		"Number of prefixes currently being advertised on the BGP session",
+		bgpLabels,
+		nil,
+	)
+
+	prefixesReceivedDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, bgpSubsystem, "received_prefixes"),
+		"Number of prefixes currently being received on the BGP session",
+		bgpLabels,
+		nil,
+	)
+
+	opensSentDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, bgpSubsystem, "opens_sent"),
+		"Number of BGP open messages sent",
+		bgpLabels,
+		nil,
+	)
+
+	opensReceivedDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, bgpSubsystem, "opens_received"),
+		"Number of BGP open messages received",
+		bgpLabels,
+		nil,
+	)
+
+	notificationsSentDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, bgpSubsystem, "notifications_sent"),
+		"Number of BGP notification messages sent",
+		bgpLabels,
+		nil,
+	)
+
+	updatesSentDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, bgpSubsystem, "updates_total_sent"),
+		"Number of BGP update messages sent",
+		bgpLabels,
+		nil,
+	)
+
+	updatesReceivedDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, bgpSubsystem, "updates_total_received"),
+		"Number of BGP update messages received",
+		bgpLabels,
+		nil,
+	)
+
+	keepalivesSentDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, bgpSubsystem, "keepalives_sent"),
+		"Number of BGP keepalive messages sent",
+		bgpLabels,
+		nil,
+	)
+
+	keepalivesReceivedDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, bgpSubsystem, "keepalives_received"),
+		"Number of BGP keepalive messages received",
+		bgpLabels,
+		nil,
+	)
+
+	routeRefreshSentDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, bgpSubsystem, "route_refresh_sent"),
+		"Number of BGP route refresh messages sent",
+		bgpLabels,
+		nil,
+	)
+
+	totalSentDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, bgpSubsystem, "total_sent"),
+		"Number of total BGP messages sent",
+		bgpLabels,
+		nil,
+	)
+
+	totalReceivedDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, bgpSubsystem, "total_received"),
+		"Number of total BGP messages received",
+		bgpLabels,
+		nil,
+	)
+
+	bfdSessionUpDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, bfdSubsystem, "session_up"),
+		"BFD session state (1 is up, 0 is down)",
+		bfdLabels,
+		nil,
+	)
+
+	pollFailuresDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "frr", "poll_failures_total"),
+		"Number of failed attempts to retrieve the BGP and BFD state from FRR",
+		nil,
+		nil,
+	)
+)
+
+// Collector periodically polls FRR for the state of the BGP and BFD sessions
+// and exposes the last retrieved snapshot as prometheus metrics.
+// It implements prometheus.Collector, the polling is run by Start.
+type Collector struct {
+	frrCli   vtysh.Cli
+	interval time.Duration
+	logger   *slog.Logger
+
+	mu           sync.Mutex
+	neighbors    []*frr.Neighbor
+	bfdPeers     []frr.BFDPeer
+	pollFailures int
+}
+
+// NewCollector returns a collector polling FRR via frrCli every interval.
+func NewCollector(frrCli vtysh.Cli, interval time.Duration, logger *slog.Logger) *Collector {
+	return &Collector{
+		frrCli:   frrCli,
+		interval: interval,
+		logger:   logger,
+	}
+}
+
+func (c *Collector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- sessionUpDesc
+	ch <- prefixesSentDesc
+	ch <- prefixesReceivedDesc
+	ch <- opensSentDesc
+	ch <- opensReceivedDesc
+	ch <- notificationsSentDesc
+	ch <- updatesSentDesc
+	ch <- updatesReceivedDesc
+	ch <- keepalivesSentDesc
+	ch <- keepalivesReceivedDesc
+	ch <- routeRefreshSentDesc
+	ch <- totalSentDesc
+	ch <- totalReceivedDesc
+	ch <- bfdSessionUpDesc
+	ch <- pollFailuresDesc
+}
+
+func (c *Collector) Collect(ch chan<- prometheus.Metric) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for _, n := range c.neighbors {
+		updateNeighborMetrics(ch, n)
+	}
+	for _, p := range c.bfdPeers {
+		up := 0.0
+		if p.Status == bfdStatusUp {
+			up = 1
+		}
+		ch <- prometheus.MustNewConstMetric(bfdSessionUpDesc, prometheus.GaugeValue, up,
+			p.Peer, p.Local, p.Vrf, p.Interface)
+	}
+	ch <- prometheus.MustNewConstMetric(pollFailuresDesc, prometheus.CounterValue, float64(c.pollFailures))
+}
+
+// Start polls FRR until the context is cancelled.
+func (c *Collector) Start(ctx context.Context) error {
+	ticker := time.NewTicker(c.interval)
+	defer ticker.Stop()
+
+	for {
+		c.Poll()
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// Poll retrieves the current BGP and BFD state from FRR and stores it as
+// the snapshot to be exposed. When FRR can't be queried, the corresponding
+// metrics are dropped instead of exposing stale values.
+func (c *Collector) Poll() {
+	neighbors, bgpErr := c.neighborsForAllVRFs()
+	if bgpErr != nil {
+		c.logger.Error("failed to retrieve bgp neighbors for metrics", "error", bgpErr)
+	}
+	bfdPeers, bfdErr := c.retrieveBFDPeers()
+	if bfdErr != nil {
+		c.logger.Error("failed to retrieve bfd peers for metrics", "error", bfdErr)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.neighbors = neighbors
+	c.bfdPeers = bfdPeers
+	if bgpErr != nil || bfdErr != nil {
+		c.pollFailures++
+	}
+}
+
+func (c *Collector) neighborsForAllVRFs() ([]*frr.Neighbor, error) {
+	res, err := c.frrCli("show bgp vrf all json")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list bgp vrfs: %w", err)
+	}
+	vrfs, err := frr.ParseVRFs(res)
+	if err != nil {
+		return nil, err
+	}
+
+	var errs []error
+	neighbors := []*frr.Neighbor{}
+	for _, vrf := range vrfs {
+		res, err := c.frrCli(fmt.Sprintf("show bgp vrf %s neighbors json", vrf))
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to list bgp neighbors for vrf %s: %w", vrf, err))
+			continue
+		}
+		vrfNeighbors, err := frr.ParseNeighbours(res)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to parse bgp neighbors for vrf %s: %w", vrf, err))
+			continue
+		}
+		for _, n := range vrfNeighbors {
+			n.VRF = vrf
+		}
+		neighbors = append(neighbors, vrfNeighbors...)
+	}
+	return neighbors, errors.Join(errs...)
+}
+
+func (c *Collector) retrieveBFDPeers() ([]frr.BFDPeer, error) {
+	res, err := c.frrCli("show bfd peers json")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list bfd peers: %w", err)
+	}
+	return frr.ParseBFDPeers(res)
+}
+
+func updateNeighborMetrics(ch chan<- prometheus.Metric, n *frr.Neighbor) {
+	labels := []string{n.ID(), n.VRF}
+
+	up := 0.0
+	if n.Connected {
+		up = 1
+	}
+	ch <- prometheus.MustNewConstMetric(sessionUpDesc, prometheus.GaugeValue, up, labels...)
+	ch <- prometheus.MustNewConstMetric(prefixesSentDesc, prometheus.GaugeValue, float64(n.PrefixSent), labels...)
+	ch <- prometheus.MustNewConstMetric(prefixesReceivedDesc, prometheus.GaugeValue, float64(n.PrefixReceived), labels...)
+
+	counters := []struct {
+		desc  *prometheus.Desc
+		value int
+	}{
+		{opensSentDesc, n.MsgStats.OpensSent},
+		{opensReceivedDesc, n.MsgStats.OpensReceived},
+		{notificationsSentDesc, n.MsgStats.NotificationsSent},
+		{updatesSentDesc, n.MsgStats.UpdatesSent},
+		{updatesReceivedDesc, n.MsgStats.UpdatesReceived},
+		{keepalivesSentDesc, n.MsgStats.KeepalivesSent},
+		{keepalivesReceivedDesc, n.MsgStats.KeepalivesReceived},
+		{routeRefreshSentDesc, n.MsgStats.RouteRefreshSent},
+		{totalSentDesc, n.MsgStats.TotalSent},
+		{totalReceivedDesc, n.MsgStats.TotalReceived},
+	}
+	for _, c := range counters {
+		ch <- prometheus.MustNewConstMetric(c.desc, prometheus.CounterValue, float64(c.value), labels...)
+	}
+}
@@ -0,0 +1,200 @@
+// SPDX-License-Identifier:Apache-2.0
+
+package metrics
+
+import (
+	"fmt"
+	"io"
+	"log/slog"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const vrfs = `{
+  "default": {"vrfId": 0, "vrfName": "default"},
+  "red": {"vrfId": 5, "vrfName": "red"}
+}`
+
+const defaultNeighbors = `{
+  "192.168.11.2": {
+    "remoteAs": 64612,
+    "localAs": 64514,
+    "bgpState": "Established",
+    "messageStats": {
+      "opensSent": 1,
+      "opensRecv": 1,
+      "updatesSent": 4,
+      "updatesRecv": 5,
+      "keepalivesSent": 6,
+      "keepalivesRecv": 7,
+      "totalSent": 11,
+      "totalRecv": 13
+    },
+    "addressFamilyInfo": {
+      "l2VpnEvpn": {"sentPrefixCounter": 3, "acceptedPrefixCounter": 2}
+    }
+  }
+}`
+
+const redNeighbors = `{
+  "192.169.10.0": {
+    "remoteAs": 64515,
+    "localAs": 64514,
+    "bgpState": "Active",
+    "addressFamilyInfo": {
+      "ipv4Unicast": {"sentPrefixCounter": 0, "acceptedPrefixCounter": 0}
+    }
+  }
+}`
+
+const bfdPeers = `[
+  {"peer": "192.168.11.2", "local": "192.168.11.3", "vrf": "default", "interface": "toswitch", "status": "up"},
+  {"peer": "192.169.10.0", "local": "192.169.10.1", "vrf": "red", "interface": "pe-100", "status": "down"}
+]`
+
+func TestCollector(t *testing.T) {
+	tests := []struct {
+		name      string
+		responses map[string]string
+		failing   map[string]bool
+		expected  map[string]float64
+	}{
+		{
+			name: "all sessions",
+			responses: map[string]string{
+				"show bgp vrf all json":               vrfs,
+				"show bgp vrf default neighbors json": defaultNeighbors,
+				"show bgp vrf red neighbors json":     redNeighbors,
+				"show bfd peers json":                 bfdPeers,
+			},
+			expected: map[string]float64{
+				`openperouter_bgp_session_up{peer="192.168.11.2",vrf="default"}`:                                           1,
+				`openperouter_bgp_session_up{peer="192.169.10.0",vrf="red"}`:                                               0,
+				`openperouter_bgp_announced_prefixes{peer="192.168.11.2",vrf="default"}`:                                   3,
+				`openperouter_bgp_received_prefixes{peer="192.168.11.2",vrf="default"}`:                                    2,
+				`openperouter_bgp_updates_total_sent{peer="192.168.11.2",vrf="default"}`:                                   4,
+				`openperouter_bgp_updates_total_received{peer="192.168.11.2",vrf="default"}`:                               5,
+				`openperouter_bgp_total_sent{peer="192.168.11.2",vrf="default"}`:                                           11,
+				`openperouter_bgp_total_received{peer="192.168.11.2",vrf="default"}`:                                       13,
+				`openperouter_bfd_session_up{interface="toswitch",local="192.168.11.3",peer="192.168.11.2",vrf="default"}`: 1,
+				`openperouter_bfd_session_up{interface="pe-100",local="192.169.10.1",peer="192.169.10.0",vrf="red"}`:       0,
+				`openperouter_frr_poll_failures_total`:                                                                     0,
+			},
+		},
+		{
+			name: "one vrf failing",
+			responses: map[string]string{
+				"show bgp vrf all json":               vrfs,
+				"show bgp vrf default neighbors json": defaultNeighbors,
+				"show bfd peers json":                 "[]",
+			},
+			failing: map[string]bool{
+				"show bgp vrf red neighbors json": true,
+			},
+			expected: map[string]float64{
+				`openperouter_bgp_session_up{peer="192.168.11.2",vrf="default"}`: 1,
+				`openperouter_frr_poll_failures_total`:                           1,
+			},
+		},
+		{
+			name:      "frr not reachable",
+			responses: map[string]string{},
+			failing: map[string]bool{
+				"show bgp vrf all json": true,
+				"show bfd peers json":   true,
+			},
+			expected: map[string]float64{
+				`openperouter_frr_poll_failures_total`: 1,
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			cli := func(args string) (string, error) {
+				if tc.failing[args] {
+					return "", fmt.Errorf("failed to run %s", args)
+				}
+				return tc.responses[args], nil
+			}
+			c := NewCollector(cli, 0, slog.New(slog.NewTextHandler(io.Discard, nil)))
+			c.Poll()
+
+			registry := prometheus.NewPedanticRegistry()
+			registry.MustRegister(c)
+
+			got, err := gatherValues(registry)
+			if err != nil {
+				t.Fatalf("failed to gather metrics: %v", err)
+			}
+			for name, value := range tc.expected {
+				v, ok := got[name]
+				if !ok {
+					t.Errorf("metric %s not found, available: %v", name, sortedKeys(got))
+					continue
+				}
+				if v != value {
+					t.Errorf("metric %s: expected %v, got %v", name, value, v)
+				}
+			}
+
+			gotNames := map[string]bool{}
+			for name := range got {
+				if strings.HasPrefix(name, "openperouter_bgp_session_up") ||
+					strings.HasPrefix(name, "openperouter_bfd_session_up") {
+					gotNames[name] = true
+				}
+			}
+			expectedNames := map[string]bool{}
+			for name := range tc.expected {
+				if strings.HasPrefix(name, "openperouter_bgp_session_up") ||
+					strings.HasPrefix(name, "openperouter_bfd_session_up") {
+					expectedNames[name] = true
+				}
+			}
+			if !cmp.Equal(expectedNames, gotNames) {
+				t.Fatalf("unexpected sessions (-want +got)\n%s", cmp.Diff(expectedNames, gotNames))
+			}
+		})
+	}
+}
+
+func gatherValues(registry *prometheus.Registry) (map[string]float64, error) {
+	families, err := registry.Gather()
+	if err != nil {
+		return nil, err
+	}
+	res := map[string]float64{}
+	for _, f := range families {
+		for _, m := range f.GetMetric() {
+			labels := []string{}
+			for _, l := range m.GetLabel() {
+				labels = append(labels, fmt.Sprintf("%s=%q", l.GetName(), l.GetValue()))
+			}
+			name := f.GetName()
+			if len(labels) > 0 {
+				name = fmt.Sprintf("%s{%s}", name, strings.Join(labels, ","))
+			}
+			switch {
+			case m.GetGauge() != nil:
+				res[name] = m.GetGauge().GetValue()
+			case m.GetCounter() != nil:
+				res[name] = m.GetCounter().GetValue()
+			}
+		}
+	}
+	return res, nil
+}
+
+func sortedKeys(m map[string]float64) []string {
+	res := make([]string, 0, len(m))
+	for k := range m {
+		res = append(res, k)
+	}
+	sort.Strings(res)
+	return res
+}
@@ -6,16 +6,22 @@ import (
 	"encoding/json"
 	"fmt"
 	"net"
+	"slices"
 	"sort"
 	"strconv"
+	"strings"
 
 	"errors"
 )
 
 type Neighbor struct {
-	IP             net.IP
+	IP net.IP
+	// Interface is set for the neighbors established over an interface
+	// (bgp unnumbered), in which case IP is the peer link local address.
+	Interface      string
 	VRF            string
 	Connected      bool
+	State          string
 	LocalAS        string
 	RemoteAS       string
 	PrefixSent     int
@@ -23,6 +29,20 @@ type Neighbor struct {
 	Port           int
 	RemoteRouterID string
 	MsgStats       MessageStats
+
+	// InboundPrefixListFamilies are the address families, as named by FRR
+	// (e.g. ipv4Unicast), where the routes received from the neighbor are
+	// filtered by a prefix list.
+	InboundPrefixListFamilies []string
+}
+
+// ID returns the identifier the neighbor is configured with in FRR, which
+// is the interface name for unnumbered neighbors and the address otherwise.
+func (n *Neighbor) ID() string {
+	if n.Interface != "" {
+		return n.Interface
+	}
+	return n.IP.String()
 }
 
 type Route struct {
@@ -40,12 +60,14 @@ type FRRNeighbor struct {
 	RemoteRouterID    string       `json:"remoteRouterId"`
 	BgpVersion        int          `json:"bgpVersion"`
 	BgpState          string       `json:"bgpState"`
+	BgpNeighborAddr   string       `json:"bgpNeighborAddr"`
 	PortForeign       int          `json:"portForeign"`
 	MsgStats          MessageStats `json:"messageStats"`
 	VRFName           string       `json:"vrf"`
 	AddressFamilyInfo map[string]struct {
-		SentPrefixCounter     int `json:"sentPrefixCounter"`
-		AcceptedPrefixCounter int `json:"acceptedPrefixCounter"`
+		SentPrefixCounter              int    `json:"sentPrefixCounter"`
+		AcceptedPrefixCounter          int    `json:"acceptedPrefixCounter"`
+		IncomingUpdatePrefixFilterList string `json:"incomingUpdatePrefixFilterList"`
 	} `json:"addressFamilyInfo"`
 }
 
@@ -134,6 +156,7 @@ func ParseNeighbour(vtyshRes string) (*Neighbor, error) {
 		return &Neighbor{
 			IP:             ip,
 			Connected:      connected,
+			State:          n.BgpState,
 			LocalAS:        strconv.Itoa(n.LocalAs),
 			RemoteAS:       strconv.Itoa(n.RemoteAs),
 			PrefixSent:     prefixSent,
@@ -158,8 +181,11 @@ func ParseNeighbours(vtyshRes string) ([]*Neighbor, error) {
 	res := make([]*Neighbor, 0)
 	for k, n := range toParse {
 		ip := net.ParseIP(k)
+		iface := ""
 		if ip == nil {
-			return nil, fmt.Errorf("failed to parse %s as ip", ip)
+			// unnumbered neighbors are keyed by the interface name
+			iface = k
+			ip = net.ParseIP(n.BgpNeighborAddr)
 		}
 		connected := true
 		if n.BgpState != bgpConnected {
@@ -167,20 +193,28 @@ func ParseNeighbours(vtyshRes string) ([]*Neighbor, error) {
 		}
 		prefixSent := 0
 		prefixReceived := 0
-		for _, s := range n.AddressFamilyInfo {
+		var filteredFamilies []string
+		for family, s := range n.AddressFamilyInfo {
 			prefixSent += s.SentPrefixCounter
 			prefixReceived += s.AcceptedPrefixCounter
+			if s.IncomingUpdatePrefixFilterList != "" {
+				filteredFamilies = append(filteredFamilies, family)
+			}
 		}
+		sort.Strings(filteredFamilies)
 		res = append(res, &Neighbor{
-			IP:             ip,
-			Connected:      connected,
-			LocalAS:        strconv.Itoa(n.LocalAs),
-			RemoteAS:       strconv.Itoa(n.RemoteAs),
-			PrefixSent:     prefixSent,
-			PrefixReceived: prefixReceived,
-			Port:           n.PortForeign,
-			RemoteRouterID: n.RemoteRouterID,
-			MsgStats:       n.MsgStats,
+			IP:                        ip,
+			Interface:                 iface,
+			Connected:                 connected,
+			State:                     n.BgpState,
+			LocalAS:                   strconv.Itoa(n.LocalAs),
+			RemoteAS:                  strconv.Itoa(n.RemoteAs),
+			PrefixSent:                prefixSent,
+			PrefixReceived:            prefixReceived,
+			InboundPrefixListFamilies: filteredFamilies,
+			Port:                      n.PortForeign,
+			RemoteRouterID:            n.RemoteRouterID,
+			MsgStats:                  n.MsgStats,
 		})
 	}
 	return res, nil
@@ -240,6 +274,21 @@ func ParseBFDPeers(vtyshRes string) ([]BFDPeer, error) {
 	return parseRes, nil
 }
 
+// ParseFilteredPrefixCount takes the result of a show bgp neighbor x.y.w.z
+// received-routes json and returns the number of received prefixes rejected
+// by the inbound filters. It requires soft reconfiguration inbound to be
+// enabled on the neighbor.
+func ParseFilteredPrefixCount(vtyshRes string) (int, error) {
+	parseRes := struct {
+		FilteredPrefixCounter int `json:"filteredPrefixCounter"`
+	}{}
+	err := json.Unmarshal([]byte(vtyshRes), &parseRes)
+	if err != nil {
+		return 0, errors.Join(err, errors.New("failed to parse vtysh response"))
+	}
+	return parseRes.FilteredPrefixCounter, nil
+}
+
 func ParseVRFs(vtyshRes string) ([]string, error) {
 	vrfs := map[string]any{}
 	err := json.Unmarshal([]byte(vtyshRes), &vrfs)
@@ -253,3 +302,128 @@ func ParseVRFs(vtyshRes string) ([]string, error) {
 	sort.Strings(res)
 	return res, nil
 }
+
+// EVPNVNI is a VNI known by the BGP EVPN address family.
+type EVPNVNI struct {
+	VNI          int      `json:"vni"`
+	Type         string   `json:"type"`
+	VRF          string   `json:"tenantVrf"`
+	OriginatorIP string   `json:"originatorIp"`
+	ImportRTs    []string `json:"importRTs"`
+}
+
+const (
+	EVPNVNITypeL2 = "L2"
+	EVPNVNITypeL3 = "L3"
+)
+
+// ParseEVPNVNIs takes the result of a show bgp l2vpn evpn vni json
+// and parses the informations related to all the vnis.
+func ParseEVPNVNIs(vtyshRes string) ([]EVPNVNI, error) {
+	toParse := map[string]json.RawMessage{}
+	err := json.Unmarshal([]byte(vtyshRes), &toParse)
+	if err != nil {
+		return nil, errors.Join(err, errors.New("failed to parse vtysh response"))
+	}
+
+	res := make([]EVPNVNI, 0)
+	for k, v := range toParse {
+		// the vnis are keyed by their number, mixed with the global evpn settings
+		if _, err := strconv.Atoi(k); err != nil {
+			continue
+		}
+		var vni EVPNVNI
+		if err := json.Unmarshal(v, &vni); err != nil {
+			return nil, errors.Join(err, fmt.Errorf("failed to parse vni %s", k))
+		}
+		res = append(res, vni)
+	}
+	sort.Slice(res, func(i, j int) bool {
+		return res[i].VNI < res[j].VNI
+	})
+	return res, nil
+}
+
+// EVPNRoute is a route of the global BGP EVPN table.
+type EVPNRoute struct {
+	RD     string
+	Prefix string
+	Type   int
+	// Received is true when the route is learned from a peer
+	// and not originated locally.
+	Received     bool
+	RouteTargets []string
+}
+
+type frrEVPNPrefix struct {
+	Paths []frrEVPNPath `json:"paths"`
+}
+
+type frrEVPNPath struct {
+	RouteType         int    `json:"routeType"`
+	PeerID            string `json:"peerId"`
+	ExtendedCommunity struct {
+		String string `json:"string"`
+	} `json:"extendedCommunity"`
+}
+
+// localPeerID is the peer id FRR shows for the locally originated paths.
+const localPeerID = "(unspec)"
+
+// ParseEVPNRoutes takes the result of a show bgp l2vpn evpn json
+// and parses the informations related to all the routes.
+func ParseEVPNRoutes(vtyshRes string) ([]EVPNRoute, error) {
+	toParse := map[string]json.RawMessage{}
+	err := json.Unmarshal([]byte(vtyshRes), &toParse)
+	if err != nil {
+		return nil, errors.Join(err, errors.New("failed to parse vtysh response"))
+	}
+
+	res := make([]EVPNRoute, 0)
+	for rd, v := range toParse {
+		// the route distinguishers are mixed with the table counters
+		if !strings.Contains(rd, ":") {
+			continue
+		}
+		prefixes := map[string]json.RawMessage{}
+		if err := json.Unmarshal(v, &prefixes); err != nil {
+			return nil, errors.Join(err, fmt.Errorf("failed to parse routes for rd %s", rd))
+		}
+		for p, v := range prefixes {
+			if !strings.HasPrefix(p, "[") {
+				continue
+			}
+			var prefix frrEVPNPrefix
+			if err := json.Unmarshal(v, &prefix); err != nil {
+				return nil, errors.Join(err, fmt.Errorf("failed to parse route %s", p))
+			}
+			if len(prefix.Paths) == 0 {
+				continue
+			}
+			route := EVPNRoute{
+				RD:     rd,
+				Prefix: p,
+				Type:   prefix.Paths[0].RouteType,
+			}
+			for _, path := range prefix.Paths {
+				if path.PeerID != "" && path.PeerID != localPeerID {
+					route.Received = true
+				}
+				for _, c := range strings.Fields(path.ExtendedCommunity.String) {
+					rt, ok := strings.CutPrefix(c, "RT:")
+					if ok && !slices.Contains(route.RouteTargets, rt) {
+						route.RouteTargets = append(route.RouteTargets, rt)
+					}
+				}
+			}
+			res = append(res, route)
+		}
+	}
+	sort.Slice(res, func(i, j int) bool {
+		if res[i].RD != res[j].RD {
+			return res[i].RD < res[j].RD
+		}
+		return res[i].Prefix < res[j].Prefix
+	})
+	return res, nil
+}
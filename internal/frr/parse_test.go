@@ -537,6 +537,36 @@ func TestNeighbours(t *testing.T) {
 	}
 }
 
+func TestNeighboursInboundPrefixListFamilies(t *testing.T) {
+	nn, err := ParseNeighbours(`{
+  "192.169.10.2":{"remoteAs":64515,"localAs":64514,"bgpState":"Established",
+    "addressFamilyInfo":{
+      "ipv6Unicast":{"acceptedPrefixCounter":0,"incomingUpdatePrefixFilterList":"allowed-ipv6"},
+      "ipv4Unicast":{"acceptedPrefixCounter":2,"incomingUpdatePrefixFilterList":"allowed-ipv4"},
+      "l2VpnEvpn":{"acceptedPrefixCounter":1}}}
+}`)
+	if err != nil {
+		t.Fatalf("Failed to parse %s", err)
+	}
+	if len(nn) != 1 {
+		t.Fatalf("Expected 1 neighbour, got %d", len(nn))
+	}
+	want := []string{"ipv4Unicast", "ipv6Unicast"}
+	if !cmp.Equal(want, nn[0].InboundPrefixListFamilies) {
+		t.Fatal("unexpected inbound prefix list families (-want +got)\n", cmp.Diff(want, nn[0].InboundPrefixListFamilies))
+	}
+}
+
+func TestFilteredPrefixCount(t *testing.T) {
+	count, err := ParseFilteredPrefixCount(`{"bgpTableVersion":3,"totalPrefixCounter":5,"filteredPrefixCounter":3}`)
+	if err != nil {
+		t.Fatalf("Failed to parse %s", err)
+	}
+	if count != 3 {
+		t.Fatalf("Expected 3 filtered prefixes, got %d", count)
+	}
+}
+
 const routes = `{
   "vrfId": 0,
   "vrfName": "default",
@@ -800,3 +830,156 @@ func TestVRFs(t *testing.T) {
 		t.Fatalf("unexpected vrf list: %s", cmp.Diff(parsed, expected))
 	}
 }
+
+const unnumberedNeighbours = `{
+  "eth1":{
+    "bgpNeighborAddr":"fe80::dc96:41ff:fe6e:1f7b",
+    "remoteAs":64612,
+    "localAs":64512,
+    "bgpState":"Established",
+    "portForeign":179
+  },
+  "192.168.11.2":{
+    "remoteAs":64612,
+    "localAs":64512,
+    "bgpState":"Active"
+  }
+}`
+
+func TestUnnumberedNeighbours(t *testing.T) {
+	nn, err := ParseNeighbours(unnumberedNeighbours)
+	if err != nil {
+		t.Fatalf("Failed to parse %s", err)
+	}
+	sort.Slice(nn, func(i, j int) bool {
+		return nn[i].ID() < nn[j].ID()
+	})
+	if len(nn) != 2 {
+		t.Fatalf("Expected 2 neighbours, got %d", len(nn))
+	}
+	if nn[0].ID() != "192.168.11.2" || nn[0].Connected || nn[0].State != "Active" {
+		t.Fatalf("unexpected neighbour %+v", nn[0])
+	}
+	if nn[1].ID() != "eth1" || !nn[1].IP.Equal(net.ParseIP("fe80::dc96:41ff:fe6e:1f7b")) || !nn[1].Connected {
+		t.Fatalf("unexpected unnumbered neighbour %+v", nn[1])
+	}
+}
+
+const evpnVNIs = `{
+  "advertiseGatewayMacip":"Disabled",
+  "advertiseSviMacIp":"Disabled",
+  "advertiseAllVnis":"Enabled",
+  "flooding":"Head-end replication",
+  "numVnis":2,
+  "numL2Vnis":1,
+  "numL3Vnis":1,
+  "110":{
+    "vni":110,
+    "type":"L2",
+    "inKernel":"True",
+    "rd":"10.0.0.1:3",
+    "originatorIp":"100.65.0.1",
+    "tenantVrf":"red",
+    "importRTs":["64514:110"],
+    "exportRTs":["64514:110"]
+  },
+  "100":{
+    "vni":100,
+    "type":"L3",
+    "inKernel":"True",
+    "rd":"10.0.0.1:2",
+    "originatorIp":"100.65.0.1",
+    "tenantVrf":"red",
+    "importRTs":["64514:100"],
+    "exportRTs":["64514:100"]
+  }
+}`
+
+func TestEVPNVNIs(t *testing.T) {
+	parsed, err := ParseEVPNVNIs(evpnVNIs)
+	if err != nil {
+		t.Fatalf("Failed to parse %s", err)
+	}
+	expected := []EVPNVNI{
+		{VNI: 100, Type: EVPNVNITypeL3, VRF: "red", OriginatorIP: "100.65.0.1", ImportRTs: []string{"64514:100"}},
+		{VNI: 110, Type: EVPNVNITypeL2, VRF: "red", OriginatorIP: "100.65.0.1", ImportRTs: []string{"64514:110"}},
+	}
+	if !cmp.Equal(parsed, expected) {
+		t.Fatalf("unexpected vni list: %s", cmp.Diff(expected, parsed))
+	}
+}
+
+const evpnRoutes = `{
+  "bgpTableVersion":4,
+  "bgpLocalRouterId":"10.0.0.1",
+  "defaultLocPrf":100,
+  "localAS":64514,
+  "10.0.0.1:2":{
+    "rd":"10.0.0.1:2",
+    "[5]:[0]:[24]:[192.169.10.0]":{
+      "prefix":"[5]:[0]:[24]:[192.169.10.0]",
+      "prefixLen":352,
+      "paths":[
+        {
+          "valid":true,
+          "bestpath":true,
+          "routeType":5,
+          "peerId":"(unspec)",
+          "extendedCommunity":{"string":"RT:64514:100 ET:8 Rmac:aa:bb:cc:dd:ee:ff"}
+        }
+      ]
+    }
+  },
+  "10.0.0.2:2":{
+    "rd":"10.0.0.2:2",
+    "[5]:[0]:[24]:[192.170.10.0]":{
+      "prefix":"[5]:[0]:[24]:[192.170.10.0]",
+      "prefixLen":352,
+      "paths":[
+        {
+          "valid":true,
+          "routeType":5,
+          "peerId":"192.168.11.2",
+          "extendedCommunity":{"string":"RT:64514:100 ET:8 Rmac:aa:bb:cc:dd:ee:00"}
+        },
+        {
+          "valid":true,
+          "bestpath":true,
+          "routeType":5,
+          "peerId":"192.168.11.3",
+          "extendedCommunity":{"string":"RT:64514:100 ET:8 Rmac:aa:bb:cc:dd:ee:00"}
+        }
+      ]
+    },
+    "[2]:[0]:[48]:[aa:bb:cc:dd:ee:11]:[32]:[192.171.10.2]":{
+      "prefix":"[2]:[0]:[48]:[aa:bb:cc:dd:ee:11]:[32]:[192.171.10.2]",
+      "prefixLen":352,
+      "paths":[
+        {
+          "valid":true,
+          "bestpath":true,
+          "routeType":2,
+          "peerId":"192.168.11.2",
+          "extendedCommunity":{"string":"RT:64514:110 RT:64514:100 ET:8"}
+        }
+      ]
+    }
+  },
+  "numPrefix":3,
+  "totalPrefix":3
+}`
+
+func TestEVPNRoutes(t *testing.T) {
+	parsed, err := ParseEVPNRoutes(evpnRoutes)
+	if err != nil {
+		t.Fatalf("Failed to parse %s", err)
+	}
+	expected := []EVPNRoute{
+		{RD: "10.0.0.1:2", Prefix: "[5]:[0]:[24]:[192.169.10.0]", Type: 5, RouteTargets: []string{"64514:100"}},
+		{RD: "10.0.0.2:2", Prefix: "[2]:[0]:[48]:[aa:bb:cc:dd:ee:11]:[32]:[192.171.10.2]", Type: 2, Received: true, RouteTargets: []string{"64514:110", "64514:100"}},
+		{RD: "10.0.0.2:2", Prefix: "[5]:[0]:[24]:[192.170.10.0]", Type: 5, Received: true, RouteTargets: []string{"64514:100"}},
+	}
+	if !cmp.Equal(parsed, expected) {
+		t.Fatalf("unexpected route list: %s", cmp.Diff(expected, parsed))
+	}
+}
@@ -0,0 +1,102 @@
+// SPDX-License-Identifier:Apache-2.0
+
+package frrconfig
+
+import (
+	"bufio"
+	"strings"
+)
+
+// ConfigChange is a single line added to or removed from the running
+// configuration, together with the configuration context it belongs to
+// (for example ["router bgp 64514", "address-family ipv4 unicast"]).
+type ConfigChange struct {
+	Context []string `json:"context,omitempty"`
+	Line    string   `json:"line"`
+}
+
+// ConfigDiff is the change set between the running configuration and a new
+// one, as computed by frr-reload.
+type ConfigDiff struct {
+	Added   []ConfigChange `json:"added,omitempty"`
+	Removed []ConfigChange `json:"removed,omitempty"`
+}
+
+// IsEmpty tells if the new configuration matches the running one.
+func (d ConfigDiff) IsEmpty() bool {
+	return len(d.Added) == 0 && len(d.Removed) == 0
+}
+
+const (
+	linesToDeleteHeader = "Lines To Delete"
+	linesToAddHeader    = "Lines To Add"
+)
+
+// parseReloadTestOutput parses the output of frr-reload in test mode, which
+// lists the commands needed to move from the running configuration to the
+// new one. Each command is printed after the context lines it belongs to,
+// indented by one space per level.
+func parseReloadTestOutput(output string) ConfigDiff {
+	var (
+		res     ConfigDiff
+		section *[]ConfigChange
+		removed bool
+		pending []string
+	)
+	flush := func() {
+		if section == nil || len(pending) == 0 {
+			pending = nil
+			return
+		}
+		line := pending[len(pending)-1]
+		if removed {
+			line = undoNegation(line)
+		}
+		change := ConfigChange{Line: line}
+		if len(pending) > 1 {
+			change.Context = pending[:len(pending)-1]
+		}
+		*section = append(*section, change)
+		pending = nil
+	}
+
+	scanner := bufio.NewScanner(strings.NewReader(output))
+	for scanner.Scan() {
+		raw := scanner.Text()
+		trimmed := strings.TrimSpace(raw)
+		switch {
+		case trimmed == linesToDeleteHeader:
+			flush()
+			section, removed = &res.Removed, true
+			continue
+		case trimmed == linesToAddHeader:
+			flush()
+			section, removed = &res.Added, false
+			continue
+		case trimmed == "" || strings.Trim(trimmed, "=") == "":
+			flush()
+			continue
+		}
+
+		// A line not indented deeper than the previous one starts a new
+		// change: the previous lines were all the context of the last one.
+		level := len(raw) - len(strings.TrimLeft(raw, " "))
+		if level < len(pending) {
+			previous := pending[:level]
+			flush()
+			pending = append([]string{}, previous...)
+		}
+		pending = append(pending, trimmed)
+	}
+	flush()
+	return res
+}
+
+// undoNegation returns the line removed from the running configuration
+// out of the command frr-reload runs to remove it.
+func undoNegation(line string) string {
+	if negated, ok := strings.CutPrefix(line, "no "); ok {
+		return negated
+	}
+	return "no " + line
+}
@@ -0,0 +1,71 @@
+// SPDX-License-Identifier:Apache-2.0
+
+package frrconfig
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestParseReloadTestOutput(t *testing.T) {
+	tests := []struct {
+		name   string
+		output string
+		want   ConfigDiff
+	}{
+		{
+			name:   "no changes",
+			output: "\n",
+			want:   ConfigDiff{},
+		},
+		{
+			name: "nested changes",
+			output: `
+Lines To Delete
+===============
+router bgp 64514
+ no neighbor 192.168.11.2 remote-as 64512
+router bgp 64514
+ address-family ipv4 unicast
+  neighbor 192.168.11.2 activate
+no vrf red
+
+Lines To Add
+============
+router bgp 64514
+ neighbor 192.168.11.3 remote-as 64512
+router bgp 64514
+ address-family ipv4 unicast
+  neighbor 192.168.11.3 activate
+  neighbor 192.168.11.3 route-map out-map out
+vrf blue
+ vni 200
+ip nht resolve-via-default
+`,
+			want: ConfigDiff{
+				Removed: []ConfigChange{
+					{Context: []string{"router bgp 64514"}, Line: "neighbor 192.168.11.2 remote-as 64512"},
+					{Context: []string{"router bgp 64514", "address-family ipv4 unicast"}, Line: "no neighbor 192.168.11.2 activate"},
+					{Line: "vrf red"},
+				},
+				Added: []ConfigChange{
+					{Context: []string{"router bgp 64514"}, Line: "neighbor 192.168.11.3 remote-as 64512"},
+					{Context: []string{"router bgp 64514", "address-family ipv4 unicast"}, Line: "neighbor 192.168.11.3 activate"},
+					{Context: []string{"router bgp 64514", "address-family ipv4 unicast"}, Line: "neighbor 192.168.11.3 route-map out-map out"},
+					{Context: []string{"vrf blue"}, Line: "vni 200"},
+					{Line: "ip nht resolve-via-default"},
+				},
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got := parseReloadTestOutput(tc.output)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("unexpected diff (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
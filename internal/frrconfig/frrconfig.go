@@ -3,6 +3,7 @@
 package frrconfig
 
 import (
+	"errors"
 	"fmt"
 	"log/slog"
 	"os/exec"
@@ -16,30 +17,42 @@ const (
 	reloaderPath        = "/usr/lib/frr/frr-reload.py"
 )
 
-// Update reloads the frr configuration at the given path.
-func Update(path string) error {
+// ErrInvalidConfig is returned by Update when frr-reload rejects the
+// configuration, in which case the running configuration is left untouched.
+var ErrInvalidConfig = errors.New("invalid frr configuration")
+
+// Update reloads the frr configuration at the given path, and returns the
+// changes applied to the running configuration. The reload is skipped when
+// the new configuration matches the running one.
+func Update(path string) (ConfigDiff, error) {
 	slog.Info("config update", "path", path)
-	err := reloadAction(path, Test)
+	output, err := reloadAction(path, Test)
 	if err != nil {
-		return err
+		return ConfigDiff{}, fmt.Errorf("%w: %w", ErrInvalidConfig, err)
+	}
+	diff := parseReloadTestOutput(output)
+	if diff.IsEmpty() {
+		slog.Info("config update", "event", "no changes, skipping reload")
+		return diff, nil
 	}
-	err = reloadAction(path, Reload)
+	slog.Info("config update", "event", "applying changes", "added", diff.Added, "removed", diff.Removed)
+	_, err = reloadAction(path, Reload)
 	if err != nil {
-		return err
+		return ConfigDiff{}, err
 	}
-	return nil
+	return diff, nil
 }
 
 var execCommand = exec.Command
 
-func reloadAction(path string, action Action) error {
+func reloadAction(path string, action Action) (string, error) {
 	reloadParameter := "--" + string(action)
 	cmd := execCommand("python3", reloaderPath, reloadParameter, "--logfile", "/dev/null", path)
 	output, err := cmd.CombinedOutput()
 	if err != nil {
 		slog.Error("frr update failed", "action", action, "error", err, "output", string(output))
-		return fmt.Errorf("frr update %s failed: %w", action, err)
+		return "", fmt.Errorf("frr update %s failed: %w", action, err)
 	}
 	slog.Debug("frr update succeeded", "action", action, "output", string(output))
-	return nil
+	return string(output), nil
 }
@@ -11,21 +11,43 @@ import (
 	"testing"
 )
 
+const changedOutput = `
+Lines To Delete
+===============
+router bgp 64514
+ no neighbor 192.168.11.2 remote-as 64512
+
+Lines To Add
+============
+router bgp 64514
+ neighbor 192.168.11.3 remote-as 64512
+`
+
 var tests = map[string]struct {
 	failValidate bool
 	failReload   bool
+	testOutput   string
 }{
 	"/tmp/shouldPass": {
 		failValidate: false,
 		failReload:   false,
+		testOutput:   changedOutput,
+	},
+	"/tmp/unchanged": {
+		failValidate: false,
+		// the reload must be skipped
+		failReload: true,
+		testOutput: "",
 	},
 	"/tmp/failValidate": {
 		failValidate: true,
 		failReload:   false,
+		testOutput:   changedOutput,
 	},
 	"/tmp/failReload": {
 		failValidate: false,
 		failReload:   true,
+		testOutput:   changedOutput,
 	},
 }
 
@@ -35,7 +57,14 @@ func TestReload(t *testing.T) {
 
 	for tc, params := range tests {
 		t.Run(fmt.Sprintf("reload %s", tc), func(t *testing.T) {
-			err := Update(tc)
+			diff, err := Update(tc)
+			unchanged := params.testOutput == ""
+			if unchanged {
+				if err != nil || !diff.IsEmpty() {
+					t.Fatalf("expecting an empty diff and no error, got %v, %v", diff, err)
+				}
+				return
+			}
 			if (params.failReload || params.failValidate) && err == nil {
 				t.Fatalf("expecting failure, got no error")
 			}
@@ -48,6 +77,9 @@ func TestReload(t *testing.T) {
 			if !params.failReload && !params.failValidate && err != nil {
 				t.Fatalf("expecting no error, got %v", err)
 			}
+			if err == nil && (len(diff.Added) != 1 || len(diff.Removed) != 1) {
+				t.Fatalf("expecting one added and one removed line, got %v", diff)
+			}
 		})
 	}
 }
@@ -108,6 +140,9 @@ func TestFakeReloadHelper(t *testing.T) {
 		fmt.Println("test failed")
 		os.Exit(1)
 	}
+	if action == string(Test) {
+		fmt.Print(params.testOutput)
+	}
 
 	os.Exit(0)
 }
@@ -4,13 +4,43 @@ package frrconfig
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"log/slog"
 	"net"
 	"net/http"
 	"os"
+	"time"
 )
 
+// ReloadResult is the reloader answer to a reload request.
+type ReloadResult struct {
+	// Reloaded tells if FRR was reloaded, which is not the case when the
+	// new configuration matches the running one.
+	Reloaded  bool       `json:"reloaded"`
+	Diff      ConfigDiff `json:"diff"`
+	Timestamp time.Time  `json:"timestamp"`
+	// Error is the reason the configuration could not be applied.
+	Error string `json:"error,omitempty"`
+	// RolledBack tells if the last known good configuration was restored
+	// after the configuration failed to apply.
+	RolledBack bool `json:"rolledBack,omitempty"`
+}
+
+// ReloadError is returned by the updater when the reloader failed to
+// apply the configuration.
+type ReloadError struct {
+	Message    string
+	RolledBack bool
+}
+
+func (e *ReloadError) Error() string {
+	if e.RolledBack {
+		return e.Message + ", rolled back to the last known good configuration"
+	}
+	return e.Message
+}
+
 func UpdaterForSocket(socketPath, configFile string) func(context.Context, string) error {
 	return func(ctx context.Context, config string) error {
 		updaterClient := func(ctx context.Context) error {
@@ -34,9 +64,25 @@ func UpdaterForSocket(socketPath, configFile string) func(context.Context, strin
 					slog.ErrorContext(ctx, "failed to close res body", "error", err)
 				}
 			}()
+			var result ReloadResult
+			decodeErr := json.NewDecoder(res.Body).Decode(&result)
 			if res.StatusCode != http.StatusOK {
+				if decodeErr == nil && result.Error != "" {
+					return &ReloadError{Message: result.Error, RolledBack: result.RolledBack}
+				}
 				return fmt.Errorf("failed to reload against socket %s, status %d", socketPath, res.StatusCode)
 			}
+			if decodeErr != nil {
+				slog.WarnContext(ctx, "failed to decode the reload result", "error", decodeErr)
+				return nil
+			}
+			if !result.Reloaded {
+				slog.InfoContext(ctx, "updater frr configuration unchanged, reload skipped")
+				return nil
+			}
+			slog.InfoContext(ctx, "updater frr configuration changed", "added", len(result.Diff.Added),
+				"removed", len(result.Diff.Removed))
+			slog.DebugContext(ctx, "updater frr configuration diff", "diff", result.Diff)
 			return nil
 		}
 
@@ -41,6 +41,7 @@ func TestUpdaterForSocket(t *testing.T) {
 			t.Errorf("expected POST request, got %s", r.Method)
 		}
 		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"reloaded":true,"diff":{"added":[{"line":"test config"}]}}`))
 	})
 
 	server := &http.Server{Handler: handler}
@@ -0,0 +1,271 @@
+// SPDX-License-Identifier:Apache-2.0
+
+package grout
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"slices"
+	"testing"
+)
+
+// fakeGrcli is a stand-in for grcli that keeps the state of a grout
+// instance in memory, so that the commands issued by the client can be
+// verified against the resulting interfaces and addresses rather than
+// against their exact sequence.
+type fakeGrcli struct {
+	socket     string
+	interfaces []*fakeInterface
+	addresses  map[string][]string
+}
+
+type fakeInterface struct {
+	Name  string            `json:"name"`
+	Type  string            `json:"type"`
+	Attrs map[string]string `json:"-"`
+	Up    bool              `json:"-"`
+}
+
+var errCommandFailed = errors.New("exit status 1")
+
+// newFakeGrcli replaces execCmd with a fake grcli serving the given socket,
+// with only the main VRF configured.
+func newFakeGrcli(t *testing.T, socket string) *fakeGrcli {
+	t.Helper()
+	f := &fakeGrcli{
+		socket:    socket,
+		addresses: map[string][]string{},
+	}
+	f.add(&fakeInterface{Name: mainVRF, Type: interfaceTypeVRF, Attrs: map[string]string{}})
+
+	original := execCmd
+	execCmd = f.exec
+	t.Cleanup(func() {
+		execCmd = original
+	})
+	return f
+}
+
+func (f *fakeGrcli) exec(_ context.Context, name string, args ...string) ([]byte, error) {
+	prefix := []string{"--err-exit", "--json", "--socket", f.socket}
+	if name != "grcli" || len(args) < len(prefix)+2 || !slices.Equal(args[:len(prefix)], prefix) {
+		return nil, fmt.Errorf("unexpected command: %s %v", name, args)
+	}
+	args = args[len(prefix):]
+
+	var (
+		out any
+		err error
+	)
+	switch args[0] + " " + args[1] {
+	case "interface show":
+		out, err = f.interfaceShow(args[2:])
+	case "interface add":
+		err = f.interfaceAdd(args[2:])
+	case "interface set":
+		err = f.interfaceSet(args[2:])
+	case "interface del":
+		err = f.interfaceDel(args[2:])
+	case "address show":
+		out, err = f.addressShow(args[2:])
+	case "address add":
+		err = f.addressAdd(args[2:])
+	case "address del":
+		err = f.addressDel(args[2:])
+	default:
+		err = fmt.Errorf("unknown command %v", args)
+	}
+	if err != nil {
+		return []byte("error: command failed: " + err.Error()), errCommandFailed
+	}
+	if out == nil {
+		return nil, nil
+	}
+	res, err := json.Marshal(out)
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+func (f *fakeGrcli) interfaceShow(args []string) (any, error) {
+	if len(args) == 0 {
+		return f.interfaces, nil
+	}
+	if len(args) != 2 || args[0] != "name" {
+		return nil, fmt.Errorf("invalid arguments %v", args)
+	}
+	iface := f.get(args[1])
+	if iface == nil {
+		return nil, errors.New("No such device (ENODEV)")
+	}
+	return iface, nil
+}
+
+func (f *fakeGrcli) interfaceAdd(args []string) error {
+	if len(args) < 2 {
+		return fmt.Errorf("invalid arguments %v", args)
+	}
+	if f.get(args[1]) != nil {
+		return errors.New("File exists (EEXIST)")
+	}
+	iface := &fakeInterface{Name: args[1], Type: args[0], Attrs: map[string]string{}}
+	if err := f.setAttrs(iface, args[2:]); err != nil {
+		return err
+	}
+	if iface.Type == interfaceTypePort && iface.Attrs["devargs"] == "" {
+		return errors.New("devargs is required (EINVAL)")
+	}
+	f.add(iface)
+	return nil
+}
+
+func (f *fakeGrcli) interfaceSet(args []string) error {
+	if len(args) < 2 {
+		return fmt.Errorf("invalid arguments %v", args)
+	}
+	iface := f.get(args[1])
+	if iface == nil {
+		return errors.New("No such device (ENODEV)")
+	}
+	if iface.Type != args[0] {
+		return fmt.Errorf("interface %s is a %s, not a %s (EMEDIUMTYPE)", iface.Name, iface.Type, args[0])
+	}
+	return f.setAttrs(iface, args[2:])
+}
+
+func (f *fakeGrcli) interfaceDel(args []string) error {
+	if len(args) != 1 {
+		return fmt.Errorf("invalid arguments %v", args)
+	}
+	iface := f.get(args[0])
+	if iface == nil {
+		return errors.New("No such device (ENODEV)")
+	}
+	for _, other := range f.interfaces {
+		if other.Attrs["vrf"] == iface.Name || other.Attrs["domain"] == iface.Name {
+			return errors.New("Device or resource busy (EBUSY)")
+		}
+	}
+	f.interfaces = slices.DeleteFunc(f.interfaces, func(i *fakeInterface) bool {
+		return i == iface
+	})
+	delete(f.addresses, iface.Name)
+	return nil
+}
+
+// setAttrs applies the key value attributes to the interface, checking that
+// the VRFs and bridge domains they reference exist. A port can either route
+// in a VRF or be plugged into a bridge domain.
+func (f *fakeGrcli) setAttrs(iface *fakeInterface, args []string) error {
+	for i := 0; i < len(args); i++ {
+		if args[i] == "up" {
+			iface.Up = true
+			continue
+		}
+		if i+1 >= len(args) {
+			return fmt.Errorf("missing value for %s (EINVAL)", args[i])
+		}
+		key, value := args[i], args[i+1]
+		i++
+		switch key {
+		case "vrf", "encap_vrf":
+			if ref := f.get(value); ref == nil || ref.Type != interfaceTypeVRF {
+				return fmt.Errorf("vrf %s: No such device (ENODEV)", value)
+			}
+			if key == "vrf" {
+				delete(iface.Attrs, "domain")
+			}
+		case "domain":
+			if ref := f.get(value); ref == nil || ref.Type != interfaceTypeBridge {
+				return fmt.Errorf("bridge %s: No such device (ENODEV)", value)
+			}
+			delete(iface.Attrs, "vrf")
+		case "mac":
+			if _, err := net.ParseMAC(value); err != nil {
+				return fmt.Errorf("invalid mac %s (EINVAL)", value)
+			}
+		case "local":
+			if net.ParseIP(value) == nil {
+				return fmt.Errorf("invalid local address %s (EINVAL)", value)
+			}
+		}
+		iface.Attrs[key] = value
+	}
+	return nil
+}
+
+func (f *fakeGrcli) addressShow(args []string) (any, error) {
+	if len(args) != 2 || args[0] != "iface" {
+		return nil, fmt.Errorf("invalid arguments %v", args)
+	}
+	if f.get(args[1]) == nil {
+		return nil, errors.New("No such device (ENODEV)")
+	}
+	res := []groutAddress{}
+	for _, addr := range f.addresses[args[1]] {
+		family := "ipv4"
+		if ip, _, _ := net.ParseCIDR(addr); ip.To4() == nil {
+			family = "ipv6"
+		}
+		res = append(res, groutAddress{Iface: args[1], Family: family, Address: addr})
+	}
+	return res, nil
+}
+
+func (f *fakeGrcli) addressAdd(args []string) error {
+	if len(args) != 3 || args[1] != "iface" {
+		return fmt.Errorf("invalid arguments %v", args)
+	}
+	if _, _, err := net.ParseCIDR(args[0]); err != nil {
+		return fmt.Errorf("invalid address %s (EINVAL)", args[0])
+	}
+	if f.get(args[2]) == nil {
+		return errors.New("No such device (ENODEV)")
+	}
+	if slices.Contains(f.addresses[args[2]], args[0]) {
+		return errors.New("address already exists (EEXIST)")
+	}
+	f.addresses[args[2]] = append(f.addresses[args[2]], args[0])
+	return nil
+}
+
+func (f *fakeGrcli) addressDel(args []string) error {
+	if len(args) != 3 || args[1] != "iface" {
+		return fmt.Errorf("invalid arguments %v", args)
+	}
+	if !slices.Contains(f.addresses[args[2]], args[0]) {
+		return errors.New("No such address (ENOENT)")
+	}
+	f.addresses[args[2]] = slices.DeleteFunc(f.addresses[args[2]], func(a string) bool {
+		return a == args[0]
+	})
+	return nil
+}
+
+func (f *fakeGrcli) add(iface *fakeInterface) {
+	f.interfaces = append(f.interfaces, iface)
+}
+
+func (f *fakeGrcli) get(name string) *fakeInterface {
+	for _, iface := range f.interfaces {
+		if iface.Name == name {
+			return iface
+		}
+	}
+	return nil
+}
+
+// names returns the names of the interfaces of the given type.
+func (f *fakeGrcli) names(ifaceType string) []string {
+	var res []string
+	for _, iface := range f.interfaces {
+		if iface.Type == ifaceType {
+			res = append(res, iface.Name)
+		}
+	}
+	return res
+}
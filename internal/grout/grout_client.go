@@ -26,6 +26,30 @@ type groutAddress struct {
 
 type groutInterface struct {
 	Name string `json:"name"`
+	Type string `json:"type"`
+}
+
+// Grout interface types, as reported by interface show.
+const (
+	interfaceTypePort   = "port"
+	interfaceTypeVRF    = "vrf"
+	interfaceTypeBridge = "bridge"
+	interfaceTypeVXLAN  = "vxlan"
+)
+
+// portAttachment tells where a grout port is plugged: a VRF for routed
+// ports, or a bridge domain for L2 ports.
+type portAttachment struct {
+	kind string
+	name string
+}
+
+func inVRF(vrf string) portAttachment {
+	return portAttachment{kind: "vrf", name: vrf}
+}
+
+func inBridgeDomain(bridge string) portAttachment {
+	return portAttachment{kind: "domain", name: bridge}
 }
 
 // NewClient creates a new grout client pointing at the given UNIX socket.
@@ -58,7 +82,7 @@ func (c *Client) ensurePort(ctx context.Context, name, devargs string) error {
 	return nil
 }
 
-func (c *Client) ensurePortInVRF(ctx context.Context, name, devargs, vrf string) error {
+func (c *Client) ensureAttachedPort(ctx context.Context, name, devargs string, attachment portAttachment) error {
 	exists, err := c.portExists(ctx, name)
 	if err != nil {
 		return fmt.Errorf("checking if port %s exists: %w", name, err)
@@ -68,25 +92,59 @@ func (c *Client) ensurePortInVRF(ctx context.Context, name, devargs, vrf string)
 		return nil
 	}
 
-	slog.InfoContext(ctx, "creating grout port in VRF", "name", name, "devargs", devargs, "vrf", vrf)
-	if err := c.run(ctx, "interface", "add", "port", name, "devargs", devargs, "vrf", vrf, "up"); err != nil {
-		return fmt.Errorf("creating grout port %s in VRF %s: %w", name, vrf, err)
+	slog.InfoContext(ctx, "creating attached grout port", "name", name, "devargs", devargs, attachment.kind, attachment.name)
+	if err := c.run(ctx, "interface", "add", "port", name, "devargs", devargs, attachment.kind, attachment.name, "up"); err != nil {
+		return fmt.Errorf("creating grout port %s in %s %s: %w", name, attachment.kind, attachment.name, err)
+	}
+	return nil
+}
+
+// ensureInterface creates a grout interface of the given type with the given
+// attributes. If the interface already exists, its attributes are updated
+// in place.
+func (c *Client) ensureInterface(ctx context.Context, ifaceType, name string, attrs ...string) error {
+	exists, err := c.portExists(ctx, name)
+	if err != nil {
+		return fmt.Errorf("checking if interface %s exists: %w", name, err)
+	}
+	if exists {
+		if len(attrs) == 0 {
+			return nil
+		}
+		slog.DebugContext(ctx, "updating grout interface", "type", ifaceType, "name", name, "attrs", attrs)
+		args := append([]string{"interface", "set", ifaceType, name}, attrs...)
+		if err := c.run(ctx, args...); err != nil {
+			return fmt.Errorf("updating grout %s %s: %w", ifaceType, name, err)
+		}
+		return nil
+	}
+
+	slog.InfoContext(ctx, "creating grout interface", "type", ifaceType, "name", name, "attrs", attrs)
+	args := append([]string{"interface", "add", ifaceType, name}, attrs...)
+	if err := c.run(ctx, args...); err != nil {
+		return fmt.Errorf("creating grout %s %s: %w", ifaceType, name, err)
 	}
 	return nil
 }
 
 func (c *Client) deletePort(ctx context.Context, name string) error {
+	return c.deleteInterface(ctx, name)
+}
+
+// deleteInterface deletes the grout interface with the given name, of any
+// type. If the interface does not exist, it is a no-op.
+func (c *Client) deleteInterface(ctx context.Context, name string) error {
 	exists, err := c.portExists(ctx, name)
 	if err != nil {
-		return fmt.Errorf("checking if port %s exists: %w", name, err)
+		return fmt.Errorf("checking if interface %s exists: %w", name, err)
 	}
 	if !exists {
 		return nil
 	}
 
-	slog.InfoContext(ctx, "deleting grout port", "name", name)
+	slog.InfoContext(ctx, "deleting grout interface", "name", name)
 	if err := c.run(ctx, "interface", "del", name); err != nil {
-		return fmt.Errorf("deleting grout port %s: %w", name, err)
+		return fmt.Errorf("deleting grout interface %s: %w", name, err)
 	}
 	return nil
 }
@@ -5,10 +5,13 @@ package grout
 import (
 	"context"
 	"crypto/sha256"
+	"errors"
 	"fmt"
 	"log/slog"
 	"math/rand"
 	"net"
+	"slices"
+	"strings"
 
 	"github.com/openperouter/openperouter/internal/hostnetwork"
 	"github.com/openperouter/openperouter/internal/netnamespace"
@@ -18,15 +21,13 @@ import (
 )
 
 // SetupPassthrough configures the passthrough interface via the grout dataplane.
-// It creates a grout port "pt-ns" with a kernel TAP "pt-host", moves the TAP
-// to the host namespace, and assigns IPs to both sides.
+// It creates a grout port "pt-ns-<id>" with a kernel TAP "pt-host-<id>", or
+// keeps the legacy "pt-ns" and "pt-host" ones of a previous release, moves
+// the TAP to the host namespace, and assigns IPs to both sides.
 func SetupPassthrough(ctx context.Context, client *Client, params hostnetwork.PassthroughParams) error {
 	slog.DebugContext(ctx, "setup passthrough", "params", params)
 	defer slog.DebugContext(ctx, "setup passthrough done")
 
-	tapName := hostnetwork.PassthroughNames.HostSide       // "pt-host"
-	portName := hostnetwork.PassthroughNames.NamespaceSide // "pt-ns"
-
 	peRouterNs, err := netns.GetFromPath(params.TargetNS)
 	if err != nil {
 		return fmt.Errorf("SetupPassthrough: failed to find namespace %s: %w", params.TargetNS, err)
@@ -37,7 +38,49 @@ func SetupPassthrough(ctx context.Context, client *Client, params hostnetwork.Pa
 		}
 	}()
 
-	if err := ensureTapPortInHostNamespace(ctx, client, portName, tapName, "main", peRouterNs); err != nil {
+	return setupRoutedTapPort(ctx, client, hostnetwork.PassthroughVethNamesOnHost(params), mainVRF, params.LinkIPs, peRouterNs)
+}
+
+// RemoveNonConfiguredPassthroughs removes the grout ports of the passthroughs
+// not in the given list, together with their host TAPs.
+func RemoveNonConfiguredPassthroughs(ctx context.Context, client *Client, configured []hostnetwork.PassthroughParams) error {
+	expected := map[string]bool{}
+	for _, p := range configured {
+		expected[hostnetwork.PassthroughVethNamesOnHost(p).NamespaceSide] = true
+	}
+
+	interfaces, err := client.listInterfaces(ctx)
+	if err != nil {
+		return fmt.Errorf("RemoveNonConfiguredPassthroughs: failed to list grout interfaces: %w", err)
+	}
+
+	var errs []error
+	for _, iface := range interfaces {
+		if iface.Type != interfaceTypePort || expected[iface.Name] ||
+			!strings.HasPrefix(iface.Name, hostnetwork.PassthroughNSPrefix) {
+			continue
+		}
+		hostTap := hostnetwork.PassthroughHostPrefix + strings.TrimPrefix(iface.Name, hostnetwork.PassthroughNSPrefix)
+		if err := hostnetwork.RemoveLinkByName(hostTap); err != nil {
+			errs = append(errs, fmt.Errorf("failed to remove host TAP %s: %w", hostTap, err))
+			continue
+		}
+		slog.InfoContext(ctx, "removing grout passthrough port", "name", iface.Name)
+		if err := client.deletePort(ctx, iface.Name); err != nil {
+			errs = append(errs, fmt.Errorf("failed to delete grout port %s: %w", iface.Name, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// setupRoutedTapPort creates a grout port in the given VRF whose kernel TAP
+// is exposed to the host namespace, and assigns the link IPs to both sides.
+func setupRoutedTapPort(ctx context.Context, client *Client, names hostnetwork.VethNames, vrf string,
+	linkIPs hostnetwork.LinkIPs, groutNs netns.NsHandle) error {
+	tapName := names.HostSide
+	portName := names.NamespaceSide
+
+	if err := ensureTapPortInHostNamespace(ctx, client, portName, tapName, inVRF(vrf), groutNs); err != nil {
 		return err
 	}
 
@@ -46,47 +89,31 @@ func SetupPassthrough(ctx context.Context, client *Client, params hostnetwork.Pa
 	if err != nil {
 		return fmt.Errorf("host TAP %s not found after move: %w", tapName, err)
 	}
-	if err := hostnetwork.AssignIPsToInterface(hostTap, params.LinkIPs.HostIPv4, params.LinkIPs.HostIPv6); err != nil {
+	if err := hostnetwork.AssignIPsToInterface(hostTap, linkIPs.HostIPv4, linkIPs.HostIPv6); err != nil {
 		return fmt.Errorf("failed to assign IPs to host TAP: %w", err)
 	}
 
-	// Assign IPs to the grout port "pt-ns" via grcli.
-	if err := ensurePortAddresses(ctx, client, portName, params.LinkIPs.NSIPv4, params.LinkIPs.NSIPv6); err != nil {
+	// Assign IPs to the grout port via grcli.
+	if err := ensurePortAddresses(ctx, client, portName, linkIPs.NSIPv4, linkIPs.NSIPv6); err != nil {
 		return fmt.Errorf("failed to ensure IPs to grout port: %w", err)
 	}
 
-	if err := netnamespace.In(peRouterNs, func() error {
+	return netnamespace.In(groutNs, func() error {
 		// Grout creates a NOARP kernel interface for each port. BGP packets leave
 		// through the `main` interface but return on the port's kernel interface (grout control plane tap),
 		// so rp_filter must be disabled to allow the asymmetric path.
 		if err := sysctl.Ensure(sysctl.DisableRPFilter(portName)); err != nil {
-			return fmt.Errorf("failed to disable rp_filter on passthrough port %s: %w", portName, err)
+			return fmt.Errorf("failed to disable rp_filter on port %s: %w", portName, err)
 		}
 		return nil
-	}); err != nil {
-		return err
-	}
-
-	return nil
-}
-
-func RemovePassthrough(ctx context.Context, client *Client) error {
-	// Remove the host-side TAP if it exists.
-	if err := hostnetwork.RemoveLinkByName(hostnetwork.PassthroughNames.HostSide); err != nil {
-		return fmt.Errorf("RemovePassthrough: failed to remove host TAP %s: %w", hostnetwork.PassthroughNames.HostSide, err)
-	}
-	// Delete the grout port so it can be recreated on the next setup.
-	portName := hostnetwork.PassthroughNames.NamespaceSide
-	if err := client.deletePort(ctx, portName); err != nil {
-		return fmt.Errorf("RemovePassthrough: failed to delete grout port %s: %w", portName, err)
-	}
-	return nil
+	})
 }
 
 // ensureTapPortInNamespace is idempotent: it creates the grout port and
 // moves the TAP to the host namespace if needed, and recovers from partial
 // setups where the port exists but the TAP is missing from the host NS.
-func ensureTapPortInHostNamespace(ctx context.Context, client *Client, portName, tapName, vrf string, groutNs netns.NsHandle) error {
+func ensureTapPortInHostNamespace(ctx context.Context, client *Client, portName, tapName string,
+	attachment portAttachment, groutNs netns.NsHandle) error {
 	portExists, err := client.portExists(ctx, portName)
 	if err != nil {
 		return fmt.Errorf("failed to check grout port %s: %w", portName, err)
@@ -96,8 +123,8 @@ func ensureTapPortInHostNamespace(ctx context.Context, client *Client, portName,
 		slog.InfoContext(ctx, "creating grout port",
 			"port", portName, "tap", tapName)
 		devargs := fmt.Sprintf("net_tap%s,iface=%s", makeTapRandomString(), tapName)
-		if err := client.ensurePortInVRF(ctx, portName, devargs, vrf); err != nil {
-			return fmt.Errorf("failed to create grout port %s in VRF %s: %w", portName, vrf, err)
+		if err := client.ensureAttachedPort(ctx, portName, devargs, attachment); err != nil {
+			return fmt.Errorf("failed to create grout port %s in %s %s: %w", portName, attachment.kind, attachment.name, err)
 		}
 	}
 
@@ -187,15 +214,21 @@ func ensurePortAddresses(ctx context.Context, client *Client, portName string, i
 	if ipv4 == "" && ipv6 == "" {
 		return fmt.Errorf("at least one IP address must be provided (IPv4 or IPv6)")
 	}
+	return syncInterfaceAddresses(ctx, client, portName, []string{ipv4, ipv6})
+}
 
-	oldPortAddresses, err := client.getAddresses(ctx, portName)
+// syncInterfaceAddresses ensures that the grout interface has exactly the
+// given addresses assigned, ignoring the empty ones and never removing
+// link-local addresses.
+func syncInterfaceAddresses(ctx context.Context, client *Client, ifaceName string, addrs []string) error {
+	oldAddresses, err := client.getAddresses(ctx, ifaceName)
 	if err != nil {
-		return fmt.Errorf("failed to get addresses for port %s: %w", portName, err)
+		return fmt.Errorf("failed to get addresses for interface %s: %w", ifaceName, err)
 	}
 
 	// Delete old addresses that are not in the new list.
-	for _, addr := range oldPortAddresses {
-		if addr == ipv4 || addr == ipv6 {
+	for _, addr := range oldAddresses {
+		if slices.Contains(addrs, addr) {
 			continue
 		}
 
@@ -209,18 +242,18 @@ func ensurePortAddresses(ctx context.Context, client *Client, portName string, i
 			continue
 		}
 
-		if err := client.deleteAddress(ctx, portName, addr); err != nil {
-			return fmt.Errorf("failed to delete address %s from port %s: %w", addr, portName, err)
+		if err := client.deleteAddress(ctx, ifaceName, addr); err != nil {
+			return fmt.Errorf("failed to delete address %s from interface %s: %w", addr, ifaceName, err)
 		}
 	}
 
 	// Assign new addresses.
-	for _, addr := range []string{ipv4, ipv6} {
+	for _, addr := range addrs {
 		if addr == "" {
 			continue
 		}
-		if err := client.ensureAddress(ctx, portName, addr); err != nil {
-			return fmt.Errorf("failed to assign IP %s to port %s: %w", addr, portName, err)
+		if err := client.ensureAddress(ctx, ifaceName, addr); err != nil {
+			return fmt.Errorf("failed to assign IP %s to interface %s: %w", addr, ifaceName, err)
 		}
 	}
 
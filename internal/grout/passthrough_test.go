@@ -0,0 +1,38 @@
+// SPDX-License-Identifier:Apache-2.0
+
+package grout
+
+import (
+	"context"
+	"testing"
+
+	"github.com/openperouter/openperouter/internal/hostnetwork"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRemoveNonConfiguredPassthroughs(t *testing.T) {
+	ctx := context.Background()
+	fake := newFakeGrcli(t, "sock")
+	client := NewClient("sock")
+
+	infra := hostnetwork.PassthroughParams{Name: "infra"}
+	storage := hostnetwork.PassthroughParams{Name: "storage"}
+
+	require.NoError(t, client.ensurePort(ctx, "u_eth0", "net_tap0,remote=eth0,iface=tap_eth0"))
+	for _, p := range []hostnetwork.PassthroughParams{infra, storage} {
+		names := hostnetwork.PassthroughVethNames(p.Name)
+		require.NoError(t, client.ensureAttachedPort(ctx, names.NamespaceSide, "net_tap,iface="+names.HostSide, inVRF(mainVRF)))
+	}
+
+	t.Run("removes the passthroughs not configured", func(t *testing.T) {
+		require.NoError(t, RemoveNonConfiguredPassthroughs(ctx, client, []hostnetwork.PassthroughParams{storage}))
+		assert.Equal(t, []string{"u_eth0", hostnetwork.PassthroughVethNames(storage.Name).NamespaceSide},
+			fake.names(interfaceTypePort))
+	})
+
+	t.Run("removes everything", func(t *testing.T) {
+		require.NoError(t, RemoveNonConfiguredPassthroughs(ctx, client, nil))
+		assert.Equal(t, []string{"u_eth0"}, fake.names(interfaceTypePort))
+	})
+}
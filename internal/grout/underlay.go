@@ -79,6 +79,19 @@ func SetupUnderlay(ctx context.Context, client *Client, params hostnetwork.Under
 		}
 	}
 
+	// The VXLan tunnels are sourced from the VTEP IPs, which must be owned
+	// by grout: they are assigned to the loopback of the main VRF.
+	for _, tunnelEndpoint := range params.TunnelEndpoints {
+		for _, cidr := range []string{tunnelEndpoint.IPv4CIDR, tunnelEndpoint.IPv6CIDR} {
+			if cidr == "" {
+				continue
+			}
+			if err := client.ensureAddress(ctx, mainVRF, cidr); err != nil {
+				return fmt.Errorf("failed to assign vtep ip %s to grout: %w", cidr, err)
+			}
+		}
+	}
+
 	return nil
 }
 
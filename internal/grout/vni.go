@@ -0,0 +1,250 @@
+// SPDX-License-Identifier:Apache-2.0
+
+package grout
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net"
+	"strconv"
+	"strings"
+
+	"github.com/openperouter/openperouter/internal/hostnetwork"
+	"github.com/vishvananda/netlink"
+	"github.com/vishvananda/netns"
+)
+
+const (
+	mainVRF          = "main"
+	defaultVXLANPort = 4789
+)
+
+// SetupL3VNI configures a Layer 3 VNI via the grout dataplane, mirroring
+// hostnetwork.SetupL3VNI. It creates the VRF and a VXLan interface routing
+// in it, and, when link IPs are provided, a grout port in the VRF whose
+// kernel TAP is exposed to the host namespace.
+func SetupL3VNI(ctx context.Context, client *Client, params hostnetwork.L3VNIParams) error {
+	slog.DebugContext(ctx, "setting up l3 VNI", "params", params)
+	defer slog.DebugContext(ctx, "end setting up l3 VNI", "params", params)
+
+	if err := ensureL3VNIInterfaces(ctx, client, params); err != nil {
+		return fmt.Errorf("SetupL3VNI: %w", err)
+	}
+
+	if params.LinkIPs == nil {
+		slog.DebugContext(ctx, "no host port configured, skipping setup")
+		return nil
+	}
+
+	peRouterNs, err := netns.GetFromPath(params.TargetNS)
+	if err != nil {
+		return fmt.Errorf("SetupL3VNI: failed to find namespace %s: %w", params.TargetNS, err)
+	}
+	defer func() {
+		if err := peRouterNs.Close(); err != nil {
+			slog.Error("failed to close namespace", "namespace", params.TargetNS, "error", err)
+		}
+	}()
+
+	if err := setupRoutedTapPort(ctx, client, hostnetwork.VethNamesFromVNI(params.VNI),
+		params.VRF, *params.LinkIPs, peRouterNs); err != nil {
+		return fmt.Errorf("SetupL3VNI: failed to setup host port: %w", err)
+	}
+	return nil
+}
+
+// SetupL2VNI configures a Layer 2 VNI via the grout dataplane, mirroring
+// hostnetwork.SetupL2VNI. It creates a bridge domain, bound to the VRF when
+// params.VRF is non-empty, with a VXLan interface and a grout port whose
+// kernel TAP is exposed to the host namespace and attached to the host master.
+// The VRF is created if it does not exist yet.
+func SetupL2VNI(ctx context.Context, client *Client, params hostnetwork.L2VNIParams) error {
+	slog.DebugContext(ctx, "setting up l2 VNI", "params", params)
+	defer slog.DebugContext(ctx, "end setting up l2 VNI", "params", params)
+
+	if err := ensureL2VNIInterfaces(ctx, client, params); err != nil {
+		return fmt.Errorf("SetupL2VNI: %w", err)
+	}
+
+	peRouterNs, err := netns.GetFromPath(params.TargetNS)
+	if err != nil {
+		return fmt.Errorf("SetupL2VNI: failed to find namespace %s: %w", params.TargetNS, err)
+	}
+	defer func() {
+		if err := peRouterNs.Close(); err != nil {
+			slog.Error("failed to close namespace", "namespace", params.TargetNS, "error", err)
+		}
+	}()
+
+	names := hostnetwork.VethNamesFromVNI(params.VNI)
+	bridge := inBridgeDomain(hostnetwork.BridgeName(params.VNI))
+	if err := ensureTapPortInHostNamespace(ctx, client, names.NamespaceSide, names.HostSide, bridge, peRouterNs); err != nil {
+		return fmt.Errorf("SetupL2VNI: %w", err)
+	}
+
+	if params.HostMaster == nil {
+		return nil
+	}
+	hostTap, err := netlink.LinkByName(names.HostSide)
+	if err != nil {
+		return fmt.Errorf("SetupL2VNI: host TAP %s not found after move: %w", names.HostSide, err)
+	}
+	if err := hostnetwork.SetupHostMaster(ctx, params, hostTap); err != nil {
+		return err
+	}
+	return nil
+}
+
+// ensureL3VNIInterfaces creates or updates the grout VRF and VXLan
+// interfaces backing an L3VNI.
+func ensureL3VNIInterfaces(ctx context.Context, client *Client, params hostnetwork.L3VNIParams) error {
+	if err := client.ensureInterface(ctx, interfaceTypeVRF, params.VRF); err != nil {
+		return fmt.Errorf("failed to setup VRF: %w", err)
+	}
+	if err := ensureVXLAN(ctx, client, params.VNIParams, inVRF(params.VRF)); err != nil {
+		return err
+	}
+	return nil
+}
+
+// ensureL2VNIInterfaces creates or updates the grout VRF, bridge and VXLan
+// interfaces backing an L2VNI, and assigns the gateway IPs to the bridge.
+func ensureL2VNIInterfaces(ctx context.Context, client *Client, params hostnetwork.L2VNIParams) error {
+	bridgeName := hostnetwork.BridgeName(params.VNI)
+	var bridgeAttrs []string
+	if params.VRF != "" {
+		if err := client.ensureInterface(ctx, interfaceTypeVRF, params.VRF); err != nil {
+			return fmt.Errorf("failed to setup VRF: %w", err)
+		}
+		bridgeAttrs = append(bridgeAttrs, "vrf", params.VRF)
+	}
+	if len(params.L2GatewayIPs) > 0 {
+		// setting up the same mac address for all the nodes for distributed gateway
+		macAddress, err := hostnetwork.GatewayMACAddress(params)
+		if err != nil {
+			return err
+		}
+		bridgeAttrs = append(bridgeAttrs, "mac", macAddress.String())
+	}
+	if err := client.ensureInterface(ctx, interfaceTypeBridge, bridgeName, bridgeAttrs...); err != nil {
+		return fmt.Errorf("failed to setup bridge: %w", err)
+	}
+	if err := syncInterfaceAddresses(ctx, client, bridgeName, params.L2GatewayIPs); err != nil {
+		return fmt.Errorf("failed to assign L2 gateway IPs to bridge %s: %w", bridgeName, err)
+	}
+	if err := ensureVXLAN(ctx, client, params.VNIParams, inBridgeDomain(bridgeName)); err != nil {
+		return err
+	}
+	return nil
+}
+
+// ensureVXLAN creates or updates the VXLan interface of the VNI, sourcing
+// the tunnels from the VTEP IP in the main VRF and plugging the decapsulated
+// traffic into the given VRF or bridge domain.
+func ensureVXLAN(ctx context.Context, client *Client, params hostnetwork.VNIParams, attachment portAttachment) error {
+	vtepIP, _, err := net.ParseCIDR(params.VTEPIP)
+	if err != nil {
+		return fmt.Errorf("failed to parse vtep ip %v: %w", params.VTEPIP, err)
+	}
+	port := int32(defaultVXLANPort)
+	if params.VXLanPort != nil {
+		port = *params.VXLanPort
+	}
+
+	name := hostnetwork.VXLanNameFromVNI(params.VNI)
+	if err := client.ensureInterface(ctx, interfaceTypeVXLAN, name,
+		"vni", strconv.Itoa(int(params.VNI)),
+		"local", vtepIP.String(),
+		"encap_vrf", mainVRF,
+		"dst_port", strconv.Itoa(int(port)),
+		attachment.kind, attachment.name,
+	); err != nil {
+		return fmt.Errorf("failed to setup vxlan %s: %w", name, err)
+	}
+	return nil
+}
+
+// RemoveNonConfiguredVNIs removes the grout VXLan, bridge and host facing
+// interfaces of the VNIs not in the given list, together with their host TAPs.
+func RemoveNonConfiguredVNIs(ctx context.Context, client *Client, configured []hostnetwork.VNIParams) error {
+	expected := map[string]bool{}
+	for _, vni := range configured {
+		expected[hostnetwork.VXLanNameFromVNI(vni.VNI)] = true
+		expected[hostnetwork.BridgeName(vni.VNI)] = true
+		expected[hostnetwork.VethNamesFromVNI(vni.VNI).NamespaceSide] = true
+	}
+
+	interfaces, err := client.listInterfaces(ctx)
+	if err != nil {
+		return fmt.Errorf("RemoveNonConfiguredVNIs: failed to list grout interfaces: %w", err)
+	}
+
+	// Ports and VXLans must go before the bridges they are plugged into.
+	var toDelete, bridges []groutInterface
+	for _, iface := range interfaces {
+		if expected[iface.Name] {
+			continue
+		}
+		switch {
+		case iface.Type == interfaceTypeVXLAN:
+			toDelete = append(toDelete, iface)
+		case iface.Type == interfaceTypeBridge:
+			bridges = append(bridges, iface)
+		case iface.Type == interfaceTypePort && strings.HasPrefix(iface.Name, hostnetwork.PEVethPrefix+hostnetwork.EvpnInfix):
+			toDelete = append(toDelete, iface)
+		}
+	}
+
+	var errs []error
+	for _, iface := range append(toDelete, bridges...) {
+		if err := removeVNIInterface(ctx, client, iface); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func removeVNIInterface(ctx context.Context, client *Client, iface groutInterface) error {
+	if iface.Type == interfaceTypePort {
+		hostTap := hostnetwork.HostVethPrefix + strings.TrimPrefix(iface.Name, hostnetwork.PEVethPrefix)
+		if err := hostnetwork.RemoveLinkByName(hostTap); err != nil {
+			return fmt.Errorf("failed to remove host TAP %s: %w", hostTap, err)
+		}
+	}
+	slog.InfoContext(ctx, "removing grout vni interface", "name", iface.Name, "type", iface.Type)
+	if err := client.deleteInterface(ctx, iface.Name); err != nil {
+		return err
+	}
+	return nil
+}
+
+// RemoveNonConfiguredVRFs removes the grout VRFs not in the given set. The
+// main VRF is never removed.
+func RemoveNonConfiguredVRFs(ctx context.Context, client *Client, configured map[string]bool) error {
+	interfaces, err := client.listInterfaces(ctx)
+	if err != nil {
+		return fmt.Errorf("RemoveNonConfiguredVRFs: failed to list grout interfaces: %w", err)
+	}
+
+	var errs []error
+	for _, iface := range interfaces {
+		if iface.Type != interfaceTypeVRF || iface.Name == mainVRF || configured[iface.Name] {
+			continue
+		}
+		slog.InfoContext(ctx, "removing grout vrf", "name", iface.Name)
+		if err := client.deleteInterface(ctx, iface.Name); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// RemoveAllVNIs removes all the grout VNI interfaces and VRFs.
+func RemoveAllVNIs(ctx context.Context, client *Client) error {
+	if err := RemoveNonConfiguredVNIs(ctx, client, nil); err != nil {
+		return err
+	}
+	return RemoveNonConfiguredVRFs(ctx, client, nil)
+}
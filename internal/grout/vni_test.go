@@ -0,0 +1,180 @@
+// SPDX-License-Identifier:Apache-2.0
+
+package grout
+
+import (
+	"context"
+	"testing"
+
+	"github.com/openperouter/openperouter/internal/hostnetwork"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/utils/ptr"
+)
+
+func l3VNIParams(vrf string, vni int32) hostnetwork.L3VNIParams {
+	return hostnetwork.L3VNIParams{
+		VNIParams: hostnetwork.VNIParams{
+			VRF:       vrf,
+			TargetNS:  "/var/run/netns/perouter",
+			VTEPIP:    "100.65.0.1/32",
+			VNI:       vni,
+			VXLanPort: ptr.To(int32(4789)),
+		},
+		Name: vrf,
+	}
+}
+
+func l2VNIParams(vrf string, vni int32, gatewayIPs ...string) hostnetwork.L2VNIParams {
+	return hostnetwork.L2VNIParams{
+		VNIParams: hostnetwork.VNIParams{
+			VRF:       vrf,
+			TargetNS:  "/var/run/netns/perouter",
+			VTEPIP:    "100.65.0.1/32",
+			VNI:       vni,
+			VXLanPort: ptr.To(int32(4789)),
+		},
+		Name:         "l2vni",
+		L2GatewayIPs: gatewayIPs,
+	}
+}
+
+func TestEnsureL3VNIInterfaces(t *testing.T) {
+	ctx := context.Background()
+	fake := newFakeGrcli(t, "sock")
+	client := NewClient("sock")
+
+	params := l3VNIParams("red", 100)
+	require.NoError(t, ensureL3VNIInterfaces(ctx, client, params))
+
+	assert.Equal(t, []string{"main", "red"}, fake.names(interfaceTypeVRF))
+	vxlan := fake.get("vni100")
+	require.NotNil(t, vxlan)
+	assert.Equal(t, interfaceTypeVXLAN, vxlan.Type)
+	assert.Equal(t, map[string]string{
+		"vni":       "100",
+		"local":     "100.65.0.1",
+		"encap_vrf": "main",
+		"dst_port":  "4789",
+		"vrf":       "red",
+	}, vxlan.Attrs)
+
+	t.Run("is idempotent and updates the vtep", func(t *testing.T) {
+		params.VTEPIP = "100.65.0.2/32"
+		params.VXLanPort = nil
+		require.NoError(t, ensureL3VNIInterfaces(ctx, client, params))
+
+		assert.Equal(t, []string{"main", "red"}, fake.names(interfaceTypeVRF))
+		assert.Equal(t, []string{"vni100"}, fake.names(interfaceTypeVXLAN))
+		assert.Equal(t, "100.65.0.2", fake.get("vni100").Attrs["local"])
+		assert.Equal(t, "4789", fake.get("vni100").Attrs["dst_port"])
+	})
+
+	t.Run("fails on an invalid vtep", func(t *testing.T) {
+		params.VTEPIP = "100.65.0.2"
+		assert.Error(t, ensureL3VNIInterfaces(ctx, client, params))
+	})
+}
+
+func TestEnsureL2VNIInterfaces(t *testing.T) {
+	ctx := context.Background()
+	fake := newFakeGrcli(t, "sock")
+	client := NewClient("sock")
+
+	t.Run("creates the vrf when it does not exist", func(t *testing.T) {
+		require.NoError(t, ensureL2VNIInterfaces(ctx, client, l2VNIParams("blue", 400)))
+
+		vrf := fake.get("blue")
+		require.NotNil(t, vrf)
+		assert.Equal(t, interfaceTypeVRF, vrf.Type)
+		assert.Equal(t, "blue", fake.get("br-pe-400").Attrs["vrf"])
+	})
+
+	require.NoError(t, ensureL3VNIInterfaces(ctx, client, l3VNIParams("red", 100)))
+
+	t.Run("with gateway ips", func(t *testing.T) {
+		params := l2VNIParams("red", 200, "192.170.1.1/24", "fd00:10::1/64")
+		require.NoError(t, ensureL2VNIInterfaces(ctx, client, params))
+
+		bridge := fake.get("br-pe-200")
+		require.NotNil(t, bridge)
+		assert.Equal(t, interfaceTypeBridge, bridge.Type)
+		assert.Equal(t, map[string]string{"vrf": "red", "mac": "00:f3:00:00:00:c9"}, bridge.Attrs)
+		assert.Equal(t, []string{"192.170.1.1/24", "fd00:10::1/64"}, fake.addresses["br-pe-200"])
+
+		vxlan := fake.get("vni200")
+		require.NotNil(t, vxlan)
+		assert.Equal(t, "br-pe-200", vxlan.Attrs["domain"])
+		assert.NotContains(t, vxlan.Attrs, "vrf")
+	})
+
+	t.Run("removes the stale gateway ips", func(t *testing.T) {
+		params := l2VNIParams("red", 200, "192.170.1.1/24")
+		require.NoError(t, ensureL2VNIInterfaces(ctx, client, params))
+		assert.Equal(t, []string{"192.170.1.1/24"}, fake.addresses["br-pe-200"])
+	})
+
+	t.Run("without vrf", func(t *testing.T) {
+		params := l2VNIParams("", 300)
+		require.NoError(t, ensureL2VNIInterfaces(ctx, client, params))
+
+		assert.Empty(t, fake.get("br-pe-300").Attrs)
+		assert.Empty(t, fake.addresses["br-pe-300"])
+		assert.Equal(t, "br-pe-300", fake.get("vni300").Attrs["domain"])
+	})
+}
+
+func TestRemoveNonConfiguredVNIs(t *testing.T) {
+	ctx := context.Background()
+	fake := newFakeGrcli(t, "sock")
+	client := NewClient("sock")
+
+	require.NoError(t, client.ensurePort(ctx, "u_eth0", "net_tap0,remote=eth0,iface=tap_eth0"))
+	require.NoError(t, client.ensureAttachedPort(ctx, "pt-ns", "net_tap1,iface=pt-host", inVRF(mainVRF)))
+
+	l3Red := l3VNIParams("red", 100)
+	l3Blue := l3VNIParams("blue", 101)
+	l2Red := l2VNIParams("red", 200, "192.170.1.1/24")
+	l2Blue := l2VNIParams("blue", 201)
+	require.NoError(t, ensureL3VNIInterfaces(ctx, client, l3Red))
+	require.NoError(t, ensureL3VNIInterfaces(ctx, client, l3Blue))
+	require.NoError(t, ensureL2VNIInterfaces(ctx, client, l2Red))
+	require.NoError(t, ensureL2VNIInterfaces(ctx, client, l2Blue))
+	for _, p := range []hostnetwork.VNIParams{l3Red.VNIParams, l3Blue.VNIParams} {
+		names := hostnetwork.VethNamesFromVNI(p.VNI)
+		require.NoError(t, client.ensureAttachedPort(ctx, names.NamespaceSide, "net_tap,iface="+names.HostSide, inVRF(p.VRF)))
+	}
+	for _, p := range []hostnetwork.VNIParams{l2Red.VNIParams, l2Blue.VNIParams} {
+		names := hostnetwork.VethNamesFromVNI(p.VNI)
+		require.NoError(t, client.ensureAttachedPort(ctx, names.NamespaceSide, "net_tap,iface="+names.HostSide,
+			inBridgeDomain(hostnetwork.BridgeName(p.VNI))))
+	}
+
+	t.Run("removes the vnis not configured", func(t *testing.T) {
+		require.NoError(t, RemoveNonConfiguredVNIs(ctx, client, []hostnetwork.VNIParams{l3Red.VNIParams, l2Red.VNIParams}))
+
+		assert.Equal(t, []string{"vni100", "vni200"}, fake.names(interfaceTypeVXLAN))
+		assert.Equal(t, []string{"br-pe-200"}, fake.names(interfaceTypeBridge))
+		assert.Equal(t, []string{"u_eth0", "pt-ns", "pe-e-100", "pe-e-200"}, fake.names(interfaceTypePort))
+		assert.Equal(t, []string{"192.170.1.1/24"}, fake.addresses["br-pe-200"])
+	})
+
+	t.Run("removes the vrfs not configured", func(t *testing.T) {
+		require.NoError(t, RemoveNonConfiguredVRFs(ctx, client, map[string]bool{"red": true}))
+		assert.Equal(t, []string{"main", "red"}, fake.names(interfaceTypeVRF))
+	})
+
+	t.Run("fails to remove a vrf still in use", func(t *testing.T) {
+		assert.Error(t, RemoveNonConfiguredVRFs(ctx, client, nil))
+		assert.Equal(t, []string{"main", "red"}, fake.names(interfaceTypeVRF))
+	})
+
+	t.Run("removes everything", func(t *testing.T) {
+		require.NoError(t, RemoveAllVNIs(ctx, client))
+
+		assert.Equal(t, []string{"main"}, fake.names(interfaceTypeVRF))
+		assert.Empty(t, fake.names(interfaceTypeVXLAN))
+		assert.Empty(t, fake.names(interfaceTypeBridge))
+		assert.Equal(t, []string{"u_eth0", "pt-ns"}, fake.names(interfaceTypePort))
+	})
+}
@@ -7,6 +7,7 @@ import (
 	"encoding/binary"
 	"errors"
 	"fmt"
+	"net"
 	"strconv"
 	"strings"
 
@@ -95,20 +96,33 @@ const (
 
 var macHeader = []byte{0x00, 0xF3}
 
-// ensureBridgeFixedMacAddress sets a deterministic MAC address on the bridge based on the VNI.
-// It is idempotent: if the MAC is already correct, it skips the update to avoid
-// unnecessary RTM_NEWLINK events that can cause FRR to flush neighbor entries.
-func ensureBridgeFixedMacAddress(bridge netlink.Link, vni int32) error {
-	macAddress := make([]byte, macSize)
+// BridgeMACAddress returns the deterministic MAC address used by the PE
+// bridge of the given VNI, so that the distributed gateway has the same
+// MAC on all the nodes.
+func BridgeMACAddress(vni int32) net.HardwareAddr {
+	macAddress := make(net.HardwareAddr, macSize)
+	copy(macAddress, macHeader)
+	binary.BigEndian.PutUint32(macAddress[2:], uint32(vni+1))
+	return macAddress
+}
 
-	buf := new(bytes.Buffer)
-	err := binary.Write(buf, binary.BigEndian, vni+1)
+// GatewayMACAddress returns the MAC address of the distributed gateway of
+// the L2VNI, the one set in the params or the deterministic one of the VNI.
+func GatewayMACAddress(params L2VNIParams) (net.HardwareAddr, error) {
+	if params.GatewayMAC == "" {
+		return BridgeMACAddress(params.VNI), nil
+	}
+	macAddress, err := net.ParseMAC(params.GatewayMAC)
 	if err != nil {
-		return err
+		return nil, fmt.Errorf("invalid gateway mac address %s: %w", params.GatewayMAC, err)
 	}
-	copy(macAddress, macHeader)
-	copy(macAddress[2:], buf.Bytes())
+	return macAddress, nil
+}
 
+// ensureBridgeMacAddress sets the given MAC address on the bridge.
+// It is idempotent: if the MAC is already correct, it skips the update to avoid
+// unnecessary RTM_NEWLINK events that can cause FRR to flush neighbor entries.
+func ensureBridgeMacAddress(bridge netlink.Link, macAddress net.HardwareAddr) error {
 	if bytes.Equal(bridge.Attrs().HardwareAddr, macAddress) {
 		return nil
 	}
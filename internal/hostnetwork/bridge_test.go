@@ -13,13 +13,13 @@ import (
 	"github.com/vishvananda/netlink"
 )
 
-var _ = Describe("ensureBridgeFixedMacAddress", func() {
+var _ = Describe("ensureBridgeMacAddress", func() {
 	const (
 		bridgeName = "testbrmac"
 		vni        = 100
 	)
 
-	// copied the function from `ensureBridgeFixedMacAddress` implementation
+	// copied the function from `BridgeMACAddress` implementation
 	expectedMAC := func(vni int) net.HardwareAddr {
 		GinkgoHelper()
 		mac := make([]byte, macSize)
@@ -49,19 +49,33 @@ var _ = Describe("ensureBridgeFixedMacAddress", func() {
 		bridge, err := netlink.LinkByName(bridgeName)
 		Expect(err).NotTo(HaveOccurred())
 
-		Expect(ensureBridgeFixedMacAddress(bridge, vni)).To(Succeed())
+		Expect(ensureBridgeMacAddress(bridge, BridgeMACAddress(vni))).To(Succeed())
 
 		bridge, err = netlink.LinkByName(bridgeName)
 		Expect(err).NotTo(HaveOccurred())
 		Expect(bridge.Attrs().HardwareAddr).To(Equal(expectedMAC(vni)))
 	})
 
+	It("should set the gateway MAC address of the L2VNI when configured", func() {
+		bridge, err := netlink.LinkByName(bridgeName)
+		Expect(err).NotTo(HaveOccurred())
+
+		params := L2VNIParams{VNIParams: VNIParams{VNI: vni}, GatewayMAC: "00:00:5e:00:01:01"}
+		macAddress, err := GatewayMACAddress(params)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ensureBridgeMacAddress(bridge, macAddress)).To(Succeed())
+
+		bridge, err = netlink.LinkByName(bridgeName)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(bridge.Attrs().HardwareAddr.String()).To(Equal("00:00:5e:00:01:01"))
+	})
+
 	It("should be idempotent and not emit netlink events when MAC is already correct", func() {
 		bridge, err := netlink.LinkByName(bridgeName)
 		Expect(err).NotTo(HaveOccurred())
 
 		By("setting the MAC for the first time")
-		Expect(ensureBridgeFixedMacAddress(bridge, vni)).To(Succeed())
+		Expect(ensureBridgeMacAddress(bridge, BridgeMACAddress(vni))).To(Succeed())
 
 		By("re-fetching the bridge to pick up the updated MAC")
 		bridge, err = netlink.LinkByName(bridgeName)
@@ -74,8 +88,8 @@ var _ = Describe("ensureBridgeFixedMacAddress", func() {
 		defer close(done)
 		Expect(netlink.LinkSubscribe(updates, done)).To(Succeed())
 
-		By("calling ensureBridgeFixedMacAddress again with the same VNI")
-		Expect(ensureBridgeFixedMacAddress(bridge, vni)).To(Succeed())
+		By("calling ensureBridgeMacAddress again with the same VNI")
+		Expect(ensureBridgeMacAddress(bridge, BridgeMACAddress(vni))).To(Succeed())
 
 		By("verifying no netlink link update was emitted for the bridge")
 		Consistently(func() bool {
@@ -89,7 +103,7 @@ var _ = Describe("ensureBridgeFixedMacAddress", func() {
 				return true
 			}
 		}).WithTimeout(500*time.Millisecond).WithPolling(50*time.Millisecond).Should(BeTrue(),
-			"ensureBridgeFixedMacAddress should not emit netlink events when MAC is already correct")
+			"ensureBridgeMacAddress should not emit netlink events when MAC is already correct")
 
 		By("verifying the MAC is still correct")
 		bridge, err = netlink.LinkByName(bridgeName)
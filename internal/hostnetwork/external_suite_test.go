@@ -107,7 +107,7 @@ var _ = Describe("EXTERNAL", func() {
 		})
 		It("should be deleted", func() {
 			Eventually(func(g Gomega) {
-				validatePassthroughRemovedInNamespace(g)
+				validatePassthroughRemovedInNamespace(g, PassthroughVethNames(params.Name))
 			}, 30*time.Second, 1*time.Second).Should(Succeed())
 		})
 	})
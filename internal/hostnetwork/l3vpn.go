@@ -25,6 +25,9 @@ type L3VPNParams struct {
 	VRF              string   `json:"vrf"`
 	TargetNS         string   `json:"targetns"`
 	RDAssignedNumber int32    `json:"rdassignednumber"`
+	// LeakRoutes tells whether routes are leaked between the VRF and the
+	// other VRFs of the router.
+	LeakRoutes bool `json:"leakroutes,omitempty"`
 }
 
 // SetupL3VPN sets up a Layer 3 VPN in the target namespace.
@@ -73,6 +76,9 @@ func setupL3VPN(ctx context.Context, params L3VPNParams) error {
 
 	return netnamespace.In(ns, func() error {
 		slog.DebugContext(ctx, "setting up vrf", "vrf", params.VRF)
+		if params.LeakRoutes {
+			return setupVRF(params.VRF, srv6VRF, leakingVRF)
+		}
 		return setupVRF(params.VRF, srv6VRF)
 	})
 }
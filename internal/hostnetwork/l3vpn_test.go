@@ -295,7 +295,7 @@ var _ = Describe("L3 VPN configuration", func() {
 
 			By("checking non needed L2VNIs are removed")
 			for _, deleted := range deletedL2vniParams {
-				vethNames := vethNamesFromVNI(deleted.VNI)
+				vethNames := VethNamesFromVNI(deleted.VNI)
 				Eventually(func(g Gomega) {
 					checkLinkdeleted(g, vethNames.HostSide)
 					checkLinkExists(g, bridgeName)
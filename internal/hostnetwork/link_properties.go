@@ -185,8 +185,14 @@ func linkSetMaster(link, master netlink.Link) error {
 	return netlink.LinkSetMaster(link, master)
 }
 
-// setNeighSuppression sets neighbor suppression to the given link.
-func setNeighSuppression(link netlink.Link) error {
+// setNeighSuppression enables or disables neighbor suppression on the given link.
+func setNeighSuppression(link netlink.Link, enabled bool) error {
+	return setBridgePortFlags(link, enabled, unix.IFLA_BRPORT_NEIGH_SUPPRESS)
+}
+
+// setBridgePortFlags enables or disables the given IFLA_BRPORT_* flags on
+// the given bridge port.
+func setBridgePortFlags(link netlink.Link, enabled bool, flags ...int) error {
 	req := nl.NewNetlinkRequest(unix.RTM_SETLINK, unix.NLM_F_ACK)
 
 	msg := nl.NewIfInfomsg(unix.AF_BRIDGE)
@@ -198,7 +204,13 @@ func setNeighSuppression(link netlink.Link) error {
 	req.AddData(msg)
 
 	br := nl.NewRtAttr(unix.IFLA_PROTINFO|unix.NLA_F_NESTED, nil)
-	br.AddRtAttr(32, []byte{1})
+	value := []byte{0}
+	if enabled {
+		value = []byte{1}
+	}
+	for _, flag := range flags {
+		br.AddRtAttr(flag, value)
+	}
 	req.AddData(br)
 	_, err = req.Execute(unix.NETLINK_ROUTE, 0)
 	if err != nil {
@@ -0,0 +1,197 @@
+// SPDX-License-Identifier:Apache-2.0
+
+package hostnetwork
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"maps"
+	"slices"
+
+	libovsclient "github.com/ovn-kubernetes/libovsdb/client"
+	"github.com/ovn-kubernetes/libovsdb/ovsdb"
+
+	"github.com/openperouter/openperouter/internal/ovsmodel"
+)
+
+const (
+	OVSSamplingSFlow = "SFlow"
+	OVSSamplingIPFIX = "IPFIX"
+)
+
+// OVSSampling is the traffic sampling of an OVS bridge, exporting the samples
+// to sFlow or IPFIX collectors.
+type OVSSampling struct {
+	Protocol string   `json:"protocol"`
+	Targets  []string `json:"targets"`
+	Rate     int      `json:"rate"`
+	// Polling is the interval in seconds between the counters samples,
+	// only used by sFlow.
+	Polling int `json:"polling,omitempty"`
+}
+
+// ensureOVSBridgeSampling sets the traffic sampling of the given bridge,
+// removing it when sampling is nil.
+func ensureOVSBridgeSampling(ctx context.Context, bridgeName string, sampling *OVSSampling) error {
+	ovs, err := NewOVSClient(ctx)
+	if err != nil {
+		return err
+	}
+	defer ovs.Close()
+
+	return ensureOVSBridgeSamplingWithClient(ctx, ovs, bridgeName, sampling)
+}
+
+// ensureOVSBridgeSamplingWithClient sets the traffic sampling of the given
+// bridge, removing it when sampling is nil. The sFlow and IPFIX rows are not
+// root rows, so OVS deletes them once the bridge stops referencing them, and
+// together with the bridge when the L2VNI goes away.
+func ensureOVSBridgeSamplingWithClient(ctx context.Context, ovs libovsclient.Client, bridgeName string, sampling *OVSSampling) error {
+	if _, err := ovs.Monitor(ctx,
+		ovs.NewMonitor(
+			libovsclient.WithTable(&ovsmodel.Bridge{}),
+			libovsclient.WithTable(&ovsmodel.SFlow{}),
+			libovsclient.WithTable(&ovsmodel.IPFIX{}),
+		),
+	); err != nil {
+		return fmt.Errorf("failed to setup monitor: %w", err)
+	}
+
+	bridge := &ovsmodel.Bridge{Name: bridgeName}
+	if err := ovs.Get(ctx, bridge); err != nil {
+		return fmt.Errorf("failed to get bridge %q: %w", bridgeName, err)
+	}
+
+	var operations []ovsdb.Operation
+	sflow, ipfix := bridge.Sflow, bridge.IPFIX
+	switch {
+	case sampling == nil:
+		sflow, ipfix = nil, nil
+	case sampling.Protocol == OVSSamplingSFlow:
+		ipfix = nil
+		ref, ops, err := sFlowOperations(ctx, ovs, bridge.Sflow, *sampling)
+		if err != nil {
+			return err
+		}
+		sflow = ref
+		operations = append(operations, ops...)
+	case sampling.Protocol == OVSSamplingIPFIX:
+		sflow = nil
+		ref, ops, err := ipfixOperations(ctx, ovs, bridge.IPFIX, *sampling)
+		if err != nil {
+			return err
+		}
+		ipfix = ref
+		operations = append(operations, ops...)
+	default:
+		return fmt.Errorf("unsupported sampling protocol %q", sampling.Protocol)
+	}
+
+	if !equalRef(sflow, bridge.Sflow) || !equalRef(ipfix, bridge.IPFIX) {
+		toUpdate := &ovsmodel.Bridge{UUID: bridge.UUID, Sflow: sflow, IPFIX: ipfix}
+		ops, err := ovs.Where(toUpdate).Update(toUpdate, &toUpdate.Sflow, &toUpdate.IPFIX)
+		if err != nil {
+			return fmt.Errorf("failed to create bridge update operation: %w", err)
+		}
+		operations = append(operations, ops...)
+	}
+	if len(operations) == 0 {
+		return nil
+	}
+
+	reply, err := ovs.Transact(ctx, operations...)
+	if err != nil {
+		return fmt.Errorf("OVS transaction failed when setting the sampling of bridge %s: %w", bridgeName, err)
+	}
+	if _, err := ovsdb.CheckOperationResults(reply, operations); err != nil {
+		return fmt.Errorf("OVS operation failed when setting the sampling of bridge %s: %w", bridgeName, err)
+	}
+	slog.Info("set the sampling of OVS bridge", "bridge", bridgeName, "sampling", sampling)
+	return nil
+}
+
+// sFlowOperations returns the reference to the sFlow row implementing the
+// given sampling, with the operations creating or updating it.
+func sFlowOperations(ctx context.Context, ovs libovsclient.Client, current *string, sampling OVSSampling) (*string, []ovsdb.Operation, error) {
+	desired := &ovsmodel.SFlow{
+		ExternalIDs: map[string]string{"created-by": "openperouter"},
+		Targets:     slices.Sorted(slices.Values(sampling.Targets)),
+		Sampling:    new(sampling.Rate),
+		Polling:     new(sampling.Polling),
+	}
+	if current != nil {
+		existing := &ovsmodel.SFlow{UUID: *current}
+		err := ovs.Get(ctx, existing)
+		if err != nil && !errors.Is(err, libovsclient.ErrNotFound) {
+			return nil, nil, fmt.Errorf("failed to get sFlow %s: %w", *current, err)
+		}
+		if err == nil {
+			if maps.Equal(existing.ExternalIDs, desired.ExternalIDs) &&
+				slices.Equal(slices.Sorted(slices.Values(existing.Targets)), desired.Targets) &&
+				equalRef(existing.Sampling, desired.Sampling) && equalRef(existing.Polling, desired.Polling) {
+				return current, nil, nil
+			}
+			desired.UUID = *current
+			ops, err := ovs.Where(desired).Update(desired,
+				&desired.ExternalIDs, &desired.Targets, &desired.Sampling, &desired.Polling)
+			if err != nil {
+				return nil, nil, fmt.Errorf("failed to create sFlow update operation: %w", err)
+			}
+			return current, ops, nil
+		}
+	}
+
+	desired.UUID = "new_sflow"
+	ops, err := ovs.Create(desired)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create sFlow insert operation: %w", err)
+	}
+	return new(desired.UUID), ops, nil
+}
+
+// ipfixOperations returns the reference to the IPFIX row implementing the
+// given sampling, with the operations creating or updating it.
+func ipfixOperations(ctx context.Context, ovs libovsclient.Client, current *string, sampling OVSSampling) (*string, []ovsdb.Operation, error) {
+	desired := &ovsmodel.IPFIX{
+		ExternalIDs: map[string]string{"created-by": "openperouter"},
+		Targets:     slices.Sorted(slices.Values(sampling.Targets)),
+		Sampling:    new(sampling.Rate),
+	}
+	if current != nil {
+		existing := &ovsmodel.IPFIX{UUID: *current}
+		err := ovs.Get(ctx, existing)
+		if err != nil && !errors.Is(err, libovsclient.ErrNotFound) {
+			return nil, nil, fmt.Errorf("failed to get IPFIX %s: %w", *current, err)
+		}
+		if err == nil {
+			if maps.Equal(existing.ExternalIDs, desired.ExternalIDs) &&
+				slices.Equal(slices.Sorted(slices.Values(existing.Targets)), desired.Targets) &&
+				equalRef(existing.Sampling, desired.Sampling) {
+				return current, nil, nil
+			}
+			desired.UUID = *current
+			ops, err := ovs.Where(desired).Update(desired, &desired.ExternalIDs, &desired.Targets, &desired.Sampling)
+			if err != nil {
+				return nil, nil, fmt.Errorf("failed to create IPFIX update operation: %w", err)
+			}
+			return current, ops, nil
+		}
+	}
+
+	desired.UUID = "new_ipfix"
+	ops, err := ovs.Create(desired)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create IPFIX insert operation: %w", err)
+	}
+	return new(desired.UUID), ops, nil
+}
+
+// equalRef tells whether the two optional values are equal.
+func equalRef[T comparable](a, b *T) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
@@ -4,9 +4,12 @@ package hostnetwork
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"errors"
 	"fmt"
 	"log/slog"
+	"strings"
 
 	"github.com/openperouter/openperouter/internal/netnamespace"
 	"github.com/vishvananda/netlink"
@@ -14,19 +17,73 @@ import (
 )
 
 type PassthroughParams struct {
+	Name     string  `json:"name"`
 	TargetNS string  `json:"namespace"`
 	LinkIPs  LinkIPs `json:"link_ips"`
 }
 
-var PassthroughNames = VethNames{
-	HostSide:      "pt-host",
-	NamespaceSide: "pt-ns",
+const (
+	PassthroughHostPrefix = "pt-host"
+	PassthroughNSPrefix   = "pt-ns"
+)
+
+// PassthroughVethNames returns the names of the veth legs of the passthrough
+// with the given name. The names embed a short hash of the passthrough name,
+// as interface names are limited to 15 characters.
+func PassthroughVethNames(name string) VethNames {
+	hash := sha256.Sum256([]byte(name))
+	id := hex.EncodeToString(hash[:3])
+	return VethNames{
+		HostSide:      fmt.Sprintf("%s-%s", PassthroughHostPrefix, id),
+		NamespaceSide: fmt.Sprintf("%s-%s", PassthroughNSPrefix, id),
+	}
+}
+
+// legacyPassthroughVethNames are the names of the veth legs of the single
+// passthrough supported by the previous releases.
+var legacyPassthroughVethNames = VethNames{
+	HostSide:      PassthroughHostPrefix,
+	NamespaceSide: PassthroughNSPrefix,
+}
+
+// PassthroughVethNamesOnHost returns the names of the veth legs of the given
+// passthrough on this host. A passthrough configured by a previous release,
+// recognized by its host IPs assigned to the legacy host leg, keeps the legacy
+// names, so that upgrading doesn't recreate its veth and reset its session.
+func PassthroughVethNamesOnHost(params PassthroughParams) VethNames {
+	if hasLegacyPassthroughVeth(params.LinkIPs) {
+		return legacyPassthroughVethNames
+	}
+	return PassthroughVethNames(params.Name)
+}
+
+// hasLegacyPassthroughVeth tells whether the legacy host leg exists and has
+// the given host IPs.
+func hasLegacyPassthroughVeth(ips LinkIPs) bool {
+	if ips.HostIPv4 == "" && ips.HostIPv6 == "" {
+		return false
+	}
+	link, err := netlink.LinkByName(legacyPassthroughVethNames.HostSide)
+	if err != nil {
+		return false
+	}
+	for _, ip := range []string{ips.HostIPv4, ips.HostIPv6} {
+		if ip == "" {
+			continue
+		}
+		hasIP, err := interfaceHasIP(link, ip)
+		if err != nil || !hasIP {
+			return false
+		}
+	}
+	return true
 }
 
 func SetupPassthrough(ctx context.Context, params PassthroughParams) error {
 	slog.DebugContext(ctx, "setup passthrough", "params", params)
 	defer slog.DebugContext(ctx, "setup passthrough done")
-	if err := setupNamespacedVeth(ctx, PassthroughNames, params.TargetNS); err != nil {
+	names := PassthroughVethNamesOnHost(params)
+	if err := setupNamespacedVeth(ctx, names, params.TargetNS); err != nil {
 		return fmt.Errorf("SetupPassthrough: failed to setup VNI veth: %w", err)
 	}
 
@@ -40,9 +97,9 @@ func SetupPassthrough(ctx context.Context, params PassthroughParams) error {
 		}
 	}()
 
-	hostVeth, err := netlink.LinkByName(PassthroughNames.HostSide)
+	hostVeth, err := netlink.LinkByName(names.HostSide)
 	if errors.As(err, &netlink.LinkNotFoundError{}) {
-		return fmt.Errorf("SetupPassthrough: host veth %s does not exist, cannot setup Passthrough", PassthroughNames.HostSide)
+		return fmt.Errorf("SetupPassthrough: host veth %s does not exist, cannot setup Passthrough", names.HostSide)
 	}
 
 	err = AssignIPsToInterface(hostVeth, params.LinkIPs.HostIPv4, params.LinkIPs.HostIPv6)
@@ -51,9 +108,9 @@ func SetupPassthrough(ctx context.Context, params PassthroughParams) error {
 	}
 
 	if err := netnamespace.In(ns, func() error {
-		peVeth, err := netlink.LinkByName(PassthroughNames.NamespaceSide)
+		peVeth, err := netlink.LinkByName(names.NamespaceSide)
 		if err != nil {
-			return fmt.Errorf("could not find peer veth %s in namespace %s: %w", PassthroughNames.NamespaceSide, params.TargetNS, err)
+			return fmt.Errorf("could not find peer veth %s in namespace %s: %w", names.NamespaceSide, params.TargetNS, err)
 		}
 
 		err = AssignIPsToInterface(peVeth, params.LinkIPs.NSIPv4, params.LinkIPs.NSIPv6)
@@ -67,14 +124,21 @@ func SetupPassthrough(ctx context.Context, params PassthroughParams) error {
 	return nil
 }
 
-func RemovePassthrough(targetNS string) error {
-	if err := RemoveLinkByName(PassthroughNames.HostSide); err != nil {
-		return fmt.Errorf("RemovePassthrough: failed to remove host link %s: %w", PassthroughNames.HostSide, err)
+// RemoveNonConfiguredPassthroughs removes the veth legs of the passthroughs
+// not in the given list, both on the host and in the target namespace.
+func RemoveNonConfiguredPassthroughs(targetNS string, configured []PassthroughParams) error {
+	expected := map[string]bool{}
+	for _, p := range configured {
+		names := PassthroughVethNamesOnHost(p)
+		expected[names.HostSide] = true
+		expected[names.NamespaceSide] = true
 	}
 
+	errs := removeStaleLinksWithPrefix(PassthroughHostPrefix, expected)
+
 	ns, err := netns.GetFromPath(targetNS)
 	if err != nil {
-		return fmt.Errorf("RemovePassthrough: failed to get network namespace %s: %w", targetNS, err)
+		return fmt.Errorf("RemoveNonConfiguredPassthroughs: failed to get network namespace %s: %w", targetNS, err)
 	}
 	defer func() {
 		if err := ns.Close(); err != nil {
@@ -83,17 +147,37 @@ func RemovePassthrough(targetNS string) error {
 	}()
 
 	if err := netnamespace.In(ns, func() error {
-		if err := RemoveLinkByName(PassthroughNames.NamespaceSide); err != nil {
-			return fmt.Errorf("remove namespace-side veth leg: %w", err)
-		}
-		return nil
+		return errors.Join(removeStaleLinksWithPrefix(PassthroughNSPrefix, expected)...)
 	}); err != nil {
-		return err
+		errs = append(errs, err)
 	}
 
+	if err := errors.Join(errs...); err != nil {
+		return fmt.Errorf("RemoveNonConfiguredPassthroughs: %w", err)
+	}
 	return nil
 }
 
+// removeStaleLinksWithPrefix deletes the links of the current namespace whose
+// name starts with the given prefix and is not in the expected set.
+func removeStaleLinksWithPrefix(prefix string, expected map[string]bool) []error {
+	links, err := netlink.LinkList()
+	if err != nil {
+		return []error{fmt.Errorf("failed to list links: %w", err)}
+	}
+	var failedDeletes []error
+	for _, l := range links {
+		name := l.Attrs().Name
+		if !strings.HasPrefix(name, prefix) || expected[name] {
+			continue
+		}
+		if err := netlink.LinkDel(l); err != nil {
+			failedDeletes = append(failedDeletes, fmt.Errorf("failed to remove link %s: %w", name, err))
+		}
+	}
+	return failedDeletes
+}
+
 func RemoveLinkByName(name string) error {
 	link, err := netlink.LinkByName(name)
 	if errors.As(err, &netlink.LinkNotFoundError{}) {
@@ -35,6 +35,7 @@ var _ = Describe("Passthrough configuration", func() {
 
 	It("should work with IPv4 only passthrough", func() {
 		params := PassthroughParams{
+			Name:     "passthrough",
 			TargetNS: testPassthroughNSPath(),
 			LinkIPs: LinkIPs{
 				HostIPv4: "192.168.10.1/32",
@@ -52,6 +53,7 @@ var _ = Describe("Passthrough configuration", func() {
 
 	It("should work with IPv6 only passthrough", func() {
 		params := PassthroughParams{
+			Name:     "passthrough",
 			TargetNS: testPassthroughNSPath(),
 			LinkIPs: LinkIPs{
 				HostIPv6: "2001:db8::1/128",
@@ -69,6 +71,7 @@ var _ = Describe("Passthrough configuration", func() {
 
 	It("should work with dual-stack passthrough", func() {
 		params := PassthroughParams{
+			Name:     "passthrough",
 			TargetNS: testPassthroughNSPath(),
 			LinkIPs: LinkIPs{
 				HostIPv4: "192.168.10.1/32",
@@ -88,6 +91,7 @@ var _ = Describe("Passthrough configuration", func() {
 
 	It("should remove passthrough interfaces correctly", func() {
 		params := PassthroughParams{
+			Name:     "passthrough",
 			TargetNS: testPassthroughNSPath(),
 			LinkIPs: LinkIPs{
 				HostIPv4: "192.168.10.1/32",
@@ -102,16 +106,54 @@ var _ = Describe("Passthrough configuration", func() {
 			validatePassthrough(g, params, testNS)
 		}, 30*time.Second, 1*time.Second).Should(Succeed())
 
-		err = RemovePassthrough(testPassthroughNSPath())
+		err = RemoveNonConfiguredPassthroughs(testPassthroughNSPath(), nil)
 		Expect(err).NotTo(HaveOccurred())
 
 		Eventually(func(g Gomega) {
-			validatePassthroughRemoved(g, testNS)
+			validatePassthroughRemoved(g, params, testNS)
+		}, 30*time.Second, 1*time.Second).Should(Succeed())
+	})
+
+	It("should work with multiple passthroughs and remove only the non configured ones", func() {
+		infra := PassthroughParams{
+			Name:     "infra",
+			TargetNS: testPassthroughNSPath(),
+			LinkIPs: LinkIPs{
+				HostIPv4: "192.168.10.1/32",
+				NSIPv4:   "192.168.10.0/32",
+			},
+		}
+		storage := PassthroughParams{
+			Name:     "storage",
+			TargetNS: testPassthroughNSPath(),
+			LinkIPs: LinkIPs{
+				HostIPv4: "192.168.20.1/32",
+				NSIPv4:   "192.168.20.0/32",
+			},
+		}
+
+		for _, params := range []PassthroughParams{infra, storage} {
+			err := SetupPassthrough(context.Background(), params)
+			Expect(err).NotTo(HaveOccurred())
+		}
+
+		Eventually(func(g Gomega) {
+			validatePassthrough(g, infra, testNS)
+			validatePassthrough(g, storage, testNS)
+		}, 30*time.Second, 1*time.Second).Should(Succeed())
+
+		err := RemoveNonConfiguredPassthroughs(testPassthroughNSPath(), []PassthroughParams{storage})
+		Expect(err).NotTo(HaveOccurred())
+
+		Eventually(func(g Gomega) {
+			validatePassthroughRemoved(g, infra, testNS)
+			validatePassthrough(g, storage, testNS)
 		}, 30*time.Second, 1*time.Second).Should(Succeed())
 	})
 
 	It("should be idempotent", func() {
 		params := PassthroughParams{
+			Name:     "passthrough",
 			TargetNS: testPassthroughNSPath(),
 			LinkIPs: LinkIPs{
 				HostIPv4: "192.168.10.1/32",
@@ -130,14 +172,82 @@ var _ = Describe("Passthrough configuration", func() {
 		}, 30*time.Second, 1*time.Second).Should(Succeed())
 	})
 
+	It("should keep the legacy veth of the passthrough configured by a previous release", func() {
+		params := PassthroughParams{
+			Name:     "passthrough",
+			TargetNS: testPassthroughNSPath(),
+			LinkIPs: LinkIPs{
+				HostIPv4: "192.168.10.1/32",
+				NSIPv4:   "192.168.10.0/32",
+			},
+		}
+		legacyParams := params
+		legacyParams.Name = ""
+		createLegacyPassthroughVeth(legacyParams)
+		legacy, err := netlink.LinkByName(legacyPassthroughVethNames.HostSide)
+		Expect(err).NotTo(HaveOccurred())
+
+		err = SetupPassthrough(context.Background(), params)
+		Expect(err).NotTo(HaveOccurred())
+		err = RemoveNonConfiguredPassthroughs(testPassthroughNSPath(), []PassthroughParams{params})
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(PassthroughVethNamesOnHost(params)).To(Equal(legacyPassthroughVethNames))
+		current, err := netlink.LinkByName(legacyPassthroughVethNames.HostSide)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(current.Attrs().Index).To(Equal(legacy.Attrs().Index), "legacy veth should not be recreated")
+		_, err = netlink.LinkByName(PassthroughVethNames(params.Name).HostSide)
+		Expect(errors.As(err, &netlink.LinkNotFoundError{})).To(BeTrue())
+	})
+
+	It("should remove the legacy veth not matching any passthrough", func() {
+		createLegacyPassthroughVeth(PassthroughParams{
+			TargetNS: testPassthroughNSPath(),
+			LinkIPs: LinkIPs{
+				HostIPv4: "192.168.20.1/32",
+				NSIPv4:   "192.168.20.0/32",
+			},
+		})
+		params := PassthroughParams{
+			Name:     "passthrough",
+			TargetNS: testPassthroughNSPath(),
+			LinkIPs: LinkIPs{
+				HostIPv4: "192.168.10.1/32",
+				NSIPv4:   "192.168.10.0/32",
+			},
+		}
+
+		err := SetupPassthrough(context.Background(), params)
+		Expect(err).NotTo(HaveOccurred())
+		err = RemoveNonConfiguredPassthroughs(testPassthroughNSPath(), []PassthroughParams{params})
+		Expect(err).NotTo(HaveOccurred())
+
+		Eventually(func(g Gomega) {
+			validatePassthrough(g, params, testNS)
+			_, err := netlink.LinkByName(legacyPassthroughVethNames.HostSide)
+			g.Expect(errors.As(err, &netlink.LinkNotFoundError{})).To(BeTrue(), "legacy veth should be deleted")
+		}, 30*time.Second, 1*time.Second).Should(Succeed())
+	})
+
 	It("should handle removal of non-existent passthrough gracefully", func() {
-		err := RemovePassthrough(testPassthroughNSPath())
+		err := RemoveNonConfiguredPassthroughs(testPassthroughNSPath(), nil)
 		Expect(err).NotTo(HaveOccurred())
 	})
 })
 
+// createLegacyPassthroughVeth creates the veth of a passthrough as configured
+// by the releases supporting a single passthrough.
+func createLegacyPassthroughVeth(params PassthroughParams) {
+	err := setupNamespacedVeth(context.Background(), legacyPassthroughVethNames, params.TargetNS)
+	Expect(err).NotTo(HaveOccurred())
+	link, err := netlink.LinkByName(legacyPassthroughVethNames.HostSide)
+	Expect(err).NotTo(HaveOccurred())
+	err = AssignIPsToInterface(link, params.LinkIPs.HostIPv4, params.LinkIPs.HostIPv6)
+	Expect(err).NotTo(HaveOccurred())
+}
+
 func validatePassthrough(g Gomega, params PassthroughParams, testNS netns.NsHandle) {
-	vethHasIPs(g, PassthroughNames.HostSide, params.LinkIPs.HostIPv4, params.LinkIPs.HostIPv6)
+	vethHasIPs(g, PassthroughVethNames(params.Name).HostSide, params.LinkIPs.HostIPv4, params.LinkIPs.HostIPv6)
 
 	_ = netnamespace.In(testNS, func() error {
 		validatePassthroughInNamespace(g, params)
@@ -146,7 +256,7 @@ func validatePassthrough(g Gomega, params PassthroughParams, testNS netns.NsHand
 }
 
 func validatePassthroughInNamespace(g Gomega, params PassthroughParams) {
-	vethHasIPs(g, PassthroughNames.NamespaceSide, params.LinkIPs.NSIPv4, params.LinkIPs.NSIPv6)
+	vethHasIPs(g, PassthroughVethNames(params.Name).NamespaceSide, params.LinkIPs.NSIPv4, params.LinkIPs.NSIPv6)
 }
 
 func vethHasIPs(g Gomega, linkName, ipv4, ipv6 string) {
@@ -168,17 +278,18 @@ func vethHasIPs(g Gomega, linkName, ipv4, ipv6 string) {
 	}
 }
 
-func validatePassthroughRemoved(g Gomega, testNS netns.NsHandle) {
-	_, err := netlink.LinkByName(PassthroughNames.HostSide)
-	g.Expect(errors.As(err, &netlink.LinkNotFoundError{})).To(BeTrue(), "host passthrough link %q should be deleted", PassthroughNames.HostSide)
+func validatePassthroughRemoved(g Gomega, params PassthroughParams, testNS netns.NsHandle) {
+	names := PassthroughVethNames(params.Name)
+	_, err := netlink.LinkByName(names.HostSide)
+	g.Expect(errors.As(err, &netlink.LinkNotFoundError{})).To(BeTrue(), "host passthrough link %q should be deleted", names.HostSide)
 
 	_ = netnamespace.In(testNS, func() error {
-		validatePassthroughRemovedInNamespace(g)
+		validatePassthroughRemovedInNamespace(g, names)
 		return nil
 	})
 }
 
-func validatePassthroughRemovedInNamespace(g Gomega) {
-	_, err := netlink.LinkByName(PassthroughNames.NamespaceSide)
-	g.Expect(errors.As(err, &netlink.LinkNotFoundError{})).To(BeTrue(), "namespace passthrough link %q should be deleted", PassthroughNames.NamespaceSide)
+func validatePassthroughRemovedInNamespace(g Gomega, names VethNames) {
+	_, err := netlink.LinkByName(names.NamespaceSide)
+	g.Expect(errors.As(err, &netlink.LinkNotFoundError{})).To(BeTrue(), "namespace passthrough link %q should be deleted", names.NamespaceSide)
 }
@@ -0,0 +1,563 @@
+// SPDX-License-Identifier:Apache-2.0
+
+package hostnetwork
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net"
+
+	"github.com/openperouter/openperouter/internal/netnamespace"
+	"github.com/vishvananda/netlink"
+	"github.com/vishvananda/netlink/nl"
+	"github.com/vishvananda/netns"
+	"golang.org/x/sys/unix"
+)
+
+const (
+	// SharedBridgeName is the name of the VLAN aware bridge shared by the
+	// VNIs carried by the single VXLan device.
+	SharedBridgeName = "br-pe"
+	// SharedVXLanName is the name of the VXLan device, with VNI filtering,
+	// shared by the VNIs mapped to a VLAN of the shared bridge.
+	SharedVXLanName = "vxlan-pe"
+
+	maxVLANID = 4094
+)
+
+// Netlink attributes of the VNI filter of a VXLan device, from
+// include/uapi/linux/if_link.h.
+const (
+	vxlanVNIFilterEntry      = 1
+	vxlanVNIFilterEntryStart = 1
+)
+
+// tunnelMsg is the header of the RTM_*TUNNEL messages used to manage the VNI
+// filter of a VXLan device, struct tunnel_msg in the kernel.
+type tunnelMsg struct {
+	family  uint8
+	ifindex uint32
+}
+
+func (m *tunnelMsg) Len() int {
+	return 8
+}
+
+func (m *tunnelMsg) Serialize() []byte {
+	b := make([]byte, m.Len())
+	b[0] = m.family
+	nl.NativeEndian().PutUint32(b[4:], m.ifindex)
+	return b
+}
+
+// setupSharedVNI sets up the configuration required by FRR to serve a
+// given VNI through the bridge and the VXLan device shared by all the VNIs
+// using the single VXLan device mode. This includes:
+// - the VLAN aware bridge and the VXLan device, if they don't exist yet
+// - a VLAN of the bridge, mapped to the VNI on the VXLan device
+// - a VLAN interface on the bridge named after the VNI as the per VNI
+// bridge, bound to the VRF when params.VRF is non-empty
+//
+// The VXLan interface of the VNI, left behind by the per VNI mode, is
+// removed, and so is its bridge, replaced by the VLAN interface.
+func setupSharedVNI(ctx context.Context, params VNIParams, sviOptions ...NetlinkOption) error {
+	slog.DebugContext(ctx, "setting up shared VNI", "params", params)
+	defer slog.DebugContext(ctx, "end setting up shared VNI", "params", params)
+	ns, err := netns.GetFromPath(params.TargetNS)
+	if err != nil {
+		return fmt.Errorf("failed to get network namespace %s: %w", params.TargetNS, err)
+	}
+	defer func() {
+		if err := ns.Close(); err != nil {
+			slog.Error("failed to close namespace", "namespace", params.TargetNS, "error", err)
+		}
+	}()
+
+	return netnamespace.In(ns, func() error {
+		if err := deleteLinkByName(VXLanNameFromVNI(params.VNI)); err != nil {
+			return err
+		}
+
+		slog.DebugContext(ctx, "setting up shared bridge")
+		bridge, err := setupSharedBridge()
+		if err != nil {
+			return err
+		}
+
+		slog.DebugContext(ctx, "setting up shared vxlan")
+		vxlan, err := setupSharedVXLan(params, bridge)
+		if err != nil {
+			return err
+		}
+
+		vid, err := vlanForVNI(bridge, params.VNI)
+		if err != nil {
+			return err
+		}
+		if err := mapVNIToVLAN(bridge, vxlan, params.VNI, vid); err != nil {
+			return err
+		}
+		return setupSVI(params, bridge, vid, sviOptions...)
+	})
+}
+
+func setupSharedBridge() (*netlink.Bridge, error) {
+	toCreate := &netlink.Bridge{
+		LinkAttrs:       netlink.LinkAttrs{Name: SharedBridgeName},
+		VlanFiltering:   new(true),
+		VlanDefaultPVID: new(uint16(0)),
+	}
+
+	link, err := netlink.LinkByName(SharedBridgeName)
+	if err != nil && !errors.As(err, &netlink.LinkNotFoundError{}) {
+		return nil, fmt.Errorf("could not find bridge by name %s: %w", SharedBridgeName, err)
+	}
+	bridge, ok := link.(*netlink.Bridge)
+	switch {
+	case !ok:
+		if link != nil {
+			if err := netlink.LinkDel(link); err != nil {
+				return nil, fmt.Errorf("failed to delete link %v: %w", link, err)
+			}
+		}
+		bridge, err = createSharedBridge(toCreate)
+		if err != nil {
+			return nil, err
+		}
+	default:
+		if bridge.VlanFiltering == nil || !*bridge.VlanFiltering {
+			if err := netlink.BridgeSetVlanFiltering(bridge, true); err != nil {
+				return nil, fmt.Errorf("could not enable vlan filtering on bridge %s: %w", SharedBridgeName, err)
+			}
+		}
+		if bridge.VlanDefaultPVID == nil || *bridge.VlanDefaultPVID != 0 {
+			if err := netlink.BridgeSetVlanDefaultPVID(bridge, 0); err != nil {
+				return nil, fmt.Errorf("could not clear the default pvid of bridge %s: %w", SharedBridgeName, err)
+			}
+		}
+	}
+
+	if err := linkSetUp(bridge); err != nil {
+		return nil, fmt.Errorf("could not set link up for bridge %s: %v", SharedBridgeName, err)
+	}
+	return bridge, nil
+}
+
+// createSharedBridge creates the shared bridge and pins its random mac
+// address, so that it doesn't follow the ones of its ports, as the VLAN
+// interfaces inherit it and the bridge delivers only the frames sent to
+// its own address to them.
+func createSharedBridge(toCreate *netlink.Bridge) (*netlink.Bridge, error) {
+	if err := netlink.LinkAdd(toCreate); err != nil {
+		return nil, fmt.Errorf("could not create bridge %s: %w", SharedBridgeName, err)
+	}
+	link, err := netlink.LinkByName(SharedBridgeName)
+	if err != nil {
+		return nil, fmt.Errorf("could not find bridge by name %s: %w", SharedBridgeName, err)
+	}
+	if err := netlink.LinkSetHardwareAddr(link, link.Attrs().HardwareAddr); err != nil {
+		return nil, fmt.Errorf("failed to set mac address to bridge %s: %w", SharedBridgeName, err)
+	}
+	bridge, ok := link.(*netlink.Bridge)
+	if !ok {
+		return nil, fmt.Errorf("link %s is not a bridge", SharedBridgeName)
+	}
+	return bridge, nil
+}
+
+// setupSharedVXLan sets up the external VXLan device with VNI filtering
+// shared by the VNIs, which all agree on its source address and port.
+// The device is recreated if they changed.
+func setupSharedVXLan(params VNIParams, bridge *netlink.Bridge) (*netlink.Vxlan, error) {
+	loopback, err := net.InterfaceByName(loopbackName)
+	if err != nil {
+		return nil, fmt.Errorf("failed looking for vtep loopback interface %s: %w", loopbackName, err)
+	}
+	vtepIP, _, err := net.ParseCIDR(params.VTEPIP)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse vtep ip %v: %w", params.VTEPIP, err)
+	}
+	if params.VXLanPort == nil {
+		return nil, errors.New("failed to parse VXLAN information, VXLAN port is nil")
+	}
+	port := int(*params.VXLanPort)
+
+	link, err := netlink.LinkByName(SharedVXLanName)
+	if err != nil && !errors.As(err, &netlink.LinkNotFoundError{}) {
+		return nil, fmt.Errorf("failed to get vxlan link by name %s: %w", SharedVXLanName, err)
+	}
+	vxlan, ok := link.(*netlink.Vxlan)
+	if !ok || checkSharedVXLanConfigured(vxlan, bridge.Index, loopback.Index, vtepIP, port) != nil {
+		if link != nil {
+			if err := netlink.LinkDel(link); err != nil {
+				return nil, fmt.Errorf("failed to delete link %v: %w", link, err)
+			}
+		}
+		if err := deletePerVNIVXLans(); err != nil {
+			return nil, err
+		}
+		vxlan, err = createSharedVXLan(bridge.Index, loopback.Index, vtepIP, port)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if err := setAddrGenModeNone(vxlan); err != nil {
+		return nil, fmt.Errorf("failed to set addr_gen_mode to 1 for %s: %w", vxlan.Name, err)
+	}
+	if err := setBridgePortFlags(vxlan, true, unix.IFLA_BRPORT_NEIGH_SUPPRESS, unix.IFLA_BRPORT_VLAN_TUNNEL); err != nil {
+		return nil, fmt.Errorf("failed to set bridge port flags for %s: %w", vxlan.Name, err)
+	}
+	if err := linkSetUp(vxlan); err != nil {
+		return nil, fmt.Errorf("could not set link up for vxlan %s: %v", vxlan.Name, err)
+	}
+	return vxlan, nil
+}
+
+// checkSharedVXLanConfigured checks if the given shared VXLan has the
+// required properties.
+func checkSharedVXLanConfigured(vxlan *netlink.Vxlan, bridgeIndex, loopbackIndex int, vtepIP net.IP, port int) error {
+	if vxlan.MasterIndex != bridgeIndex {
+		return fmt.Errorf("master index is not bridge index: %d, %d", vxlan.MasterIndex, bridgeIndex)
+	}
+	if !vxlan.FlowBased {
+		return errors.New("external mode is disabled")
+	}
+	if vxlan.Port != port {
+		return fmt.Errorf("port is not one coming from params: %d, %d", vxlan.Port, port)
+	}
+	if vxlan.Learning {
+		return errors.New("learning is enabled")
+	}
+	if !vxlan.SrcAddr.Equal(vtepIP) {
+		return fmt.Errorf("src addr does not match vtep ip: %v, expected %v", vxlan.SrcAddr, vtepIP)
+	}
+	if vxlan.VtepDevIndex != loopbackIndex {
+		return fmt.Errorf("vtep dev index is not loopback index: %d %d", vxlan.VtepDevIndex, loopbackIndex)
+	}
+	return nil
+}
+
+// createSharedVXLan creates the shared VXLan device with a raw netlink
+// request, as the netlink library can't enable VNI filtering.
+func createSharedVXLan(bridgeIndex, loopbackIndex int, vtepIP net.IP, port int) (*netlink.Vxlan, error) {
+	req := nl.NewNetlinkRequest(unix.RTM_NEWLINK, unix.NLM_F_CREATE|unix.NLM_F_EXCL|unix.NLM_F_ACK)
+	req.AddData(nl.NewIfInfomsg(unix.AF_UNSPEC))
+	req.AddData(nl.NewRtAttr(unix.IFLA_IFNAME, nl.ZeroTerminated(SharedVXLanName)))
+	req.AddData(nl.NewRtAttr(unix.IFLA_MASTER, nl.Uint32Attr(uint32(bridgeIndex))))
+
+	linkInfo := nl.NewRtAttr(unix.IFLA_LINKINFO, nil)
+	linkInfo.AddRtAttr(nl.IFLA_INFO_KIND, nl.NonZeroTerminated("vxlan"))
+	data := linkInfo.AddRtAttr(nl.IFLA_INFO_DATA, nil)
+	data.AddRtAttr(unix.IFLA_VXLAN_LINK, nl.Uint32Attr(uint32(loopbackIndex)))
+	if ip := vtepIP.To4(); ip != nil {
+		data.AddRtAttr(unix.IFLA_VXLAN_LOCAL, []byte(ip))
+	} else {
+		data.AddRtAttr(unix.IFLA_VXLAN_LOCAL6, []byte(vtepIP.To16()))
+	}
+	portAttr := make([]byte, 2)
+	binary.BigEndian.PutUint16(portAttr, uint16(port))
+	data.AddRtAttr(unix.IFLA_VXLAN_PORT, portAttr)
+	data.AddRtAttr(unix.IFLA_VXLAN_LEARNING, nl.Uint8Attr(0))
+	data.AddRtAttr(unix.IFLA_VXLAN_COLLECT_METADATA, nl.Uint8Attr(1))
+	data.AddRtAttr(unix.IFLA_VXLAN_VNIFILTER, nl.Uint8Attr(1))
+	req.AddData(linkInfo)
+
+	if _, err := req.Execute(unix.NETLINK_ROUTE, 0); err != nil {
+		return nil, fmt.Errorf("failed to create vxlan %s: %w", SharedVXLanName, err)
+	}
+
+	link, err := netlink.LinkByName(SharedVXLanName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get vxlan link by name %s: %w", SharedVXLanName, err)
+	}
+	vxlan, ok := link.(*netlink.Vxlan)
+	if !ok {
+		return nil, fmt.Errorf("link %s is not a vxlan", SharedVXLanName)
+	}
+	return vxlan, nil
+}
+
+// vlanForVNI returns the VLAN of the shared bridge the VNI is mapped to,
+// allocating the lowest free one if the VNI is not mapped yet. The VLAN
+// of an existing VLAN interface of the VNI is kept, so the VNI keeps its
+// VLAN even when the shared VXLan device is recreated.
+func vlanForVNI(bridge *netlink.Bridge, vni int32) (uint16, error) {
+	link, err := netlink.LinkByName(BridgeName(vni))
+	if err != nil && !errors.As(err, &netlink.LinkNotFoundError{}) {
+		return 0, fmt.Errorf("could not find link by name %s: %w", BridgeName(vni), err)
+	}
+	if svi, ok := link.(*netlink.Vlan); ok && svi.ParentIndex == bridge.Index {
+		return uint16(svi.VlanId), nil
+	}
+
+	tunnels, err := netlink.BridgeVlanTunnelShow()
+	if err != nil {
+		return 0, fmt.Errorf("failed to list the vlan to vni mappings: %w", err)
+	}
+	used := map[uint16]bool{}
+	for _, t := range tunnels {
+		if t.TunId == uint32(vni) {
+			return t.Vid, nil
+		}
+		used[t.Vid] = true
+	}
+	vlans, err := netlink.BridgeVlanList()
+	if err != nil {
+		return 0, fmt.Errorf("failed to list bridge vlans: %w", err)
+	}
+	for _, v := range vlans[int32(bridge.Index)] {
+		used[v.Vid] = true
+	}
+
+	for vid := uint16(1); vid <= maxVLANID; vid++ {
+		if !used[vid] {
+			return vid, nil
+		}
+	}
+	return 0, fmt.Errorf("no free vlan on bridge %s for vni %d", SharedBridgeName, vni)
+}
+
+// mapVNIToVLAN adds the VLAN to the shared bridge and VXLan device, and maps
+// it to the VNI, adding the VNI to the VNI filter of the VXLan device.
+// The kernel ignores the additions not changing anything, so they don't
+// generate netlink events, except for the mapping that must be checked.
+func mapVNIToVLAN(bridge *netlink.Bridge, vxlan *netlink.Vxlan, vni int32, vid uint16) error {
+	if err := vniFilterRequest(unix.RTM_NEWTUNNEL, vxlan, vni); err != nil {
+		return fmt.Errorf("failed to add vni %d to the filter of %s: %w", vni, vxlan.Name, err)
+	}
+	if err := netlink.BridgeVlanAdd(bridge, vid, false, false, true, false); err != nil {
+		return fmt.Errorf("failed to add vlan %d to bridge %s: %w", vid, bridge.Name, err)
+	}
+	if err := netlink.BridgeVlanAdd(vxlan, vid, false, false, false, true); err != nil {
+		return fmt.Errorf("failed to add vlan %d to vxlan %s: %w", vid, vxlan.Name, err)
+	}
+
+	tunnels, err := netlink.BridgeVlanTunnelShow()
+	if err != nil {
+		return fmt.Errorf("failed to list the vlan to vni mappings: %w", err)
+	}
+	for _, t := range tunnels {
+		if t.Vid != vid {
+			continue
+		}
+		if t.TunId == uint32(vni) {
+			return nil
+		}
+		if err := netlink.BridgeVlanDelTunnelInfo(vxlan, vid, t.TunId, false, true); err != nil {
+			return fmt.Errorf("failed to unmap vlan %d from vni %d on %s: %w", vid, t.TunId, vxlan.Name, err)
+		}
+	}
+	if err := netlink.BridgeVlanAddTunnelInfo(vxlan, vid, uint32(vni), false, true); err != nil {
+		return fmt.Errorf("failed to map vlan %d to vni %d on %s: %w", vid, vni, vxlan.Name, err)
+	}
+	return nil
+}
+
+// setupSVI creates the VLAN interface of the VNI on the shared bridge,
+// replacing the per VNI bridge with the same name if any, binds it to
+// the VRF when params.VRF is non-empty and applies the given options.
+func setupSVI(params VNIParams, bridge *netlink.Bridge, vid uint16, opts ...NetlinkOption) error {
+	name := BridgeName(params.VNI)
+	toCreate := &netlink.Vlan{
+		LinkAttrs: netlink.LinkAttrs{
+			Name:        name,
+			ParentIndex: bridge.Index,
+		},
+		VlanId: int(vid),
+	}
+
+	var svi netlink.Link = toCreate
+	link, err := netlink.LinkByName(name)
+	if err != nil && !errors.As(err, &netlink.LinkNotFoundError{}) {
+		return fmt.Errorf("could not find link by name %s: %w", name, err)
+	}
+	existing, ok := link.(*netlink.Vlan)
+	if ok && existing.ParentIndex == bridge.Index && existing.VlanId == int(vid) {
+		svi = existing
+	} else {
+		if link != nil {
+			if err := netlink.LinkDel(link); err != nil {
+				return fmt.Errorf("failed to delete link %v: %w", link, err)
+			}
+		}
+		if err := netlink.LinkAdd(toCreate); err != nil {
+			return fmt.Errorf("could not create vlan interface %s: %w", name, err)
+		}
+	}
+
+	if params.VRF != "" {
+		vrf, err := lookupVRF(params.VRF)
+		if err != nil {
+			return fmt.Errorf("could not find vrf %s for vlan interface %s: %w", params.VRF, name, err)
+		}
+		if err := linkSetMaster(svi, vrf); err != nil {
+			return fmt.Errorf("could not bind vlan interface %s to vrf %s: %w", name, params.VRF, err)
+		}
+	}
+
+	for _, opt := range opts {
+		if err := opt(svi); err != nil {
+			return fmt.Errorf("failed to apply option for vlan interface %s: %w", name, err)
+		}
+	}
+
+	if err := linkSetUp(svi); err != nil {
+		return fmt.Errorf("could not set link up for vlan interface %s: %v", name, err)
+	}
+	return nil
+}
+
+// attachToSharedBridge enslaves the link to the shared bridge as an access
+// port of the VLAN the VNI is mapped to.
+func attachToSharedBridge(link netlink.Link, vni int32) error {
+	master, err := netlink.LinkByName(SharedBridgeName)
+	if err != nil {
+		return fmt.Errorf("could not find bridge %s: %w", SharedBridgeName, err)
+	}
+	bridge, ok := master.(*netlink.Bridge)
+	if !ok {
+		return fmt.Errorf("link %s is not a bridge", SharedBridgeName)
+	}
+	if err := linkSetMaster(link, bridge); err != nil {
+		return fmt.Errorf("failed to set bridge %s as master of %s: %w", SharedBridgeName, link.Attrs().Name, err)
+	}
+	vid, err := vlanForVNI(bridge, vni)
+	if err != nil {
+		return err
+	}
+	if err := netlink.BridgeVlanAdd(link, vid, true, true, false, true); err != nil {
+		return fmt.Errorf("failed to add vlan %d to %s: %w", vid, link.Attrs().Name, err)
+	}
+	return nil
+}
+
+// ensureSVIMacAddress sets the MAC address of the distributed gateway on
+// the VLAN interface of the VNI, and makes the shared bridge deliver the
+// frames sent to it on the VLAN of the VNI.
+func ensureSVIMacAddress(svi netlink.Link, macAddress net.HardwareAddr) error {
+	vlan, ok := svi.(*netlink.Vlan)
+	if !ok {
+		return fmt.Errorf("link %s is not a vlan interface", svi.Attrs().Name)
+	}
+	bridge, err := netlink.LinkByIndex(vlan.ParentIndex)
+	if err != nil {
+		return fmt.Errorf("failed to find the parent of %s: %w", svi.Attrs().Name, err)
+	}
+	oldMacAddress := svi.Attrs().HardwareAddr
+	if err := ensureBridgeMacAddress(svi, macAddress); err != nil {
+		return err
+	}
+
+	fdbEntry := func(mac net.HardwareAddr) *netlink.Neigh {
+		return &netlink.Neigh{
+			LinkIndex:    vlan.ParentIndex,
+			Family:       unix.AF_BRIDGE,
+			State:        netlink.NUD_PERMANENT,
+			Flags:        netlink.NTF_SELF,
+			HardwareAddr: mac,
+			Vlan:         vlan.VlanId,
+		}
+	}
+	if err := netlink.NeighSet(fdbEntry(macAddress)); err != nil {
+		return fmt.Errorf("failed to add the fdb entry of %s to bridge %s: %w", svi.Attrs().Name, SharedBridgeName, err)
+	}
+	// the entry of the previous gateway mac, unless it is the one of the bridge
+	// that the vlan interface inherits when created
+	if bytes.Equal(oldMacAddress, macAddress) || bytes.Equal(oldMacAddress, bridge.Attrs().HardwareAddr) {
+		return nil
+	}
+	if err := netlink.NeighDel(fdbEntry(oldMacAddress)); err != nil && !errors.Is(err, unix.ENOENT) {
+		return fmt.Errorf("failed to remove the stale fdb entry of %s from bridge %s: %w", svi.Attrs().Name, SharedBridgeName, err)
+	}
+	return nil
+}
+
+// removeSharedVNIs removes from the shared VXLan device the VNIs that are not
+// carried by it anymore, and the shared devices themselves when no VNI is.
+func removeSharedVNIs(vnis map[int32]bool) error {
+	if len(vnis) == 0 {
+		return errors.Join(deleteLinkByName(SharedVXLanName), deleteLinkByName(SharedBridgeName))
+	}
+
+	link, err := netlink.LinkByName(SharedVXLanName)
+	if errors.As(err, &netlink.LinkNotFoundError{}) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get vxlan link by name %s: %w", SharedVXLanName, err)
+	}
+	bridge, err := netlink.LinkByName(SharedBridgeName)
+	if err != nil {
+		return fmt.Errorf("could not find bridge %s: %w", SharedBridgeName, err)
+	}
+
+	tunnels, err := netlink.BridgeVlanTunnelShow()
+	if err != nil {
+		return fmt.Errorf("failed to list the vlan to vni mappings: %w", err)
+	}
+	var errs []error
+	for _, t := range tunnels {
+		if vnis[int32(t.TunId)] {
+			continue
+		}
+		if err := netlink.BridgeVlanDelTunnelInfo(link, t.Vid, t.TunId, false, true); err != nil {
+			errs = append(errs, fmt.Errorf("failed to unmap vlan %d from vni %d: %w", t.Vid, t.TunId, err))
+		}
+		if err := netlink.BridgeVlanDel(link, t.Vid, false, false, false, true); err != nil {
+			errs = append(errs, fmt.Errorf("failed to remove vlan %d from %s: %w", t.Vid, SharedVXLanName, err))
+		}
+		if err := netlink.BridgeVlanDel(bridge, t.Vid, false, false, true, false); err != nil {
+			errs = append(errs, fmt.Errorf("failed to remove vlan %d from %s: %w", t.Vid, SharedBridgeName, err))
+		}
+		err := vniFilterRequest(unix.RTM_DELTUNNEL, link, int32(t.TunId))
+		if err != nil && !errors.Is(err, unix.ENOENT) {
+			errs = append(errs, fmt.Errorf("failed to remove vni %d from the filter of %s: %w", t.TunId, SharedVXLanName, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// vniFilterRequest adds or removes, depending on msgType, the VNI to or from
+// the VNI filter of the given VXLan device.
+func vniFilterRequest(msgType int, vxlan netlink.Link, vni int32) error {
+	req := nl.NewNetlinkRequest(msgType, unix.NLM_F_ACK)
+	req.AddData(&tunnelMsg{family: unix.AF_BRIDGE, ifindex: uint32(vxlan.Attrs().Index)})
+	entry := nl.NewRtAttr(vxlanVNIFilterEntry|unix.NLA_F_NESTED, nil)
+	entry.AddRtAttr(vxlanVNIFilterEntryStart, nl.Uint32Attr(uint32(vni)))
+	req.AddData(entry)
+	if _, err := req.Execute(unix.NETLINK_ROUTE, 0); err != nil {
+		return fmt.Errorf("error executing request: %w", err)
+	}
+	return nil
+}
+
+// deleteLinkByName deletes the link with the given name, if it exists.
+func deleteLinkByName(name string) error {
+	link, err := netlink.LinkByName(name)
+	if errors.As(err, &netlink.LinkNotFoundError{}) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get link by name %s: %w", name, err)
+	}
+	if err := netlink.LinkDel(link); err != nil {
+		return fmt.Errorf("failed to delete link %s: %w", name, err)
+	}
+	return nil
+}
+
+// deletePerVNIVXLans deletes the VXLan interfaces of the VNIs, left behind
+// by the per VNI mode, as they can't share the port of the shared VXLan
+// device.
+func deletePerVNIVXLans() error {
+	links, err := netlink.LinkList()
+	if err != nil {
+		return fmt.Errorf("failed to list links: %w", err)
+	}
+	return deleteLinksForType(VXLanLinkType, map[int32]bool{}, links, vniFromVXLanName)
+}
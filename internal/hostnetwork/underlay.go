@@ -27,9 +27,11 @@ type UnderlayParams struct {
 	// UnderlayInterfaces are the underlay interfaces to provision: either
 	// host network devices moved into the namespace or CNI-provisioned
 	// interfaces; an underlay uses one mode or the other.
-	UnderlayInterfaces []UnderlayInterface           `json:"underlay_interfaces"`
-	TargetNS           string                        `json:"target_ns"`
-	TunnelEndpoint     *UnderlayTunnelEndpointParams `json:"tunnel_endpoint"`
+	UnderlayInterfaces []UnderlayInterface `json:"underlay_interfaces"`
+	TargetNS           string              `json:"target_ns"`
+	// TunnelEndpoints are the VTEPs of the node, one for each underlay
+	// with a tunnel endpoint.
+	TunnelEndpoints []UnderlayTunnelEndpointParams `json:"tunnel_endpoints"`
 }
 
 // UnderlayInterface describes how a single underlay interface is
@@ -57,6 +59,9 @@ type CNIDeviceParams struct {
 type UnderlayTunnelEndpointParams struct {
 	IPv4CIDR string `json:"ipv4_cidr"`
 	IPv6CIDR string `json:"ipv6_cidr"`
+	// SingleVXLanDevice tells whether the VNIs using this tunnel endpoint
+	// share the VLAN aware bridge and the VXLan device.
+	SingleVXLanDevice bool `json:"single_vxlan_device,omitempty"`
 }
 
 func SetupUnderlay(ctx context.Context, params UnderlayParams) error {
@@ -106,16 +111,18 @@ func SetupUnderlay(ctx context.Context, params UnderlayParams) error {
 
 	}
 
-	if params.TunnelEndpoint == nil {
+	if len(params.TunnelEndpoints) == 0 {
 		return nil
 	}
 
-	vtepIPs := make([]string, 0, 2)
-	if ip := params.TunnelEndpoint.IPv4CIDR; ip != "" {
-		vtepIPs = append(vtepIPs, ip)
-	}
-	if ip := params.TunnelEndpoint.IPv6CIDR; ip != "" {
-		vtepIPs = append(vtepIPs, ip)
+	vtepIPs := make([]string, 0, 2*len(params.TunnelEndpoints))
+	for _, tunnelEndpoint := range params.TunnelEndpoints {
+		if ip := tunnelEndpoint.IPv4CIDR; ip != "" {
+			vtepIPs = append(vtepIPs, ip)
+		}
+		if ip := tunnelEndpoint.IPv6CIDR; ip != "" {
+			vtepIPs = append(vtepIPs, ip)
+		}
 	}
 	if err := ensureLoopback(ctx, targetNetNS, vtepIPs...); err != nil {
 		return err
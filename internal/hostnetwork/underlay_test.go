@@ -51,9 +51,9 @@ var _ = Describe("Underlay configuration should work when", func() {
 	It("should work with a single underlay", func() {
 		params := UnderlayParams{
 			UnderlayInterfaces: netdevInterfaces(underlayTestInterface),
-			TunnelEndpoint: &UnderlayTunnelEndpointParams{
+			TunnelEndpoints: []UnderlayTunnelEndpointParams{{
 				IPv4CIDR: "192.168.1.1/32",
-			},
+			}},
 			TargetNS: underlayTestNSPath(),
 		}
 		err := SetupUnderlay(context.Background(), params)
@@ -67,9 +67,9 @@ var _ = Describe("Underlay configuration should work when", func() {
 	It("creating the same underlay twice should be idempotent", func() {
 		params := UnderlayParams{
 			UnderlayInterfaces: netdevInterfaces(underlayTestInterface),
-			TunnelEndpoint: &UnderlayTunnelEndpointParams{
+			TunnelEndpoints: []UnderlayTunnelEndpointParams{{
 				IPv4CIDR: "192.168.1.1/32",
-			},
+			}},
 			TargetNS: underlayTestNSPath(),
 		}
 		err := SetupUnderlay(context.Background(), params)
@@ -85,9 +85,9 @@ var _ = Describe("Underlay configuration should work when", func() {
 	It("changing the underlay interface should restore old and move new", func() {
 		params := UnderlayParams{
 			UnderlayInterfaces: netdevInterfaces(underlayTestInterface),
-			TunnelEndpoint: &UnderlayTunnelEndpointParams{
+			TunnelEndpoints: []UnderlayTunnelEndpointParams{{
 				IPv4CIDR: "192.168.1.1/32",
-			},
+			}},
 			TargetNS: underlayTestNSPath(),
 		}
 		err := SetupUnderlay(context.Background(), params)
@@ -98,9 +98,9 @@ var _ = Describe("Underlay configuration should work when", func() {
 
 		newParams := UnderlayParams{
 			UnderlayInterfaces: netdevInterfaces(underlayTestInterfaceEdit),
-			TunnelEndpoint: &UnderlayTunnelEndpointParams{
+			TunnelEndpoints: []UnderlayTunnelEndpointParams{{
 				IPv4CIDR: "192.168.1.1/32",
-			},
+			}},
 			TargetNS: underlayTestNSPath(),
 		}
 		err = SetupUnderlay(context.Background(), newParams)
@@ -131,9 +131,9 @@ var _ = Describe("Underlay configuration should work when", func() {
 	It("changing the vtepip should work", func() {
 		params := UnderlayParams{
 			UnderlayInterfaces: netdevInterfaces(underlayTestInterface),
-			TunnelEndpoint: &UnderlayTunnelEndpointParams{
+			TunnelEndpoints: []UnderlayTunnelEndpointParams{{
 				IPv4CIDR: "192.168.1.1/32",
-			},
+			}},
 			TargetNS: underlayTestNSPath(),
 		}
 		err := SetupUnderlay(context.Background(), params)
@@ -143,8 +143,8 @@ var _ = Describe("Underlay configuration should work when", func() {
 			validateUnderlayInNS(g, testNs, params)
 		}, 30*time.Second, 1*time.Second).Should(Succeed())
 
-		params.TunnelEndpoint.IPv4CIDR = "192.168.1.2/32"
-		params.TunnelEndpoint.IPv6CIDR = "2001:db8:192:168:1::2/128"
+		params.TunnelEndpoints[0].IPv4CIDR = "192.168.1.2/32"
+		params.TunnelEndpoints[0].IPv6CIDR = "2001:db8:192:168:1::2/128"
 
 		err = SetupUnderlay(context.Background(), params)
 		Expect(err).NotTo(HaveOccurred())
@@ -171,9 +171,9 @@ var _ = Describe("Underlay configuration should work when", func() {
 		params := UnderlayParams{
 			UnderlayInterfaces: netdevInterfaces(underlayTestInterface),
 			TargetNS:           underlayTestNSPath(),
-			TunnelEndpoint: &UnderlayTunnelEndpointParams{
+			TunnelEndpoints: []UnderlayTunnelEndpointParams{{
 				IPv6CIDR: "2001:db8:192:168::1/128",
-			},
+			}},
 		}
 		err := SetupUnderlay(context.Background(), params)
 		Expect(err).NotTo(HaveOccurred())
@@ -187,10 +187,10 @@ var _ = Describe("Underlay configuration should work when", func() {
 		params := UnderlayParams{
 			UnderlayInterfaces: netdevInterfaces(underlayTestInterface),
 			TargetNS:           underlayTestNSPath(),
-			TunnelEndpoint: &UnderlayTunnelEndpointParams{
+			TunnelEndpoints: []UnderlayTunnelEndpointParams{{
 				IPv4CIDR: "192.168.1.1/32",
 				IPv6CIDR: "2001:db8:192:168::1/128",
-			},
+			}},
 		}
 		err := SetupUnderlay(context.Background(), params)
 		Expect(err).NotTo(HaveOccurred())
@@ -206,9 +206,9 @@ var _ = Describe("Underlay configuration should work when", func() {
 		}
 		params := UnderlayParams{
 			UnderlayInterfaces: netdevInterfaces(slices.Collect(maps.Keys(underlayInterfaces))...),
-			TunnelEndpoint: &UnderlayTunnelEndpointParams{
+			TunnelEndpoints: []UnderlayTunnelEndpointParams{{
 				IPv4CIDR: "192.168.1.1/32",
-			},
+			}},
 			TargetNS: underlayTestNSPath(),
 		}
 		Expect(SetupUnderlay(context.Background(), params)).To(Succeed())
@@ -387,13 +387,15 @@ func validateUnderlay(g Gomega, params UnderlayParams, interfaceIPs map[string]s
 
 func validateLoopback(g Gomega, l netlink.Link, params UnderlayParams) {
 	hasIP := false
-	if params.TunnelEndpoint != nil && params.TunnelEndpoint.IPv4CIDR != "" {
-		hasIP = true
-		validateIP(g, l, params.TunnelEndpoint.IPv4CIDR)
-	}
-	if params.TunnelEndpoint != nil && params.TunnelEndpoint.IPv6CIDR != "" {
-		hasIP = true
-		validateIP(g, l, params.TunnelEndpoint.IPv6CIDR)
+	for _, tunnelEndpoint := range params.TunnelEndpoints {
+		if tunnelEndpoint.IPv4CIDR != "" {
+			hasIP = true
+			validateIP(g, l, tunnelEndpoint.IPv4CIDR)
+		}
+		if tunnelEndpoint.IPv6CIDR != "" {
+			hasIP = true
+			validateIP(g, l, tunnelEndpoint.IPv6CIDR)
+		}
 	}
 	if hasIP {
 		return
@@ -437,15 +439,13 @@ func cleanTest(namespace string) {
 	for _, l := range links {
 		if strings.HasPrefix(l.Attrs().Name, "test") ||
 			strings.HasPrefix(l.Attrs().Name, PEVethPrefix) ||
-			strings.HasPrefix(l.Attrs().Name, HostVethPrefix) {
+			strings.HasPrefix(l.Attrs().Name, HostVethPrefix) ||
+			strings.HasPrefix(l.Attrs().Name, PassthroughHostPrefix) {
 			err := netlink.LinkDel(l)
 			Expect(err).NotTo(HaveOccurred())
 		}
 	}
 
-	err = RemoveLinkByName(PassthroughNames.HostSide)
-	Expect(err).NotTo(HaveOccurred())
-
 	curNS, err := netns.Get()
 	defer func() {
 		if err := curNS.Close(); err != nil {
@@ -140,9 +140,9 @@ const PEVethPrefix = "pe-"
 const EvpnInfix = "e-"
 const SRv6Infix = "s-"
 
-// vethNamesFromVNI returns the names of the veth legs
+// VethNamesFromVNI returns the names of the veth legs
 // corresponding to the default namespace and the target namespace, based on VNI.
-func vethNamesFromVNI(vni int32) VethNames {
+func VethNamesFromVNI(vni int32) VethNames {
 	hostSide := fmt.Sprintf("%s%s%d", HostVethPrefix, EvpnInfix, vni)
 	peSide := fmt.Sprintf("%s%s%d", PEVethPrefix, EvpnInfix, vni)
 	return VethNames{HostSide: hostSide, NamespaceSide: peSide}
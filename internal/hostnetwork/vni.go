@@ -30,6 +30,15 @@ type VNIParams struct {
 	VTEPIP    string `json:"vtepip"`
 	VNI       int32  `json:"vni"`
 	VXLanPort *int32 `json:"vxlanPort,omitempty"`
+	// LeakRoutes tells whether routes are leaked between the VRF and the
+	// other VRFs of the router.
+	LeakRoutes bool `json:"leakroutes,omitempty"`
+	// SharedVXLan tells whether the VNI is carried by the VLAN aware bridge
+	// and the VXLan device shared by all the VNIs, instead of having its own.
+	SharedVXLan bool `json:"sharedvxlan,omitempty"`
+	// NoNeighSuppression disables the ARP and ND suppression on the VXLan
+	// interface of the VNI.
+	NoNeighSuppression bool `json:"noneighsuppression,omitempty"`
 }
 
 type L3VNIParams struct {
@@ -55,18 +64,25 @@ type L2VNIParams struct {
 	Name         string      `json:"name"`
 	L2GatewayIPs []string    `json:"l2gatewayips"`
 	HostMaster   *HostMaster `json:"hostMaster"`
+	// GatewayMAC is the MAC address of the distributed gateway. When empty,
+	// the one derived from the VNI is used.
+	GatewayMAC string `json:"gatewaymac,omitempty"`
 }
 
 type HostMaster struct {
 	Name       *string `json:"name,omitempty"`
 	Type       string  `json:"type,omitempty"`
 	AutoCreate *bool   `json:"autocreate,omitempty"`
+	// Sampling is the traffic sampling of the OVS bridge, only applied to
+	// the bridges created by the controller.
+	Sampling *OVSSampling `json:"sampling,omitempty"`
 }
 
 const (
 	VRFLinkType       = "vrf"
 	BridgeLinkType    = "LinuxBridge"
 	VXLanLinkType     = "vxlan"
+	VLanLinkType      = "vlan"
 	OVSBridgeLinkType = "OVSBridge"
 )
 
@@ -90,6 +106,9 @@ func setupVRFInNS(ctx context.Context, params VNIParams) error {
 	}()
 	return netnamespace.In(ns, func() error {
 		slog.DebugContext(ctx, "setting up vrf", "vrf", params.VRF)
+		if params.LeakRoutes {
+			return setupVRF(params.VRF, leakingVRF)
+		}
 		return setupVRF(params.VRF)
 	})
 }
@@ -102,7 +121,7 @@ func SetupL3VNI(ctx context.Context, params L3VNIParams) error {
 	if err := setupVRFInNS(ctx, params.VNIParams); err != nil {
 		return fmt.Errorf("SetupL3VNI: failed to setup VRF: %w", err)
 	}
-	if err := setupVNI(ctx, params.VNIParams, setAddrGenModeNone); err != nil {
+	if err := setupVNIForMode(ctx, params.VNIParams, setAddrGenModeNone); err != nil {
 		return fmt.Errorf("SetupL3VNI: failed to setup VNI: %w", err)
 	}
 	slog.DebugContext(ctx, "setting up l3 VNI", "params", params)
@@ -115,7 +134,7 @@ func SetupL3VNI(ctx context.Context, params L3VNIParams) error {
 
 	if err := setupHostVeth(
 		ctx,
-		vethNamesFromVNI(params.VNI),
+		VethNamesFromVNI(params.VNI),
 		params.TargetNS,
 		params.LinkIPs,
 		params.VRF,
@@ -131,11 +150,13 @@ func SetupL3VNI(ctx context.Context, params L3VNIParams) error {
 // domain to the default host namespace.
 // The VRF must already exist (created by SetupL3VNI); setupBridge
 // looks it up and binds the bridge to it.
+// When params.SharedVXLan is set, the veth leg is connected to the
+// shared bridge instead, as an access port of the VLAN of the VNI.
 func SetupL2VNI(ctx context.Context, params L2VNIParams) error {
-	if err := setupVNI(ctx, params.VNIParams); err != nil {
+	if err := setupVNIForMode(ctx, params.VNIParams); err != nil {
 		return fmt.Errorf("SetupL2VNI: failed to setup VNI: %w", err)
 	}
-	vethNames := vethNamesFromVNI(params.VNI)
+	vethNames := VethNamesFromVNI(params.VNI)
 	if err := setupNamespacedVeth(ctx, vethNames, params.TargetNS); err != nil {
 		return fmt.Errorf("SetupL2VNI: failed to setup VNI veth: %w", err)
 	}
@@ -172,7 +193,7 @@ func SetupL2VNI(ctx context.Context, params L2VNIParams) error {
 	}
 
 	if params.HostMaster != nil {
-		if err := setupHostMaster(ctx, params, hostVeth); err != nil {
+		if err := SetupHostMaster(ctx, params, hostVeth); err != nil {
 			return err
 		}
 	}
@@ -196,12 +217,17 @@ func setupL2VNIRouterSide(params L2VNIParams, vethName string, underlayMTU int)
 		return fmt.Errorf("failed to set MTU on pe veth %s: %w", vethName, err)
 	}
 
+	// with the shared vxlan, this is the vlan interface of the VNI
 	name := BridgeName(params.VNI)
 	bridge, err := netlink.LinkByName(name)
 	if err != nil {
 		return fmt.Errorf("could not find bridge %s in namespace %s: %w", name, params.TargetNS, err)
 	}
-	if err := linkSetMaster(peVeth, bridge); err != nil {
+	if params.SharedVXLan {
+		if err := attachToSharedBridge(peVeth, params.VNI); err != nil {
+			return fmt.Errorf("failed to attach pe veth %s to the shared bridge: %w", peVeth.Attrs().Name, err)
+		}
+	} else if err := linkSetMaster(peVeth, bridge); err != nil {
 		return fmt.Errorf("failed to set bridge %s as master of pe veth %s: %w", name, peVeth.Attrs().Name, err)
 	}
 	if len(params.L2GatewayIPs) > 0 {
@@ -212,14 +238,24 @@ func setupL2VNIRouterSide(params L2VNIParams, vethName string, underlayMTU int)
 		}
 
 		// setting up the same mac address for all the nodes for distributed gateway
-		if err := ensureBridgeFixedMacAddress(bridge, params.VNI); err != nil {
+		macAddress, err := GatewayMACAddress(params)
+		if err != nil {
+			return err
+		}
+		setMacAddress := ensureBridgeMacAddress
+		if params.SharedVXLan {
+			setMacAddress = ensureSVIMacAddress
+		}
+		if err := setMacAddress(bridge, macAddress); err != nil {
 			return fmt.Errorf("failed to set bridge mac address %s: %v", name, err)
 		}
 	}
 	return nil
 }
 
-func setupHostMaster(ctx context.Context, params L2VNIParams, hostVeth netlink.Link) error {
+// SetupHostMaster attaches the host leg of an L2VNI to its host master, a
+// linux or OVS bridge, creating the bridge first when it is auto created.
+func SetupHostMaster(ctx context.Context, params L2VNIParams, hostVeth netlink.Link) error {
 	bridgeConfig := *params.HostMaster
 	switch bridgeConfig.Type {
 	case OVSBridgeLinkType:
@@ -230,6 +266,12 @@ func setupHostMaster(ctx context.Context, params L2VNIParams, hostVeth netlink.L
 		if err := ensureOVSBridgeAndAttach(ctx, lowerDeviceName, hostVeth.Attrs().Name); err != nil {
 			return fmt.Errorf("failed to ensure OVS bridge %s and attach %s: %w", lowerDeviceName, hostVeth.Attrs().Name, err)
 		}
+		// the sampling of the bridges not created by us is left to their owners
+		if ptr.Deref(bridgeConfig.AutoCreate, false) {
+			if err := ensureOVSBridgeSampling(ctx, lowerDeviceName, bridgeConfig.Sampling); err != nil {
+				return fmt.Errorf("failed to set the sampling of OVS bridge %s: %w", lowerDeviceName, err)
+			}
+		}
 	case BridgeLinkType:
 		master, err := hostMaster(params.VNI, bridgeConfig)
 		if err != nil {
@@ -265,6 +307,12 @@ func setupVNI(ctx context.Context, params VNIParams, bridgeOptions ...NetlinkOpt
 	}()
 
 	return netnamespace.In(ns, func() error {
+		// the shared vxlan, left behind by the single vxlan device mode,
+		// can't share its port with the vxlan of the VNI
+		if err := deleteLinkByName(SharedVXLanName); err != nil {
+			return err
+		}
+
 		slog.DebugContext(ctx, "setting up bridge")
 		bridge, err := setupBridge(params, bridgeOptions...)
 		if err != nil {
@@ -276,6 +324,15 @@ func setupVNI(ctx context.Context, params VNIParams, bridgeOptions ...NetlinkOpt
 	})
 }
 
+// setupVNIForMode sets up the VNI on its own bridge and VXLan interface, or
+// on the shared ones when params.SharedVXLan is set.
+func setupVNIForMode(ctx context.Context, params VNIParams, bridgeOptions ...NetlinkOption) error {
+	if params.SharedVXLan {
+		return setupSharedVNI(ctx, params, bridgeOptions...)
+	}
+	return setupVNI(ctx, params, bridgeOptions...)
+}
+
 // RemoveAllVNIs removes from the target namespace the bridges / veths
 // for all VNIs.
 func RemoveAllVNIs(targetNS string) error {
@@ -283,11 +340,20 @@ func RemoveAllVNIs(targetNS string) error {
 }
 
 // RemoveNonConfiguredVNIs removes from the target namespace the
-// leftovers corresponding to VNIs that are not configured anymore.
+// leftovers corresponding to VNIs that are not configured anymore,
+// including the ones left behind by a VNI switching between its own
+// bridge and VXLan interface and the shared ones.
 func RemoveNonConfiguredVNIs(targetNS string, params []VNIParams) error {
 	vnis := map[int32]bool{}
+	perVNI := map[int32]bool{}
+	shared := map[int32]bool{}
 	for _, p := range params {
 		vnis[p.VNI] = true
+		if p.SharedVXLan {
+			shared[p.VNI] = true
+			continue
+		}
+		perVNI[p.VNI] = true
 	}
 
 	errs := removeHostSideVNIs(vnis)
@@ -303,7 +369,7 @@ func RemoveNonConfiguredVNIs(targetNS string, params []VNIParams) error {
 	}()
 
 	if err := netnamespace.In(ns, func() error {
-		nsErrors := removeNamespaceSideVNIs(vnis)
+		nsErrors := removeNamespaceSideVNIs(perVNI, shared)
 		return errors.Join(nsErrors...)
 	}); err != nil {
 		errs = append(errs, err)
@@ -332,19 +398,25 @@ func removeHostSideVNIs(vnis map[int32]bool) []error {
 	return append(failedDeletes, removeHostSideVeths(hostLinks, HostVethPrefix+EvpnInfix, vnis)...)
 }
 
-func removeNamespaceSideVNIs(vnis map[int32]bool) []error {
+func removeNamespaceSideVNIs(perVNI, shared map[int32]bool) []error {
 	var failedDeletes []error
 
 	links, err := netlink.LinkList()
 	if err != nil {
 		return []error{fmt.Errorf("remove non configured vnis: failed to list links: %w", err)}
 	}
-	if err := deleteLinksForType(VXLanLinkType, vnis, links, vniFromVXLanName); err != nil {
+	if err := deleteLinksForType(VXLanLinkType, perVNI, links, vniFromVXLanName); err != nil {
 		failedDeletes = append(failedDeletes, fmt.Errorf("remove vlan links: %w", err))
 	}
-	if err := deleteLinksForType(BridgeLinkType, vnis, links, vniFromBridgeName); err != nil {
+	if err := deleteLinksForType(BridgeLinkType, perVNI, links, vniFromBridgeName); err != nil {
 		failedDeletes = append(failedDeletes, fmt.Errorf("remove bridge links: %w", err))
 	}
+	if err := deleteLinksForType(VLanLinkType, shared, links, vniFromBridgeName); err != nil {
+		failedDeletes = append(failedDeletes, fmt.Errorf("remove vlan interfaces: %w", err))
+	}
+	if err := removeSharedVNIs(shared); err != nil {
+		failedDeletes = append(failedDeletes, fmt.Errorf("remove shared vnis: %w", err))
+	}
 	return failedDeletes
 }
 
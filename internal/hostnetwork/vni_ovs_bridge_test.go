@@ -61,7 +61,7 @@ var _ = Describe("L2 VNI configuration with OVS bridges", func() {
 		Expect(err).NotTo(HaveOccurred())
 
 		By("checking the VNI and OVS bridge are removed")
-		vethNames := vethNamesFromVNI(params.VNI)
+		vethNames := VethNamesFromVNI(params.VNI)
 		Eventually(func(g Gomega) {
 			checkLinkdeleted(g, vethNames.HostSide)
 			checkOVSHostBridgeDeleted(g, params)
@@ -94,7 +94,7 @@ var _ = Describe("L2 VNI configuration with OVS bridges", func() {
 		Eventually(func(g Gomega) {
 			validateL2HostLeg(g, params)
 			checkOVSBridgeExists(g, bridgeName)
-			checkVethAttachedToOVSBridge(g, bridgeName, vethNamesFromVNI(params.VNI).HostSide)
+			checkVethAttachedToOVSBridge(g, bridgeName, VethNamesFromVNI(params.VNI).HostSide)
 		}, 30*time.Second, 1*time.Second).Should(Succeed())
 
 		By("removing the VNI")
@@ -104,7 +104,7 @@ var _ = Describe("L2 VNI configuration with OVS bridges", func() {
 		Expect(err).NotTo(HaveOccurred())
 
 		By("checking the bridge persists but veth is cleaned up")
-		vethNames := vethNamesFromVNI(params.VNI)
+		vethNames := VethNamesFromVNI(params.VNI)
 		Eventually(func(g Gomega) {
 			checkOVSBridgeExists(g, bridgeName) // Bridge should still exist
 			checkLinkdeleted(g, vethNames.HostSide)
@@ -206,8 +206,93 @@ var _ = Describe("L2 VNI configuration with OVS bridges", func() {
 			})
 		}, 30*time.Second, 1*time.Second).Should(Succeed())
 	})
+
+	It("should program and remove the sampling of an auto-created OVS bridge", func() {
+		params := L2VNIParams{
+			VNIParams: VNIParams{
+				VRF: "testred", TargetNS: testNSPath(),
+				VTEPIP: "192.170.0.9/32", VNI: 100, VXLanPort: new(int32(4789)),
+			},
+			HostMaster: &HostMaster{
+				Type:       OVSBridgeLinkType,
+				AutoCreate: new(true),
+				Sampling: &OVSSampling{
+					Protocol: OVSSamplingSFlow,
+					Targets:  []string{"192.168.1.10:6343"},
+					Rate:     400,
+					Polling:  30,
+				},
+			},
+		}
+		bridgeName := hostBridgeName(params.VNI)
+
+		createVRFInNamespace(testNS, params.VRF)
+		Expect(SetupL2VNI(context.Background(), params)).To(Succeed())
+		Eventually(func(g Gomega) {
+			sflow, ipfix := getOVSBridgeSampling(g, bridgeName)
+			g.Expect(ipfix).To(BeNil())
+			g.Expect(sflow).NotTo(BeNil())
+			g.Expect(sflow.Targets).To(Equal([]string{"192.168.1.10:6343"}))
+			g.Expect(sflow.Sampling).To(Equal(new(400)))
+			g.Expect(sflow.Polling).To(Equal(new(30)))
+		}, 30*time.Second, 1*time.Second).Should(Succeed())
+
+		By("switching to IPFIX")
+		params.HostMaster.Sampling = &OVSSampling{
+			Protocol: OVSSamplingIPFIX,
+			Targets:  []string{"192.168.1.10:4739"},
+			Rate:     1000,
+		}
+		Expect(SetupL2VNI(context.Background(), params)).To(Succeed())
+		Eventually(func(g Gomega) {
+			sflow, ipfix := getOVSBridgeSampling(g, bridgeName)
+			g.Expect(sflow).To(BeNil())
+			g.Expect(ipfix).NotTo(BeNil())
+			g.Expect(ipfix.Targets).To(Equal([]string{"192.168.1.10:4739"}))
+			g.Expect(ipfix.Sampling).To(Equal(new(1000)))
+		}, 30*time.Second, 1*time.Second).Should(Succeed())
+
+		By("removing the sampling")
+		params.HostMaster.Sampling = nil
+		Expect(SetupL2VNI(context.Background(), params)).To(Succeed())
+		Eventually(func(g Gomega) {
+			sflow, ipfix := getOVSBridgeSampling(g, bridgeName)
+			g.Expect(sflow).To(BeNil())
+			g.Expect(ipfix).To(BeNil())
+		}, 30*time.Second, 1*time.Second).Should(Succeed())
+	})
 })
 
+// getOVSBridgeSampling returns the sFlow and IPFIX rows referenced by the
+// given OVS bridge.
+func getOVSBridgeSampling(g Gomega, bridgeName string) (*ovsmodel.SFlow, *ovsmodel.IPFIX) {
+	ctx := context.Background()
+	ovs, err := NewOVSClient(ctx)
+	g.Expect(err).NotTo(HaveOccurred())
+	defer ovs.Close()
+
+	_, err = ovs.Monitor(ctx, ovs.NewMonitor(
+		libovsclient.WithTable(&ovsmodel.Bridge{}),
+		libovsclient.WithTable(&ovsmodel.SFlow{}),
+		libovsclient.WithTable(&ovsmodel.IPFIX{}),
+	))
+	g.Expect(err).NotTo(HaveOccurred())
+
+	bridge := &ovsmodel.Bridge{Name: bridgeName}
+	g.Expect(ovs.Get(ctx, bridge)).To(Succeed())
+	var sflow *ovsmodel.SFlow
+	if bridge.Sflow != nil {
+		sflow = &ovsmodel.SFlow{UUID: *bridge.Sflow}
+		g.Expect(ovs.Get(ctx, sflow)).To(Succeed())
+	}
+	var ipfix *ovsmodel.IPFIX
+	if bridge.IPFIX != nil {
+		ipfix = &ovsmodel.IPFIX{UUID: *bridge.IPFIX}
+		g.Expect(ovs.Get(ctx, ipfix)).To(Succeed())
+	}
+	return sflow, ipfix
+}
+
 func checkOVSBridgeExists(g Gomega, bridgeName string) {
 	bridge, err := getOVSBridge(bridgeName)
 	g.Expect(err).NotTo(HaveOccurred(), "failed to get OVS bridge %q", bridgeName)
@@ -15,6 +15,7 @@ import (
 	. "github.com/onsi/gomega"
 	"github.com/openperouter/openperouter/internal/netnamespace"
 	"github.com/vishvananda/netlink"
+	"github.com/vishvananda/netlink/nl"
 	"github.com/vishvananda/netns"
 	"k8s.io/utils/ptr"
 )
@@ -184,7 +185,7 @@ var _ = Describe("L3 VNI configuration", func() {
 		}, 30*time.Second, 1*time.Second).Should(Succeed())
 
 		By("checking non needed L3VNIs are removed")
-		vethNames := vethNamesFromVNI(toDelete.VNI)
+		vethNames := VethNamesFromVNI(toDelete.VNI)
 		Eventually(func(g Gomega) {
 			checkLinkdeleted(g, vethNames.HostSide)
 			_ = netnamespace.In(testNS, func() error {
@@ -251,7 +252,7 @@ var _ = Describe("L3 VNI configuration", func() {
 		}, 30*time.Second, 1*time.Second).Should(Succeed())
 
 		// Verify that no host veth was created
-		vethNames := vethNamesFromVNI(params.VNI)
+		vethNames := VethNamesFromVNI(params.VNI)
 		_, err = netlink.LinkByName(vethNames.HostSide)
 		Expect(errors.As(err, &netlink.LinkNotFoundError{})).To(BeTrue(), "host veth should not exist when LinkIPs is nil")
 	})
@@ -279,7 +280,7 @@ var _ = Describe("L3 VNI configuration", func() {
 
 		expectedMTU := underlayMTU - VXLanOverhead
 		Eventually(func(g Gomega) {
-			vethNames := vethNamesFromVNI(params.VNI)
+			vethNames := VethNamesFromVNI(params.VNI)
 			validateVethMTU(g, vethNames, expectedMTU)
 			_ = netnamespace.In(testNS, func() error {
 				validateNSVethMTU(g, vethNames, expectedMTU)
@@ -312,7 +313,7 @@ var _ = Describe("L3 VNI configuration", func() {
 		Expect(err).NotTo(HaveOccurred())
 
 		Eventually(func(g Gomega) {
-			vethNames := vethNamesFromVNI(params.VNI)
+			vethNames := VethNamesFromVNI(params.VNI)
 			validateVethMTU(g, vethNames, defaultVethMTU)
 			_ = netnamespace.In(testNS, func() error {
 				validateNSVethMTU(g, vethNames, defaultVethMTU)
@@ -372,7 +373,7 @@ var _ = Describe("L2 VNI configuration", func() {
 		Expect(err).NotTo(HaveOccurred())
 
 		By("checking the VNI is removed")
-		vethNames := vethNamesFromVNI(params.VNI)
+		vethNames := VethNamesFromVNI(params.VNI)
 		Eventually(func(g Gomega) {
 			checkLinkdeleted(g, vethNames.HostSide)
 			checkLinkExists(g, bridgeName)
@@ -452,7 +453,7 @@ var _ = Describe("L2 VNI configuration", func() {
 		}, 30*time.Second, 1*time.Second).Should(Succeed())
 
 		By("checking non needed L2VNIs are removed")
-		vethNames := vethNamesFromVNI(toDelete.VNI)
+		vethNames := VethNamesFromVNI(toDelete.VNI)
 		Eventually(func(g Gomega) {
 			checkLinkdeleted(g, vethNames.HostSide)
 			checkHostBridgedeleted(g, toDelete)
@@ -466,6 +467,107 @@ var _ = Describe("L2 VNI configuration", func() {
 		}, 30*time.Second, 1*time.Second).Should(Succeed())
 	})
 
+	It("should work with L2VNIs on the shared vxlan, migrating from and to per VNI devices", func() {
+		params := []L2VNIParams{
+			{
+				VNIParams: VNIParams{
+					VRF:       "testred",
+					TargetNS:  testNSPath(),
+					VTEPIP:    "192.170.0.9/32",
+					VNI:       100,
+					VXLanPort: new(int32(4789)),
+				},
+				L2GatewayIPs: []string{"192.168.1.0/24"},
+				HostMaster: &HostMaster{
+					Name: new(bridgeName),
+					Type: BridgeLinkType,
+				},
+			},
+			{
+				VNIParams: VNIParams{
+					VRF:         "testblue",
+					TargetNS:    testNSPath(),
+					VTEPIP:      "192.170.0.9/32",
+					VNI:         101,
+					VXLanPort:   new(int32(4789)),
+					SharedVXLan: true,
+				},
+				HostMaster: &HostMaster{
+					AutoCreate: new(true),
+					Type:       BridgeLinkType,
+				},
+			},
+		}
+		for _, p := range params {
+			createVRFInNamespace(testNS, p.VRF)
+		}
+
+		By("setting up the first L2VNI with its own devices")
+		err := SetupL2VNI(context.Background(), params[0])
+		Expect(err).NotTo(HaveOccurred())
+		Eventually(func(g Gomega) {
+			_ = netnamespace.In(testNS, func() error {
+				validateL2VNI(g, params[0])
+				return nil
+			})
+		}, 30*time.Second, 1*time.Second).Should(Succeed())
+
+		By("moving the first L2VNI to the shared vxlan and adding the second one")
+		params[0].SharedVXLan = true
+		for _, p := range params {
+			err := SetupL2VNI(context.Background(), p)
+			Expect(err).NotTo(HaveOccurred())
+		}
+		err = RemoveNonConfiguredVNIs(testNSPath(), []VNIParams{params[0].VNIParams, params[1].VNIParams})
+		Expect(err).NotTo(HaveOccurred())
+
+		Eventually(func(g Gomega) {
+			_ = netnamespace.In(testNS, func() error {
+				vids := map[uint16]bool{}
+				for _, p := range params {
+					validateL2HostLeg(g, p)
+					vids[validateSharedL2VNI(g, p)] = true
+				}
+				g.Expect(vids).To(HaveLen(2), "vnis must be mapped to different vlans")
+				return nil
+			})
+		}, 30*time.Second, 1*time.Second).Should(Succeed())
+
+		By("removing the second L2VNI")
+		err = RemoveNonConfiguredVNIs(testNSPath(), []VNIParams{params[0].VNIParams})
+		Expect(err).NotTo(HaveOccurred())
+
+		Eventually(func(g Gomega) {
+			_ = netnamespace.In(testNS, func() error {
+				validateSharedL2VNI(g, params[0])
+				validateVNIIsNotConfigured(g, params[1].VNIParams)
+				tunnels, err := netlink.BridgeVlanTunnelShow()
+				g.Expect(err).NotTo(HaveOccurred())
+				for _, t := range tunnels {
+					g.Expect(t.TunId).NotTo(BeEquivalentTo(params[1].VNI), "vni still mapped to a vlan")
+				}
+				return nil
+			})
+		}, 30*time.Second, 1*time.Second).Should(Succeed())
+
+		By("moving the first L2VNI back to its own devices")
+		params[0].SharedVXLan = false
+		err = SetupL2VNI(context.Background(), params[0])
+		Expect(err).NotTo(HaveOccurred())
+		err = RemoveNonConfiguredVNIs(testNSPath(), []VNIParams{params[0].VNIParams})
+		Expect(err).NotTo(HaveOccurred())
+
+		Eventually(func(g Gomega) {
+			validateL2HostLeg(g, params[0])
+			_ = netnamespace.In(testNS, func() error {
+				validateL2VNI(g, params[0])
+				checkLinkdeleted(g, SharedVXLanName)
+				checkLinkdeleted(g, SharedBridgeName)
+				return nil
+			})
+		}, 30*time.Second, 1*time.Second).Should(Succeed())
+	})
+
 	DescribeTable("should be idempotent",
 		func(params L2VNIParams) {
 			if params.VRF != "" {
@@ -569,7 +671,7 @@ var _ = Describe("L2 VNI configuration", func() {
 
 		expectedMTU := underlayMTU - VXLanOverhead
 		Eventually(func(g Gomega) {
-			vethNames := vethNamesFromVNI(params.VNI)
+			vethNames := VethNamesFromVNI(params.VNI)
 			validateVethMTU(g, vethNames, expectedMTU)
 			_ = netnamespace.In(testNS, func() error {
 				validateNSVethMTU(g, vethNames, expectedMTU)
@@ -601,7 +703,7 @@ var _ = Describe("L2 VNI configuration", func() {
 		Expect(err).NotTo(HaveOccurred())
 
 		Eventually(func(g Gomega) {
-			vethNames := vethNamesFromVNI(params.VNI)
+			vethNames := VethNamesFromVNI(params.VNI)
 			validateVethMTU(g, vethNames, defaultVethMTU)
 			_ = netnamespace.In(testNS, func() error {
 				validateNSVethMTU(g, vethNames, defaultVethMTU)
@@ -612,7 +714,7 @@ var _ = Describe("L2 VNI configuration", func() {
 })
 
 func validateL3HostLeg(g Gomega, params L3VNIParams) {
-	vethNames := vethNamesFromVNI(params.VNI)
+	vethNames := VethNamesFromVNI(params.VNI)
 	hostLegLink, err := netlink.LinkByName(vethNames.HostSide)
 	g.Expect(err).NotTo(HaveOccurred(), "host side not found", vethNames.HostSide)
 
@@ -634,7 +736,7 @@ func validateL3HostLeg(g Gomega, params L3VNIParams) {
 }
 
 func validateL2HostLeg(g Gomega, params L2VNIParams) {
-	vethNames := vethNamesFromVNI(params.VNI)
+	vethNames := VethNamesFromVNI(params.VNI)
 	hostLegLink, err := netlink.LinkByName(vethNames.HostSide)
 	g.Expect(err).NotTo(HaveOccurred(), "host side not found", vethNames.HostSide)
 
@@ -678,7 +780,7 @@ func validateL3VNI(g Gomega, params L3VNIParams) {
 	g.Expect(err).NotTo(HaveOccurred(), "bridge not found for addr_gen_mode check", BridgeName(params.VNI))
 	g.Expect(checkAddrGenModeNone(bridgeLink)).To(BeTrue(), "L3VNI bridge must have addr_gen_mode=1")
 
-	vethNames := vethNamesFromVNI(params.VNI)
+	vethNames := VethNamesFromVNI(params.VNI)
 	peLegLink, err := netlink.LinkByName(vethNames.NamespaceSide)
 	g.Expect(err).NotTo(HaveOccurred(), "veth pe side not found", vethNames.NamespaceSide)
 	g.Expect(peLegLink.Attrs().OperState).To(BeEquivalentTo(netlink.OperUp))
@@ -710,7 +812,7 @@ func validateL2VNI(g Gomega, params L2VNIParams) {
 	g.Expect(err).NotTo(HaveOccurred(), "bridge not found for addr_gen_mode check", BridgeName(params.VNI))
 	g.Expect(checkAddrGenModeNone(bridgeLinkForMode)).To(BeFalse(), "L2VNI bridge must NOT have addr_gen_mode=1")
 
-	vethNames := vethNamesFromVNI(params.VNI)
+	vethNames := VethNamesFromVNI(params.VNI)
 	peLegLink, err := netlink.LinkByName(vethNames.NamespaceSide)
 	g.Expect(err).NotTo(HaveOccurred(), "veth pe side not found", vethNames.NamespaceSide)
 	g.Expect(peLegLink.Attrs().OperState).To(BeEquivalentTo(netlink.OperUp))
@@ -738,12 +840,69 @@ func validateL2VNI(g Gomega, params L2VNIParams) {
 	}
 }
 
+// validateSharedL2VNI validates the L2VNI carried by the shared vxlan,
+// returning the vlan it is mapped to.
+func validateSharedL2VNI(g Gomega, params L2VNIParams) uint16 {
+	vtepDev, err := netlink.LinkByName(loopbackName)
+	g.Expect(err).NotTo(HaveOccurred(), "vtep device not found %q", loopbackName)
+	checkLinkdeleted(g, VXLanNameFromVNI(params.VNI))
+
+	bridgeLink, err := netlink.LinkByName(SharedBridgeName)
+	g.Expect(err).NotTo(HaveOccurred(), "shared bridge not found")
+	bridge := bridgeLink.(*netlink.Bridge)
+	g.Expect(bridge.OperState).To(BeEquivalentTo(netlink.OperUp))
+	g.Expect(ptr.Deref(bridge.VlanFiltering, false)).To(BeTrue(), "shared bridge is not vlan aware")
+
+	vxlanLink, err := netlink.LinkByName(SharedVXLanName)
+	g.Expect(err).NotTo(HaveOccurred(), "shared vxlan not found")
+	vxlan := vxlanLink.(*netlink.Vxlan)
+	g.Expect(checkAddrGenModeNone(vxlan)).To(BeTrue())
+	vtepIP, _, err := net.ParseCIDR(params.VTEPIP)
+	g.Expect(err).NotTo(HaveOccurred())
+	err = checkSharedVXLanConfigured(vxlan, bridge.Index, vtepDev.Attrs().Index, vtepIP, int(*params.VXLanPort))
+	g.Expect(err).NotTo(HaveOccurred())
+
+	sviLink, err := netlink.LinkByName(BridgeName(params.VNI))
+	g.Expect(err).NotTo(HaveOccurred(), "vlan interface not found", BridgeName(params.VNI))
+	svi, ok := sviLink.(*netlink.Vlan)
+	g.Expect(ok).To(BeTrue(), "link %s is not a vlan interface", BridgeName(params.VNI))
+	g.Expect(svi.ParentIndex).To(Equal(bridge.Index))
+	g.Expect(svi.OperState).To(BeEquivalentTo(netlink.OperUp))
+	_, vrf := validateVRF(g, params.VRF)
+	g.Expect(svi.MasterIndex).To(Equal(vrf.Index))
+	vid := uint16(svi.VlanId)
+
+	tunnels, err := netlink.BridgeVlanTunnelShow()
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(tunnels).To(ContainElement(nl.TunnelInfo{TunId: uint32(params.VNI), Vid: vid}))
+
+	vethNames := VethNamesFromVNI(params.VNI)
+	peLegLink, err := netlink.LinkByName(vethNames.NamespaceSide)
+	g.Expect(err).NotTo(HaveOccurred(), "veth pe side not found", vethNames.NamespaceSide)
+	g.Expect(peLegLink.Attrs().OperState).To(BeEquivalentTo(netlink.OperUp))
+	g.Expect(peLegLink.Attrs().MasterIndex).To(Equal(bridge.Index))
+	vlans, err := netlink.BridgeVlanList()
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(vlans[int32(peLegLink.Attrs().Index)]).To(ContainElement(
+		&nl.BridgeVlanInfo{Flags: nl.BRIDGE_VLAN_INFO_PVID | nl.BRIDGE_VLAN_INFO_UNTAGGED, Vid: vid}))
+
+	for _, ip := range params.L2GatewayIPs {
+		hasIP, err := interfaceHasIP(svi, ip)
+		g.Expect(err).NotTo(HaveOccurred())
+		g.Expect(hasIP).To(BeTrue(), "vlan interface does not have ip", ip)
+	}
+	if len(params.L2GatewayIPs) > 0 {
+		validateBridgeMacAddress(g, svi, params.VNI)
+	}
+	return vid
+}
+
 func validateVNI(g Gomega, params VNIParams) {
 	vtepDev, err := netlink.LinkByName(loopbackName)
 	g.Expect(err).NotTo(HaveOccurred(), "vtep device not found %q", loopbackName)
 
-	vxlanLink, err := netlink.LinkByName(vxLanNameFromVNI(params.VNI))
-	g.Expect(err).NotTo(HaveOccurred(), "vxlan link not found %q", vxLanNameFromVNI(params.VNI))
+	vxlanLink, err := netlink.LinkByName(VXLanNameFromVNI(params.VNI))
+	g.Expect(err).NotTo(HaveOccurred(), "vxlan link not found %q", VXLanNameFromVNI(params.VNI))
 
 	vxlan := vxlanLink.(*netlink.Vxlan)
 	g.Expect(vxlan.OperState).To(BeEquivalentTo(netlink.OperUnknown))
@@ -783,7 +942,7 @@ func validateVRF(g Gomega, vrfName string) (netlink.Link, *netlink.Vrf) {
 }
 
 func validateVethForVNI(g Gomega, params VNIParams) {
-	vethNames := vethNamesFromVNI(params.VNI)
+	vethNames := VethNamesFromVNI(params.VNI)
 	peLegLink, err := netlink.LinkByName(vethNames.NamespaceSide)
 	g.Expect(err).NotTo(HaveOccurred(), "veth pe side not found", vethNames.NamespaceSide)
 	g.Expect(peLegLink.Attrs().OperState).To(BeEquivalentTo(netlink.OperUp))
@@ -826,10 +985,10 @@ func checkLinkExists(g Gomega, name string) {
 }
 
 func validateVNIIsNotConfigured(g Gomega, params VNIParams) {
-	checkLinkdeleted(g, vxLanNameFromVNI(params.VNI))
+	checkLinkdeleted(g, VXLanNameFromVNI(params.VNI))
 	checkLinkdeleted(g, BridgeName(params.VNI))
 
-	vethNames := vethNamesFromVNI(params.VNI)
+	vethNames := VethNamesFromVNI(params.VNI)
 	checkLinkdeleted(g, vethNames.NamespaceSide)
 }
 
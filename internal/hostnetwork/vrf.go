@@ -13,7 +13,8 @@ import (
 )
 
 const (
-	srv6VRF = "srv6VRF"
+	srv6VRF    = "srv6VRF"
+	leakingVRF = "leakingVRF"
 )
 
 // lookupVRF finds an existing VRF by name and returns an error if it
@@ -49,6 +50,15 @@ func setupVRF(name string, opts ...string) error {
 		}
 	}
 
+	if slices.Contains(opts, leakingVRF) {
+		// Routes leaked from other VRFs point to interfaces enslaved to those
+		// VRFs, so the traffic of a leaking VRF is received on a VRF other
+		// than the one its source address is routed through.
+		if err := sysctl.Ensure(sysctl.DisableRPFilter(vrf.Name)); err != nil {
+			return fmt.Errorf("failed to disable rp_filter after adding VRF %s: %w", name, err)
+		}
+	}
+
 	err = linkSetUp(vrf)
 	if err != nil {
 		return fmt.Errorf("could not set link up for VRF %s: %v", name, err)
@@ -23,7 +23,7 @@ func setupVXLan(params VNIParams, bridge *netlink.Bridge) error {
 	if err := setAddrGenModeNone(vxlan); err != nil {
 		return fmt.Errorf("failed to set addr_gen_mode to 1 for %s: %w", vxlan.Name, err)
 	}
-	if err := setNeighSuppression(vxlan); err != nil {
+	if err := setNeighSuppression(vxlan, !params.NoNeighSuppression); err != nil {
 		return fmt.Errorf("failed to set neigh suppression for %s: %w", vxlan.Name, err)
 	}
 
@@ -80,7 +80,7 @@ func createVXLan(params VNIParams, bridge *netlink.Bridge) (*netlink.Vxlan, erro
 		return nil, errors.New("failed to parse VXLAN information, VXLAN port is nil")
 	}
 
-	vxlanName := vxLanNameFromVNI(params.VNI)
+	vxlanName := VXLanNameFromVNI(params.VNI)
 	toCreate := &netlink.Vxlan{
 		LinkAttrs: netlink.LinkAttrs{
 			Name:        vxlanName,
@@ -119,7 +119,7 @@ func createVXLan(params VNIParams, bridge *netlink.Bridge) (*netlink.Vxlan, erro
 
 const vniPrefix = "vni"
 
-func vxLanNameFromVNI(vni int32) string {
+func VXLanNameFromVNI(vni int32) string {
 	return fmt.Sprintf("%s%d", vniPrefix, vni)
 }
 
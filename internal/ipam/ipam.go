@@ -153,6 +153,32 @@ func OffsetWithPrefix(basePrefix string, nodeIndex, prefixLen int) (string, erro
 	return ipNet.String(), nil
 }
 
+// VethIndexesInCIDR returns the number of indexes the given pool can provide
+// the veth IPs for, which is the number of its IPs minus the ones preceding
+// the first host side IP.
+func VethIndexesInCIDR(pool string) (uint64, error) {
+	_, cidr, err := net.ParseCIDR(pool)
+	if err != nil {
+		return 0, fmt.Errorf("failed to parse cidr %s: %w", pool, err)
+	}
+	size := gocidr.AddressCount(cidr)
+	offset := uint64(vethHostSideOffset(cidr))
+	if size <= offset {
+		return 0, nil
+	}
+	return size - offset, nil
+}
+
+// vethHostSideOffset returns the position in the pool of the host side IP of
+// the first index. The PE side IP precedes it, skipping the first IP of the
+// pool when it ends in 0.
+func vethHostSideOffset(cidr *net.IPNet) int {
+	if cidr.IP[len(cidr.IP)-1] == 0 {
+		return 2
+	}
+	return 1
+}
+
 // vethIPsForFamily returns the host side and PE side IPs for a given pool and index.
 func vethIPsForFamily(pool string, index int) (VethIPsForFamily, error) {
 	_, cidr, err := net.ParseCIDR(pool)
@@ -160,21 +186,13 @@ func vethIPsForFamily(pool string, index int) (VethIPsForFamily, error) {
 		return VethIPsForFamily{}, fmt.Errorf("failed to parse pool %s: %w", pool, err)
 	}
 
-	peSide, err := cidrElem(cidr, 0)
+	offset := vethHostSideOffset(cidr)
+	peSide, err := cidrElem(cidr, offset-1)
 	if err != nil {
 		return VethIPsForFamily{}, err
 	}
 
-	hostSideIndex := index + 1
-	if peSide.IP[len(peSide.IP)-1] == 0 {
-		peSide, err = cidrElem(cidr, 1)
-		if err != nil {
-			return VethIPsForFamily{}, err
-		}
-		hostSideIndex = index + 2
-	}
-
-	hostSide, err := cidrElem(cidr, hostSideIndex)
+	hostSide, err := cidrElem(cidr, index+offset)
 	if err != nil {
 		return VethIPsForFamily{}, err
 	}
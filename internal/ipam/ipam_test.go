@@ -451,3 +451,37 @@ func TestOffsetIPv6Prefix(t *testing.T) {
 		})
 	}
 }
+
+func TestVethIndexesInCIDR(t *testing.T) {
+	tests := []struct {
+		name     string
+		pool     string
+		expected uint64
+	}{
+		{"ipv4_ending_in_zero", "192.168.1.0/28", 14},
+		{"ipv4_not_ending_in_zero", "192.168.1.4/30", 3},
+		{"ipv4_too_small", "192.168.1.0/31", 0},
+		{"ipv6_ending_in_zero", "fd00::/125", 6},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := VethIndexesInCIDR(tc.pool)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tc.expected {
+				t.Fatalf("expected %d indexes, got %d", tc.expected, got)
+			}
+			if got == 0 {
+				return
+			}
+			if _, err := vethIPsForFamily(tc.pool, int(got)-1); err != nil {
+				t.Errorf("expected the last index %d to be allocated, got %v", got-1, err)
+			}
+			if _, err := vethIPsForFamily(tc.pool, int(got)); err == nil {
+				t.Errorf("expected index %d to be out of the pool", got)
+			}
+		})
+	}
+}
@@ -0,0 +1,114 @@
+// SPDX-License-Identifier:Apache-2.0
+
+package webhooks
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/openperouter/openperouter/api/v1alpha1"
+	"github.com/openperouter/openperouter/internal/conversion"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+// addressPlanResponse admits the given resource if the address plan of the
+// nodes stays valid once it is applied, warning about the addresses used
+// by more than one owner on a node.
+func addressPlanResponse(obj client.Object) admission.Response {
+	warnings, err := validateAddressPlan(obj)
+	if err != nil {
+		return admission.Denied(err.Error())
+	}
+	return admission.Allowed("").WithWarnings(warnings...)
+}
+
+// validateAddressPlan validates the address plan of the nodes across all the
+// resources of the cluster, with the given one replacing the existing one
+// with the same namespace and name.
+func validateAddressPlan(obj client.Object) ([]string, error) {
+	underlays, err := getUnderlays()
+	if err != nil {
+		return nil, err
+	}
+	l3vnis, err := getL3VNIs()
+	if err != nil {
+		return nil, err
+	}
+	l3vpns, err := getL3VPNs()
+	if err != nil {
+		return nil, err
+	}
+	l2vnis, err := getL2VNIs()
+	if err != nil {
+		return nil, err
+	}
+	l3passthroughs, err := getL3Passthroughs()
+	if err != nil {
+		return nil, err
+	}
+	nodeAddressings := &v1alpha1.NodeAddressingList{}
+	if err := WebhookClient.List(context.Background(), nodeAddressings, &client.ListOptions{}); err != nil {
+		return nil, fmt.Errorf("failed to get existing NodeAddressing objects: %w", err)
+	}
+
+	config := conversion.APIConfigData{
+		Underlays:       underlays.Items,
+		L3VNIs:          l3vnis.Items,
+		L3VPNs:          l3vpns.Items,
+		L2VNIs:          l2vnis.Items,
+		L3Passthrough:   l3passthroughs.Items,
+		NodeAddressings: nodeAddressings.Items,
+	}
+	switch o := obj.(type) {
+	case *v1alpha1.Underlay:
+		config.Underlays = withResource(config.Underlays, o)
+	case *v1alpha1.L3VNI:
+		config.L3VNIs = withResource(config.L3VNIs, o)
+	case *v1alpha1.L3VPN:
+		config.L3VPNs = withResource(config.L3VPNs, o)
+	case *v1alpha1.L2VNI:
+		config.L2VNIs = withResource(config.L2VNIs, o)
+	case *v1alpha1.L3Passthrough:
+		config.L3Passthrough = withResource(config.L3Passthrough, o)
+	case *v1alpha1.NodeAddressing:
+		config.NodeAddressings = withResource(config.NodeAddressings, o)
+	default:
+		return nil, fmt.Errorf("unexpected type %T when validating the address plan", obj)
+	}
+
+	nodeList := &corev1.NodeList{}
+	if err := WebhookClient.List(context.Background(), nodeList, &client.ListOptions{}); err != nil {
+		return nil, fmt.Errorf("failed to get existing Node objects when validating the address plan: %w", err)
+	}
+
+	warnings, err := conversion.ValidateAddressPlanForNodes(nodeList.Items, config)
+	if err != nil {
+		return nil, fmt.Errorf("validation failed: %w", err)
+	}
+	return warnings, nil
+}
+
+// withResource returns the given resources with obj replacing the one with
+// the same namespace and name, or appended if there is none.
+func withResource[T any, PT interface {
+	*T
+	client.Object
+}](resources []T, obj PT) []T {
+	res := make([]T, 0, len(resources)+1)
+	found := false
+	for i := range resources {
+		existing := PT(&resources[i])
+		if existing.GetName() == obj.GetName() && existing.GetNamespace() == obj.GetNamespace() {
+			res = append(res, *obj)
+			found = true
+			continue
+		}
+		res = append(res, resources[i])
+	}
+	if !found {
+		res = append(res, *obj)
+	}
+	return res
+}
@@ -0,0 +1,177 @@
+// SPDX-License-Identifier:Apache-2.0
+
+package webhooks
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/openperouter/openperouter/api/v1alpha1"
+	"github.com/openperouter/openperouter/internal/logging"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// TestValidateAddressPlan tests that the address plan is validated across the resources of the cluster,
+// with the resource being validated replacing the existing one.
+func TestValidateAddressPlan(t *testing.T) {
+	nodes := []*v1.Node{
+		{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        "node0",
+				Labels:      map[string]string{"rack": "a"},
+				Annotations: map[string]string{v1alpha1.NodeIndexAnnotation: "0"},
+			},
+		},
+	}
+	underlays := []*v1alpha1.Underlay{
+		{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "underlay"},
+			Spec: v1alpha1.UnderlaySpec{
+				RouterIDCIDR:   new("10.0.0.0/24"),
+				TunnelEndpoint: &v1alpha1.TunnelEndpointConfig{CIDRs: []string{"100.65.0.0/24"}},
+			},
+		},
+	}
+	l3passthroughs := []*v1alpha1.L3Passthrough{
+		{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "passthrough"},
+			Spec: v1alpha1.L3PassthroughSpec{
+				HostSession: v1alpha1.HostSession{
+					LocalCIDR: v1alpha1.LocalCIDRConfig{IPv4: new("192.169.11.0/24")},
+				},
+			},
+		},
+	}
+
+	tcs := []struct {
+		name         string
+		obj          client.Object
+		errorString  string
+		wantWarnings []string
+	}{
+		{
+			name: "l3vni not overlapping",
+			obj: &v1alpha1.L3VNI{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "red"},
+				Spec: v1alpha1.L3VNISpec{
+					VRF: "red",
+					HostSession: &v1alpha1.HostSession{
+						LocalCIDR: v1alpha1.LocalCIDRConfig{IPv4: new("192.169.10.0/24")},
+					},
+				},
+			},
+		},
+		{
+			name: "l3vni overlapping the tunnel endpoints learned by the host",
+			obj: &v1alpha1.L3VNI{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "red"},
+				Spec: v1alpha1.L3VNISpec{
+					VRF: "red",
+					HostSession: &v1alpha1.HostSession{
+						LocalCIDR: v1alpha1.LocalCIDRConfig{IPv4: new("100.65.0.0/16")},
+					},
+				},
+			},
+			errorString: "subnet overlap in the host routing table",
+		},
+		{
+			name: "l3vni overlapping the router id cidr",
+			obj: &v1alpha1.L3VNI{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "red"},
+				Spec: v1alpha1.L3VNISpec{
+					VRF: "red",
+					HostSession: &v1alpha1.HostSession{
+						LocalCIDR: v1alpha1.LocalCIDRConfig{IPv4: new("10.0.0.0/28")},
+					},
+				},
+			},
+			errorString: "subnet overlap with the router ids",
+		},
+		{
+			name: "updated underlay with the router id cidr overlapping the passthrough",
+			obj: &v1alpha1.Underlay{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "underlay"},
+				Spec: v1alpha1.UnderlaySpec{
+					RouterIDCIDR:   new("192.169.0.0/16"),
+					TunnelEndpoint: &v1alpha1.TunnelEndpointConfig{CIDRs: []string{"100.65.0.0/24"}},
+				},
+			},
+			errorString: "IPNet 192.169.11.0/24 (L3Passthrough default/passthrough) overlaps with IPNet 192.169.0.0/16 (Underlay underlay router ids)",
+		},
+		{
+			name: "updated passthrough overlapping the tunnel endpoints",
+			obj: &v1alpha1.L3Passthrough{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "passthrough"},
+				Spec: v1alpha1.L3PassthroughSpec{
+					HostSession: v1alpha1.HostSession{
+						LocalCIDR: v1alpha1.LocalCIDRConfig{IPv4: new("100.65.0.0/25")},
+					},
+				},
+			},
+			errorString: "subnet overlap in the default VRF",
+		},
+		{
+			name: "passthrough not selecting the node",
+			obj: &v1alpha1.L3Passthrough{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "passthrough"},
+				Spec: v1alpha1.L3PassthroughSpec{
+					NodeSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"rack": "b"}},
+					HostSession: v1alpha1.HostSession{
+						LocalCIDR: v1alpha1.LocalCIDRConfig{IPv4: new("100.65.0.0/25")},
+					},
+				},
+			},
+		},
+		{
+			name: "router id shared with a host session",
+			obj: &v1alpha1.NodeAddressing{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "node0"},
+				Spec: v1alpha1.NodeAddressingSpec{
+					NodeName: "node0",
+					RouterID: new("192.169.11.1"),
+				},
+			},
+			wantWarnings: []string{
+				"node node0: address 192.169.11.1 is used by the router id and the router side of the host session of L3Passthrough passthrough",
+			},
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			objects := objectsFromResources(underlays)
+			objects = append(objects, objectsFromResources(l3passthroughs)...)
+			objects = append(objects, objectsFromResources(nodes)...)
+			client, err := setupFakeWebhookClient(objects)
+			if err != nil {
+				t.Fatal(err)
+			}
+			origWebhookClient := WebhookClient
+			origLogger := Logger
+			defer func() {
+				WebhookClient = origWebhookClient
+				Logger = origLogger
+			}()
+			WebhookClient = client
+			Logger, _ = logging.New("debug")
+
+			warnings, err := validateAddressPlan(tc.obj)
+			if tc.errorString == "" {
+				if err != nil {
+					t.Fatalf("expected no error, but got %q", err)
+				}
+				if strings.Join(warnings, "\n") != strings.Join(tc.wantWarnings, "\n") {
+					t.Fatalf("expected warnings %q, got %q", tc.wantWarnings, warnings)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatalf("expected error to contain %q but got no error", tc.errorString)
+			}
+			if !strings.Contains(err.Error(), tc.errorString) {
+				t.Fatalf("expected error message %q to contain substring %q", err.Error(), tc.errorString)
+			}
+		})
+	}
+}
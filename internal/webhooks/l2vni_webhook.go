@@ -83,7 +83,10 @@ func (v *L2VNIValidator) Handle(ctx context.Context, req admission.Request) (res
 			return admission.Denied(err.Error())
 		}
 	}
-	return admission.Allowed("")
+	if req.Operation == v1.Delete {
+		return admission.Allowed("")
+	}
+	return addressPlanResponse(&l2vni)
 }
 
 func validateL2VNICreate(l2vni *v1alpha1.L2VNI) error {
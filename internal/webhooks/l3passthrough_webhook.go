@@ -82,7 +82,10 @@ func (v *L3PassthroughValidator) Handle(ctx context.Context, req admission.Reque
 		}
 	}
 
-	return admission.Allowed("")
+	if req.Operation == v1.Delete {
+		return admission.Allowed("")
+	}
+	return addressPlanResponse(&l3passthrough)
 }
 
 func validateL3PassthroughCreate(l3passthrough *v1alpha1.L3Passthrough) error {
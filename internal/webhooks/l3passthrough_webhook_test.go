@@ -54,7 +54,7 @@ func TestValidateL3Passthrough(t *testing.T) {
 			},
 		},
 		{
-			name: "testing conversion.ValidatePassthroughsForNodes is hit - more than one passthrough per node",
+			name: "multiple passthroughs on the same node",
 			nodes: []*v1.Node{
 				{
 					ObjectMeta: metav1.ObjectMeta{
@@ -99,7 +99,54 @@ func TestValidateL3Passthrough(t *testing.T) {
 					},
 				},
 			},
-			errorString: "can't have more than one l3passthrough per node",
+		},
+		{
+			name: "testing conversion.ValidateHostSessionsForNodes is hit - overlapping local CIDRs",
+			nodes: []*v1.Node{
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "node1",
+						Labels: map[string]string{
+							"nodeName": "node1",
+						},
+					},
+				},
+			},
+			l3passthroughs: []*v1alpha1.L3Passthrough{
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "default",
+						Name:      "existingL3Passthrough",
+					},
+					Spec: v1alpha1.L3PassthroughSpec{
+						HostSession: v1alpha1.HostSession{
+							LocalCIDR: v1alpha1.LocalCIDRConfig{IPv4: new("192.0.2.0/24")},
+						},
+						NodeSelector: &metav1.LabelSelector{
+							MatchLabels: map[string]string{
+								"nodeName": "node1",
+							},
+						},
+					},
+				},
+			},
+			newL3Passthrough: &v1alpha1.L3Passthrough{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "default",
+					Name:      "newL3Passthrough",
+				},
+				Spec: v1alpha1.L3PassthroughSpec{
+					HostSession: v1alpha1.HostSession{
+						LocalCIDR: v1alpha1.LocalCIDRConfig{IPv4: new("192.0.2.128/25")},
+					},
+					NodeSelector: &metav1.LabelSelector{
+						MatchLabels: map[string]string{
+							"nodeName": "node1",
+						},
+					},
+				},
+			},
+			errorString: "overlapping cidrs",
 		},
 		{
 			name: "testing conversion.ValidateHostSessionsForNodes is hit - missing local CIDR",
@@ -83,23 +83,16 @@ func (v *L3VNIValidator) Handle(ctx context.Context, req admission.Request) (res
 			return admission.Denied(err.Error())
 		}
 	}
-	return admission.Allowed("")
+	if req.Operation == v1.Delete {
+		return admission.Allowed("")
+	}
+	return addressPlanResponse(&l3vni)
 }
 
 func validateL3VNICreate(l3vni *v1alpha1.L3VNI) error {
 	Logger.Debug("webhook l3vni", "action", "create", "name", l3vni.Name, "namespace", l3vni.Namespace)
 	defer Logger.Debug("webhook l3vni", "action", "end create", "name", l3vni.Name, "namespace", l3vni.Namespace)
 
-	existingL3VPNs, err := getL3VPNs()
-	if err != nil {
-		return err
-	}
-	if len(existingL3VPNs.Items) > 0 {
-		return fmt.Errorf("cannot create L3VNI %s/%s when L3VPNs already exist",
-			l3vni.GetNamespace(), l3vni.GetName(),
-		)
-	}
-
 	return validateL3VNI(l3vni)
 }
 
@@ -155,7 +148,13 @@ func validateL3VNI(l3vni *v1alpha1.L3VNI) error {
 		return err
 	}
 
-	if err := conversion.ValidateOverlayResourcesForNodes(nodeList.Items, toValidateL2.Items, toValidate, nil); err != nil {
+	l3vpns, err := getL3VPNs()
+	if err != nil {
+		return err
+	}
+
+	if err := conversion.ValidateOverlayResourcesForNodes(nodeList.Items, toValidateL2.Items, toValidate,
+		l3vpns.Items); err != nil {
 		return fmt.Errorf("validation failed: %w", err)
 	}
 
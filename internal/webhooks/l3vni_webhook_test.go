@@ -166,7 +166,7 @@ func TestValidateL3VNICreate(t *testing.T) {
 			errorString: "more than one L3VNI detected in VRF",
 		},
 		{
-			name: "testing L3VNIs and L3VPNs are mutually exclusive",
+			name: "L3VNIs and L3VPNs coexist in different VRFs",
 			nodes: []*v1.Node{
 				{
 					ObjectMeta: metav1.ObjectMeta{
@@ -184,7 +184,9 @@ func TestValidateL3VNICreate(t *testing.T) {
 						Name:      "existingL3VPN",
 					},
 					Spec: v1alpha1.L3VPNSpec{
-						VRF: "0123456789abcdefghijkl",
+						VRF:              "vrfb",
+						RDAssignedNumber: 200,
+						ImportRTs:        []v1alpha1.RouteTarget{"65000:200"},
 						HostSession: &v1alpha1.HostSession{
 							LocalCIDR: v1alpha1.LocalCIDRConfig{IPv4: new("192.0.4.0/24")},
 						},
@@ -213,7 +215,58 @@ func TestValidateL3VNICreate(t *testing.T) {
 					},
 				},
 			},
-			errorString: "cannot create L3VNI default/newL3VNI when L3VPNs already exist",
+		},
+		{
+			name: "testing L3VNIs and L3VPNs can't share a VRF",
+			nodes: []*v1.Node{
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "node1",
+						Labels: map[string]string{
+							"nodeName": "node1",
+						},
+					},
+				},
+			},
+			l3vpns: []*v1alpha1.L3VPN{
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "default",
+						Name:      "existingL3VPN",
+					},
+					Spec: v1alpha1.L3VPNSpec{
+						VRF:              "vrfa",
+						RDAssignedNumber: 200,
+						ImportRTs:        []v1alpha1.RouteTarget{"65000:200"},
+						HostSession: &v1alpha1.HostSession{
+							LocalCIDR: v1alpha1.LocalCIDRConfig{IPv4: new("192.0.4.0/24")},
+						},
+						NodeSelector: &metav1.LabelSelector{
+							MatchLabels: map[string]string{
+								"nodeName": "node1",
+							},
+						},
+					},
+				},
+			},
+			newL3VNI: &v1alpha1.L3VNI{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "default",
+					Name:      "newL3VNI",
+				},
+				Spec: v1alpha1.L3VNISpec{
+					VRF: "vrfa",
+					HostSession: &v1alpha1.HostSession{
+						LocalCIDR: v1alpha1.LocalCIDRConfig{IPv4: new("192.0.3.0/24")},
+					},
+					NodeSelector: &metav1.LabelSelector{
+						MatchLabels: map[string]string{
+							"nodeName": "node1",
+						},
+					},
+				},
+			},
+			errorString: "L3VPN \"default/existingL3VPN\" already uses it",
 		},
 	}
 	for _, tc := range tcs {
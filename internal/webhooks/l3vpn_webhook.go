@@ -77,23 +77,16 @@ func (v *L3VPNValidator) Handle(ctx context.Context, req admission.Request) (res
 			return admission.Denied(err.Error())
 		}
 	}
-	return admission.Allowed("")
+	if req.Operation == v1.Delete {
+		return admission.Allowed("")
+	}
+	return addressPlanResponse(&l3vpn)
 }
 
 func validateL3VPNCreate(l3vpn *v1alpha1.L3VPN) error {
 	Logger.Debug("webhook l3vpn", "action", "create", "name", l3vpn.Name, "namespace", l3vpn.Namespace)
 	defer Logger.Debug("webhook l3vpn", "action", "end create", "name", l3vpn.Name, "namespace", l3vpn.Namespace)
 
-	existingL3VNIs, err := getL3VNIs()
-	if err != nil {
-		return err
-	}
-	if len(existingL3VNIs.Items) > 0 {
-		return fmt.Errorf("cannot create L3VPN %s/%s when L3VNIs already exist",
-			l3vpn.GetNamespace(), l3vpn.GetName(),
-		)
-	}
-
 	return validateL3VPN(l3vpn)
 }
 
@@ -152,7 +145,12 @@ func validateL3VPN(l3vpn *v1alpha1.L3VPN) error {
 		return fmt.Errorf("validation failed: %w", err)
 	}
 
-	if err := conversion.ValidateOverlayResourcesForNodes(nodeList.Items, l2vniList.Items, nil,
+	l3vniList, err := getL3VNIs()
+	if err != nil {
+		return err
+	}
+
+	if err := conversion.ValidateOverlayResourcesForNodes(nodeList.Items, l2vniList.Items, l3vniList.Items,
 		toValidate); err != nil {
 		return fmt.Errorf("validation failed: %w", err)
 	}
@@ -0,0 +1,135 @@
+// SPDX-License-Identifier:Apache-2.0
+
+package webhooks
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/openperouter/openperouter/api/v1alpha1"
+	"github.com/openperouter/openperouter/internal/conversion"
+	v1 "k8s.io/api/admission/v1"
+	corev1 "k8s.io/api/core/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+const (
+	nodeAddressingValidationWebhookPath = "/validate-openperouter-io-v1alpha1-nodeaddressing"
+)
+
+type NodeAddressingValidator struct {
+	client  client.Client
+	decoder admission.Decoder
+}
+
+func SetupNodeAddressing(mgr ctrl.Manager) error {
+	validator := &NodeAddressingValidator{
+		client:  mgr.GetClient(),
+		decoder: admission.NewDecoder(mgr.GetScheme()),
+	}
+
+	mgr.GetWebhookServer().Register(
+		nodeAddressingValidationWebhookPath,
+		&webhook.Admission{Handler: validator})
+
+	if _, err := mgr.GetCache().GetInformer(context.Background(), &v1alpha1.NodeAddressing{}); err != nil {
+		return fmt.Errorf("failed to get informer for NodeAddressing: %w", err)
+	}
+	return nil
+}
+
+func (v *NodeAddressingValidator) Handle(ctx context.Context, req admission.Request) (resp admission.Response) {
+	var nodeAddressing v1alpha1.NodeAddressing
+	if req.Operation == v1.Delete {
+		return admission.Allowed("")
+	}
+	if err := v.decoder.Decode(req, &nodeAddressing); err != nil {
+		return admission.Errored(http.StatusBadRequest, err)
+	}
+
+	switch req.Operation {
+	case v1.Create:
+		if err := validateNodeAddressingCreate(&nodeAddressing); err != nil {
+			return admission.Denied(err.Error())
+		}
+	case v1.Update:
+		if err := validateNodeAddressingUpdate(&nodeAddressing); err != nil {
+			return admission.Denied(err.Error())
+		}
+	}
+
+	return addressPlanResponse(&nodeAddressing)
+}
+
+func validateNodeAddressingCreate(nodeAddressing *v1alpha1.NodeAddressing) error {
+	Logger.Debug("webhook nodeaddressing", "action", "create", "name", nodeAddressing.Name, "namespace", nodeAddressing.Namespace)
+	defer Logger.Debug("webhook nodeaddressing", "action", "end create", "name", nodeAddressing.Name, "namespace", nodeAddressing.Namespace)
+
+	return validateNodeAddressing(nodeAddressing)
+}
+
+func validateNodeAddressingUpdate(nodeAddressing *v1alpha1.NodeAddressing) error {
+	Logger.Debug("webhook nodeaddressing", "action", "update", "name", nodeAddressing.Name, "namespace", nodeAddressing.Namespace)
+	defer Logger.Debug("webhook nodeaddressing", "action", "end update", "name", nodeAddressing.Name, "namespace", nodeAddressing.Namespace)
+
+	return validateNodeAddressing(nodeAddressing)
+}
+
+// validateNodeAddressing checks the addresses of every node, including the
+// ones derived from the node index, don't collide once the given
+// NodeAddressing is applied.
+func validateNodeAddressing(nodeAddressing *v1alpha1.NodeAddressing) error {
+	existing := &v1alpha1.NodeAddressingList{}
+	if err := WebhookClient.List(context.Background(), existing, &client.ListOptions{}); err != nil {
+		return fmt.Errorf("failed to get existing NodeAddressing objects: %w", err)
+	}
+	toValidate := make([]v1alpha1.NodeAddressing, 0, len(existing.Items))
+	found := false
+	for _, e := range existing.Items {
+		if e.Name == nodeAddressing.Name && e.Namespace == nodeAddressing.Namespace {
+			toValidate = append(toValidate, *nodeAddressing.DeepCopy())
+			found = true
+			continue
+		}
+		toValidate = append(toValidate, e)
+	}
+	if !found {
+		toValidate = append(toValidate, *nodeAddressing.DeepCopy())
+	}
+
+	nodeList := &corev1.NodeList{}
+	if err := WebhookClient.List(context.Background(), nodeList, &client.ListOptions{}); err != nil {
+		return fmt.Errorf("failed to get existing Node objects when validating NodeAddressing: %w", err)
+	}
+	underlays, err := getUnderlays()
+	if err != nil {
+		return err
+	}
+	l3vnis, err := getL3VNIs()
+	if err != nil {
+		return err
+	}
+	l3vpns, err := getL3VPNs()
+	if err != nil {
+		return err
+	}
+	l3passthroughs, err := getL3Passthroughs()
+	if err != nil {
+		return err
+	}
+
+	config := conversion.APIConfigData{
+		Underlays:     underlays.Items,
+		L3VNIs:        l3vnis.Items,
+		L3VPNs:        l3vpns.Items,
+		L3Passthrough: l3passthroughs.Items,
+	}
+	if err := conversion.ValidateNodeAddressingsForNodes(nodeList.Items, toValidate, config); err != nil {
+		return fmt.Errorf("validation failed: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,145 @@
+// SPDX-License-Identifier:Apache-2.0
+
+package webhooks
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/openperouter/openperouter/api/v1alpha1"
+	"github.com/openperouter/openperouter/internal/logging"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// TestValidateNodeAddressing tests the create and update logic of the NodeAddressing webhook. The goal
+// is not to test each called function (functions themselves should have unit tests for that),
+// but to make sure that the webhook's logic overall is sound.
+func TestValidateNodeAddressing(t *testing.T) {
+	nodes := []*v1.Node{
+		{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        "node0",
+				Annotations: map[string]string{v1alpha1.NodeIndexAnnotation: "0"},
+			},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        "node1",
+				Annotations: map[string]string{v1alpha1.NodeIndexAnnotation: "1"},
+			},
+		},
+	}
+	underlays := []*v1alpha1.Underlay{
+		{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "underlay"},
+			Spec: v1alpha1.UnderlaySpec{
+				RouterIDCIDR:   new("10.0.0.0/24"),
+				TunnelEndpoint: &v1alpha1.TunnelEndpointConfig{CIDRs: []string{"100.65.0.0/24"}},
+			},
+		},
+	}
+
+	tcs := []struct {
+		name              string
+		nodeAddressings   []*v1alpha1.NodeAddressing
+		newNodeAddressing *v1alpha1.NodeAddressing
+		errorString       string
+	}{
+		{
+			name: "webhook passes",
+			newNodeAddressing: &v1alpha1.NodeAddressing{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "node1"},
+				Spec: v1alpha1.NodeAddressingSpec{
+					NodeName: "node1",
+					RouterID: new("192.0.2.1"),
+				},
+			},
+		},
+		{
+			name: "update of an existing addressing",
+			nodeAddressings: []*v1alpha1.NodeAddressing{
+				{
+					ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "node1"},
+					Spec: v1alpha1.NodeAddressingSpec{
+						NodeName: "node1",
+						RouterID: new("192.0.2.1"),
+					},
+				},
+			},
+			newNodeAddressing: &v1alpha1.NodeAddressing{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "node1"},
+				Spec: v1alpha1.NodeAddressingSpec{
+					NodeName: "node1",
+					RouterID: new("192.0.2.2"),
+				},
+			},
+		},
+		{
+			name: "second addressing for the same node",
+			nodeAddressings: []*v1alpha1.NodeAddressing{
+				{
+					ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "node1"},
+					Spec: v1alpha1.NodeAddressingSpec{
+						NodeName: "node1",
+						RouterID: new("192.0.2.1"),
+					},
+				},
+			},
+			newNodeAddressing: &v1alpha1.NodeAddressing{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "node1-other"},
+				Spec: v1alpha1.NodeAddressingSpec{
+					NodeName: "node1",
+					RouterID: new("192.0.2.2"),
+				},
+			},
+			errorString: "more than one NodeAddressing",
+		},
+		{
+			name: "tunnel endpoint colliding with the one derived for another node",
+			newNodeAddressing: &v1alpha1.NodeAddressing{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "node1"},
+				Spec: v1alpha1.NodeAddressingSpec{
+					NodeName: "node1",
+					TunnelEndpoints: []v1alpha1.TunnelEndpointAddresses{
+						{Underlay: "underlay", IPs: []string{"100.65.0.0"}},
+					},
+				},
+			},
+			errorString: "tunnel endpoint ip 100.65.0.0 is assigned to both node node0 and node node1",
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			objects := objectsFromResources(tc.nodeAddressings)
+			objects = append(objects, objectsFromResources(underlays)...)
+			objects = append(objects, objectsFromResources(nodes)...)
+			client, err := setupFakeWebhookClient(objects)
+			if err != nil {
+				t.Fatal(err)
+			}
+			origWebhookClient := WebhookClient
+			origLogger := Logger
+			defer func() {
+				WebhookClient = origWebhookClient
+				Logger = origLogger
+			}()
+			WebhookClient = client
+			Logger, _ = logging.New("debug")
+
+			err = validateNodeAddressing(tc.newNodeAddressing)
+			if tc.errorString == "" {
+				if err != nil {
+					t.Fatalf("expected no error, but got %q", err)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatalf("expected error to contain %q but got no error", tc.errorString)
+			}
+			if !strings.Contains(err.Error(), tc.errorString) {
+				t.Fatalf("expected error message %q to contain substring %q", err.Error(), tc.errorString)
+			}
+		})
+	}
+}
@@ -87,7 +87,10 @@ func (v *UnderlayValidator) Handle(ctx context.Context, req admission.Request) (
 		}
 	}
 
-	return admission.Allowed("")
+	if req.Operation == v1.Delete {
+		return admission.Allowed("")
+	}
+	return addressPlanResponse(&underlay)
 }
 
 func validateUnderlayCreate(underlay *v1alpha1.Underlay) error {
@@ -57,7 +57,7 @@ func TestValidateUnderlay(t *testing.T) {
 			},
 		},
 		{
-			name: "testing conversion.ValidateUnderlaysForNodes is hit - more than one underlay per node",
+			name: "testing conversion.ValidateUnderlaysForNodes is hit - underlays on the same node with different asn",
 			nodes: []*v1.Node{
 				{
 					ObjectMeta: metav1.ObjectMeta{
@@ -103,9 +103,99 @@ func TestValidateUnderlay(t *testing.T) {
 							"nodeName": "node1",
 						},
 					},
+					Neighbors: []v1alpha1.Neighbor{{}},
+				},
+			},
+			errorString: "must have the same asn",
+		},
+		{
+			name: "two underlays on the same node configuring ospf",
+			nodes: []*v1.Node{
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "node1",
+						Labels: map[string]string{
+							"nodeName": "node1",
+						},
+					},
+				},
+			},
+			underlays: []*v1alpha1.Underlay{
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "default",
+						Name:      "existingUnderlay",
+					},
+					Spec: v1alpha1.UnderlaySpec{
+						ASN: 65000,
+						TunnelEndpoint: &v1alpha1.TunnelEndpointConfig{
+							CIDRs: []string{"10.0.0.0/24"},
+						},
+						NodeSelector: &metav1.LabelSelector{
+							MatchLabels: map[string]string{
+								"nodeName": "node1",
+							},
+						},
+						Neighbors: []v1alpha1.Neighbor{{}},
+						OSPF:      &v1alpha1.OSPFConfig{},
+					},
+				},
+			},
+			newUnderlay: &v1alpha1.Underlay{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "default",
+					Name:      "newUnderlay",
+				},
+				Spec: v1alpha1.UnderlaySpec{
+					ASN: 65000,
+					TunnelEndpoint: &v1alpha1.TunnelEndpointConfig{
+						CIDRs: []string{"10.0.1.0/24"},
+					},
+					NodeSelector: &metav1.LabelSelector{
+						MatchLabels: map[string]string{
+							"nodeName": "node1",
+						},
+					},
+					Neighbors: []v1alpha1.Neighbor{{}},
+					OSPF:      &v1alpha1.OSPFConfig{},
+				},
+			},
+			errorString: "ospf is already configured",
+		},
+		{
+			name: "underlay with an invalid ospf area",
+			nodes: []*v1.Node{
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "node1",
+						Labels: map[string]string{
+							"nodeName": "node1",
+						},
+					},
+				},
+			},
+			newUnderlay: &v1alpha1.Underlay{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "default",
+					Name:      "newUnderlay",
+				},
+				Spec: v1alpha1.UnderlaySpec{
+					ASN: 65000,
+					TunnelEndpoint: &v1alpha1.TunnelEndpointConfig{
+						CIDRs: []string{"10.0.0.0/24"},
+					},
+					NodeSelector: &metav1.LabelSelector{
+						MatchLabels: map[string]string{
+							"nodeName": "node1",
+						},
+					},
+					Neighbors: []v1alpha1.Neighbor{{}},
+					OSPF: &v1alpha1.OSPFConfig{
+						Area: new(v1alpha1.OSPFArea("4294967296")),
+					},
 				},
 			},
-			errorString: "can't have more than one underlay per node",
+			errorString: "invalid OSPF area",
 		},
 		// We do not want to block underlays with invalid configuration, only overlay resources.
 		{